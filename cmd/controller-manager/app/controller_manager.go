@@ -40,7 +40,10 @@ import (
 	"github.com/kubernetes-sigs/service-catalog/pkg/kubernetes/pkg/util/configz"
 	"github.com/kubernetes-sigs/service-catalog/pkg/metrics"
 	"github.com/kubernetes-sigs/service-catalog/pkg/metrics/osbclientproxy"
+	"github.com/kubernetes-sigs/service-catalog/pkg/tracing"
+	"github.com/kubernetes-sigs/service-catalog/pkg/tracing/osbclienttracing"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -51,6 +54,7 @@ import (
 	"github.com/kubernetes-sigs/service-catalog/cmd/controller-manager/app/options"
 	servicecatalogv1beta1 "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	settingsv1alpha1 "github.com/kubernetes-sigs/service-catalog/pkg/apis/settings/v1alpha1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/cataloghistory"
 	servicecataloginformers "github.com/kubernetes-sigs/service-catalog/pkg/client/informers_generated/externalversions"
 	"github.com/kubernetes-sigs/service-catalog/pkg/controller"
 	"github.com/kubernetes-sigs/service-catalog/pkg/probe"
@@ -321,6 +325,16 @@ func StartControllers(s *options.ControllerManagerServer,
 	coreInformerFactory := informers.NewSharedInformerFactory(coreClient, s.ResyncInterval)
 	coreInformers := coreInformerFactory.Core()
 
+	// The catalog history informer only watches the ConfigMaps holding
+	// snapshots of the catalogs of brokers
+	catalogHistoryInformerFactory := informers.NewSharedInformerFactoryWithOptions(
+		coreClient,
+		s.ResyncInterval,
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = cataloghistory.RevisionLabel
+		}),
+	)
+
 	// Build the informer factory for service-catalog resources
 	informerFactory := servicecataloginformers.NewSharedInformerFactory(
 		serviceCatalogClientBuilder.ClientOrDie("shared-informers"),
@@ -329,10 +343,16 @@ func StartControllers(s *options.ControllerManagerServer,
 	// All shared informers are v1beta1 API level
 	serviceCatalogSharedInformers := informerFactory.Servicecatalog().V1beta1()
 
+	brokerClientCreateFunc := osbclientproxy.NewClient
+	if tracing.Enabled() {
+		brokerClientCreateFunc = osbclienttracing.NewCreateFunc(brokerClientCreateFunc)
+	}
+
 	klog.V(5).Infof("Creating controller; broker relist interval: %v", s.ServiceBrokerRelistInterval)
 	serviceCatalogController, err := controller.NewController(
 		coreClient,
 		coreInformers.V1().Secrets(),
+		catalogHistoryInformerFactory.Core().V1().ConfigMaps(),
 		serviceCatalogClientBuilder.ClientOrDie(controllerManagerAgentName).ServicecatalogV1beta1(),
 		serviceCatalogSharedInformers.ClusterServiceBrokers(),
 		serviceCatalogSharedInformers.ServiceBrokers(),
@@ -342,7 +362,7 @@ func StartControllers(s *options.ControllerManagerServer,
 		serviceCatalogSharedInformers.ServiceBindings(),
 		serviceCatalogSharedInformers.ClusterServicePlans(),
 		serviceCatalogSharedInformers.ServicePlans(),
-		osbclientproxy.NewClient,
+		brokerClientCreateFunc,
 		s.ServiceBrokerRelistInterval,
 		s.OSBAPIPreferredVersion,
 		recorder,
@@ -351,18 +371,34 @@ func StartControllers(s *options.ControllerManagerServer,
 		s.ClusterIDConfigMapName,
 		s.ClusterIDConfigMapNamespace,
 		s.OSBAPITimeOut,
+		s.InstanceRetrievalInterval,
+		s.BindingRetrievalInterval,
+		s.BindingRotationGracePeriod,
+		s.CatalogHistoryLimit,
+		s.CatalogHistoryNamespace,
+		s.BrokerCircuitBreakerFailureThreshold,
+		s.BrokerCircuitBreakerOpenDuration,
+		s.BrokerHealthCheckInterval,
 	)
 	if err != nil {
 		return err
 	}
 
+	if err := metrics.RegisterCollector(serviceCatalogController.ResourceStateCollector()); err != nil {
+		return err
+	}
+
 	klog.V(1).Info("Starting shared informers")
 	informerFactory.Start(stop)
 	coreInformerFactory.Start(stop)
+	if s.CatalogHistoryLimit > 0 {
+		catalogHistoryInformerFactory.Start(stop)
+	}
 
 	klog.V(5).Info("Waiting for caches to sync")
 	informerFactory.WaitForCacheSync(stop)
 	coreInformerFactory.WaitForCacheSync(stop)
+	catalogHistoryInformerFactory.WaitForCacheSync(stop)
 
 	klog.V(5).Info("Running controller")
 	go serviceCatalogController.Run(s.ConcurrentSyncs, stop)
@@ -26,11 +26,11 @@ import (
 	"github.com/spf13/pflag"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
-	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/componentconfig"
 	"github.com/kubernetes-sigs/service-catalog/pkg/controller"
 	k8scomponentconfig "github.com/kubernetes-sigs/service-catalog/pkg/kubernetes/pkg/apis/componentconfig"
 	"github.com/kubernetes-sigs/service-catalog/pkg/kubernetes/pkg/client/leaderelectionconfig"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
 	genericoptions "k8s.io/apiserver/pkg/server/options"
 )
 
@@ -61,6 +61,9 @@ const (
 	defaultReconciliationRetryDuration            = 7 * 24 * time.Hour
 	defaultOperationPollingMaximumBackoffDuration = 20 * time.Minute
 	defaultOSBAPITimeOut                          = 60 * time.Second
+	defaultBindingRotationGracePeriod             = 10 * time.Minute
+	defaultBrokerCircuitBreakerFailureThreshold   = 5
+	defaultBrokerCircuitBreakerOpenDuration       = time.Minute
 )
 
 var defaultOSBAPIPreferredVersion = osb.LatestAPIVersion().HeaderValue()
@@ -80,6 +83,9 @@ func NewControllerManagerServer() *ControllerManagerServer {
 			OSBAPIContextProfile:                   defaultOSBAPIContextProfile,
 			OSBAPIPreferredVersion:                 defaultOSBAPIPreferredVersion,
 			OSBAPITimeOut:                          defaultOSBAPITimeOut,
+			BindingRotationGracePeriod:             defaultBindingRotationGracePeriod,
+			BrokerCircuitBreakerFailureThreshold:   defaultBrokerCircuitBreakerFailureThreshold,
+			BrokerCircuitBreakerOpenDuration:       defaultBrokerCircuitBreakerOpenDuration,
 			ConcurrentSyncs:                        defaultConcurrentSyncs,
 			LeaderElection:                         leaderelectionconfig.DefaultLeaderElectionConfiguration(),
 			LeaderElectionNamespace:                defaultLeaderElectionNamespace,
@@ -122,6 +128,14 @@ func (s *ControllerManagerServer) AddFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&s.ReconciliationRetryDuration, "reconciliation-retry-duration", s.ReconciliationRetryDuration, "The maximum amount of time to retry reconciliations on a resource before failing")
 	fs.DurationVar(&s.OperationPollingMaximumBackoffDuration, "operation-polling-maximum-backoff-duration", s.OperationPollingMaximumBackoffDuration, "The maximum amount of time to back-off while polling an OSB API operation")
 	fs.DurationVar(&s.OSBAPITimeOut, "osb-api-request-timeout", s.OSBAPITimeOut, "The maximum amount of timeout to any request to the broker.")
+	fs.DurationVar(&s.InstanceRetrievalInterval, "instance-retrieval-interval", s.InstanceRetrievalInterval, "The interval on which the state of instances of retrievable service classes is fetched from the broker; 0 disables retrieval")
+	fs.DurationVar(&s.BindingRetrievalInterval, "binding-retrieval-interval", s.BindingRetrievalInterval, "The interval on which the credentials of bindings of retrievable service classes are fetched from the broker and resynced into their Secrets; 0 disables retrieval")
+	fs.DurationVar(&s.BindingRotationGracePeriod, "binding-rotation-grace-period", s.BindingRotationGracePeriod, "The amount of time the previous binding is kept at the broker after the credentials of a binding have been rotated")
+	fs.IntVar(&s.CatalogHistoryLimit, "catalog-history-limit", s.CatalogHistoryLimit, "The number of snapshots of the catalog of each broker that are kept in ConfigMaps; 0 disables the catalog history")
+	fs.StringVar(&s.CatalogHistoryNamespace, "catalog-history-namespace", controller.DefaultCatalogHistoryNamespace, "k8s namespace for the catalog snapshots of cluster service brokers")
+	fs.IntVar(&s.BrokerCircuitBreakerFailureThreshold, "broker-circuit-breaker-failure-threshold", s.BrokerCircuitBreakerFailureThreshold, "The number of consecutive requests to a broker that fail to reach it before requests to the broker are suspended; 0 disables the circuit breaker")
+	fs.DurationVar(&s.BrokerCircuitBreakerOpenDuration, "broker-circuit-breaker-open-duration", s.BrokerCircuitBreakerOpenDuration, "The amount of time requests to an unreachable broker are suspended for before a request is sent to probe whether it has recovered")
+	fs.DurationVar(&s.BrokerHealthCheckInterval, "broker-health-check-interval", s.BrokerHealthCheckInterval, "The interval on which the health of brokers is checked and reported by their Healthy condition; 0 disables health checks")
 	s.SecureServingOptions.AddFlags(fs)
 	utilfeature.DefaultMutableFeatureGate.AddFlag(fs)
 	fs.StringVar(&s.ClusterIDConfigMapName, "cluster-id-configmap-name", controller.DefaultClusterIDConfigMapName, "k8s name for clusterid configmap")
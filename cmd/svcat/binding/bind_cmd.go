@@ -22,12 +22,16 @@ import (
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/parameters"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
 )
 
 type bindCmd struct {
 	*command.Namespaced
 	*command.Waitable
+	*command.Formatted
+	*command.DryRunnable
 
 	instanceName string
 	bindingName  string
@@ -43,8 +47,10 @@ type bindCmd struct {
 // NewBindCmd builds a "svcat bind" command
 func NewBindCmd(cxt *command.Context) *cobra.Command {
 	bindCmd := &bindCmd{
-		Namespaced: command.NewNamespaced(cxt),
-		Waitable:   command.NewWaitable(),
+		Namespaced:  command.NewNamespaced(cxt),
+		Waitable:    command.NewWaitable(),
+		Formatted:   command.NewFormatted(),
+		DryRunnable: command.NewDryRunnable(),
 	}
 	cmd := &cobra.Command{
 		Use:   "bind INSTANCE_NAME",
@@ -54,6 +60,7 @@ func NewBindCmd(cxt *command.Context) *cobra.Command {
   svcat bind wordpress-mysql-instance --name wordpress-mysql-binding --secret-name wordpress-mysql-secret
   svcat bind wordpress-mysql-instance --name wordpress-mysql-binding --external-id c8ca2fcc-4398-11e8-842f-0ed5f89f718b
   svcat bind wordpress-instance --params type=admin
+  svcat bind wordpress-instance --secret mysecret[credentials] --dry-run=client -o yaml
   svcat bind wordpress-instance --params-json '{
 	"type": "admin",
 	"teams": [
@@ -91,6 +98,8 @@ func NewBindCmd(cxt *command.Context) *cobra.Command {
 	cmd.Flags().StringVar(&bindCmd.jsonParams, "params-json", "",
 		"Additional parameters to use when binding the instance, provided as a JSON object. Cannot be combined with --param")
 	bindCmd.AddWaitFlags(cmd)
+	bindCmd.AddOutputFlags(cmd.Flags())
+	bindCmd.AddDryRunFlag(cmd.Flags(), false)
 	return cmd
 }
 
@@ -130,9 +139,45 @@ func (c *bindCmd) Validate(args []string) error {
 // Run creates the binding.
 // An error returned when failed.
 func (c *bindCmd) Run() error {
+	err := c.validateParameters()
+	if err != nil {
+		return err
+	}
+	if c.IsClientDryRun() {
+		binding := servicecatalog.BuildBinding(c.Namespace, c.bindingName, c.externalID, c.instanceName, c.secretName, c.params, c.secrets)
+		output.WriteBindingManifest(c.Output, c.OutputFormat, binding)
+		return nil
+	}
 	return c.bind()
 }
 
+// validateParameters converts the --param values to the types of the
+// parameters in the binding create schema of the plan of the instance, and
+// validates the parameters against the schema before the binding is created.
+// The parameters are not validated if the plan of the instance is not known
+// yet, as the binding may be created before its instance.
+func (c *bindCmd) validateParameters() error {
+	instance, err := c.App.RetrieveInstance(c.Namespace, c.instanceName)
+	if err != nil {
+		return nil
+	}
+	plan, err := c.App.RetrieveInstancePlan(instance)
+	if err != nil || plan == nil {
+		return nil
+	}
+	schema, err := servicecatalog.ParseSchema(plan.GetBindingCreateSchema())
+	if err != nil {
+		return err
+	}
+	if len(c.rawParams) > 0 {
+		c.params, err = parameters.CoerceVariableAssignments(c.params.(map[string]interface{}), schema)
+		if err != nil {
+			return fmt.Errorf("invalid --param value (%s)", err)
+		}
+	}
+	return parameters.Validate(schema, c.params, len(c.secrets) > 0)
+}
+
 func (c *bindCmd) bind() error {
 	binding, err := c.App.Bind(c.Namespace, c.bindingName, c.externalID, c.instanceName, c.secretName, c.params, c.secrets)
 	if err != nil {
@@ -148,10 +193,18 @@ func (c *bindCmd) bind() error {
 
 		// Always print the binding because the bind did succeed,
 		// and just print any errors that occurred while polling
-		output.WriteBindingDetails(c.Output, binding)
+		c.writeBinding(binding)
 		return err
 	}
 
-	output.WriteBindingDetails(c.Output, binding)
+	c.writeBinding(binding)
 	return nil
 }
+
+func (c *bindCmd) writeBinding(binding *v1beta1.ServiceBinding) {
+	if c.OutputFormat == output.FormatTable {
+		output.WriteBindingDetails(c.Output, binding)
+		return
+	}
+	output.WriteBinding(c.Output, c.OutputFormat, *binding)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
+	"github.com/spf13/cobra"
+)
+
+type rotateCmd struct {
+	*command.Namespaced
+	*command.Waitable
+
+	name string
+}
+
+// NewRotateCmd builds a "svcat rotate binding" command.
+func NewRotateCmd(cxt *command.Context) *cobra.Command {
+	rotateCmd := &rotateCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Waitable:   command.NewWaitable(),
+	}
+	cmd := &cobra.Command{
+		Use:   "binding NAME",
+		Short: "Rotate the credentials of a binding",
+		Long: `Rotate binding will increment the rotationRequests field on the binding.
+Then, service catalog will create a new binding with the broker, replace the
+contents of the secret with the new credentials and unbind the previous
+credentials once the grace period configured on the controller has elapsed.`,
+		Example: command.NormalizeExamples(`
+  svcat rotate binding wordpress-mysql-binding
+  svcat rotate binding wordpress-mysql-binding --namespace mynamespace --wait
+`),
+		PreRunE: command.PreRunE(rotateCmd),
+		RunE:    command.RunE(rotateCmd),
+	}
+	rotateCmd.AddNamespaceFlags(cmd.Flags(), false)
+	rotateCmd.AddWaitFlags(cmd)
+
+	return cmd
+}
+
+// Validate checks that the required arguments have been provided.
+func (c *rotateCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a binding name is required")
+	}
+	c.name = args[0]
+
+	return nil
+}
+
+// Run requests the rotation of the credentials of the binding.
+func (c *rotateCmd) Run() error {
+	const retries = 3
+	binding, err := c.App.RotateBinding(c.Namespace, c.name, retries)
+	if err != nil {
+		return err
+	}
+
+	if c.Wait {
+		fmt.Fprintln(c.Output, "Waiting for the credentials to be rotated...")
+		finalBinding, err := c.App.WaitForBindingRotation(binding.Namespace, binding.Name, binding.Spec.RotationRequests, c.Interval, c.Timeout)
+		if err == nil {
+			binding = finalBinding
+		}
+
+		// Always print the binding because the rotation was requested,
+		// and just print any errors that occurred while polling
+		output.WriteBindingDetails(c.Output, binding)
+		return err
+	}
+
+	output.WriteBindingDetails(c.Output, binding)
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	svcattest "github.com/kubernetes-sigs/service-catalog/cmd/svcat/test"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	svcatfake "github.com/kubernetes-sigs/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRotateCommand(t *testing.T) {
+	const namespace = "default"
+	testcases := []struct {
+		name           string
+		fakeBindings   []string
+		rotationStatus *v1beta1.ServiceBindingRotationStatus
+		bindingName    string
+		wait           bool
+		wantOutput     string
+		wantError      bool
+	}{
+		{
+			name:         "rotate non existing binding",
+			fakeBindings: []string{},
+			bindingName:  "mybinding",
+			wantOutput:   "unable to get binding '" + namespace + ".mybinding'",
+			wantError:    true,
+		},
+		{
+			name:         "rotate binding",
+			fakeBindings: []string{"mybinding"},
+			bindingName:  "mybinding",
+			wantOutput:   "mybinding",
+		},
+		{
+			name:         "rotate binding and wait",
+			fakeBindings: []string{"mybinding"},
+			rotationStatus: &v1beta1.ServiceBindingRotationStatus{
+				RotationRequests:   1,
+				Phase:              v1beta1.ServiceBindingRotationPhaseGracePeriod,
+				PreviousExternalID: "previous-id",
+			},
+			bindingName: "mybinding",
+			wait:        true,
+			wantOutput:  "Waiting for the credentials to be rotated...",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup fake data for the app
+			k8sClient := k8sfake.NewSimpleClientset()
+			var fakes []runtime.Object
+			for _, name := range tc.fakeBindings {
+				fakes = append(fakes, &v1beta1.ServiceBinding{
+					ObjectMeta: v1.ObjectMeta{
+						Namespace: namespace,
+						Name:      name,
+					},
+					Status: v1beta1.ServiceBindingStatus{
+						RotationStatus: tc.rotationStatus,
+					},
+				})
+			}
+
+			svcatClient := svcatfake.NewSimpleClientset(fakes...)
+			fakeApp, _ := svcat.NewApp(k8sClient, svcatClient, namespace)
+			output := &bytes.Buffer{}
+			cxt := svcattest.NewContext(output, fakeApp)
+
+			// Initialize the command arguments
+			cmd := &rotateCmd{
+				Namespaced: command.NewNamespaced(cxt),
+				Waitable:   command.NewWaitable(),
+			}
+			cmd.Namespace = namespace
+			cmd.name = tc.bindingName
+			cmd.Wait = tc.wait
+			cmd.Interval = 100 * time.Millisecond
+			timeout := time.Second
+			cmd.Timeout = &timeout
+
+			err := cmd.Run()
+
+			if tc.wantError {
+				if err == nil {
+					t.Errorf("expected a non-zero exit code, but the command succeeded")
+				}
+
+				errorOutput := err.Error()
+				if !strings.Contains(errorOutput, tc.wantOutput) {
+					t.Errorf("Unexpected output:\n\nExpected:\n%q\n\nActual:\n%q\n", tc.wantOutput, errorOutput)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected the command to succeed but it failed with %q", err)
+			}
+
+			gotOutput := output.String()
+			if !strings.Contains(gotOutput, tc.wantOutput) {
+				t.Errorf("Unexpected output:\n\nExpected:\n%q\n\nActual:\n%q\n", tc.wantOutput, gotOutput)
+			}
+
+			binding, err := svcatClient.ServicecatalogV1beta1().ServiceBindings(namespace).Get(tc.bindingName, v1.GetOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error getting binding: %v", err)
+			}
+			if binding.Spec.RotationRequests != 1 {
+				t.Errorf("expected rotationRequests to be 1, got %d", binding.Spec.RotationRequests)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-sigs/service-catalog/pkg/cataloghistory"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+	"github.com/spf13/cobra"
+)
+
+// DiffCatalogCmd contains the information needed to show the changes between
+// a revision of the catalog of a broker and the revision recorded before it
+type DiffCatalogCmd struct {
+	*command.Namespaced
+	*command.Formatted
+	*command.Scoped
+
+	Name             string
+	Revision         int
+	HistoryNamespace string
+}
+
+// NewDiffCatalogCmd builds a "svcat diff catalog" command
+func NewDiffCatalogCmd(cxt *command.Context) *cobra.Command {
+	diffCmd := &DiffCatalogCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Formatted:  command.NewFormatted(),
+		Scoped:     command.NewScoped(),
+	}
+	cmd := &cobra.Command{
+		Use:   "catalog NAME",
+		Short: "Show the classes and plans changed by a revision of the catalog of a broker",
+		Long: `Show the classes and plans changed by a revision of the catalog of a broker,
+compared to the revision recorded before it. The changes of the first recorded
+revision are compared to an empty catalog.`,
+		Example: command.NormalizeExamples(`
+  svcat diff catalog minibroker
+  svcat diff catalog minibroker --rev 3
+`),
+		PreRunE: command.PreRunE(diffCmd),
+		RunE:    command.RunE(diffCmd),
+	}
+	cmd.Flags().IntVar(
+		&diffCmd.Revision,
+		"rev",
+		0,
+		"The revision of the catalog to show the changes of. Defaults to the latest recorded revision",
+	)
+	cmd.Flags().StringVar(
+		&diffCmd.HistoryNamespace,
+		"catalog-history-namespace",
+		cataloghistory.DefaultNamespace,
+		"The namespace the controller records the catalog history of cluster-scoped brokers in, set with its --catalog-history-namespace flag",
+	)
+	diffCmd.AddOutputFlags(cmd.Flags())
+	diffCmd.AddScopedFlags(cmd.Flags(), true)
+	diffCmd.AddNamespaceFlags(cmd.Flags(), false)
+	return cmd
+}
+
+// Validate checks that the required arguments have been provided
+func (c *DiffCatalogCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a broker name is required")
+	}
+	c.Name = args[0]
+
+	if c.Revision < 0 {
+		return fmt.Errorf("invalid --rev value (%d), must be a positive revision number", c.Revision)
+	}
+
+	return nil
+}
+
+// Run retrieves the catalog history of the broker with the requested name and
+// displays the changes of the requested revision
+func (c *DiffCatalogCmd) Run() error {
+	history, err := retrieveCatalogHistory(c.App, c.Name, servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}, c.HistoryNamespace)
+	if err != nil {
+		return err
+	}
+	if len(history) == 0 {
+		return fmt.Errorf("no catalog history was recorded for broker %s", c.Name)
+	}
+
+	index := len(history) - 1
+	if c.Revision != 0 {
+		index = -1
+		for i, revision := range history {
+			if revision.Revision == c.Revision {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return fmt.Errorf("revision %d of the catalog of broker %s was not recorded, or was deleted from the catalog history", c.Revision, c.Name)
+		}
+	}
+
+	var previous *osb.CatalogResponse
+	if index > 0 {
+		previous = history[index-1].Catalog
+	}
+	changes := cataloghistory.Diff(previous, history[index].Catalog)
+
+	output.WriteCatalogDiff(c.Output, c.OutputFormat, changes)
+	return nil
+}
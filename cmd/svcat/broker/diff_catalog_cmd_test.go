@@ -0,0 +1,153 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker_test
+
+import (
+	"bytes"
+
+	. "github.com/kubernetes-sigs/service-catalog/cmd/svcat/broker"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/test"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat"
+	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog/service-catalogfakes"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Diff Catalog Command", func() {
+	Describe("NewDiffCatalogCmd", func() {
+		It("Builds and returns a cobra command with the correct flags", func() {
+			cxt := &command.Context{}
+			cmd := NewDiffCatalogCmd(cxt)
+			Expect(*cmd).NotTo(BeNil())
+			Expect(cmd.Use).To(Equal("catalog NAME"))
+			Expect(cmd.Short).To(ContainSubstring("Show the classes and plans changed by a revision of the catalog of a broker"))
+			Expect(cmd.Example).To(ContainSubstring("svcat diff catalog minibroker --rev 3"))
+
+			revFlag := cmd.Flags().Lookup("rev")
+			Expect(revFlag).NotTo(BeNil())
+			Expect(revFlag.DefValue).To(Equal("0"))
+		})
+	})
+
+	Describe("Validate", func() {
+		It("succeeds if a broker name is provided", func() {
+			cmd := DiffCatalogCmd{}
+			err := cmd.Validate([]string{"bananabroker"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cmd.Name).To(Equal("bananabroker"))
+		})
+		It("errors if a broker name is not provided", func() {
+			cmd := DiffCatalogCmd{}
+			err := cmd.Validate([]string{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("a broker name is required"))
+		})
+		It("errors if the revision is negative", func() {
+			cmd := DiffCatalogCmd{Revision: -1}
+			err := cmd.Validate([]string{"bananabroker"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid --rev value (-1)"))
+		})
+	})
+
+	Describe("Run", func() {
+		var (
+			fakeSDK      *servicecatalogfakes.FakeSvcatClient
+			outputBuffer *bytes.Buffer
+			cmd          *DiffCatalogCmd
+		)
+		BeforeEach(func() {
+			history := []servicecatalog.CatalogRevision{
+				{
+					Revision: 2,
+					Catalog: &osb.CatalogResponse{Services: []osb.Service{
+						{ID: "a", Name: "service-a", Plans: []osb.Plan{{ID: "a-1", Name: "small"}}},
+					}},
+				},
+				{
+					Revision: 3,
+					Catalog: &osb.CatalogResponse{Services: []osb.Service{
+						{ID: "a", Name: "service-a", Plans: []osb.Plan{{ID: "a-2", Name: "large"}}},
+						{ID: "b", Name: "service-b"},
+					}},
+				},
+			}
+			fakeSDK = new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.RetrieveBrokerByIDReturns(&v1beta1.ClusterServiceBroker{ObjectMeta: v1.ObjectMeta{Name: "foobarbroker"}}, nil)
+			fakeSDK.RetrieveCatalogHistoryReturns(history, nil)
+
+			outputBuffer = &bytes.Buffer{}
+			fakeApp, _ := svcat.NewApp(nil, nil, "banana-namespace")
+			fakeApp.SvcatClient = fakeSDK
+			cxt := svcattest.NewContext(outputBuffer, fakeApp)
+			cmd = &DiffCatalogCmd{
+				Namespaced: command.NewNamespaced(cxt),
+				Formatted:  command.NewFormatted(),
+				Scoped:     command.NewScoped(),
+				Name:       "foobarbroker",
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Scope = servicecatalog.AllScope
+			cmd.OutputFormat = output.FormatTable
+		})
+
+		It("shows the changes of the latest revision by default", func() {
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.RetrieveCatalogHistoryCallCount()).To(Equal(1))
+			output := outputBuffer.String()
+			Expect(output).To(MatchRegexp(`added\s+class\s+service-b\s+b`))
+			Expect(output).To(MatchRegexp(`added\s+plan\s+service-a/large\s+a-2`))
+			Expect(output).To(MatchRegexp(`removed\s+plan\s+service-a/small\s+a-1`))
+		})
+		It("compares the first recorded revision to an empty catalog", func() {
+			cmd.Revision = 2
+
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			output := outputBuffer.String()
+			Expect(output).To(MatchRegexp(`added\s+class\s+service-a\s+a`))
+			Expect(output).To(MatchRegexp(`added\s+plan\s+service-a/small\s+a-1`))
+			Expect(output).NotTo(ContainSubstring("service-b"))
+		})
+		It("errors if the revision was not recorded", func() {
+			cmd.Revision = 1
+
+			err := cmd.Run()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("revision 1 of the catalog of broker foobarbroker was not recorded"))
+		})
+		It("errors if no catalog history was recorded", func() {
+			fakeSDK.RetrieveCatalogHistoryReturns([]servicecatalog.CatalogRevision{}, nil)
+
+			err := cmd.Run()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no catalog history was recorded for broker foobarbroker"))
+		})
+	})
+})
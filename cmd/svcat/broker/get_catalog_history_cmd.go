@@ -0,0 +1,112 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-sigs/service-catalog/pkg/cataloghistory"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+)
+
+// GetCatalogHistoryCmd contains the information needed to list the recorded
+// revisions of the catalog of a broker
+type GetCatalogHistoryCmd struct {
+	*command.Namespaced
+	*command.Formatted
+	*command.Scoped
+
+	Name             string
+	HistoryNamespace string
+}
+
+// NewGetCatalogHistoryCmd builds a "svcat get catalog-history" command
+func NewGetCatalogHistoryCmd(cxt *command.Context) *cobra.Command {
+	getCmd := &GetCatalogHistoryCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Formatted:  command.NewFormatted(),
+		Scoped:     command.NewScoped(),
+	}
+	cmd := &cobra.Command{
+		Use:   "catalog-history NAME",
+		Short: "List the recorded revisions of the catalog of a broker",
+		Long: `List the recorded revisions of the catalog of a broker.
+
+The controller records a revision each time the catalog of a broker changes,
+when it is started with --catalog-history-limit greater than zero.`,
+		Example: command.NormalizeExamples(`
+  svcat get catalog-history minibroker
+  svcat get catalog-history minibroker --scope=namespace --namespace=dev
+`),
+		PreRunE: command.PreRunE(getCmd),
+		RunE:    command.RunE(getCmd),
+	}
+	cmd.Flags().StringVar(
+		&getCmd.HistoryNamespace,
+		"catalog-history-namespace",
+		cataloghistory.DefaultNamespace,
+		"The namespace the controller records the catalog history of cluster-scoped brokers in, set with its --catalog-history-namespace flag",
+	)
+	getCmd.AddOutputFlags(cmd.Flags())
+	getCmd.AddScopedFlags(cmd.Flags(), true)
+	getCmd.AddNamespaceFlags(cmd.Flags(), false)
+	return cmd
+}
+
+// Validate checks that the required arguments have been provided
+func (c *GetCatalogHistoryCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a broker name is required")
+	}
+	c.Name = args[0]
+
+	return nil
+}
+
+// Run retrieves the broker with the requested name and displays the recorded
+// revisions of its catalog
+func (c *GetCatalogHistoryCmd) Run() error {
+	history, err := retrieveCatalogHistory(c.App, c.Name, servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}, c.HistoryNamespace)
+	if err != nil {
+		return err
+	}
+
+	output.WriteCatalogHistory(c.Output, c.OutputFormat, history)
+	return nil
+}
+
+// retrieveCatalogHistory retrieves the broker with the requested name and
+// the recorded revisions of its catalog, oldest first. The catalog history of
+// a cluster-scoped broker is read from historyNamespace
+func retrieveCatalogHistory(app *svcat.App, name string, scopeOpts servicecatalog.ScopeOptions, historyNamespace string) ([]servicecatalog.CatalogRevision, error) {
+	broker, err := app.RetrieveBrokerByID(name, scopeOpts)
+	if err != nil {
+		if strings.Contains(err.Error(), servicecatalog.MultipleBrokersFoundError) {
+			return nil, fmt.Errorf("%s, please specify a scope with --scope", err)
+		}
+		return nil, err
+	}
+	return app.RetrieveCatalogHistory(broker, historyNamespace)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker_test
+
+import (
+	"bytes"
+	"fmt"
+
+	. "github.com/kubernetes-sigs/service-catalog/cmd/svcat/broker"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/test"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat"
+	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog/service-catalogfakes"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Get Catalog History Command", func() {
+	Describe("NewGetCatalogHistoryCmd", func() {
+		It("Builds and returns a cobra command with the correct flags", func() {
+			cxt := &command.Context{}
+			cmd := NewGetCatalogHistoryCmd(cxt)
+			Expect(*cmd).NotTo(BeNil())
+			Expect(cmd.Use).To(Equal("catalog-history NAME"))
+			Expect(cmd.Short).To(ContainSubstring("List the recorded revisions of the catalog of a broker"))
+			Expect(cmd.Example).To(ContainSubstring("svcat get catalog-history minibroker"))
+
+			outputFlag := cmd.Flags().Lookup("output")
+			Expect(outputFlag).NotTo(BeNil())
+			scopeFlag := cmd.Flags().Lookup("scope")
+			Expect(scopeFlag).NotTo(BeNil())
+		})
+	})
+
+	Describe("Validate", func() {
+		It("succeeds if a broker name is provided", func() {
+			cmd := GetCatalogHistoryCmd{}
+			err := cmd.Validate([]string{"bananabroker"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cmd.Name).To(Equal("bananabroker"))
+		})
+		It("errors if a broker name is not provided", func() {
+			cmd := GetCatalogHistoryCmd{}
+			err := cmd.Validate([]string{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("a broker name is required"))
+		})
+	})
+
+	Describe("Run", func() {
+		var (
+			brokerName     string
+			namespace      string
+			brokerToReturn *v1beta1.ClusterServiceBroker
+			history        []servicecatalog.CatalogRevision
+		)
+		BeforeEach(func() {
+			brokerName = "foobarbroker"
+			namespace = "banana-namespace"
+			brokerToReturn = &v1beta1.ClusterServiceBroker{ObjectMeta: v1.ObjectMeta{Name: brokerName}}
+			history = []servicecatalog.CatalogRevision{
+				{
+					Revision: 4,
+					Catalog: &osb.CatalogResponse{Services: []osb.Service{
+						{ID: "a", Name: "service-a", Plans: []osb.Plan{{ID: "a-1", Name: "small"}, {ID: "a-2", Name: "large"}}},
+					}},
+				},
+			}
+		})
+		newCmd := func(outputBuffer *bytes.Buffer, fakeSDK *servicecatalogfakes.FakeSvcatClient) *GetCatalogHistoryCmd {
+			fakeApp, _ := svcat.NewApp(nil, nil, namespace)
+			fakeApp.SvcatClient = fakeSDK
+			cxt := svcattest.NewContext(outputBuffer, fakeApp)
+			cmd := &GetCatalogHistoryCmd{
+				Namespaced: command.NewNamespaced(cxt),
+				Formatted:  command.NewFormatted(),
+				Scoped:     command.NewScoped(),
+				Name:       brokerName,
+
+				HistoryNamespace: "catalog-namespace",
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Scope = servicecatalog.AllScope
+			cmd.OutputFormat = output.FormatTable
+			return cmd
+		}
+
+		It("Retrieves the broker and prints the revisions of its catalog", func() {
+			outputBuffer := &bytes.Buffer{}
+			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.RetrieveBrokerByIDReturns(brokerToReturn, nil)
+			fakeSDK.RetrieveCatalogHistoryReturns(history, nil)
+
+			err := newCmd(outputBuffer, fakeSDK).Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.RetrieveBrokerByIDCallCount()).To(Equal(1))
+			returnedName, returnedScopeOpts := fakeSDK.RetrieveBrokerByIDArgsForCall(0)
+			Expect(returnedName).To(Equal(brokerName))
+			Expect(returnedScopeOpts).To(Equal(servicecatalog.ScopeOptions{
+				Scope:     servicecatalog.AllScope,
+				Namespace: namespace,
+			}))
+			Expect(fakeSDK.RetrieveCatalogHistoryCallCount()).To(Equal(1))
+			returnedBroker, returnedHistoryNamespace := fakeSDK.RetrieveCatalogHistoryArgsForCall(0)
+			Expect(returnedBroker).To(Equal(brokerToReturn))
+			Expect(returnedHistoryNamespace).To(Equal("catalog-namespace"))
+
+			output := outputBuffer.String()
+			Expect(output).To(ContainSubstring("REVISION"))
+			Expect(output).To(MatchRegexp(`4\s.*\s1\s+2`))
+		})
+		It("prompts the user for more input when it gets a MultipleBrokersFound error", func() {
+			outputBuffer := &bytes.Buffer{}
+			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.RetrieveBrokerByIDReturns(nil, fmt.Errorf("%s for '%s'", servicecatalog.MultipleBrokersFoundError, brokerName))
+
+			err := newCmd(outputBuffer, fakeSDK).Run()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("please specify a scope with --scope"))
+			Expect(fakeSDK.RetrieveCatalogHistoryCallCount()).To(Equal(0))
+		})
+		It("bubbles up errors", func() {
+			outputBuffer := &bytes.Buffer{}
+			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.RetrieveBrokerByIDReturns(brokerToReturn, nil)
+			fakeSDK.RetrieveCatalogHistoryReturns(nil, fmt.Errorf("incompatible potato"))
+
+			err := newCmd(outputBuffer, fakeSDK).Run()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("incompatible potato"))
+		})
+	})
+})
@@ -35,31 +35,40 @@ type RegisterCmd struct {
 	*command.Namespaced
 	*command.Scoped
 	*command.Waitable
+	*command.Formatted
+	*command.DryRunnable
 
 	BasicSecret       string
 	BearerSecret      string
 	BrokerName        string
 	CAFile            string
 	ClassRestrictions []string
+	OAuth2Secret      string
 	PlanRestrictions  []string
 	SkipTLS           bool
+	TLSSecret         string
 	RelistBehavior    string
 	RelistDuration    time.Duration
+	ServiceAccount    string
+	TokenAudience     string
 	URL               string
 }
 
 // NewRegisterCmd builds a "svcat register" command
 func NewRegisterCmd(cxt *command.Context) *cobra.Command {
 	registerCmd := &RegisterCmd{
-		Namespaced: command.NewNamespaced(cxt),
-		Scoped:     command.NewScoped(),
-		Waitable:   command.NewWaitable(),
+		Namespaced:  command.NewNamespaced(cxt),
+		Scoped:      command.NewScoped(),
+		Waitable:    command.NewWaitable(),
+		Formatted:   command.NewFormatted(),
+		DryRunnable: command.NewDryRunnable(),
 	}
 	cmd := &cobra.Command{
 		Use:   "register NAME --url URL",
 		Short: "Registers a new broker with service catalog",
 		Example: command.NormalizeExamples(`
 		svcat register mysqlbroker --url http://mysqlbroker.com
+		svcat register mysqlbroker --url http://mysqlbroker.com --basic-secret mysqlbroker-auth --dry-run=client -o yaml
 		`),
 		PreRunE: command.PreRunE(registerCmd),
 		RunE:    command.RunE(registerCmd),
@@ -71,6 +80,14 @@ func NewRegisterCmd(cxt *command.Context) *cobra.Command {
 		"A secret containing basic auth (username/password) information to connect to the broker")
 	cmd.Flags().StringVar(&registerCmd.BearerSecret, "bearer-secret", "",
 		"A secret containing a bearer token to connect to the broker")
+	cmd.Flags().StringVar(&registerCmd.TLSSecret, "tls-secret", "",
+		"A kubernetes.io/tls secret containing the client certificate and key to connect to the broker with mutual TLS")
+	cmd.Flags().StringVar(&registerCmd.OAuth2Secret, "oauth2-secret", "",
+		"A secret containing the OAuth2 client credentials (clientID/clientSecret/tokenURL/scopes) used to obtain access tokens to connect to the broker")
+	cmd.Flags().StringVar(&registerCmd.ServiceAccount, "service-account", "",
+		"A service account whose bound tokens, requested by the controller, are used to connect to the broker")
+	cmd.Flags().StringVar(&registerCmd.TokenAudience, "token-audience", "",
+		"The audience of the service account tokens used to connect to the broker, which the broker must verify. Required with --service-account")
 	cmd.Flags().StringVar(&registerCmd.CAFile, "ca", "",
 		"A file containing the CA certificate to connect to the broker")
 	cmd.Flags().StringSliceVar(&registerCmd.ClassRestrictions, "class-restrictions", []string{},
@@ -78,14 +95,16 @@ func NewRegisterCmd(cxt *command.Context) *cobra.Command {
 	cmd.Flags().StringSliceVar(&registerCmd.PlanRestrictions, "plan-restrictions", []string{},
 		"A list of restrictions to apply to the plans allowed from the broker")
 	cmd.Flags().StringVar(&registerCmd.RelistBehavior, "relist-behavior", "",
-		"Behavior for relisting the broker's catalog. Valid options are manual or duration. Defaults to duration with an interval of 15m.")
+		"Behavior for relisting the broker's catalog. Valid options are manual or duration. Defaults to duration with an interval of 15m. The proxy option relists like duration, but does not reconcile a catalog that is unchanged since the last relist.")
 	cmd.Flags().DurationVar(&registerCmd.RelistDuration, "relist-duration", 0*time.Second,
-		"Interval to refetch broker catalog when relist-behavior is set to duration, specified in human readable format: 30s, 1m, 1h")
+		"Interval to refetch broker catalog when relist-behavior is set to duration or proxy, specified in human readable format: 30s, 1m, 1h")
 	cmd.Flags().BoolVar(&registerCmd.SkipTLS, "skip-tls", false,
 		"Disables TLS certificate verification when communicating with this broker. This is strongly discouraged. You should use --ca instead.")
 	registerCmd.AddNamespaceFlags(cmd.Flags(), false)
 	registerCmd.AddScopedFlags(cmd.Flags(), false)
 	registerCmd.AddWaitFlags(cmd)
+	registerCmd.AddOutputFlags(cmd.Flags())
+	registerCmd.AddDryRunFlag(cmd.Flags(), false)
 
 	return cmd
 }
@@ -100,6 +119,21 @@ func (c *RegisterCmd) Validate(args []string) error {
 	if c.BasicSecret != "" && c.BearerSecret != "" {
 		return fmt.Errorf("cannot use both basic auth and bearer auth")
 	}
+	authSecrets := 0
+	for _, secret := range []string{c.BasicSecret, c.BearerSecret, c.TLSSecret, c.OAuth2Secret, c.ServiceAccount} {
+		if secret != "" {
+			authSecrets++
+		}
+	}
+	if authSecrets > 1 {
+		return fmt.Errorf("cannot use more than one of basic auth, bearer auth, tls auth, oauth2 auth and service account auth")
+	}
+	if c.TokenAudience != "" && c.ServiceAccount == "" {
+		return fmt.Errorf("--token-audience can only be used with --service-account")
+	}
+	if c.ServiceAccount != "" && c.TokenAudience == "" {
+		return fmt.Errorf("--token-audience is required with --service-account")
+	}
 
 	if c.CAFile != "" {
 		_, err := os.Stat(c.CAFile)
@@ -109,8 +143,8 @@ func (c *RegisterCmd) Validate(args []string) error {
 	}
 	if c.RelistBehavior != "" {
 		c.RelistBehavior = strings.ToLower(c.RelistBehavior)
-		if c.RelistBehavior != "duration" && c.RelistBehavior != "manual" {
-			return fmt.Errorf("invalid --relist-duration value, allowed values are: duration, manual")
+		if c.RelistBehavior != "duration" && c.RelistBehavior != "manual" && c.RelistBehavior != "proxy" {
+			return fmt.Errorf("invalid --relist-duration value, allowed values are: duration, manual, proxy")
 		}
 	}
 	return nil
@@ -124,8 +158,12 @@ func (c *RegisterCmd) Run() error {
 		CAFile:            c.CAFile,
 		ClassRestrictions: c.ClassRestrictions,
 		Namespace:         c.Namespace,
+		OAuth2Secret:      c.OAuth2Secret,
 		PlanRestrictions:  c.PlanRestrictions,
+		ServiceAccount:    c.ServiceAccount,
 		SkipTLS:           c.SkipTLS,
+		TLSSecret:         c.TLSSecret,
+		TokenAudience:     c.TokenAudience,
 	}
 	scopeOpts := &servicecatalog.ScopeOptions{
 		Namespace: c.Namespace,
@@ -133,9 +171,24 @@ func (c *RegisterCmd) Run() error {
 	}
 	if c.RelistBehavior == "duration" {
 		opts.RelistBehavior = v1beta1.ServiceBrokerRelistBehaviorDuration
-		opts.RelistDuration = &metav1.Duration{Duration: c.RelistDuration}
 	} else if c.RelistBehavior == "manual" {
 		opts.RelistBehavior = v1beta1.ServiceBrokerRelistBehaviorManual
+	} else if c.RelistBehavior == "proxy" {
+		opts.RelistBehavior = v1beta1.ServiceBrokerRelistBehaviorProxy
+	}
+	// without --relist-duration the relist duration is left to the defaults
+	// of the API server, which rejects a zero duration
+	if c.RelistDuration != 0 && opts.RelistBehavior != "" && opts.RelistBehavior != v1beta1.ServiceBrokerRelistBehaviorManual {
+		opts.RelistDuration = &metav1.Duration{Duration: c.RelistDuration}
+	}
+
+	if c.IsClientDryRun() {
+		broker, err := servicecatalog.BuildBroker(c.BrokerName, c.URL, opts, scopeOpts)
+		if err != nil {
+			return err
+		}
+		output.WriteBrokerManifest(c.Output, c.OutputFormat, broker)
+		return nil
 	}
 
 	broker, err := c.Context.App.Register(c.BrokerName, c.URL, opts, scopeOpts)
@@ -150,10 +203,18 @@ func (c *RegisterCmd) Run() error {
 			broker = finalBroker.(*v1beta1.ClusterServiceBroker)
 		}
 
-		output.WriteBrokerDetails(c.Output, broker)
+		c.writeBroker(broker)
 		return err
 	}
 
-	output.WriteBrokerDetails(c.Context.Output, broker)
+	c.writeBroker(broker)
 	return nil
 }
+
+func (c *RegisterCmd) writeBroker(broker servicecatalog.Broker) {
+	if c.OutputFormat == output.FormatTable {
+		output.WriteBrokerDetails(c.Output, broker)
+		return
+	}
+	output.WriteBroker(c.Output, c.OutputFormat, broker)
+}
@@ -22,6 +22,7 @@ import (
 
 	. "github.com/kubernetes-sigs/service-catalog/cmd/svcat/broker"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/test"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/pkg/svcat"
@@ -57,6 +58,21 @@ var _ = Describe("Register Command", func() {
 			Expect(bearerSecretFlag).NotTo(BeNil())
 			Expect(bearerSecretFlag.Usage).To(ContainSubstring("A secret containing a bearer token to connect to the broker"))
 
+			tlsSecretFlag := cmd.Flags().Lookup("tls-secret")
+			Expect(tlsSecretFlag).NotTo(BeNil())
+			Expect(tlsSecretFlag.Usage).To(ContainSubstring("A kubernetes.io/tls secret containing the client certificate and key to connect to the broker with mutual TLS"))
+
+			oauth2SecretFlag := cmd.Flags().Lookup("oauth2-secret")
+			Expect(oauth2SecretFlag).NotTo(BeNil())
+			Expect(oauth2SecretFlag.Usage).To(ContainSubstring("A secret containing the OAuth2 client credentials"))
+
+			serviceAccountFlag := cmd.Flags().Lookup("service-account")
+			Expect(serviceAccountFlag).NotTo(BeNil())
+			Expect(serviceAccountFlag.Usage).To(ContainSubstring("A service account whose bound tokens, requested by the controller, are used to connect to the broker"))
+
+			tokenAudienceFlag := cmd.Flags().Lookup("token-audience")
+			Expect(tokenAudienceFlag).NotTo(BeNil())
+
 			caFlag := cmd.Flags().Lookup("ca")
 			Expect(caFlag).NotTo(BeNil())
 			Expect(caFlag.Usage).To(ContainSubstring("A file containing the CA certificate to connect to the broker"))
@@ -75,7 +91,7 @@ var _ = Describe("Register Command", func() {
 
 			relistDurationFlag := cmd.Flags().Lookup("relist-duration")
 			Expect(relistDurationFlag).NotTo(BeNil())
-			Expect(relistDurationFlag.Usage).To(ContainSubstring("Interval to refetch broker catalog when relist-behavior is set to duration, specified in human readable format: 30s, 1m, 1h"))
+			Expect(relistDurationFlag.Usage).To(ContainSubstring("Interval to refetch broker catalog when relist-behavior is set to duration or proxy, specified in human readable format: 30s, 1m, 1h"))
 
 			skipTLSFlag := cmd.Flags().Lookup("skip-tls")
 			Expect(skipTLSFlag).NotTo(BeNil())
@@ -113,6 +129,44 @@ var _ = Describe("Register Command", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("cannot use both basic auth and bearer auth"))
 		})
+		It("errors if both tls-secret and basic-secret are provided", func() {
+			basicSecret := "basicsecret"
+			tlsSecret := "tlssecret"
+			cmd := RegisterCmd{
+				BasicSecret: basicSecret,
+				TLSSecret:   tlsSecret,
+			}
+			err := cmd.Validate([]string{"bananabroker", "http://bananabroker.com", "--basic-secret", basicSecret, "--tls-secret", tlsSecret})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot use more than one of basic auth, bearer auth, tls auth, oauth2 auth and service account auth"))
+		})
+		It("errors if both oauth2-secret and tls-secret are provided", func() {
+			oauth2Secret := "oauth2secret"
+			tlsSecret := "tlssecret"
+			cmd := RegisterCmd{
+				OAuth2Secret: oauth2Secret,
+				TLSSecret:    tlsSecret,
+			}
+			err := cmd.Validate([]string{"bananabroker", "http://bananabroker.com", "--oauth2-secret", oauth2Secret, "--tls-secret", tlsSecret})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot use more than one of basic auth, bearer auth, tls auth, oauth2 auth and service account auth"))
+		})
+		It("errors if service-account is provided without token-audience", func() {
+			cmd := &RegisterCmd{
+				ServiceAccount: "bananabroker-client",
+			}
+			err := cmd.Validate([]string{"bananabroker", "http://bananabroker.com", "--service-account", "bananabroker-client"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("--token-audience is required with --service-account"))
+		})
+		It("errors if token-audience is provided without service-account", func() {
+			cmd := RegisterCmd{
+				TokenAudience: "https://bananabroker.com",
+			}
+			err := cmd.Validate([]string{"bananabroker", "http://bananabroker.com", "--token-audience", "https://bananabroker.com"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("--token-audience can only be used with --service-account"))
+		})
 		It("errors if a provided CA file does not exist", func() {
 			cmd := RegisterCmd{
 				CAFile: "/not/a/real/file",
@@ -127,7 +181,7 @@ var _ = Describe("Register Command", func() {
 			}
 			err := cmd.Validate([]string{"bananabroker", "http://bananabroker.com"})
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("invalid --relist-duration value, allowed values are: duration, manual"))
+			Expect(err.Error()).To(ContainSubstring("invalid --relist-duration value, allowed values are: duration, manual, proxy"))
 
 			cmd = RegisterCmd{
 				RelistBehavior: "Duration",
@@ -140,6 +194,12 @@ var _ = Describe("Register Command", func() {
 			}
 			err = cmd.Validate([]string{"bananabroker", "http://bananabroker.com"})
 			Expect(err).NotTo(HaveOccurred())
+
+			cmd = RegisterCmd{
+				RelistBehavior: "Proxy",
+			}
+			err = cmd.Validate([]string{"bananabroker", "http://bananabroker.com"})
+			Expect(err).NotTo(HaveOccurred())
 		})
 	})
 	Describe("Run", func() {
@@ -222,6 +282,8 @@ var _ = Describe("Register Command", func() {
 				SkipTLS:           skipTLS,
 				URL:               brokerURL,
 				Waitable:          command.NewWaitable(),
+				Formatted:         command.NewFormatted(),
+				DryRunnable:       command.NewDryRunnable(),
 			}
 			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
 			cmd.Scope = servicecatalog.NamespaceScope
@@ -250,6 +312,34 @@ var _ = Describe("Register Command", func() {
 			Expect(output).To(ContainSubstring(brokerName))
 			Expect(output).To(ContainSubstring(brokerURL))
 		})
+		It("Leaves the relist duration unset without --relist-duration", func() {
+			outputBuffer := &bytes.Buffer{}
+
+			fakeApp, _ := svcat.NewApp(nil, nil, namespace)
+			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.RegisterReturns(brokerToReturn, nil)
+			fakeApp.SvcatClient = fakeSDK
+			cxt := svcattest.NewContext(outputBuffer, fakeApp)
+			cmd := RegisterCmd{
+				BrokerName:     brokerName,
+				Namespaced:     command.NewNamespaced(cxt),
+				RelistBehavior: "proxy",
+				Scoped:         command.NewScoped(),
+				Waitable:       command.NewWaitable(),
+				Formatted:      command.NewFormatted(),
+				DryRunnable:    command.NewDryRunnable(),
+				URL:            brokerURL,
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Waitable.ApplyWaitFlags()
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.RegisterCallCount()).To(Equal(1))
+			_, _, returnedOpts, _ := fakeSDK.RegisterArgsForCall(0)
+			Expect(returnedOpts.RelistBehavior).To(Equal(v1beta1.ServiceBrokerRelistBehaviorProxy))
+			Expect(returnedOpts.RelistDuration).To(BeNil())
+		})
 		It("Passes in the bearer secret", func() {
 			bearerSecret := "foobarsecret"
 			brokerToReturn.Spec.AuthInfo.Basic = nil
@@ -272,6 +362,8 @@ var _ = Describe("Register Command", func() {
 				Namespaced:   command.NewNamespaced(cxt),
 				Scoped:       command.NewScoped(),
 				Waitable:     command.NewWaitable(),
+				Formatted:    command.NewFormatted(),
+				DryRunnable:  command.NewDryRunnable(),
 				URL:          brokerURL,
 			}
 			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
@@ -293,6 +385,95 @@ var _ = Describe("Register Command", func() {
 			Expect(output).To(ContainSubstring(brokerName))
 			Expect(output).To(ContainSubstring(brokerURL))
 		})
+		It("Passes in the tls secret", func() {
+			tlsSecret := "foobarsecret"
+			brokerToReturn.Spec.AuthInfo.Basic = nil
+			brokerToReturn.Spec.AuthInfo.TLS = &v1beta1.ClusterTLSAuthConfig{
+				SecretRef: &v1beta1.ObjectReference{
+					Name: tlsSecret,
+				},
+			}
+
+			outputBuffer := &bytes.Buffer{}
+
+			fakeApp, _ := svcat.NewApp(nil, nil, namespace)
+			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.RegisterReturns(brokerToReturn, nil)
+			fakeApp.SvcatClient = fakeSDK
+			cxt := svcattest.NewContext(outputBuffer, fakeApp)
+			cmd := RegisterCmd{
+				BrokerName:  brokerName,
+				Namespaced:  command.NewNamespaced(cxt),
+				Scoped:      command.NewScoped(),
+				TLSSecret:   tlsSecret,
+				Waitable:    command.NewWaitable(),
+				Formatted:   command.NewFormatted(),
+				DryRunnable: command.NewDryRunnable(),
+				URL:         brokerURL,
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Waitable.ApplyWaitFlags()
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.RegisterCallCount()).To(Equal(1))
+			returnedName, returnedURL, returnedOpts, _ := fakeSDK.RegisterArgsForCall(0)
+			Expect(returnedName).To(Equal(brokerName))
+			Expect(returnedURL).To(Equal(brokerURL))
+			opts := servicecatalog.RegisterOptions{
+				Namespace: namespace,
+				TLSSecret: tlsSecret,
+			}
+			Expect(*returnedOpts).To(Equal(opts))
+
+			output := outputBuffer.String()
+			Expect(output).To(ContainSubstring(brokerName))
+			Expect(output).To(ContainSubstring(brokerURL))
+		})
+		It("Prints the manifest of the broker without registering it on a client dry run", func() {
+			outputBuffer := &bytes.Buffer{}
+
+			fakeApp, _ := svcat.NewApp(nil, nil, namespace)
+			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+			fakeApp.SvcatClient = fakeSDK
+			cxt := svcattest.NewContext(outputBuffer, fakeApp)
+			cmd := RegisterCmd{
+				BasicSecret: basicSecret,
+				BrokerName:  brokerName,
+				Namespaced:  command.NewNamespaced(cxt),
+				Scoped:      command.NewScoped(),
+				Waitable:    command.NewWaitable(),
+				Formatted:   command.NewFormatted(),
+				DryRunnable: command.NewDryRunnable(),
+				URL:         brokerURL,
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Waitable.ApplyWaitFlags()
+			cmd.Scope = servicecatalog.ClusterScope
+			cmd.DryRun = command.DryRunClient
+			cmd.OutputFormat = output.FormatYAML
+
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.RegisterCallCount()).To(Equal(0))
+			Expect(outputBuffer.String()).To(Equal(`apiVersion: servicecatalog.k8s.io/v1beta1
+kind: ClusterServiceBroker
+metadata:
+  creationTimestamp: null
+  name: foobarbroker
+spec:
+  authInfo:
+    basic:
+      secretRef:
+        name: foobarsecret
+        namespace: foobarnamespace
+  catalogRestrictions: {}
+  relistBehavior: ""
+  relistRequests: 0
+  url: http://foobar.com
+`))
+		})
 		It("Calls the SDK's WaitForBroker method with the passed in interval and timeout when Wait==true", func() {
 			interval := 1 * time.Second
 			timeout := 1 * time.Minute
@@ -306,11 +487,13 @@ var _ = Describe("Register Command", func() {
 			fakeApp.SvcatClient = fakeSDK
 			cxt := svcattest.NewContext(outputBuffer, fakeApp)
 			cmd := RegisterCmd{
-				BrokerName: brokerName,
-				Namespaced: command.NewNamespaced(cxt),
-				Scoped:     command.NewScoped(),
-				Waitable:   command.NewWaitable(),
-				URL:        brokerURL,
+				BrokerName:  brokerName,
+				Namespaced:  command.NewNamespaced(cxt),
+				Scoped:      command.NewScoped(),
+				Waitable:    command.NewWaitable(),
+				Formatted:   command.NewFormatted(),
+				DryRunnable: command.NewDryRunnable(),
+				URL:         brokerURL,
 			}
 			cmd.Wait = true
 			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
@@ -70,6 +70,12 @@ func PreRunE(cmd Command) func(*cobra.Command, []string) error {
 				return err
 			}
 		}
+		if dryRunCmd, ok := cmd.(HasDryRunFlag); ok {
+			err := dryRunCmd.ApplyDryRunFlag()
+			if err != nil {
+				return err
+			}
+		}
 		// validate the args and print help info if needed.
 		err := cmd.Validate(args)
 		if err != nil {
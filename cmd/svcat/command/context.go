@@ -28,6 +28,9 @@ type Context struct {
 	// Output should be used instead of directly writing to stdout/stderr, to enable unit testing.
 	Output io.Writer
 
+	// Input should be used instead of directly reading from stdin, to enable unit testing.
+	Input io.Reader
+
 	// svcat application, the library behind the cli
 	App *svcat.App
 
@@ -0,0 +1,100 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	// DryRunNone creates the resource.
+	DryRunNone = "none"
+
+	// DryRunClient prints the resource that would be created, without
+	// sending it to the server.
+	DryRunClient = "client"
+
+	// DryRunServer submits the resource to the server without persisting
+	// it, and prints the resource as defaulted and validated by the server.
+	DryRunServer = "server"
+)
+
+// HasDryRunFlag represents a command that supports --dry-run.
+type HasDryRunFlag interface {
+	// ApplyDryRunFlag validates and persists the --dry-run flag.
+	ApplyDryRunFlag() error
+}
+
+// DryRunnable adds support to a command for the --dry-run flag.
+type DryRunnable struct {
+	DryRun string
+
+	allowServer bool
+}
+
+// NewDryRunnable initializes a new dry runnable command.
+func NewDryRunnable() *DryRunnable {
+	return &DryRunnable{
+		DryRun: DryRunNone,
+	}
+}
+
+// AddDryRunFlag adds the --dry-run flag. --dry-run without a value is a
+// client dry run. allowServer indicates if the command supports server
+// dry runs.
+func (c *DryRunnable) AddDryRunFlag(flags *pflag.FlagSet, allowServer bool) {
+	c.allowServer = allowServer
+	usage := `Must be "none" or "client". If client, only print the resource that would be created, without creating it`
+	if allowServer {
+		usage = `Must be "none", "client" or "server". If client, only print the resource that would be created, without creating it. If server, submit the resource to the server without persisting it, and print it as defaulted and validated by the server`
+	}
+	flags.StringVar(&c.DryRun, "dry-run", DryRunNone, usage)
+	flags.Lookup("dry-run").NoOptDefVal = DryRunClient
+}
+
+// ApplyDryRunFlag validates and persists the --dry-run flag.
+func (c *DryRunnable) ApplyDryRunFlag() error {
+	c.DryRun = strings.ToLower(c.DryRun)
+
+	switch c.DryRun {
+	case DryRunNone, DryRunClient:
+		return nil
+	case DryRunServer:
+		if c.allowServer {
+			return nil
+		}
+	}
+	if c.allowServer {
+		return fmt.Errorf("invalid --dry-run value %q, allowed values are: none, client and server", c.DryRun)
+	}
+	return fmt.Errorf("invalid --dry-run value %q, allowed values are: none and client", c.DryRun)
+}
+
+// IsClientDryRun returns whether the command only prints the resource that
+// would be created.
+func (c *DryRunnable) IsClientDryRun() bool {
+	return c.DryRun == DryRunClient
+}
+
+// IsServerDryRun returns whether the command submits the resource to the
+// server without persisting it.
+func (c *DryRunnable) IsServerDryRun() bool {
+	return c.DryRun == DryRunServer
+}
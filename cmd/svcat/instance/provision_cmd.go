@@ -23,6 +23,7 @@ import (
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/parameters"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
 )
@@ -31,11 +32,14 @@ import (
 type ProvisionCmd struct {
 	*command.Namespaced
 	*command.Waitable
+	*command.Formatted
+	*command.DryRunnable
 
 	ClassKubeName            string
 	ClassName                string
 	ExternalID               string
 	InstanceName             string
+	Interactive              bool
 	JSONParams               string
 	LookupByKubeName         bool
 	Params                   interface{}
@@ -45,13 +49,17 @@ type ProvisionCmd struct {
 	RawParams                []string
 	RawSecrets               []string
 	Secrets                  map[string]string
+
+	plan servicecatalog.Plan
 }
 
 // NewProvisionCmd builds a "svcat provision" command
 func NewProvisionCmd(cxt *command.Context) *cobra.Command {
 	provisionCmd := &ProvisionCmd{
-		Namespaced: command.NewNamespaced(cxt),
-		Waitable:   command.NewWaitable(),
+		Namespaced:  command.NewNamespaced(cxt),
+		Waitable:    command.NewWaitable(),
+		Formatted:   command.NewFormatted(),
+		DryRunnable: command.NewDryRunnable(),
 	}
 	cmd := &cobra.Command{
 		Use:   "provision NAME --plan PLAN --class CLASS",
@@ -60,6 +68,9 @@ func NewProvisionCmd(cxt *command.Context) *cobra.Command {
   svcat provision wordpress-mysql-instance --class mysqldb --plan free -p location=eastus -p sslEnforcement=disabled
   svcat provision wordpress-mysql-instance --external-id a7c00676-4398-11e8-842f-0ed5f89f718b --class mysqldb --plan free
   svcat provision wordpress-mysql-instance --class mysqldb --plan free -s mysecret[dbparams]
+  svcat provision wordpress-mysql-instance --interactive
+  svcat provision wordpress-mysql-instance --class mysqldb --plan free -s mysecret[dbparams] --dry-run=client -o yaml
+  svcat provision wordpress-mysql-instance --class mysqldb --dry-run=server -o yaml
   svcat provision secure-instance --class mysqldb --plan secureDB --params-json '{
     "encrypt" : true,
     "firewallRules" : [
@@ -71,20 +82,47 @@ func NewProvisionCmd(cxt *command.Context) *cobra.Command {
     ]
   }'
 `),
-		PreRunE: command.PreRunE(provisionCmd),
-		RunE:    command.RunE(provisionCmd),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			// The class and plan are prompted for in interactive mode, and
+			// the plan is defaulted by the server in a server dry run
+			if !provisionCmd.Interactive {
+				required := []string{"class", "plan"}
+				if strings.EqualFold(provisionCmd.DryRun, command.DryRunServer) {
+					required = required[:1]
+				}
+				var missing []string
+				for _, name := range required {
+					if !cmd.Flags().Changed(name) {
+						missing = append(missing, name)
+					}
+				}
+				if len(missing) > 0 {
+					return fmt.Errorf(`required flag(s) "%s" not set`, strings.Join(missing, `", "`))
+				}
+			}
+			if err := command.PreRunE(provisionCmd)(cmd, args); err != nil {
+				return err
+			}
+			// Nothing is persisted by a server dry run, so there is nothing to wait for
+			if provisionCmd.Wait && provisionCmd.IsServerDryRun() {
+				return fmt.Errorf("--wait cannot be used with --dry-run=server")
+			}
+			return nil
+		},
+		RunE: command.RunE(provisionCmd),
 	}
 	cmd.Flags().StringVar(&provisionCmd.ClassName, "class", "", "The class name (Required)")
-	cmd.MarkFlagRequired("class")
 	cmd.Flags().StringVar(&provisionCmd.PlanName, "plan", "", "The plan name (Required)")
-	cmd.MarkFlagRequired("plan")
 	cmd.Flags().StringVar(&provisionCmd.ExternalID, "external-id", "", "The ID of the instance for use with the OSB SB API (Optional)")
 	cmd.Flags().BoolVarP(&provisionCmd.LookupByKubeName, "kube-name", "k", false, "Whether or not to interpret the Class/Plan names as Kubernetes names (the default is by external name)")
 	cmd.Flags().StringSliceVarP(&provisionCmd.RawParams, "param", "p", nil, "Additional parameter to use when provisioning the service, format: NAME=VALUE. Cannot be combined with --params-json, Sensitive information should be placed in a secret and specified with --secret")
 	cmd.Flags().StringVar(&provisionCmd.JSONParams, "params-json", "", "Additional parameters to use when provisioning the service, provided as a JSON object. Cannot be combined with --param")
+	cmd.Flags().BoolVarP(&provisionCmd.Interactive, "interactive", "i", false, "Prompt for the instance name, class, plan and parameters that are not provided, then print the equivalent command and manifest before provisioning")
 	cmd.Flags().StringSliceVarP(&provisionCmd.RawSecrets, "secret", "s", nil, "Additional parameter, whose value is stored in a secret, to use when provisioning the service, format: SECRET[KEY]")
 	provisionCmd.AddNamespaceFlags(cmd.Flags(), false)
 	provisionCmd.AddWaitFlags(cmd)
+	provisionCmd.AddOutputFlags(cmd.Flags())
+	provisionCmd.AddDryRunFlag(cmd.Flags(), true)
 
 	return cmd
 }
@@ -92,16 +130,20 @@ func NewProvisionCmd(cxt *command.Context) *cobra.Command {
 // Validate ensures the required args were provided
 // and parses provided params and secrets
 func (c *ProvisionCmd) Validate(args []string) error {
-	if len(args) == 0 {
+	if len(args) > 0 {
+		c.InstanceName = args[0]
+	} else if !c.Interactive {
 		return fmt.Errorf("an instance name is required")
 	}
-	c.InstanceName = args[0]
 
 	var err error
 
 	if c.JSONParams != "" && len(c.RawParams) > 0 {
 		return fmt.Errorf("--params-json cannot be used with --param")
 	}
+	if c.Interactive && (c.JSONParams != "" || len(c.RawParams) > 0) {
+		return fmt.Errorf("--interactive cannot be used with --param or --params-json")
+	}
 
 	if c.JSONParams != "" {
 		c.Params, err = parameters.ParseVariableJSON(c.JSONParams)
@@ -125,10 +167,25 @@ func (c *ProvisionCmd) Validate(args []string) error {
 
 // Run calls the Provision method
 func (c *ProvisionCmd) Run() error {
+	if c.Interactive {
+		return c.runInteractive()
+	}
 	err := c.findKubeNames()
 	if err != nil {
 		return err
 	}
+	err = c.validateParameters()
+	if err != nil {
+		return err
+	}
+	if c.IsClientDryRun() {
+		instance := servicecatalog.BuildInstance(c.InstanceName, c.ClassKubeName, c.PlanKubeName, c.ProvisionClusterInstance, c.provisionOptions())
+		output.WriteInstanceManifest(c.Output, c.OutputFormat, instance)
+		return nil
+	}
+	if c.IsServerDryRun() {
+		return c.provisionDryRun()
+	}
 	return c.provision()
 }
 
@@ -137,54 +194,104 @@ func (c *ProvisionCmd) Run() error {
 // It also sets whether we are provisioning a ClusterServiceClass
 // or ServiceClass instance
 func (c *ProvisionCmd) findKubeNames() error {
+	class, err := c.findClass()
+	if err != nil {
+		return err
+	}
+	return c.findPlan(class)
+}
+
+// findClass finds the class by its Kubernetes or external name, and sets
+// whether we are provisioning a ClusterServiceClass or ServiceClass instance.
+func (c *ProvisionCmd) findClass() (servicecatalog.Class, error) {
 	scopeOpts := servicecatalog.ScopeOptions{
 		Namespace: c.Namespace,
 		Scope:     servicecatalog.AllScope,
 	}
 	if c.LookupByKubeName {
 		c.ClassKubeName = c.ClassName
-		c.PlanKubeName = c.PlanName
 
 		class, err := c.App.RetrieveClassByID(c.ClassKubeName, scopeOpts)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		c.ProvisionClusterInstance = class.IsClusterServiceClass()
-		return nil
+		return class, nil
 	} // else lookup by external name
 	class, err := c.App.RetrieveClassByName(c.ClassName, scopeOpts)
 	if err != nil {
 		if strings.Contains(err.Error(), "more than one matching class") {
-			return fmt.Errorf("More than one class '%s' found, please specify Kubernetes names using --kube-name", c.ClassName)
+			return nil, fmt.Errorf("More than one class '%s' found, please specify Kubernetes names using --kube-name", c.ClassName)
 		}
-		return err
+		return nil, err
 	}
 	c.ClassKubeName = class.GetName()
 	c.ProvisionClusterInstance = class.IsClusterServiceClass()
-	if class.IsClusterServiceClass() {
-		scopeOpts.Scope = servicecatalog.ClusterScope
-	} else {
-		scopeOpts.Scope = servicecatalog.NamespaceScope
+	return class, nil
+}
+
+// findPlan finds the plan of the class by its Kubernetes or external name.
+// Without a plan name, the plan is left to be defaulted by the server.
+func (c *ProvisionCmd) findPlan(class servicecatalog.Class) error {
+	if c.PlanName == "" {
+		return nil
 	}
+	scopeOpts := c.classScopeOptions(class)
+	if c.LookupByKubeName {
+		c.PlanKubeName = c.PlanName
+
+		var err error
+		c.plan, err = c.App.RetrievePlanByID(c.PlanKubeName, scopeOpts)
+		return err
+	} // else lookup by external name
 	plan, err := c.App.RetrievePlanByClassIDAndName(c.ClassKubeName, c.PlanName, scopeOpts)
 	if err != nil {
 		return fmt.Errorf("Unable to find plan '%s': %s", c.PlanName, err.Error())
 	}
 	c.PlanKubeName = plan.GetName()
+	c.plan = plan
 	return nil
 }
 
+// classScopeOptions returns the options to look up the plans of the class in
+// its scope.
+func (c *ProvisionCmd) classScopeOptions(class servicecatalog.Class) servicecatalog.ScopeOptions {
+	scopeOpts := servicecatalog.ScopeOptions{
+		Namespace: c.Namespace,
+		Scope:     servicecatalog.NamespaceScope,
+	}
+	if class.IsClusterServiceClass() {
+		scopeOpts.Scope = servicecatalog.ClusterScope
+	}
+	return scopeOpts
+}
+
+// validateParameters converts the --param values to the types of the
+// parameters in the create schema of the plan, and validates the parameters
+// against the schema before the instance is created.
+func (c *ProvisionCmd) validateParameters() error {
+	if c.plan == nil {
+		return nil
+	}
+	schema, err := servicecatalog.ParseSchema(c.plan.GetInstanceCreateSchema())
+	if err != nil {
+		return err
+	}
+	if len(c.RawParams) > 0 {
+		c.Params, err = parameters.CoerceVariableAssignments(c.Params.(map[string]interface{}), schema)
+		if err != nil {
+			return fmt.Errorf("invalid --param value (%s)", err)
+		}
+	}
+	partial := len(c.Secrets) > 0 || c.plan.GetDefaultProvisionParameters() != nil
+	return parameters.Validate(schema, c.Params, partial)
+}
+
 // Provision calls the pkg/svcat lib to provision the instance,
 // waits if necessary, and then displays the created instance
 // to the user
 func (c *ProvisionCmd) provision() error {
-	opts := &servicecatalog.ProvisionOptions{
-		ExternalID: c.ExternalID,
-		Namespace:  c.Namespace,
-		Params:     c.Params,
-		Secrets:    c.Secrets,
-	}
-	instance, err := c.App.Provision(c.InstanceName, c.ClassKubeName, c.PlanKubeName, c.ProvisionClusterInstance, opts)
+	instance, err := c.App.Provision(c.InstanceName, c.ClassKubeName, c.PlanKubeName, c.ProvisionClusterInstance, c.provisionOptions())
 	if err != nil {
 		return err
 	}
@@ -198,10 +305,43 @@ func (c *ProvisionCmd) provision() error {
 
 		// Always print the instance because the provision did succeed,
 		// and just print any errors that occurred while polling
-		output.WriteInstanceDetails(c.Output, instance)
+		c.writeInstance(instance)
 		return err
 	}
 
-	output.WriteInstanceDetails(c.Output, instance)
+	c.writeInstance(instance)
 	return nil
 }
+
+// provisionDryRun submits the instance to the server without persisting it,
+// and displays the instance as defaulted and validated by the server, e.g.
+// with the default plan and the generated external ID. When the plan is left
+// to be defaulted by the server, the --param values are not coerced to the
+// types of the plan schema, and are submitted as strings.
+func (c *ProvisionCmd) provisionDryRun() error {
+	opts := c.provisionOptions()
+	opts.DryRun = true
+	instance, err := c.App.Provision(c.InstanceName, c.ClassKubeName, c.PlanKubeName, c.ProvisionClusterInstance, opts)
+	if err != nil {
+		return err
+	}
+	output.WriteInstancePreview(c.Output, c.OutputFormat, instance)
+	return nil
+}
+
+func (c *ProvisionCmd) writeInstance(instance *v1beta1.ServiceInstance) {
+	if c.OutputFormat == output.FormatTable {
+		output.WriteInstanceDetails(c.Output, instance)
+		return
+	}
+	output.WriteInstance(c.Output, c.OutputFormat, *instance)
+}
+
+func (c *ProvisionCmd) provisionOptions() *servicecatalog.ProvisionOptions {
+	return &servicecatalog.ProvisionOptions{
+		ExternalID: c.ExternalID,
+		Namespace:  c.Namespace,
+		Params:     c.Params,
+		Secrets:    c.Secrets,
+	}
+}
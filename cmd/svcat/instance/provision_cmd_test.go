@@ -19,10 +19,12 @@ package instance_test
 import (
 	"bytes"
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
 	. "github.com/kubernetes-sigs/service-catalog/cmd/svcat/instance"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
 	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/test"
 	_ "github.com/kubernetes-sigs/service-catalog/internal/test"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
@@ -99,6 +101,20 @@ var _ = Describe("Provision Command", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("--params-json cannot be used with --param"))
 		})
+		It("succeeds without an instance name in interactive mode", func() {
+			cmd := ProvisionCmd{Interactive: true}
+			err := cmd.Validate([]string{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("errors if params are provided in interactive mode", func() {
+			cmd := ProvisionCmd{
+				Interactive: true,
+				RawParams:   []string{"a=b"},
+			}
+			err := cmd.Validate([]string{"bananainstance"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("--interactive cannot be used with --param or --params-json"))
+		})
 		It("succeeds only if the provided json params are parseable json", func() {
 			cmd := ProvisionCmd{
 				JSONParams: "{\"foo\":\"bar\"}",
@@ -231,6 +247,8 @@ var _ = Describe("Provision Command", func() {
 				Secrets:      secrets,
 				Namespaced:   command.NewNamespaced(cxt),
 				Waitable:     command.NewWaitable(),
+				Formatted:    command.NewFormatted(),
+				DryRunnable:  command.NewDryRunnable(),
 			}
 			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
 			cmd.Waitable.ApplyWaitFlags()
@@ -291,6 +309,8 @@ var _ = Describe("Provision Command", func() {
 				Secrets:      secrets,
 				Namespaced:   command.NewNamespaced(cxt),
 				Waitable:     command.NewWaitable(),
+				Formatted:    command.NewFormatted(),
+				DryRunnable:  command.NewDryRunnable(),
 			}
 			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
 			cmd.Waitable.ApplyWaitFlags()
@@ -338,6 +358,8 @@ var _ = Describe("Provision Command", func() {
 				Secrets:      secrets,
 				Namespaced:   command.NewNamespaced(cxt),
 				Waitable:     command.NewWaitable(),
+				Formatted:    command.NewFormatted(),
+				DryRunnable:  command.NewDryRunnable(),
 			}
 			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
 			cmd.Waitable.ApplyWaitFlags()
@@ -350,6 +372,78 @@ var _ = Describe("Provision Command", func() {
 			_, _, _, returnedProvisionClusterInstance, _ := fakeSDK.ProvisionArgsForCall(0)
 			Expect(returnedProvisionClusterInstance).To(BeTrue())
 		})
+		It("prints the manifest of the instance without provisioning it on a client dry run", func() {
+			cmd := ProvisionCmd{
+				ClassName:    className,
+				InstanceName: instanceName,
+				Params:       params,
+				PlanName:     planName,
+				Secrets:      secrets,
+				Namespaced:   command.NewNamespaced(cxt),
+				Waitable:     command.NewWaitable(),
+				Formatted:    command.NewFormatted(),
+				DryRunnable:  command.NewDryRunnable(),
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Waitable.ApplyWaitFlags()
+			cmd.DryRun = command.DryRunClient
+			cmd.OutputFormat = output.FormatYAML
+
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.RetrieveClassByNameCallCount()).To(Equal(1))
+			Expect(fakeSDK.RetrievePlanByClassIDAndNameCallCount()).To(Equal(1))
+			Expect(fakeSDK.ProvisionCallCount()).To(Equal(0))
+			Expect(outputBuffer.String()).To(Equal(`apiVersion: servicecatalog.k8s.io/v1beta1
+kind: ServiceInstance
+metadata:
+  creationTimestamp: null
+  name: myMysql
+  namespace: foobarnamespace
+spec:
+  clusterServiceClassName: mysqlclass1234
+  clusterServicePlanName: mysqlplan1234
+  externalID: ""
+  parameters:
+    foo: bar
+  parametersFrom:
+  - secretKeyRef:
+      key: bar
+      name: foo
+  updateRequests: 0
+`))
+		})
+		It("submits the instance on a server dry run and prints the instance returned by the server, leaving the plan to be defaulted", func() {
+			cmd := ProvisionCmd{
+				ClassName:    className,
+				InstanceName: instanceName,
+				Params:       params,
+				Secrets:      secrets,
+				Namespaced:   command.NewNamespaced(cxt),
+				Waitable:     command.NewWaitable(),
+				Formatted:    command.NewFormatted(),
+				DryRunnable:  command.NewDryRunnable(),
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Waitable.ApplyWaitFlags()
+			cmd.DryRun = command.DryRunServer
+			cmd.OutputFormat = output.FormatYAML
+
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.RetrieveClassByNameCallCount()).To(Equal(1))
+			Expect(fakeSDK.RetrievePlanByClassIDAndNameCallCount()).To(Equal(0))
+			Expect(fakeSDK.ProvisionCallCount()).To(Equal(1))
+			_, returnedClassKubeName, returnedPlanKubeName, _, returnedOpts := fakeSDK.ProvisionArgsForCall(0)
+			Expect(returnedClassKubeName).To(Equal(classKubeName))
+			Expect(returnedPlanKubeName).To(BeEmpty())
+			Expect(returnedOpts.DryRun).To(BeTrue())
+			Expect(fakeSDK.WaitForInstanceCallCount()).To(Equal(0))
+			Expect(outputBuffer.String()).To(ContainSubstring("clusterServicePlanExternalName: " + planName))
+			Expect(outputBuffer.String()).To(ContainSubstring("externalID: " + externalID))
+		})
 		It("sets scope to namespaced for RetrievePlanByClassIDAndName and sets ProvisionClusterInstance to false if provisioning a namespace class instance", func() {
 			instanceToReturn = &v1beta1.ServiceInstance{
 				ObjectMeta: v1.ObjectMeta{
@@ -394,6 +488,8 @@ var _ = Describe("Provision Command", func() {
 				Secrets:      secrets,
 				Namespaced:   command.NewNamespaced(cxt),
 				Waitable:     command.NewWaitable(),
+				Formatted:    command.NewFormatted(),
+				DryRunnable:  command.NewDryRunnable(),
 			}
 			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
 			cmd.Waitable.ApplyWaitFlags()
@@ -423,5 +519,193 @@ var _ = Describe("Provision Command", func() {
 			_, _, _, returnedProvisionClusterInstance, _ := fakeSDK.ProvisionArgsForCall(0)
 			Expect(returnedProvisionClusterInstance).To(BeFalse())
 		})
+		Context("when the plan has a create schema", func() {
+			BeforeEach(func() {
+				planToReturn = &v1beta1.ClusterServicePlan{
+					ObjectMeta: v1.ObjectMeta{
+						Name: planKubeName,
+					},
+					Spec: v1beta1.ClusterServicePlanSpec{
+						CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
+							InstanceCreateParameterSchema: &runtime.RawExtension{Raw: []byte(`{
+								"type": "object",
+								"required": ["location"],
+								"properties": {
+									"location": {"type": "string"},
+									"sslEnforcement": {"type": "boolean"}
+								}
+							}`)},
+						},
+					},
+				}
+				fakeSDK.RetrievePlanByClassIDAndNameReturns(planToReturn, nil)
+			})
+			It("converts the --param values to the types of the schema", func() {
+				cmd := ProvisionCmd{
+					Namespaced:  command.NewNamespaced(cxt),
+					Waitable:    command.NewWaitable(),
+					Formatted:   command.NewFormatted(),
+					DryRunnable: command.NewDryRunnable(),
+					ClassName:   className,
+					PlanName:    planName,
+					RawParams:   []string{"location=eastus", "sslEnforcement=false"},
+				}
+				cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+				cmd.Waitable.ApplyWaitFlags()
+				Expect(cmd.Validate([]string{instanceName})).To(Succeed())
+
+				err := cmd.Run()
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeSDK.ProvisionCallCount()).To(Equal(1))
+				_, _, _, _, returnedOpts := fakeSDK.ProvisionArgsForCall(0)
+				Expect(returnedOpts.Params).To(Equal(map[string]interface{}{"location": "eastus", "sslEnforcement": false}))
+			})
+			It("does not provision the instance if the parameters are invalid", func() {
+				cmd := ProvisionCmd{
+					Namespaced:  command.NewNamespaced(cxt),
+					Waitable:    command.NewWaitable(),
+					Formatted:   command.NewFormatted(),
+					DryRunnable: command.NewDryRunnable(),
+					ClassName:   className,
+					PlanName:    planName,
+					RawParams:   []string{"sslEnforcement=sometimes"},
+				}
+				cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+				cmd.Waitable.ApplyWaitFlags()
+				Expect(cmd.Validate([]string{instanceName})).To(Succeed())
+
+				err := cmd.Run()
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("location: is required"))
+				Expect(err.Error()).To(ContainSubstring("sslEnforcement: must be of type boolean, got string"))
+				Expect(fakeSDK.ProvisionCallCount()).To(Equal(0))
+			})
+			It("does not require the parameters provided by secrets", func() {
+				cmd := ProvisionCmd{
+					Namespaced:  command.NewNamespaced(cxt),
+					Waitable:    command.NewWaitable(),
+					Formatted:   command.NewFormatted(),
+					DryRunnable: command.NewDryRunnable(),
+					ClassName:   className,
+					PlanName:    planName,
+					RawSecrets:  []string{"mysecret[location]"},
+				}
+				cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+				cmd.Waitable.ApplyWaitFlags()
+				Expect(cmd.Validate([]string{instanceName})).To(Succeed())
+
+				err := cmd.Run()
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeSDK.ProvisionCallCount()).To(Equal(1))
+			})
+		})
+		Context("when provisioning interactively", func() {
+			var cmd ProvisionCmd
+			BeforeEach(func() {
+				classes := []servicecatalog.Class{
+					&v1beta1.ClusterServiceClass{
+						ObjectMeta: v1.ObjectMeta{Name: "redisclass1234"},
+						Spec: v1beta1.ClusterServiceClassSpec{
+							CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{ExternalName: "redis", Description: "Redis cache"},
+						},
+					},
+					&v1beta1.ClusterServiceClass{
+						ObjectMeta: v1.ObjectMeta{Name: classKubeName},
+						Spec: v1beta1.ClusterServiceClassSpec{
+							CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{ExternalName: className, Description: "MySQL database"},
+						},
+					},
+				}
+				plans := []servicecatalog.Plan{
+					&v1beta1.ClusterServicePlan{
+						ObjectMeta: v1.ObjectMeta{Name: planKubeName},
+						Spec: v1beta1.ClusterServicePlanSpec{
+							CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
+								ExternalName: planName,
+								Description:  "10 MB of storage",
+								InstanceCreateParameterSchema: &runtime.RawExtension{Raw: []byte(`{
+									"type": "object",
+									"required": ["location"],
+									"properties": {
+										"location": {"type": "string", "description": "The region", "enum": ["eastus", "westus"]},
+										"size": {"type": "integer", "minimum": 1},
+										"sslEnforcement": {"type": "boolean", "default": true}
+									}
+								}`)},
+							},
+						},
+					},
+				}
+				fakeSDK.RetrieveClassesReturns(classes, nil)
+				fakeSDK.RetrievePlansReturns(plans, nil)
+
+				cmd = ProvisionCmd{
+					Namespaced:   command.NewNamespaced(cxt),
+					Waitable:     command.NewWaitable(),
+					Formatted:    command.NewFormatted(),
+					DryRunnable:  command.NewDryRunnable(),
+					InstanceName: instanceName,
+					Interactive:  true,
+				}
+				cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+				cmd.Waitable.ApplyWaitFlags()
+			})
+			It("prompts for the class, plan and parameters, and prints the equivalent command and manifest", func() {
+				// class, plan, location (empty, not allowed, valid), size (invalid, skipped), sslEnforcement (default), confirmation
+				cxt.Input = strings.NewReader("1\n10mb\n\nnorthpole\neastus\n0\n\n\ny\n")
+
+				err := cmd.Run()
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeSDK.RetrievePlansCallCount()).To(Equal(1))
+				returnedClassID, returnedScopeOpts := fakeSDK.RetrievePlansArgsForCall(0)
+				Expect(returnedClassID).To(Equal(classKubeName))
+				Expect(returnedScopeOpts).To(Equal(servicecatalog.ScopeOptions{
+					Namespace: namespace,
+					Scope:     servicecatalog.ClusterScope,
+				}))
+
+				Expect(fakeSDK.ProvisionCallCount()).To(Equal(1))
+				returnedName, returnedClass, returnedPlan, returnedProvisionClusterInstance, returnedOpts := fakeSDK.ProvisionArgsForCall(0)
+				Expect(returnedName).To(Equal(instanceName))
+				Expect(returnedClass).To(Equal(classKubeName))
+				Expect(returnedPlan).To(Equal(planKubeName))
+				Expect(returnedProvisionClusterInstance).To(BeTrue())
+				Expect(returnedOpts.Params).To(Equal(map[string]interface{}{"location": "eastus", "sslEnforcement": true}))
+
+				output := outputBuffer.String()
+				Expect(output).To(ContainSubstring("1)  mysqlclass  MySQL database"))
+				Expect(output).To(ContainSubstring("The region"))
+				Expect(output).To(ContainSubstring("location (string, required): A value is required"))
+				Expect(output).To(ContainSubstring(`Invalid value, must be one of "eastus", "westus"`))
+				Expect(output).To(ContainSubstring("Invalid value, must be greater than or equal to 1"))
+				Expect(output).To(ContainSubstring("sslEnforcement (boolean) [true]: "))
+				Expect(output).To(ContainSubstring("svcat provision myMysql --class mysqlclass --plan 10mb --namespace foobarnamespace --param location=eastus --param sslEnforcement=true"))
+				Expect(output).To(ContainSubstring("kind: ServiceInstance"))
+				Expect(output).To(ContainSubstring("clusterServiceClassName: " + classKubeName))
+				Expect(output).To(ContainSubstring("clusterServicePlanName: " + planKubeName))
+			})
+			It("does not provision the instance unless confirmed", func() {
+				cxt.Input = strings.NewReader("mysqlclass\n1\nwestus\n\n\nn\n")
+
+				err := cmd.Run()
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("aborted provision operation"))
+				Expect(fakeSDK.ProvisionCallCount()).To(Equal(0))
+			})
+			It("errors when the input ends before all the questions are answered", func() {
+				cxt.Input = strings.NewReader("1\n")
+
+				err := cmd.Run()
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("aborted interactive provisioning"))
+				Expect(fakeSDK.ProvisionCallCount()).To(Equal(0))
+			})
+		})
 	})
 })
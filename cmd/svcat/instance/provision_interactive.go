@@ -0,0 +1,433 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/parameters"
+	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+)
+
+// runInteractive prompts for the instance name, class, plan and parameters
+// that were not provided as flags, prints the equivalent non-interactive
+// command and the manifest of the instance, and provisions the instance once
+// confirmed. A client dry run stops after printing the manifest, and a server
+// dry run is submitted without confirmation as nothing is persisted.
+func (c *ProvisionCmd) runInteractive() error {
+	p := newPrompter(c.Input, c.Output)
+
+	for c.InstanceName == "" {
+		name, err := p.ask("Instance name")
+		if err != nil {
+			return err
+		}
+		c.InstanceName = name
+	}
+
+	class, ambiguous, err := c.selectClass(p)
+	if err != nil {
+		return err
+	}
+	err = c.selectPlan(p, class)
+	if err != nil {
+		return err
+	}
+	// Classes from different brokers may share the same external name, in
+	// which case the equivalent command has to use the Kubernetes names
+	if ambiguous && !c.LookupByKubeName {
+		c.LookupByKubeName = true
+		c.ClassName = c.ClassKubeName
+		c.PlanName = c.PlanKubeName
+	}
+
+	err = c.promptParameters(p)
+	if err != nil {
+		return err
+	}
+	c.Params, err = parameters.ParseVariableAssignments(c.RawParams)
+	if err != nil {
+		return fmt.Errorf("invalid --param value (%s)", err)
+	}
+	err = c.validateParameters()
+	if err != nil {
+		return err
+	}
+
+	instance := servicecatalog.BuildInstance(c.InstanceName, c.ClassKubeName, c.PlanKubeName, c.ProvisionClusterInstance, c.provisionOptions())
+	fmt.Fprintf(c.Output, "\nEquivalent command:\n  %s\n\n", c.equivalentCommand())
+	output.WriteInstanceManifest(c.Output, output.FormatYAML, instance)
+	fmt.Fprintln(c.Output)
+	if c.IsClientDryRun() {
+		return nil
+	}
+	if c.IsServerDryRun() {
+		return c.provisionDryRun()
+	}
+
+	answer, err := p.ask("Provision the instance? [y|n]")
+	if err != nil {
+		return err
+	}
+	if strings.ToLower(answer) != "y" {
+		return fmt.Errorf("aborted provision operation")
+	}
+	return c.provision()
+}
+
+// selectClass finds the class provided with --class, or prompts to select
+// one of the classes. It also returns whether other classes have the same
+// external name.
+func (c *ProvisionCmd) selectClass(p *prompter) (servicecatalog.Class, bool, error) {
+	if c.ClassName != "" {
+		class, err := c.findClass()
+		return class, false, err
+	}
+
+	classes, err := c.App.RetrieveClasses(servicecatalog.ScopeOptions{
+		Namespace: c.Namespace,
+		Scope:     servicecatalog.AllScope,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if len(classes) == 0 {
+		return nil, false, fmt.Errorf("no classes found in the cluster or in namespace %s", c.Namespace)
+	}
+	sort.SliceStable(classes, func(i, j int) bool {
+		return classes[i].GetExternalName() < classes[j].GetExternalName()
+	})
+
+	names := make([]string, len(classes))
+	descriptions := make([]string, len(classes))
+	for i, class := range classes {
+		names[i] = class.GetExternalName()
+		descriptions[i] = class.GetDescription()
+	}
+	i, err := p.choose("class", "classes", names, descriptions)
+	if err != nil {
+		return nil, false, err
+	}
+	class := classes[i]
+
+	ambiguous := false
+	for j, other := range classes {
+		if j != i && other.GetExternalName() == class.GetExternalName() {
+			ambiguous = true
+		}
+	}
+	c.ClassName = class.GetExternalName()
+	if c.LookupByKubeName {
+		c.ClassName = class.GetName()
+	}
+	c.ClassKubeName = class.GetName()
+	c.ProvisionClusterInstance = class.IsClusterServiceClass()
+	return class, ambiguous, nil
+}
+
+// selectPlan finds the plan provided with --plan, or prompts to select one of
+// the plans of the class.
+func (c *ProvisionCmd) selectPlan(p *prompter, class servicecatalog.Class) error {
+	if c.PlanName != "" {
+		return c.findPlan(class)
+	}
+
+	plans, err := c.App.RetrievePlans(class.GetName(), c.classScopeOptions(class))
+	if err != nil {
+		return err
+	}
+	if len(plans) == 0 {
+		return fmt.Errorf("no plans found for class %s", class.GetExternalName())
+	}
+	sort.SliceStable(plans, func(i, j int) bool {
+		return plans[i].GetExternalName() < plans[j].GetExternalName()
+	})
+
+	names := make([]string, len(plans))
+	descriptions := make([]string, len(plans))
+	for i, plan := range plans {
+		names[i] = plan.GetExternalName()
+		descriptions[i] = plan.GetDescription()
+	}
+	i, err := p.choose("plan", "plans", names, descriptions)
+	if err != nil {
+		return err
+	}
+	c.plan = plans[i]
+	c.PlanName = c.plan.GetExternalName()
+	if c.LookupByKubeName {
+		c.PlanName = c.plan.GetName()
+	}
+	c.PlanKubeName = c.plan.GetName()
+	return nil
+}
+
+// promptParameters prompts for each parameter in the create schema of the
+// plan, and records the answers as --param values.
+func (c *ProvisionCmd) promptParameters(p *prompter) error {
+	schema, err := servicecatalog.ParseSchema(c.plan.GetInstanceCreateSchema())
+	if err != nil {
+		return err
+	}
+	if schema == nil || len(schema.Properties) == 0 {
+		return nil
+	}
+	fmt.Fprintln(c.Output, "\nParameters (leave empty to skip an optional parameter):")
+	return c.promptProperties(p, schema, "", true)
+}
+
+// promptProperties prompts for the properties of an object, the required
+// properties first. The properties of nested objects are prompted for with
+// their dotted path.
+func (c *ProvisionCmd) promptProperties(p *prompter, schema *servicecatalog.Schema, path string, required bool) error {
+	isRequired := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		isRequired[name] = required
+	}
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if isRequired[names[i]] != isRequired[names[j]] {
+			return isRequired[names[i]]
+		}
+		return names[i] < names[j]
+	})
+
+	for _, name := range names {
+		property := schema.Properties[name]
+		if property == nil {
+			continue
+		}
+		propertyPath := name
+		if path != "" {
+			propertyPath = path + "." + name
+		}
+		var err error
+		if len(property.Type) > 0 && property.HasType("object") && len(property.Properties) > 0 {
+			err = c.promptProperties(p, property, propertyPath, isRequired[name])
+		} else {
+			err = c.promptProperty(p, property, propertyPath, isRequired[name])
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// promptProperty prompts for the value of a parameter until it matches its
+// schema. Array values are separated by commas.
+func (c *ProvisionCmd) promptProperty(p *prompter, schema *servicecatalog.Schema, path string, required bool) error {
+	fmt.Fprintln(c.Output)
+	if schema.Title != "" {
+		fmt.Fprintf(c.Output, "%s\n", schema.Title)
+	}
+	if schema.Description != "" {
+		fmt.Fprintf(c.Output, "%s\n", schema.Description)
+	}
+	if len(schema.Enum) > 0 {
+		values := make([]string, len(schema.Enum))
+		for i, value := range schema.Enum {
+			values[i] = fmt.Sprint(value)
+		}
+		fmt.Fprintf(c.Output, "Allowed values: %s\n", strings.Join(values, ", "))
+	}
+
+	question := path
+	if len(schema.Type) > 0 {
+		question += " (" + strings.Join(schema.Type, "|")
+		if required {
+			question += ", required"
+		}
+		question += ")"
+	} else if required {
+		question += " (required)"
+	}
+	defaultValue, hasDefault := formatDefault(schema.Default)
+	if hasDefault {
+		question += " [" + defaultValue + "]"
+	}
+	isArray := len(schema.Type) > 0 && schema.HasType("array")
+
+	for {
+		answer, err := p.ask(question)
+		if err != nil {
+			return err
+		}
+		if answer == "" {
+			if !hasDefault && required {
+				fmt.Fprintln(c.Output, "A value is required")
+				continue
+			}
+			if !hasDefault {
+				return nil
+			}
+			answer = defaultValue
+		}
+
+		values := []string{answer}
+		var value interface{} = answer
+		if isArray {
+			values = strings.Split(answer, ",")
+			for i := range values {
+				values[i] = strings.TrimSpace(values[i])
+			}
+			value = values
+		}
+		err = schema.Validate(parameters.CoerceValue(schema, value))
+		if errs, ok := err.(servicecatalog.ParameterErrors); ok {
+			for _, e := range errs {
+				fmt.Fprintf(c.Output, "Invalid value, %s\n", e.Error())
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, v := range values {
+			c.RawParams = append(c.RawParams, path+"="+v)
+		}
+		return nil
+	}
+}
+
+// formatDefault formats the default value of a parameter as an answer. Only
+// scalar and array of scalar defaults are supported.
+func formatDefault(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, v != ""
+	case bool, float64:
+		return fmt.Sprint(v), true
+	case []interface{}:
+		values := make([]string, len(v))
+		for i, item := range v {
+			s, ok := formatDefault(item)
+			if !ok {
+				return "", false
+			}
+			values[i] = s
+		}
+		return strings.Join(values, ","), len(values) > 0
+	}
+	return "", false
+}
+
+// equivalentCommand returns the svcat provision command that provisions the
+// same instance without prompting.
+func (c *ProvisionCmd) equivalentCommand() string {
+	args := []string{"svcat", "provision", c.InstanceName, "--class", c.ClassName, "--plan", c.PlanName}
+	if c.LookupByKubeName {
+		args = append(args, "--kube-name")
+	}
+	if c.Namespace != "" {
+		args = append(args, "--namespace", c.Namespace)
+	}
+	if c.ExternalID != "" {
+		args = append(args, "--external-id", c.ExternalID)
+	}
+	for _, param := range c.RawParams {
+		args = append(args, "--param", param)
+	}
+	for _, secret := range c.RawSecrets {
+		args = append(args, "--secret", secret)
+	}
+	if c.Wait {
+		args = append(args, "--wait")
+	}
+
+	for i, arg := range args {
+		args[i] = shellQuote(arg)
+	}
+	return strings.Join(args, " ")
+}
+
+var shellSafe = regexp.MustCompile(`^[a-zA-Z0-9_@%+=:,./-]+$`)
+
+// shellQuote quotes an argument for a POSIX shell when necessary.
+func shellQuote(arg string) string {
+	if shellSafe.MatchString(arg) {
+		return arg
+	}
+	return "'" + strings.Replace(arg, "'", `'"'"'`, -1) + "'"
+}
+
+// prompter asks questions on the output and reads the answers, one per line,
+// from the input.
+type prompter struct {
+	in  *bufio.Scanner
+	out io.Writer
+}
+
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	if in == nil {
+		in = os.Stdin
+	}
+	return &prompter{in: bufio.NewScanner(in), out: out}
+}
+
+// ask prints the question and returns the trimmed answer.
+func (p *prompter) ask(question string) (string, error) {
+	fmt.Fprintf(p.out, "%s: ", question)
+	if !p.in.Scan() {
+		if err := p.in.Err(); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("aborted interactive provisioning, no answer to %q", question)
+	}
+	return strings.TrimSpace(p.in.Text()), nil
+}
+
+// choose lists the numbered options with their description and asks to
+// select one by its number or name, then returns the index of the selected
+// option.
+func (p *prompter) choose(kind, plural string, names, descriptions []string) (int, error) {
+	fmt.Fprintf(p.out, "\nAvailable %s:\n", plural)
+	t := tabwriter.NewWriter(p.out, 0, 4, 2, ' ', 0)
+	for i, name := range names {
+		fmt.Fprintf(t, "  %d)\t%s\t%s\n", i+1, name, descriptions[i])
+	}
+	t.Flush()
+
+	for {
+		answer, err := p.ask(fmt.Sprintf("Select a %s [1-%d]", kind, len(names)))
+		if err != nil {
+			return 0, err
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(names) {
+			return n - 1, nil
+		}
+		for i, name := range names {
+			if name == answer {
+				return i, nil
+			}
+		}
+		fmt.Fprintf(p.out, "Invalid %s %q\n", kind, answer)
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/parameters"
+	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+)
+
+// UpdateCmd contains the info needed to update the plan or parameters of a
+// service instance
+type UpdateCmd struct {
+	*command.Namespaced
+	*command.Waitable
+
+	InstanceName string
+	JSONParams   string
+	Params       interface{}
+	PlanName     string
+	RawParams    []string
+	RawSecrets   []string
+	Secrets      map[string]string
+}
+
+// NewUpdateCmd builds a "svcat update instance" command
+func NewUpdateCmd(cxt *command.Context) *cobra.Command {
+	updateCmd := &UpdateCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Waitable:   command.NewWaitable(),
+	}
+	cmd := &cobra.Command{
+		Use:   "instance NAME",
+		Short: "Update the plan or parameters of an instance",
+		Long: `Update instance changes the plan of the instance, or replaces its parameters
+and secrets. The plan can only be changed if the class of the instance is plan
+updatable, and the parameters are validated against the update schema of the plan.`,
+		Example: command.NormalizeExamples(`
+  svcat update instance wordpress-mysql-instance --plan premium
+  svcat update instance wordpress-mysql-instance -p location=eastus -p sslEnforcement=disabled --wait
+  svcat update instance wordpress-mysql-instance --plan secureDB -s mysecret[dbparams]
+`),
+		PreRunE: command.PreRunE(updateCmd),
+		RunE:    command.RunE(updateCmd),
+	}
+	cmd.Flags().StringVar(&updateCmd.PlanName, "plan", "", "The name of the plan to change the instance to")
+	cmd.Flags().StringSliceVarP(&updateCmd.RawParams, "param", "p", nil, "Parameter replacing the parameters of the instance, format: NAME=VALUE. Cannot be combined with --params-json, Sensitive information should be placed in a secret and specified with --secret")
+	cmd.Flags().StringVar(&updateCmd.JSONParams, "params-json", "", "Parameters replacing the parameters of the instance, provided as a JSON object. Cannot be combined with --param")
+	cmd.Flags().StringSliceVarP(&updateCmd.RawSecrets, "secret", "s", nil, "Parameter, whose value is stored in a secret, replacing the secrets of the instance, format: SECRET[KEY]")
+	updateCmd.AddNamespaceFlags(cmd.Flags(), false)
+	updateCmd.AddWaitFlags(cmd)
+
+	return cmd
+}
+
+// Validate ensures the required args were provided
+// and parses provided params and secrets
+func (c *UpdateCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("an instance name is required")
+	}
+	c.InstanceName = args[0]
+
+	var err error
+
+	if c.JSONParams != "" && len(c.RawParams) > 0 {
+		return fmt.Errorf("--params-json cannot be used with --param")
+	}
+
+	if c.JSONParams != "" {
+		c.Params, err = parameters.ParseVariableJSON(c.JSONParams)
+		if err != nil {
+			return fmt.Errorf("invalid --params-json value (%s)", err)
+		}
+	} else if len(c.RawParams) > 0 {
+		c.Params, err = parameters.ParseVariableAssignments(c.RawParams)
+		if err != nil {
+			return fmt.Errorf("invalid --param value (%s)", err)
+		}
+	}
+
+	if len(c.RawSecrets) > 0 {
+		c.Secrets, err = parameters.ParseKeyMaps(c.RawSecrets)
+		if err != nil {
+			return fmt.Errorf("invalid --secret value (%s)", err)
+		}
+	}
+
+	if c.PlanName == "" && c.Params == nil && c.Secrets == nil {
+		return fmt.Errorf("at least one of --plan, --param, --params-json or --secret is required")
+	}
+
+	return nil
+}
+
+// Run calls the UpdateInstance method, waits if necessary, and then displays
+// the updated instance to the user
+func (c *UpdateCmd) Run() error {
+	if err := c.coerceParameters(); err != nil {
+		return err
+	}
+
+	opts := &servicecatalog.UpdateInstanceOptions{
+		PlanName: c.PlanName,
+		Params:   c.Params,
+		Secrets:  c.Secrets,
+	}
+	instance, err := c.App.UpdateInstance(c.Namespace, c.InstanceName, opts)
+	if err != nil {
+		return err
+	}
+
+	if c.Wait {
+		fmt.Fprintln(c.Output, "Waiting for the instance to be updated...")
+		finalInstance, err := c.App.WaitForInstance(instance.Namespace, instance.Name, c.Interval, c.Timeout)
+		if err == nil {
+			instance = finalInstance
+		}
+
+		// Always print the instance because the update did succeed,
+		// and just print any errors that occurred while polling
+		output.WriteInstanceDetails(c.Output, instance)
+		return err
+	}
+
+	output.WriteInstanceDetails(c.Output, instance)
+	return nil
+}
+
+// coerceParameters converts the --param values to the types of the parameters
+// in the update schema of the plan the instance is updated to. UpdateInstance
+// validates the parameters against the schema.
+func (c *UpdateCmd) coerceParameters() error {
+	if len(c.RawParams) == 0 {
+		return nil
+	}
+	instance, err := c.App.RetrieveInstance(c.Namespace, c.InstanceName)
+	if err != nil {
+		return err
+	}
+	var plan servicecatalog.Plan
+	if c.PlanName != "" {
+		plan, err = c.App.RetrieveInstanceUpdatePlan(instance, c.PlanName)
+	} else {
+		plan, err = c.App.RetrieveInstancePlan(instance)
+	}
+	if err != nil {
+		return err
+	}
+	if plan == nil {
+		return nil
+	}
+	schema, err := servicecatalog.ParseSchema(plan.GetInstanceUpdateSchema())
+	if err != nil {
+		return err
+	}
+	c.Params, err = parameters.CoerceVariableAssignments(c.Params.(map[string]interface{}), schema)
+	if err != nil {
+		return fmt.Errorf("invalid --param value (%s)", err)
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/command"
+	. "github.com/kubernetes-sigs/service-catalog/cmd/svcat/instance"
+	"github.com/kubernetes-sigs/service-catalog/cmd/svcat/test"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat"
+	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog/service-catalogfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("Update Command", func() {
+	Describe("NewUpdateCmd", func() {
+		It("Builds and returns a cobra command with the correct flags", func() {
+			cxt := &command.Context{}
+			cmd := NewUpdateCmd(cxt)
+
+			Expect(*cmd).NotTo(BeNil())
+			Expect(cmd.Use).To(Equal("instance NAME"))
+			Expect(cmd.Short).To(ContainSubstring("Update the plan or parameters of an instance"))
+			Expect(cmd.Example).To(ContainSubstring("svcat update instance wordpress-mysql-instance --plan premium"))
+
+			for _, name := range []string{"plan", "param", "params-json", "secret", "wait", "namespace"} {
+				Expect(cmd.Flags().Lookup(name)).NotTo(BeNil())
+			}
+		})
+	})
+	Describe("Validate", func() {
+		It("errors if no instance name is provided", func() {
+			cmd := UpdateCmd{PlanName: "premium"}
+			err := cmd.Validate([]string{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("an instance name is required"))
+		})
+		It("errors if nothing is updated", func() {
+			cmd := UpdateCmd{}
+			err := cmd.Validate([]string{"bananainstance"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("at least one of --plan, --param, --params-json or --secret is required"))
+		})
+		It("errors if both json params and raw params are provided", func() {
+			cmd := UpdateCmd{
+				JSONParams: "{\"foo\":\"bar\"}",
+				RawParams:  []string{"a=b"},
+			}
+			err := cmd.Validate([]string{"bananainstance"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("--params-json cannot be used with --param"))
+		})
+		It("leaves the params and secrets unset if only the plan is changed", func() {
+			cmd := UpdateCmd{PlanName: "premium"}
+			err := cmd.Validate([]string{"bananainstance"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cmd.Params).To(BeNil())
+			Expect(cmd.Secrets).To(BeNil())
+		})
+		It("parses the params and secrets", func() {
+			cmd := UpdateCmd{
+				RawParams:  []string{"a=b"},
+				RawSecrets: []string{"foo[bar]"},
+			}
+			err := cmd.Validate([]string{"bananainstance"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cmd.Params).To(Equal(map[string]interface{}{"a": "b"}))
+			Expect(cmd.Secrets).To(Equal(map[string]string{"foo": "bar"}))
+		})
+	})
+	Describe("Run", func() {
+		var (
+			cxt              *command.Context
+			fakeApp          *svcat.App
+			fakeSDK          *servicecatalogfakes.FakeSvcatClient
+			instanceName     string
+			instanceToReturn *v1beta1.ServiceInstance
+			namespace        string
+			outputBuffer     *bytes.Buffer
+		)
+		BeforeEach(func() {
+			instanceName = "myMysql"
+			namespace = "foobarnamespace"
+			instanceToReturn = &v1beta1.ServiceInstance{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      instanceName,
+					Namespace: namespace,
+				},
+				Spec: v1beta1.ServiceInstanceSpec{
+					PlanReference: v1beta1.PlanReference{
+						ClusterServiceClassExternalName: "mysqlclass",
+						ClusterServicePlanExternalName:  "premium",
+					},
+				},
+			}
+
+			fakeSDK = new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.UpdateInstanceReturns(instanceToReturn, nil)
+			fakeSDK.WaitForInstanceReturns(instanceToReturn, nil)
+			fakeApp, _ = svcat.NewApp(nil, nil, namespace)
+			fakeApp.SvcatClient = fakeSDK
+			outputBuffer = &bytes.Buffer{}
+			cxt = svcattest.NewContext(outputBuffer, fakeApp)
+		})
+
+		It("Calls the SDK's UpdateInstance method with the changes, and prints the instance", func() {
+			params := map[string]interface{}{"foo": "bar"}
+			cmd := UpdateCmd{
+				InstanceName: instanceName,
+				Params:       params,
+				PlanName:     "premium",
+				Namespaced:   command.NewNamespaced(cxt),
+				Waitable:     command.NewWaitable(),
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Waitable.ApplyWaitFlags()
+
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.UpdateInstanceCallCount()).To(Equal(1))
+			returnedNamespace, returnedName, returnedOpts := fakeSDK.UpdateInstanceArgsForCall(0)
+			Expect(returnedNamespace).To(Equal(namespace))
+			Expect(returnedName).To(Equal(instanceName))
+			Expect(*returnedOpts).To(Equal(servicecatalog.UpdateInstanceOptions{PlanName: "premium", Params: params}))
+			Expect(fakeSDK.WaitForInstanceCallCount()).To(Equal(0))
+
+			output := outputBuffer.String()
+			Expect(output).To(ContainSubstring(instanceName))
+			Expect(output).To(ContainSubstring("premium"))
+		})
+		It("Waits for the instance when Wait==true", func() {
+			interval := 1 * time.Second
+			timeout := 1 * time.Minute
+			cmd := UpdateCmd{
+				InstanceName: instanceName,
+				PlanName:     "premium",
+				Namespaced:   command.NewNamespaced(cxt),
+				Waitable:     command.NewWaitable(),
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Waitable.ApplyWaitFlags()
+			cmd.Wait = true
+			cmd.Interval = interval
+			cmd.Timeout = &timeout
+
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.WaitForInstanceCallCount()).To(Equal(1))
+			waitNamespace, waitName, waitInterval, waitTimeout := fakeSDK.WaitForInstanceArgsForCall(0)
+			Expect(waitNamespace).To(Equal(namespace))
+			Expect(waitName).To(Equal(instanceName))
+			Expect(waitInterval).To(Equal(interval))
+			Expect(*waitTimeout).To(Equal(timeout))
+			Expect(outputBuffer.String()).To(ContainSubstring("Waiting for the instance to be updated..."))
+		})
+		It("Converts the --param values to the types of the update schema of the plan", func() {
+			fakeSDK.RetrieveInstanceReturns(instanceToReturn, nil)
+			fakeSDK.RetrieveInstancePlanReturns(&v1beta1.ClusterServicePlan{
+				Spec: v1beta1.ClusterServicePlanSpec{
+					CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
+						InstanceUpdateParameterSchema: &runtime.RawExtension{Raw: []byte(`{
+							"type": "object",
+							"properties": {
+								"size": {"type": "integer"},
+								"sslEnforcement": {"type": "boolean"}
+							}
+						}`)},
+					},
+				},
+			}, nil)
+			cmd := UpdateCmd{
+				RawParams:  []string{"size=3", "sslEnforcement=false", "location=eastus"},
+				Namespaced: command.NewNamespaced(cxt),
+				Waitable:   command.NewWaitable(),
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Waitable.ApplyWaitFlags()
+			Expect(cmd.Validate([]string{instanceName})).To(Succeed())
+
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.RetrievePlanByClassIDAndNameCallCount()).To(Equal(0))
+			Expect(fakeSDK.UpdateInstanceCallCount()).To(Equal(1))
+			_, _, returnedOpts := fakeSDK.UpdateInstanceArgsForCall(0)
+			Expect(returnedOpts.Params).To(Equal(map[string]interface{}{"size": json.Number("3"), "sslEnforcement": false, "location": "eastus"}))
+		})
+		It("Converts the --param values to the types of the update schema of the new plan", func() {
+			fakeSDK.RetrieveInstanceReturns(instanceToReturn, nil)
+			fakeSDK.RetrieveInstanceUpdatePlanReturns(&v1beta1.ClusterServicePlan{
+				Spec: v1beta1.ClusterServicePlanSpec{
+					CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
+						InstanceUpdateParameterSchema: &runtime.RawExtension{Raw: []byte(`{
+							"type": "object",
+							"properties": {"replicas": {"type": "number"}}
+						}`)},
+					},
+				},
+			}, nil)
+			cmd := UpdateCmd{
+				PlanName:   "premium",
+				RawParams:  []string{"replicas=2.5"},
+				Namespaced: command.NewNamespaced(cxt),
+				Waitable:   command.NewWaitable(),
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Waitable.ApplyWaitFlags()
+			Expect(cmd.Validate([]string{instanceName})).To(Succeed())
+
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			_, returnedPlanName := fakeSDK.RetrieveInstanceUpdatePlanArgsForCall(0)
+			Expect(returnedPlanName).To(Equal("premium"))
+			_, _, returnedOpts := fakeSDK.UpdateInstanceArgsForCall(0)
+			Expect(returnedOpts.Params).To(Equal(map[string]interface{}{"replicas": json.Number("2.5")}))
+		})
+		It("Bubbles up errors", func() {
+			fakeSDK.UpdateInstanceReturns(nil, errors.New("class 'mysqlclass' is not plan updatable"))
+			cmd := UpdateCmd{
+				InstanceName: instanceName,
+				PlanName:     "premium",
+				Namespaced:   command.NewNamespaced(cxt),
+				Waitable:     command.NewWaitable(),
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Waitable.ApplyWaitFlags()
+
+			err := cmd.Run()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("not plan updatable"))
+		})
+	})
+})
@@ -81,10 +81,13 @@ func buildRootCommand(cxt *command.Context) *cobra.Command {
 		Short:        "The Kubernetes Service Catalog Command-Line Interface (CLI)",
 		SilenceUsage: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			// Enable tests to swap the output
+			// Enable tests to swap the output and input
 			if cxt.Output == nil {
 				cxt.Output = cmd.OutOrStdout()
 			}
+			if cxt.Input == nil {
+				cxt.Input = os.Stdin
+			}
 
 			// Initialize flags from kubectl plugin environment variables
 			if plugin.IsPlugin() {
@@ -132,6 +135,9 @@ func buildRootCommand(cxt *command.Context) *cobra.Command {
 		cmd.AddCommand(newInstallCmd(cxt))
 	}
 	cmd.AddCommand(newTouchCmd(cxt))
+	cmd.AddCommand(newUpdateCmd(cxt))
+	cmd.AddCommand(newRotateCmd(cxt))
+	cmd.AddCommand(newDiffCmd(cxt))
 	cmd.AddCommand(versions.NewVersionCmd(cxt))
 	cmd.AddCommand(newCompletionCmd(cxt))
 
@@ -166,6 +172,7 @@ func newGetCmd(cxt *command.Context) *cobra.Command {
 	}
 	cmd.AddCommand(binding.NewGetCmd(cxt))
 	cmd.AddCommand(broker.NewGetCmd(cxt))
+	cmd.AddCommand(broker.NewGetCatalogHistoryCmd(cxt))
 	cmd.AddCommand(class.NewGetCmd(cxt))
 	cmd.AddCommand(instance.NewGetCmd(cxt))
 	cmd.AddCommand(plan.NewGetCmd(cxt))
@@ -206,6 +213,33 @@ func newTouchCmd(cxt *command.Context) *cobra.Command {
 	return cmd
 }
 
+func newUpdateCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update the spec of a resource",
+	}
+	cmd.AddCommand(instance.NewUpdateCmd(cxt))
+	return cmd
+}
+
+func newRotateCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Rotate the credentials of a resource",
+	}
+	cmd.AddCommand(binding.NewRotateCmd(cxt))
+	return cmd
+}
+
+func newDiffCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show the changes between revisions of a resource",
+	}
+	cmd.AddCommand(broker.NewDiffCatalogCmd(cxt))
+	return cmd
+}
+
 func newCompletionCmd(ctx *command.Context) *cobra.Command {
 	return completion.NewCompletionCmd(ctx)
 }
@@ -93,6 +93,12 @@ func WriteBindingDetails(w io.Writer, binding *v1beta1.ServiceBinding) {
 		{"Secret:", binding.Spec.SecretName},
 		{"Instance:", binding.Spec.InstanceRef.Name},
 	})
+	if expiresAt := binding.Status.ExpiresAt; expiresAt != nil {
+		t.Append([]string{"Expires:", expiresAt.String()})
+	}
+	if rotation := binding.Status.RotationStatus; rotation != nil {
+		t.Append([]string{"Rotation:", fmt.Sprintf("%s (request %d)", rotation.Phase, rotation.RotationRequests)})
+	}
 	t.Render()
 
 	writeParameters(w, binding.Spec.Parameters)
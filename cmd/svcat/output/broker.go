@@ -31,8 +31,13 @@ func getBrokerScope(broker servicecatalog.Broker) string {
 }
 
 func getBrokerStatusCondition(status v1beta1.CommonServiceBrokerStatus) v1beta1.ServiceBrokerCondition {
-	if len(status.Conditions) > 0 {
-		return status.Conditions[len(status.Conditions)-1]
+	for i := len(status.Conditions) - 1; i >= 0; i-- {
+		// The Healthy condition describes the last health check and not
+		// the reconciliation of the broker.
+		if status.Conditions[i].Type == v1beta1.ServiceBrokerConditionHealthy {
+			continue
+		}
+		return status.Conditions[i]
 	}
 	return v1beta1.ServiceBrokerCondition{}
 }
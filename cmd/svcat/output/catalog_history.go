@@ -0,0 +1,97 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/cataloghistory"
+	"github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+)
+
+func countCatalogPlans(revision servicecatalog.CatalogRevision) int {
+	plans := 0
+	for _, service := range revision.Catalog.Services {
+		plans += len(service.Plans)
+	}
+	return plans
+}
+
+func writeCatalogHistoryListTable(w io.Writer, history []servicecatalog.CatalogRevision) {
+	t := NewListTable(w)
+	t.SetHeader([]string{
+		"Revision",
+		"Recorded",
+		"Classes",
+		"Plans",
+	})
+	for _, revision := range history {
+		t.Append([]string{
+			strconv.Itoa(revision.Revision),
+			revision.Recorded.UTC().String(),
+			strconv.Itoa(len(revision.Catalog.Services)),
+			strconv.Itoa(countCatalogPlans(revision)),
+		})
+	}
+	t.Render()
+}
+
+// WriteCatalogHistory prints the revisions of the catalog of a broker in the
+// specified output format.
+func WriteCatalogHistory(w io.Writer, outputFormat string, history []servicecatalog.CatalogRevision) {
+	switch outputFormat {
+	case FormatJSON:
+		writeJSON(w, history)
+	case FormatYAML:
+		writeYAML(w, history, 0)
+	case FormatTable:
+		writeCatalogHistoryListTable(w, history)
+	}
+}
+
+func writeCatalogDiffListTable(w io.Writer, changes []cataloghistory.Change) {
+	t := NewListTable(w)
+	t.SetHeader([]string{
+		"Change",
+		"Kind",
+		"Name",
+		"ID",
+	})
+	for _, change := range changes {
+		t.Append([]string{
+			string(change.Type),
+			string(change.Kind),
+			change.Name,
+			change.ID,
+		})
+	}
+	t.Render()
+}
+
+// WriteCatalogDiff prints the changes between two revisions of the catalog of
+// a broker in the specified output format.
+func WriteCatalogDiff(w io.Writer, outputFormat string, changes []cataloghistory.Change) {
+	switch outputFormat {
+	case FormatJSON:
+		writeJSON(w, changes)
+	case FormatYAML:
+		writeYAML(w, changes, 0)
+	case FormatTable:
+		writeCatalogDiffListTable(w, changes)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// manifest is a resource as it is sent to the API server to create it,
+// without its status.
+type manifest struct {
+	metav1.TypeMeta `json:",inline"`
+	Metadata        metav1.ObjectMeta `json:"metadata"`
+	Spec            interface{}       `json:"spec"`
+}
+
+// writeManifest writes the manifest of a resource of the given kind in the
+// given format.
+func writeManifest(w io.Writer, outputFormat string, kind string, meta metav1.ObjectMeta, spec interface{}) {
+	m := manifest{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1beta1.SchemeGroupVersion.String(),
+			Kind:       kind,
+		},
+		Metadata: meta,
+		Spec:     spec,
+	}
+	switch outputFormat {
+	case FormatJSON:
+		writeJSON(w, m)
+		fmt.Fprintln(w)
+	case FormatYAML:
+		writeYAML(w, m, 0)
+	}
+}
+
+// WriteInstanceManifest prints the manifest of an instance that is not
+// created yet. The table format prints the details of the instance.
+func WriteInstanceManifest(w io.Writer, outputFormat string, instance *v1beta1.ServiceInstance) {
+	if outputFormat == FormatTable {
+		WriteInstanceDetails(w, instance)
+		return
+	}
+	writeManifest(w, outputFormat, "ServiceInstance", instance.ObjectMeta, instance.Spec)
+}
+
+// WriteInstancePreview prints an instance returned by a server dry run, as it
+// would have been persisted. The table format prints the details of the
+// instance, including its generated external ID.
+func WriteInstancePreview(w io.Writer, outputFormat string, instance *v1beta1.ServiceInstance) {
+	if outputFormat != FormatTable {
+		writeManifest(w, outputFormat, "ServiceInstance", instance.ObjectMeta, instance.Spec)
+		return
+	}
+	class := instance.Spec.GetSpecifiedClusterServiceClass()
+	plan := instance.Spec.GetSpecifiedClusterServicePlan()
+	if instance.Spec.ServiceClassSpecified() {
+		class = instance.Spec.GetSpecifiedServiceClass()
+		plan = instance.Spec.GetSpecifiedServicePlan()
+	}
+	t := NewDetailsTable(w)
+	t.AppendBulk([][]string{
+		{"Name:", instance.Name},
+		{"Namespace:", instance.Namespace},
+		{"Class:", class},
+		{"Plan:", plan},
+		{"External ID:", instance.Spec.ExternalID},
+	})
+	t.Render()
+
+	writeParameters(w, instance.Spec.Parameters)
+	writeParametersFrom(w, instance.Spec.ParametersFrom)
+}
+
+// WriteBindingManifest prints the manifest of a binding that is not created
+// yet. The table format prints the details of the binding.
+func WriteBindingManifest(w io.Writer, outputFormat string, binding *v1beta1.ServiceBinding) {
+	if outputFormat == FormatTable {
+		WriteBindingDetails(w, binding)
+		return
+	}
+	writeManifest(w, outputFormat, "ServiceBinding", binding.ObjectMeta, binding.Spec)
+}
+
+// WriteBrokerManifest prints the manifest of a broker that is not created
+// yet. The table format prints the details of the broker.
+func WriteBrokerManifest(w io.Writer, outputFormat string, broker servicecatalog.Broker) {
+	if outputFormat == FormatTable {
+		WriteBrokerDetails(w, broker)
+		return
+	}
+	switch b := broker.(type) {
+	case *v1beta1.ClusterServiceBroker:
+		writeManifest(w, outputFormat, "ClusterServiceBroker", b.ObjectMeta, b.Spec)
+	case *v1beta1.ServiceBroker:
+		writeManifest(w, outputFormat, "ServiceBroker", b.ObjectMeta, b.Spec)
+	}
+}
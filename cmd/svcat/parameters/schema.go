@@ -0,0 +1,167 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parameters
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+)
+
+// CoerceVariableAssignments converts the variables parsed by
+// ParseVariableAssignments to the types of the parameters of the schema, and
+// nests the variables named by a dotted path of the schema in objects.
+// Example, for a schema with an integer "size" and an object "network" with
+// a boolean "public" property:
+// map[size:10 network.public:true] becomes map[size:10 network:map[public:true]]
+// Variables that are not in the schema are left unchanged.
+func CoerceVariableAssignments(variables map[string]interface{}, schema *servicecatalog.Schema) (map[string]interface{}, error) {
+	if schema == nil {
+		return variables, nil
+	}
+
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	params := make(map[string]interface{}, len(variables))
+	for _, name := range names {
+		if err := setVariable(params, schema, "", name, variables[name]); err != nil {
+			return nil, err
+		}
+	}
+	return params, nil
+}
+
+// setVariable sets the variable with the given name, relative to the given
+// path, in the params of an object with the given schema.
+func setVariable(params map[string]interface{}, schema *servicecatalog.Schema, path, name string, value interface{}) error {
+	if property, ok := schema.Properties[name]; ok || !strings.Contains(name, ".") {
+		if _, exists := params[name]; exists {
+			return fmt.Errorf("parameter %s is set both as a value and as an object", joinPath(path, name))
+		}
+		params[name] = CoerceValue(property, value)
+		return nil
+	}
+
+	for i := strings.Index(name, "."); i >= 0; i = nextIndex(name, ".", i) {
+		head, rest := name[:i], name[i+1:]
+		property, ok := schema.Properties[head]
+		if !ok || len(property.Type) == 0 || !property.HasType("object") {
+			continue
+		}
+		object, exists := params[head]
+		if !exists {
+			object = map[string]interface{}{}
+			params[head] = object
+		}
+		nested, ok := object.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("parameter %s is set both as a value and as an object", joinPath(path, head))
+		}
+		return setVariable(nested, property, joinPath(path, head), rest, value)
+	}
+
+	// The variable is not in the schema
+	params[name] = value
+	return nil
+}
+
+// CoerceValue converts the string value of a variable, or each of its string
+// values, to the type of the parameter of the schema. Values that cannot be
+// converted are left as strings, so that they are reported by Validate.
+func CoerceValue(schema *servicecatalog.Schema, value interface{}) interface{} {
+	if schema == nil {
+		return value
+	}
+	switch v := value.(type) {
+	case []string:
+		if len(schema.Type) == 0 || !schema.HasType("array") {
+			return v
+		}
+		values := make([]interface{}, len(v))
+		for i, s := range v {
+			values[i] = CoerceValue(schema.Items, s)
+		}
+		return values
+	case string:
+		if schema.HasType("string") {
+			return v
+		}
+		if schema.HasType("boolean") {
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b
+			}
+		}
+		if schema.HasType("integer") || schema.HasType("number") {
+			if _, err := strconv.ParseFloat(v, 64); err == nil {
+				return json.Number(v)
+			}
+		}
+		if schema.HasType("null") && v == "null" {
+			return nil
+		}
+		if schema.HasType("array") {
+			return []interface{}{CoerceValue(schema.Items, v)}
+		}
+		if schema.HasType("object") {
+			var object map[string]interface{}
+			if err := json.Unmarshal([]byte(v), &object); err == nil {
+				return object
+			}
+		}
+	}
+	return value
+}
+
+// Validate validates the parameters against the schema of a plan, and returns
+// the servicecatalog.ParameterErrors listing each invalid parameter. Partial
+// parameters may be completed by the cluster, e.g. from secrets or the
+// defaults of the plan, so they are not required to include the required
+// parameters.
+func Validate(schema *servicecatalog.Schema, params interface{}, partial bool) error {
+	if schema == nil {
+		return nil
+	}
+	if partial {
+		partialSchema := *schema
+		partialSchema.Required = nil
+		schema = &partialSchema
+	}
+	return schema.Validate(params)
+}
+
+func nextIndex(s, sep string, i int) int {
+	j := strings.Index(s[i+1:], sep)
+	if j < 0 {
+		return j
+	}
+	return i + 1 + j
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parameters
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	servicecatalog "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const testSchema = `{
+	"type": "object",
+	"required": ["location"],
+	"properties": {
+		"location": {"type": "string"},
+		"size": {"type": "integer", "minimum": 1},
+		"ratio": {"type": "number"},
+		"encrypt": {"type": "boolean"},
+		"zones": {"type": "array", "items": {"type": "integer"}},
+		"network": {
+			"type": "object",
+			"properties": {
+				"public": {"type": "boolean"},
+				"firewall": {"type": "object", "properties": {"port": {"type": "integer"}}}
+			}
+		},
+		"tags.owner": {"type": "string"}
+	}
+}`
+
+func mustParseSchema(t *testing.T, schema string) *servicecatalog.Schema {
+	s, err := servicecatalog.ParseSchema(&runtime.RawExtension{Raw: []byte(schema)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestCoerceVariableAssignments(t *testing.T) {
+	schema := mustParseSchema(t, testSchema)
+	variables, err := ParseVariableAssignments([]string{
+		"location=eastus",
+		"size=10",
+		"ratio=0.5",
+		"encrypt=true",
+		"zones=1",
+		"zones=2",
+		"network.public=false",
+		"network.firewall.port=3306",
+		"tags.owner=me",
+		"other.key=value",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := CoerceVariableAssignments(variables, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"location": "eastus",
+		"size":     json.Number("10"),
+		"ratio":    json.Number("0.5"),
+		"encrypt":  true,
+		"zones":    []interface{}{json.Number("1"), json.Number("2")},
+		"network": map[string]interface{}{
+			"public":   false,
+			"firewall": map[string]interface{}{"port": json.Number("3306")},
+		},
+		"tags.owner": "me",
+		"other.key":  "value",
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("expected:\n\t%v\ngot:\n\t%v\n", want, got)
+	}
+	if err := Validate(schema, got, false); err != nil {
+		t.Fatalf("expected the coerced parameters to be valid, got %v", err)
+	}
+}
+
+func TestCoerceVariableAssignments_Conflict(t *testing.T) {
+	schema := mustParseSchema(t, testSchema)
+	variables := map[string]interface{}{"network": "public", "network.public": "true"}
+
+	_, err := CoerceVariableAssignments(variables, schema)
+	if err == nil || !strings.Contains(err.Error(), "parameter network is set both as a value and as an object") {
+		t.Fatalf("expected a conflict error, got %v", err)
+	}
+}
+
+func TestCoerceVariableAssignments_NoSchema(t *testing.T) {
+	variables := map[string]interface{}{"size": "10", "network.public": "true"}
+
+	got, err := CoerceVariableAssignments(variables, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(variables, got) {
+		t.Fatalf("expected the variables to be unchanged, got %v", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	schema := mustParseSchema(t, testSchema)
+	variables := map[string]interface{}{"size": "0", "encrypt": "maybe", "network.firewall.port": "any"}
+	params, err := CoerceVariableAssignments(variables, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Validate(schema, params, false)
+	errs, ok := err.(servicecatalog.ParameterErrors)
+	if !ok {
+		t.Fatalf("expected parameter errors, got %v", err)
+	}
+	want := servicecatalog.ParameterErrors{
+		{Path: "location", Message: "is required"},
+		{Path: "encrypt", Message: "must be of type boolean, got string"},
+		{Path: "network.firewall.port", Message: "must be of type integer, got string"},
+		{Path: "size", Message: "must be greater than or equal to 1"},
+	}
+	if !reflect.DeepEqual(want, errs) {
+		t.Fatalf("expected:\n\t%v\ngot:\n\t%v\n", want, errs)
+	}
+
+	// Partial parameters do not need to include the required parameters
+	err = Validate(schema, map[string]interface{}{"size": 1}, true)
+	if err != nil {
+		t.Fatalf("expected the partial parameters to be valid, got %v", err)
+	}
+	if len(schema.Required) != 1 {
+		t.Fatal("expected the schema not to be modified")
+	}
+}
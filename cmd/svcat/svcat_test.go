@@ -169,6 +169,24 @@ func TestCommandValidation(t *testing.T) {
 		{"bind does not accept --param and --params-json",
 			`bind name --params-json '{}' --param k=v`,
 			"--params-json cannot be used with --param"},
+		{"provision requires a known --dry-run value",
+			"provision name --class class --plan plan --dry-run=bogus",
+			`invalid --dry-run value "bogus", allowed values are: none, client and server`},
+		{"provision requires the class and plan",
+			"provision name",
+			`required flag(s) "class", "plan" not set`},
+		{"provision server dry run defaults the plan",
+			"provision name --class class --dry-run=server",
+			""},
+		{"provision server dry run requires the class",
+			"provision name --dry-run=server",
+			`required flag(s) "class" not set`},
+		{"provision server dry run cannot wait",
+			"provision name --class class --dry-run=server --wait",
+			"--wait cannot be used with --dry-run=server"},
+		{"bind does not support server dry run",
+			"bind name --dry-run=server",
+			`invalid --dry-run value "server", allowed values are: none and client`},
 		{"completion no shell specified", "completion", "Shell not specified"},
 		{"completion too many args", "completion arg0 arg1", "Too many arguments. Expected only the shell type"},
 		{"completion unsupported shell", "completion unsupportedShell", "Unsupported shell type \"unsupportedShell\""},
@@ -198,6 +216,7 @@ func TestCommandOutput(t *testing.T) {
 		{name: "get cluster scoped broker (yaml)", cmd: "get broker ups-broker --scope cluster -o yaml", golden: "output/get-broker.yaml"},
 		{name: "describe cluster broker", cmd: "describe broker ups-broker --scope cluster", golden: "output/describe-broker.txt"},
 		{name: "register broker", cmd: "register ups-broker --url http://upsbroker.com", golden: "output/register-broker.txt"},
+		{name: "register broker (dry run)", cmd: "register ups-broker --url http://upsbroker.com --basic-secret ups-auth --dry-run=client -o yaml", golden: "output/register-broker-dry-run.yaml"},
 		{name: "deregister broker", cmd: "deregister ups-broker", golden: "output/deregister-broker.txt"},
 
 		{name: "sync broker", cmd: "sync broker ups-broker", golden: "output/sync-broker.txt"},
@@ -256,10 +275,13 @@ func TestCommandOutput(t *testing.T) {
 		{name: "get instance (yaml)", cmd: "get instance ups-instance -n test-ns -o yaml", golden: "output/get-instance.yaml"},
 		{name: "describe instance", cmd: "describe instance ups-instance -n test-ns", golden: "output/describe-instance.txt"},
 		{name: "bind instance", cmd: "bind ups-instance --name ups-binding -n test-ns", golden: "output/bind-instance.txt"},
+		{name: "bind instance (dry run)", cmd: "bind ups-instance --name ups-binding -n test-ns --secret ups-secret[params] --dry-run=client -o yaml", golden: "output/bind-instance-dry-run.yaml"},
 		{name: "bind instance and wait", cmd: "bind ups-instance --name ups-binding -n test-ns --wait", golden: "output/bind-instance-and-wait.txt"},
 		{name: "unbind instance", cmd: "unbind ups-instance -n test-ns", golden: "output/unbind-instance.txt"},
 		{name: "unbind instance and wait", cmd: "unbind ups-instance -n test-ns --wait", golden: "output/unbind-instance-and-wait.txt"},
 		{name: "provision instance", cmd: "provision ups-instance -n test-ns --class user-provided-service --plan default", golden: "output/provision-instance.txt"},
+		{name: "provision instance (dry run)", cmd: "provision ups-instance -n test-ns --class user-provided-service --plan default --secret ups-secret[params] --dry-run=client -o yaml", golden: "output/provision-instance-dry-run.yaml"},
+		{name: "provision instance (server dry run)", cmd: "provision ups-instance -n test-ns --class user-provided-service --plan default --dry-run=server", golden: "output/provision-instance-dry-run-server.txt"},
 		{name: "provision instance and wait", cmd: "provision ups-instance -n test-ns --class user-provided-service --plan default --wait", golden: "output/provision-instance-and-wait.txt"},
 		{name: "deprovision instance", cmd: "deprovision ups-instance -n test-ns", golden: "output/deprovision-instance.txt"},
 		{name: "list all bindings in a namespace", cmd: "get bindings -n test-ns", golden: "output/get-bindings.txt"},
@@ -416,10 +438,12 @@ func TestParametersForBinding(t *testing.T) {
 
 			executeFakeCommand(t, tc.cmd, cxt, true)
 
-			if c := fakeClient.Actions(); len(c) != 1 {
-				t.Fatal("Expected only 1 action, got ", c)
+			// The instance is retrieved to validate the parameters against
+			// the schema of its plan before the binding is created
+			if c := fakeClient.Actions(); len(c) != 2 || c[0].GetVerb() != "get" {
+				t.Fatal("Expected a get and a create action, got ", c)
 			}
-			action := fakeClient.Actions()[0]
+			action := fakeClient.Actions()[1]
 
 			if action.GetVerb() != "create" {
 				t.Fatal("Expected a create action, but got ", action.GetVerb())
@@ -449,6 +473,88 @@ func TestParametersForBinding(t *testing.T) {
 	}
 }
 
+// TestParametersForBindingWithSchema confirms that --param values are converted
+// to the types of the binding schema of the plan, and validated against it
+func TestParametersForBindingWithSchema(t *testing.T) {
+	instance := &v1beta1.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "NAME", Namespace: "default"},
+		Spec: v1beta1.ServiceInstanceSpec{
+			ClusterServicePlanRef: &v1beta1.ClusterObjectReference{Name: "PLAN"},
+		},
+	}
+	plan := &v1beta1.ClusterServicePlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "PLAN"},
+		Spec: v1beta1.ClusterServicePlanSpec{
+			CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
+				ServiceBindingCreateParameterSchema: &runtime.RawExtension{Raw: []byte(`{
+					"type": "object",
+					"properties": {"admin": {"type": "boolean"}, "ttl": {"type": "integer"}}
+				}`)},
+			},
+		},
+	}
+
+	testcases := []struct {
+		name      string
+		cmd       string
+		params    map[string]interface{}
+		wantError string
+	}{
+		{
+			name:   "bind with --param",
+			cmd:    "bind NAME --param admin=true --param ttl=3600",
+			params: map[string]interface{}{"admin": true, "ttl": float64(3600)},
+		},
+		{
+			name:      "bind with an invalid --param",
+			cmd:       "bind NAME --param admin=yes",
+			wantError: "admin: must be of type boolean, got string",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset(instance, plan)
+
+			cxt := newContext()
+			cxt.App = &svcat.App{
+				CurrentNamespace: "default",
+				SvcatClient:      &servicecatalog.SDK{ServiceCatalogClient: fakeClient},
+			}
+			cxt.Output = ioutil.Discard
+
+			output := executeFakeCommand(t, tc.cmd, cxt, true)
+
+			var binding *v1beta1.ServiceBinding
+			for _, action := range fakeClient.Actions() {
+				if createAction, ok := action.(clientgotesting.CreateAction); ok {
+					binding = createAction.GetObject().(*v1beta1.ServiceBinding)
+				}
+			}
+			if tc.wantError != "" {
+				if binding != nil {
+					t.Fatal("Expected the binding not to be created")
+				}
+				if !strings.Contains(output, tc.wantError) {
+					t.Fatalf("Expected the output to contain %q, got %q", tc.wantError, output)
+				}
+				return
+			}
+			if binding == nil {
+				t.Fatalf("Expected the binding to be created, got %q", output)
+			}
+
+			var params map[string]interface{}
+			if err := json.Unmarshal(binding.Spec.Parameters.Raw, &params); err != nil {
+				t.Error("failed to unmarshal binding.Spec.Parameters")
+			}
+			if eq := reflect.DeepEqual(params, tc.params); !eq {
+				t.Errorf("parameters mismatch, \nwant: %+v, \ngot: %+v", tc.params, params)
+			}
+		})
+	}
+}
+
 // TestPluginFlags ensures that flags are parsed the same in both standalone and plugin mode.
 func TestPluginFlags(t *testing.T) {
 	testcases := []struct {
@@ -80,6 +80,46 @@ type ControllerManagerConfiguration struct {
 	// OSBAPITimeOut the length of the timeout of any request to the broker.
 	OSBAPITimeOut time.Duration
 
+	// InstanceRetrievalInterval is the interval on which the state of
+	// instances of retrievable service classes is fetched from the broker.
+	// Zero disables retrieval.
+	InstanceRetrievalInterval time.Duration
+
+	// BindingRetrievalInterval is the interval on which the credentials of
+	// bindings of retrievable service classes are fetched from the broker and
+	// compared against the Secrets of the bindings. Zero disables retrieval.
+	BindingRetrievalInterval time.Duration
+
+	// BindingRotationGracePeriod is the amount of time the previous binding
+	// is kept at the broker after the credentials of a binding have been
+	// rotated, so that consumers can pick up the new credentials.
+	BindingRotationGracePeriod time.Duration
+
+	// CatalogHistoryLimit is the number of snapshots of the catalog of each
+	// broker that are kept in ConfigMaps. Zero disables the catalog history.
+	CatalogHistoryLimit int
+
+	// CatalogHistoryNamespace is the k8s namespace that the catalog snapshots
+	// of ClusterServiceBrokers are stored in. The snapshots of ServiceBrokers
+	// are stored in the namespace of the broker.
+	CatalogHistoryNamespace string
+
+	// BrokerCircuitBreakerFailureThreshold is the number of consecutive
+	// requests to a broker that have to fail because the broker could not be
+	// reached before requests to the broker are suspended. Zero disables the
+	// circuit breaker.
+	BrokerCircuitBreakerFailureThreshold int
+
+	// BrokerCircuitBreakerOpenDuration is the amount of time requests to a
+	// broker are suspended for before a single request is let through to
+	// probe whether the broker has recovered.
+	BrokerCircuitBreakerOpenDuration time.Duration
+
+	// BrokerHealthCheckInterval is the interval on which the health of ready
+	// brokers is checked, independently of the relisting of their catalogs.
+	// Zero disables health checks.
+	BrokerHealthCheckInterval time.Duration
+
 	// ConcurrentSyncs is the number of resources, per resource type,
 	// that are allowed to sync concurrently. Larger number = more responsive
 	// SC operations, but more CPU (and network) load.
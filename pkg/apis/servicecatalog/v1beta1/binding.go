@@ -0,0 +1,37 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// GetRotationExternalID returns the external ID the binding may be given
+// during the rotation of its credentials: the one chosen by the controller for
+// the new binding once the rotation has started, or the previous one once the
+// new binding has failed. It returns an empty string otherwise.
+func (b *ServiceBinding) GetRotationExternalID() string {
+	rotation := b.Status.RotationStatus
+	if rotation == nil || rotation.PreviousExternalID == "" {
+		return ""
+	}
+	switch {
+	case rotation.Phase == ServiceBindingRotationPhaseBinding &&
+		rotation.PreviousExternalID == b.Spec.ExternalID:
+		return rotation.ExternalID
+	case rotation.Phase == ServiceBindingRotationPhaseFailed &&
+		rotation.PreviousExternalID != b.Spec.ExternalID:
+		return rotation.PreviousExternalID
+	}
+	return ""
+}
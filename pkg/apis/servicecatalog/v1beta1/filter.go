@@ -17,10 +17,14 @@ limitations under the License.
 package v1beta1
 
 import (
+	"bytes"
+	"encoding/json"
 	"strconv"
+	"strings"
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/filter"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // These are functions to support filtering. This is where we can add more fields
@@ -33,17 +37,19 @@ func ConvertServiceClassToProperties(serviceClass *ServiceClass) filter.Properti
 	if serviceClass == nil {
 		return labels.Set{}
 	}
-	return labels.Set{
-		FilterName:             serviceClass.Name,
-		FilterSpecExternalName: serviceClass.Spec.ExternalName,
-		FilterSpecExternalID:   serviceClass.Spec.ExternalID,
+	properties := filter.Set{
+		FilterName:             {serviceClass.Name},
+		FilterSpecExternalName: {serviceClass.Spec.ExternalName},
+		FilterSpecExternalID:   {serviceClass.Spec.ExternalID},
 	}
+	addCommonServiceClassProperties(properties, &serviceClass.Spec.CommonServiceClassSpec)
+	return properties
 }
 
 // IsValidServiceClassProperty returns true if the specified property
 // is a valid filterable property of ServiceClasses
 func IsValidServiceClassProperty(p string) bool {
-	return p == FilterName || p == FilterSpecExternalName || p == FilterSpecExternalID
+	return p == FilterName || p == FilterSpecExternalName || p == FilterSpecExternalID || isValidCommonServiceClassProperty(p)
 }
 
 // ConvertServicePlanToProperties takes a Service Plan and pulls out the
@@ -53,19 +59,21 @@ func ConvertServicePlanToProperties(servicePlan *ServicePlan) filter.Properties
 	if servicePlan == nil {
 		return labels.Set{}
 	}
-	return labels.Set{
-		FilterName:                 servicePlan.Name,
-		FilterSpecExternalName:     servicePlan.Spec.ExternalName,
-		FilterSpecExternalID:       servicePlan.Spec.ExternalID,
-		FilterSpecServiceClassName: servicePlan.Spec.ServiceClassRef.Name,
-		FilterSpecFree:             strconv.FormatBool(servicePlan.Spec.Free),
+	properties := filter.Set{
+		FilterName:                 {servicePlan.Name},
+		FilterSpecExternalName:     {servicePlan.Spec.ExternalName},
+		FilterSpecExternalID:       {servicePlan.Spec.ExternalID},
+		FilterSpecServiceClassName: {servicePlan.Spec.ServiceClassRef.Name},
+		FilterSpecFree:             {strconv.FormatBool(servicePlan.Spec.Free)},
 	}
+	addCommonServicePlanProperties(properties, &servicePlan.Spec.CommonServicePlanSpec)
+	return properties
 }
 
 // IsValidServicePlanProperty returns true if the specified property
 // is a valid filterable property of ServicePlans
 func IsValidServicePlanProperty(p string) bool {
-	return p == FilterName || p == FilterSpecExternalName || p == FilterSpecExternalID || p == FilterSpecServiceClassName || p == FilterSpecFree
+	return p == FilterName || p == FilterSpecExternalName || p == FilterSpecExternalID || p == FilterSpecServiceClassName || p == FilterSpecFree || isValidCommonServicePlanProperty(p)
 }
 
 // ConvertClusterServiceClassToProperties takes a Service Class and pulls out the
@@ -75,17 +83,19 @@ func ConvertClusterServiceClassToProperties(serviceClass *ClusterServiceClass) f
 	if serviceClass == nil {
 		return labels.Set{}
 	}
-	return labels.Set{
-		FilterName:             serviceClass.Name,
-		FilterSpecExternalName: serviceClass.Spec.ExternalName,
-		FilterSpecExternalID:   serviceClass.Spec.ExternalID,
+	properties := filter.Set{
+		FilterName:             {serviceClass.Name},
+		FilterSpecExternalName: {serviceClass.Spec.ExternalName},
+		FilterSpecExternalID:   {serviceClass.Spec.ExternalID},
 	}
+	addCommonServiceClassProperties(properties, &serviceClass.Spec.CommonServiceClassSpec)
+	return properties
 }
 
 // IsValidClusterServiceClassProperty returns true if the specified property
 // is a valid filterable property of ClusterServiceClasses
 func IsValidClusterServiceClassProperty(p string) bool {
-	return p == FilterName || p == FilterSpecExternalName || p == FilterSpecExternalID
+	return p == FilterName || p == FilterSpecExternalName || p == FilterSpecExternalID || isValidCommonServiceClassProperty(p)
 }
 
 // ConvertClusterServicePlanToProperties takes a Service Plan and pulls out the
@@ -95,17 +105,87 @@ func ConvertClusterServicePlanToProperties(servicePlan *ClusterServicePlan) filt
 	if servicePlan == nil {
 		return labels.Set{}
 	}
-	return labels.Set{
-		FilterName:                        servicePlan.Name,
-		FilterSpecExternalName:            servicePlan.Spec.ExternalName,
-		FilterSpecExternalID:              servicePlan.Spec.ExternalID,
-		FilterSpecClusterServiceClassName: servicePlan.Spec.ClusterServiceClassRef.Name,
-		FilterSpecFree:                    strconv.FormatBool(servicePlan.Spec.Free),
+	properties := filter.Set{
+		FilterName:                        {servicePlan.Name},
+		FilterSpecExternalName:            {servicePlan.Spec.ExternalName},
+		FilterSpecExternalID:              {servicePlan.Spec.ExternalID},
+		FilterSpecClusterServiceClassName: {servicePlan.Spec.ClusterServiceClassRef.Name},
+		FilterSpecFree:                    {strconv.FormatBool(servicePlan.Spec.Free)},
 	}
+	addCommonServicePlanProperties(properties, &servicePlan.Spec.CommonServicePlanSpec)
+	return properties
 }
 
 // IsValidClusterServicePlanProperty returns true if the specified property
 // is a valid filterable property of ServicePlans
 func IsValidClusterServicePlanProperty(p string) bool {
-	return p == FilterName || p == FilterSpecExternalName || p == FilterSpecExternalID || p == FilterSpecClusterServiceClassName || p == FilterSpecFree
+	return p == FilterName || p == FilterSpecExternalName || p == FilterSpecExternalID || p == FilterSpecClusterServiceClassName || p == FilterSpecFree || isValidCommonServicePlanProperty(p)
+}
+
+// addCommonServiceClassProperties adds the filterable properties shared by
+// ServiceClasses and ClusterServiceClasses.
+func addCommonServiceClassProperties(properties filter.Set, spec *CommonServiceClassSpec) {
+	properties.Add(FilterSpecTags, spec.Tags...)
+	properties.Add(FilterSpecBindable, strconv.FormatBool(spec.Bindable))
+	properties.Add(FilterSpecPlanUpdatable, strconv.FormatBool(spec.PlanUpdatable))
+	addExternalMetadataProperties(properties, spec.ExternalMetadata)
+}
+
+func isValidCommonServiceClassProperty(p string) bool {
+	return p == FilterSpecTags || p == FilterSpecBindable || p == FilterSpecPlanUpdatable || isExternalMetadataProperty(p)
+}
+
+// addCommonServicePlanProperties adds the filterable properties shared by
+// ServicePlans and ClusterServicePlans.
+func addCommonServicePlanProperties(properties filter.Set, spec *CommonServicePlanSpec) {
+	if spec.Bindable != nil {
+		properties.Add(FilterSpecBindable, strconv.FormatBool(*spec.Bindable))
+	}
+	addExternalMetadataProperties(properties, spec.ExternalMetadata)
+}
+
+func isValidCommonServicePlanProperty(p string) bool {
+	return p == FilterSpecBindable || isExternalMetadataProperty(p)
+}
+
+// isExternalMetadataProperty returns true if the property is a path into the
+// external metadata, e.g. externalMetadata.displayName.
+func isExternalMetadataProperty(p string) bool {
+	return strings.HasPrefix(p, FilterExternalMetadata+".") && len(p) > len(FilterExternalMetadata)+1
+}
+
+// addExternalMetadataProperties adds a property for each scalar value of the
+// external metadata, named after its dotted JSON path. The elements of arrays
+// are values of the same property, so externalMetadata.tags matches any
+// element of a "tags" array. Metadata that is not valid JSON is ignored.
+func addExternalMetadataProperties(properties filter.Set, metadata *runtime.RawExtension) {
+	if metadata == nil || len(metadata.Raw) == 0 {
+		return
+	}
+	decoder := json.NewDecoder(bytes.NewReader(metadata.Raw))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return
+	}
+	addJSONProperties(properties, FilterExternalMetadata, value)
+}
+
+func addJSONProperties(properties filter.Set, path string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			addJSONProperties(properties, path+"."+key, child)
+		}
+	case []interface{}:
+		for _, child := range v {
+			addJSONProperties(properties, path, child)
+		}
+	case string:
+		properties.Add(path, v)
+	case json.Number:
+		properties.Add(path, v.String())
+	case bool:
+		properties.Add(path, strconv.FormatBool(v))
+	}
 }
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func TestConvertServiceClassToProperties(t *testing.T) {
@@ -44,7 +45,7 @@ func TestConvertServiceClassToProperties(t *testing.T) {
 					},
 				},
 			},
-			json: `{"name":"service-class","spec.externalID":"external-id","spec.externalName":"external-class-name"}`,
+			json: `{"name":["service-class"],"spec.bindable":["false"],"spec.externalID":["external-id"],"spec.externalName":["external-class-name"],"spec.planUpdatable":["false"]}`,
 		},
 	}
 	for _, tc := range cases {
@@ -90,7 +91,7 @@ func TestConvertServicePlanToProperties(t *testing.T) {
 					},
 				},
 			},
-			json: `{"name":"service-plan","spec.externalID":"external-id","spec.externalName":"external-plan-name","spec.free":"true","spec.serviceClass.name":"service-class-name"}`,
+			json: `{"name":["service-plan"],"spec.externalID":["external-id"],"spec.externalName":["external-plan-name"],"spec.free":["true"],"spec.serviceClass.name":["service-class-name"]}`,
 		},
 	}
 	for _, tc := range cases {
@@ -132,7 +133,26 @@ func TestConvertClusterServiceClassToProperties(t *testing.T) {
 					},
 				},
 			},
-			json: `{"name":"service-class","spec.externalID":"external-id","spec.externalName":"external-class-name"}`,
+			json: `{"name":["service-class"],"spec.bindable":["false"],"spec.externalID":["external-id"],"spec.externalName":["external-class-name"],"spec.planUpdatable":["false"]}`,
+		},
+		{
+			name: "object with tags and external metadata",
+			sc: &ClusterServiceClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "service-class"},
+				Spec: ClusterServiceClassSpec{
+					CommonServiceClassSpec: CommonServiceClassSpec{
+						ExternalName:  "external-class-name",
+						ExternalID:    "external-id",
+						Bindable:      true,
+						PlanUpdatable: true,
+						Tags:          []string{"mysql", "production"},
+						ExternalMetadata: &runtime.RawExtension{
+							Raw: []byte(`{"displayName":"MySQL","costs":{"amount":4.5,"regions":["eu","us"]},"ha":true,"docs":null}`),
+						},
+					},
+				},
+			},
+			json: `{"externalMetadata.costs.amount":["4.5"],"externalMetadata.costs.regions":["eu","us"],"externalMetadata.displayName":["MySQL"],"externalMetadata.ha":["true"],"name":["service-class"],"spec.bindable":["true"],"spec.externalID":["external-id"],"spec.externalName":["external-class-name"],"spec.planUpdatable":["true"],"spec.tags":["mysql","production"]}`,
 		},
 	}
 	for _, tc := range cases {
@@ -154,6 +174,7 @@ func TestConvertClusterServiceClassToProperties(t *testing.T) {
 }
 
 func TestConvertClusterServicePlanToProperties(t *testing.T) {
+	bindable := false
 	cases := []struct {
 		name string
 		sp   *ClusterServicePlan
@@ -178,7 +199,25 @@ func TestConvertClusterServicePlanToProperties(t *testing.T) {
 					},
 				},
 			},
-			json: `{"name":"service-plan","spec.clusterServiceClass.name":"cluster-service-class-name","spec.externalID":"external-id","spec.externalName":"external-plan-name","spec.free":"true"}`,
+			json: `{"name":["service-plan"],"spec.clusterServiceClass.name":["cluster-service-class-name"],"spec.externalID":["external-id"],"spec.externalName":["external-plan-name"],"spec.free":["true"]}`,
+		},
+		{
+			name: "object overriding bindable with external metadata",
+			sp: &ClusterServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "service-plan"},
+				Spec: ClusterServicePlanSpec{
+					CommonServicePlanSpec: CommonServicePlanSpec{
+						ExternalName:     "external-plan-name",
+						ExternalID:       "external-id",
+						Bindable:         &bindable,
+						ExternalMetadata: &runtime.RawExtension{Raw: []byte(`{"tier":"gold"}`)},
+					},
+					ClusterServiceClassRef: ClusterObjectReference{
+						Name: "cluster-service-class-name",
+					},
+				},
+			},
+			json: `{"externalMetadata.tier":["gold"],"name":["service-plan"],"spec.bindable":["false"],"spec.clusterServiceClass.name":["cluster-service-class-name"],"spec.externalID":["external-id"],"spec.externalName":["external-plan-name"],"spec.free":["false"]}`,
 		},
 	}
 	for _, tc := range cases {
@@ -198,3 +237,23 @@ func TestConvertClusterServicePlanToProperties(t *testing.T) {
 		})
 	}
 }
+
+func TestIsValidClusterServiceClassProperty(t *testing.T) {
+	cases := map[string]bool{
+		FilterName:                     true,
+		FilterSpecTags:                 true,
+		FilterSpecBindable:             true,
+		FilterSpecPlanUpdatable:        true,
+		"externalMetadata.displayName": true,
+		"externalMetadata.costs.unit":  true,
+		"externalMetadata":             false,
+		"externalMetadata.":            false,
+		FilterSpecFree:                 false,
+		"spec.invalidProperty":         false,
+	}
+	for p, expected := range cases {
+		if e, a := expected, IsValidClusterServiceClassProperty(p); e != a {
+			t.Errorf("unexpected validity of property %q; expected %v, got %v", p, e, a)
+		}
+	}
+}
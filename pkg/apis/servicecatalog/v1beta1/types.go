@@ -108,7 +108,8 @@ type CommonServiceBrokerSpec struct {
 	RelistBehavior ServiceBrokerRelistBehavior `json:"relistBehavior"`
 
 	// RelistDuration is the frequency by which a controller will relist the
-	// broker when the RelistBehavior is set to ServiceBrokerRelistBehaviorDuration.
+	// broker when the RelistBehavior is set to ServiceBrokerRelistBehaviorDuration
+	// or ServiceBrokerRelistBehaviorProxy.
 	// Users are cautioned against configuring low values for the RelistDuration,
 	// as this can easily overload the controller manager in an environment with
 	// many brokers. The actual interval is intrinsically governed by the
@@ -126,6 +127,45 @@ type CommonServiceBrokerSpec struct {
 	// and plans have resources created for them.
 	// +optional
 	CatalogRestrictions *CatalogRestrictions `json:"catalogRestrictions,omitempty"`
+
+	// RateLimit limits the rate and concurrency of the requests sent to the
+	// broker by the controller. Requests are not limited when it is unset.
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// +optional
+	RateLimit *ServiceBrokerRateLimit `json:"rateLimit,omitempty"`
+
+	// HealthCheckPath is the path of the endpoint of the broker, relative to
+	// its URL, that the controller sends GET requests to in order to check
+	// whether the broker is up. If unset, HEAD requests are sent to the
+	// catalog endpoint of the broker instead.
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// +optional
+	HealthCheckPath string `json:"healthCheckPath,omitempty"`
+}
+
+// ServiceBrokerRateLimit limits the requests sent to a broker. Requests that
+// exceed the limits are not sent, and the resources they were sent for are
+// reconciled again later.
+type ServiceBrokerRateLimit struct {
+	// QPS is the maximum average number of requests per second sent to the
+	// broker. Zero means the rate of requests is not limited.
+	// +optional
+	QPS int32 `json:"qps,omitempty"`
+
+	// Burst is the maximum number of requests sent to the broker at once
+	// when it has not received requests for a while. Defaults to QPS.
+	// +optional
+	Burst int32 `json:"burst,omitempty"`
+
+	// MaxInFlight is the maximum number of requests sent to the broker that
+	// are awaiting a response. Zero means the number of concurrent requests
+	// is not limited.
+	// +optional
+	MaxInFlight int32 `json:"maxInFlight,omitempty"`
 }
 
 // CatalogRestrictions is a set of restrictions on which of a broker's services
@@ -210,6 +250,16 @@ const (
 	// ServiceBrokerRelistBehaviorManual indicates that the broker is only
 	// relisted when the spec of the broker changes.
 	ServiceBrokerRelistBehaviorManual ServiceBrokerRelistBehavior = "Manual"
+
+	// ServiceBrokerRelistBehaviorProxy indicates that the broker will be
+	// relisted automatically after the specified duration has passed, and
+	// that a relisted catalog identical to the last one is not reconciled
+	// again. The full catalog is fetched on every relist; it is compared to
+	// the last one by a hash of its content.
+	//
+	// Currently, this relist behavior is ALPHA: it may change or disappear
+	// at any time.
+	ServiceBrokerRelistBehaviorProxy ServiceBrokerRelistBehavior = "Proxy"
 )
 
 // ClusterServiceBrokerAuthInfo is a union type that contains information on
@@ -223,6 +273,26 @@ type ClusterServiceBrokerAuthInfo struct {
 	// The value is referenced from the 'token' field of the given secret.  This value should only
 	// contain the token value and not the `Bearer` scheme.
 	Bearer *ClusterBearerTokenAuthConfig `json:"bearer,omitempty"`
+	// TLS provides configuration to authenticate with a client certificate
+	// and key, referenced from the given kubernetes.io/tls secret.
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	TLS *ClusterTLSAuthConfig `json:"tls,omitempty"`
+	// OAuth2 provides configuration to authenticate with an access token
+	// obtained through the OAuth2 client credentials grant, using the client
+	// credentials and token endpoint referenced from the given secret.
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	OAuth2 *ClusterOAuth2AuthConfig `json:"oauth2,omitempty"`
+	// ServiceAccountToken provides configuration to authenticate with a bound
+	// token of the given ServiceAccount, requested by the controller through
+	// the TokenRequest API and presented as a bearer token.
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	ServiceAccountToken *ClusterServiceAccountTokenAuthConfig `json:"serviceAccountToken,omitempty"`
 }
 
 // ClusterBasicAuthConfig provides config for the basic authentication of
@@ -248,6 +318,53 @@ type ClusterBearerTokenAuthConfig struct {
 	SecretRef *ObjectReference `json:"secretRef,omitempty"`
 }
 
+// ClusterTLSAuthConfig provides config for the mutual TLS authentication of
+// cluster scoped brokers.
+type ClusterTLSAuthConfig struct {
+	// SecretRef is a reference to a kubernetes.io/tls Secret containing the
+	// client certificate the catalog should present to this ServiceBroker.
+	//
+	// Required fields:
+	// - Secret.Data["tls.crt"] - PEM encoded client certificate
+	// - Secret.Data["tls.key"] - PEM encoded private key of the certificate
+	SecretRef *ObjectReference `json:"secretRef,omitempty"`
+}
+
+// ClusterOAuth2AuthConfig provides config for the OAuth2 client credentials
+// authentication of cluster scoped brokers.
+type ClusterOAuth2AuthConfig struct {
+	// SecretRef is a reference to a Secret containing the OAuth2 client
+	// credentials the catalog should use to obtain access tokens for this
+	// ServiceBroker.
+	//
+	// Required fields:
+	// - Secret.Data["clientID"] - the client identifier
+	// - Secret.Data["clientSecret"] - the client secret
+	// - Secret.Data["tokenURL"] - the URL of the token endpoint
+	//
+	// Optional fields:
+	// - Secret.Data["scopes"] - space separated scopes to request
+	SecretRef *ObjectReference `json:"secretRef,omitempty"`
+}
+
+// ClusterServiceAccountTokenAuthConfig provides config for the service account
+// token authentication of cluster scoped brokers.
+type ClusterServiceAccountTokenAuthConfig struct {
+	// ServiceAccountRef is a reference to the ServiceAccount whose tokens are
+	// presented to this ServiceBroker.
+	ServiceAccountRef *ObjectReference `json:"serviceAccountRef,omitempty"`
+
+	// Audience is the intended audience of the tokens, which the broker
+	// must verify. It is required, so that the tokens are not accepted by
+	// the API server.
+	Audience string `json:"audience,omitempty"`
+
+	// ExpirationSeconds is the requested lifetime of the tokens. The
+	// controller requests a new token once 80% of its lifetime has elapsed.
+	// Defaults to one hour, and must be at least 10 minutes.
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
 // ServiceBrokerAuthInfo is a union type that contains information on
 // one of the authentication methods the service catalog and brokers may
 // support, according to the OpenServiceBroker API specification
@@ -259,6 +376,26 @@ type ServiceBrokerAuthInfo struct {
 	// The value is referenced from the 'token' field of the given secret.  This value should only
 	// contain the token value and not the `Bearer` scheme.
 	Bearer *BearerTokenAuthConfig `json:"bearer,omitempty"`
+	// TLS provides configuration to authenticate with a client certificate
+	// and key, referenced from the given kubernetes.io/tls secret.
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	TLS *TLSAuthConfig `json:"tls,omitempty"`
+	// OAuth2 provides configuration to authenticate with an access token
+	// obtained through the OAuth2 client credentials grant, using the client
+	// credentials and token endpoint referenced from the given secret.
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	OAuth2 *OAuth2AuthConfig `json:"oauth2,omitempty"`
+	// ServiceAccountToken provides configuration to authenticate with a bound
+	// token of the given ServiceAccount, requested by the controller through
+	// the TokenRequest API and presented as a bearer token.
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	ServiceAccountToken *ServiceAccountTokenAuthConfig `json:"serviceAccountToken,omitempty"`
 }
 
 // BasicAuthConfig provides config for the basic authentication of
@@ -284,6 +421,53 @@ type BearerTokenAuthConfig struct {
 	SecretRef *LocalObjectReference `json:"secretRef,omitempty"`
 }
 
+// TLSAuthConfig provides config for the mutual TLS authentication of
+// namespaced brokers.
+type TLSAuthConfig struct {
+	// SecretRef is a reference to a kubernetes.io/tls Secret containing the
+	// client certificate the catalog should present to this ServiceBroker.
+	//
+	// Required fields:
+	// - Secret.Data["tls.crt"] - PEM encoded client certificate
+	// - Secret.Data["tls.key"] - PEM encoded private key of the certificate
+	SecretRef *LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+// OAuth2AuthConfig provides config for the OAuth2 client credentials
+// authentication of namespaced brokers.
+type OAuth2AuthConfig struct {
+	// SecretRef is a reference to a Secret containing the OAuth2 client
+	// credentials the catalog should use to obtain access tokens for this
+	// ServiceBroker.
+	//
+	// Required fields:
+	// - Secret.Data["clientID"] - the client identifier
+	// - Secret.Data["clientSecret"] - the client secret
+	// - Secret.Data["tokenURL"] - the URL of the token endpoint
+	//
+	// Optional fields:
+	// - Secret.Data["scopes"] - space separated scopes to request
+	SecretRef *LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+// ServiceAccountTokenAuthConfig provides config for the service account
+// token authentication of namespaced brokers.
+type ServiceAccountTokenAuthConfig struct {
+	// ServiceAccountRef is a reference to the ServiceAccount, in the namespace
+	// of the ServiceBroker, whose tokens are presented to this ServiceBroker.
+	ServiceAccountRef *LocalObjectReference `json:"serviceAccountRef,omitempty"`
+
+	// Audience is the intended audience of the tokens, which the broker
+	// must verify. It is required, so that the tokens are not accepted by
+	// the API server.
+	Audience string `json:"audience,omitempty"`
+
+	// ExpirationSeconds is the requested lifetime of the tokens. The
+	// controller requests a new token once 80% of its lifetime has elapsed.
+	// Defaults to one hour, and must be at least 10 minutes.
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
 const (
 	// BasicAuthUsernameKey is the key of the username for SecretTypeBasicAuth secrets
 	BasicAuthUsernameKey = "username"
@@ -292,6 +476,15 @@ const (
 
 	// BearerTokenKey is the key of the bearer token for SecretTypeBearerTokenAuth secrets
 	BearerTokenKey = "token"
+
+	// OAuth2ClientIDKey is the key of the client identifier for OAuth2 secrets
+	OAuth2ClientIDKey = "clientID"
+	// OAuth2ClientSecretKey is the key of the client secret for OAuth2 secrets
+	OAuth2ClientSecretKey = "clientSecret"
+	// OAuth2TokenURLKey is the key of the token endpoint URL for OAuth2 secrets
+	OAuth2TokenURLKey = "tokenURL"
+	// OAuth2ScopesKey is the key of the space separated scopes for OAuth2 secrets
+	OAuth2ScopesKey = "scopes"
 )
 
 // CommonServiceBrokerStatus represents the current status of a Broker.
@@ -313,6 +506,55 @@ type CommonServiceBrokerStatus struct {
 	// LastConditionState aggregates state from the Conditions array
 	// It is used for printing in a kubectl output via additionalPrinterColumns
 	LastConditionState string `json:"lastConditionState"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// LastCatalogDiff summarizes the changes made to the broker's service
+	// classes and plans the last time its catalog was reconciled.
+	LastCatalogDiff *CatalogDiff `json:"lastCatalogDiff,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// LastHealthCheckTime is the time the health of the broker was last
+	// checked.
+	LastHealthCheckTime *metav1.Time `json:"lastHealthCheckTime,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// CatalogHistoryHash is the hash of the catalog recorded as the latest
+	// revision of the catalog history of the broker.
+	CatalogHistoryHash string `json:"catalogHistoryHash,omitempty"`
+}
+
+// CatalogDiff counts the service classes and plans that were added, removed
+// or updated while reconciling a broker's catalog.
+type CatalogDiff struct {
+	// AddedServiceClasses is the number of service classes that were created
+	// or that reappeared in the broker's catalog.
+	AddedServiceClasses int32 `json:"addedServiceClasses"`
+
+	// RemovedServiceClasses is the number of service classes that were
+	// marked as removed from the broker's catalog.
+	RemovedServiceClasses int32 `json:"removedServiceClasses"`
+
+	// UpdatedServiceClasses is the number of existing service classes whose
+	// spec changed.
+	UpdatedServiceClasses int32 `json:"updatedServiceClasses"`
+
+	// AddedServicePlans is the number of service plans that were created or
+	// that reappeared in the broker's catalog.
+	AddedServicePlans int32 `json:"addedServicePlans"`
+
+	// RemovedServicePlans is the number of service plans that were marked as
+	// removed from the broker's catalog.
+	RemovedServicePlans int32 `json:"removedServicePlans"`
+
+	// UpdatedServicePlans is the number of existing service plans whose spec
+	// changed.
+	UpdatedServicePlans int32 `json:"updatedServicePlans"`
 }
 
 // ClusterServiceBrokerStatus represents the current status of a
@@ -358,6 +600,11 @@ const (
 	// ServiceBrokerConditionFailed represents information about a final failure
 	// that should not be retried.
 	ServiceBrokerConditionFailed ServiceBrokerConditionType = "Failed"
+
+	// ServiceBrokerConditionHealthy represents whether the broker was up the
+	// last time its health was checked, independently of the last time its
+	// catalog was fetched.
+	ServiceBrokerConditionHealthy ServiceBrokerConditionType = "Healthy"
 )
 
 // ConditionStatus represents a condition's status.
@@ -489,6 +736,13 @@ type CommonServiceClassSpec struct {
 	// its endpoint is supported for all plans.
 	BindingRetrievable bool `json:"bindingRetrievable"`
 
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// InstanceRetrievable indicates whether fetching a service instance via
+	// a GET on its endpoint is supported for all plans.
+	InstanceRetrievable bool `json:"instanceRetrievable,omitempty"`
+
 	// PlanUpdatable indicates whether instances provisioned from this
 	// ServiceClass may change ServicePlans after being
 	// provisioned.
@@ -651,6 +905,26 @@ type CommonServicePlanSpec struct {
 	// the instance are merged with these defaults, with instance-defined
 	// parameters taking precedence over defaults.
 	DefaultProvisionParameters *runtime.RawExtension `json:"defaultProvisionParameters,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// MaintenanceInfo is the maintenance information of this plan, as
+	// reported by the broker. A change in its version indicates that an
+	// upgrade is available for ServiceInstances on this plan.
+	MaintenanceInfo *MaintenanceInfo `json:"maintenanceInfo,omitempty"`
+}
+
+// MaintenanceInfo describes the version of the software deployed for
+// ServiceInstances of a plan.
+type MaintenanceInfo struct {
+	// Version is the semantic version of the maintenance information.
+	Version string `json:"version"`
+
+	// Description is a human-readable description of the changes in this
+	// version.
+	// +optional
+	Description string `json:"description,omitempty"`
 }
 
 // ClusterServicePlanSpec represents details about a ClusterServicePlan.
@@ -929,6 +1203,15 @@ type ServiceInstanceSpec struct {
 	// been made to the secrets from which the parameters are sourced.
 	// +optional
 	UpdateRequests int64 `json:"updateRequests"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// MaintenanceInfo is the maintenance information this instance should be
+	// at. Setting it to the MaintenanceInfo of the referenced plan requests
+	// an upgrade of the instance when the UpgradeAvailable condition is true.
+	// +optional
+	MaintenanceInfo *MaintenanceInfo `json:"maintenanceInfo,omitempty"`
 }
 
 // ServiceInstanceStatus represents the current status of an Instance.
@@ -954,6 +1237,22 @@ type ServiceInstanceStatus struct {
 	// the service instance.
 	DashboardURL *string `json:"dashboardURL,omitempty"`
 
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// LastRetrievalTime is the time the state of the ServiceInstance was last
+	// fetched from the broker.
+	LastRetrievalTime *metav1.Time `json:"lastRetrievalTime,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// BrokerReportedParametersChecksum is the checksum of the parameters of
+	// the ServiceInstance reported by the broker when its state was last
+	// fetched. The parameters themselves are not stored, since they may hold
+	// values the ServiceInstance gets from secrets.
+	BrokerReportedParametersChecksum string `json:"brokerReportedParametersChecksum,omitempty"`
+
 	// CurrentOperation is the operation the Controller is currently performing
 	// on the ServiceInstance.
 	CurrentOperation ServiceInstanceOperation `json:"currentOperation,omitempty"`
@@ -1041,6 +1340,15 @@ const (
 	// ServiceInstanceConditionOrphanMitigation represents information about an
 	// orphan mitigation that is required after failed provisioning.
 	ServiceInstanceConditionOrphanMitigation ServiceInstanceConditionType = "OrphanMitigation"
+
+	// ServiceInstanceConditionUpgradeAvailable represents information about
+	// whether the plan of the instance offers newer maintenance information
+	// than the instance is at.
+	ServiceInstanceConditionUpgradeAvailable ServiceInstanceConditionType = "UpgradeAvailable"
+
+	// ServiceInstanceConditionDrifted represents information about whether
+	// the state of the instance reported by the broker differs from its spec.
+	ServiceInstanceConditionDrifted ServiceInstanceConditionType = "Drifted"
 )
 
 // ServiceInstanceOperation represents a type of operation the controller can
@@ -1090,6 +1398,10 @@ type ServiceInstancePropertiesState struct {
 
 	// UserInfo is information about the user that made the request.
 	UserInfo *UserInfo `json:"userInfo,omitempty"`
+
+	// MaintenanceInfo is the maintenance information that the broker knows
+	// this ServiceInstance to be at.
+	MaintenanceInfo *MaintenanceInfo `json:"maintenanceInfo,omitempty"`
 }
 
 // ServiceInstanceDeprovisionStatus is the status of deprovisioning a
@@ -1203,7 +1515,8 @@ type ServiceBindingSpec struct {
 
 	// ExternalID is the identity of this object for use with the OSB API.
 	//
-	// Immutable.
+	// Immutable, except when it is replaced by the controller while rotating
+	// the credentials of the ServiceBinding.
 	// +optional
 	ExternalID string `json:"externalID"`
 
@@ -1215,6 +1528,17 @@ type ServiceBindingSpec struct {
 	// settable by the end-user. User-provided values for this field are not saved.
 	// +optional
 	UserInfo *UserInfo `json:"userInfo,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// RotationRequests is a strictly increasing, non-negative integer counter
+	// that can be manually incremented by a user to request the credentials
+	// of the ServiceBinding to be rotated. A new binding is created at the
+	// broker, its credentials replace those in the Secret, and the previous
+	// binding is unbound once the rotation grace period has elapsed.
+	// +optional
+	RotationRequests int64 `json:"rotationRequests,omitempty"`
 }
 
 // ServiceBindingStatus represents the current status of a ServiceBinding.
@@ -1265,6 +1589,35 @@ type ServiceBindingStatus struct {
 	// UnbindStatus describes what has been done to unbind the ServiceBinding.
 	UnbindStatus ServiceBindingUnbindStatus `json:"unbindStatus"`
 
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// LastRetrievalTime is the time the credentials of the ServiceBinding
+	// were last fetched from the broker to be compared against its Secret.
+	LastRetrievalTime *metav1.Time `json:"lastRetrievalTime,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// RotationStatus describes the progress of the most recent rotation of
+	// the credentials of the ServiceBinding.
+	RotationStatus *ServiceBindingRotationStatus `json:"rotationStatus,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// ExpiresAt is the time at which the credentials of the ServiceBinding
+	// expire, as reported by the broker.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// RenewBefore is the time, as reported by the broker, before which the
+	// credentials of the ServiceBinding should be renewed. The controller
+	// rotates the credentials of the ServiceBinding once it is reached.
+	RenewBefore *metav1.Time `json:"renewBefore,omitempty"`
+
 	// LastConditionState aggregates state from the Conditions array
 	// It is used for printing in a kubectl output via additionalPrinterColumns
 	LastConditionState string `json:"lastConditionState"`
@@ -1301,6 +1654,11 @@ const (
 	// ServiceBindingConditionFailed represents a ServiceBindingCondition that has failed
 	// completely and should not be retried.
 	ServiceBindingConditionFailed ServiceBindingConditionType = "Failed"
+
+	// ServiceBindingConditionDrifted represents whether the Secret of the
+	// binding was found to differ from the credentials reported by the broker
+	// the last time they were retrieved.
+	ServiceBindingConditionDrifted ServiceBindingConditionType = "Drifted"
 )
 
 // ServiceBindingOperation represents a type of operation
@@ -1337,6 +1695,60 @@ const (
 	ServiceBindingUnbindStatusFailed ServiceBindingUnbindStatus = "Failed"
 )
 
+// ServiceBindingRotationStatus describes the progress of the rotation of the
+// credentials of a ServiceBinding.
+type ServiceBindingRotationStatus struct {
+	// RotationRequests is the value of spec.rotationRequests the rotation was
+	// started for.
+	RotationRequests int64 `json:"rotationRequests"`
+
+	// Phase is the phase the rotation is in.
+	Phase ServiceBindingRotationPhase `json:"phase"`
+
+	// PreviousExternalID is the external ID of the binding whose credentials
+	// are being replaced. It is cleared once that binding has been unbound.
+	PreviousExternalID string `json:"previousExternalID,omitempty"`
+
+	// ExternalID is the external ID chosen by the controller for the new
+	// binding. Only this value may replace spec.externalID while the new
+	// binding is created.
+	ExternalID string `json:"externalID,omitempty"`
+
+	// StartTime is the time at which the rotation started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// PreviousUnbindTime is the time after which the binding whose
+	// credentials were replaced is unbound.
+	PreviousUnbindTime *metav1.Time `json:"previousUnbindTime,omitempty"`
+
+	// CompletionTime is the time at which the rotation completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Message is a human readable description of why the rotation failed.
+	Message string `json:"message,omitempty"`
+}
+
+// ServiceBindingRotationPhase is the phase of the rotation of the credentials
+// of a ServiceBinding.
+type ServiceBindingRotationPhase string
+
+const (
+	// ServiceBindingRotationPhaseBinding indicates that a new binding is
+	// being created at the broker.
+	ServiceBindingRotationPhaseBinding ServiceBindingRotationPhase = "Binding"
+	// ServiceBindingRotationPhaseGracePeriod indicates that the Secret holds
+	// the credentials of the new binding, and that the previous binding is
+	// kept until the grace period has elapsed.
+	ServiceBindingRotationPhaseGracePeriod ServiceBindingRotationPhase = "GracePeriod"
+	// ServiceBindingRotationPhaseCompleted indicates that the previous
+	// binding has been unbound.
+	ServiceBindingRotationPhaseCompleted ServiceBindingRotationPhase = "Completed"
+	// ServiceBindingRotationPhaseFailed indicates that the new binding could
+	// not be created, and that the binding keeps the credentials of the
+	// previous binding.
+	ServiceBindingRotationPhaseFailed ServiceBindingRotationPhase = "Failed"
+)
+
 // These are external finalizer values to service catalog, must be qualified name.
 const (
 	FinalizerServiceCatalog string = "kubernetes-incubator/service-catalog"
@@ -1426,6 +1838,21 @@ const (
 
 	// FilterSpecFree is only used for plans, determines if the plan is free.
 	FilterSpecFree = "spec.free"
+
+	// FilterSpecTags is only used for classes, the tags of the class. A
+	// requirement on the tags is met if any tag meets it, or for negative
+	// requirements if all tags meet it.
+	FilterSpecTags = "spec.tags"
+	// FilterSpecBindable determines if the class is bindable. Plans only
+	// have this property if they override the value of their class.
+	FilterSpecBindable = "spec.bindable"
+	// FilterSpecPlanUpdatable is only used for classes, determines if
+	// instances of the class can be updated to another plan.
+	FilterSpecPlanUpdatable = "spec.planUpdatable"
+	// FilterExternalMetadata is the prefix of the properties looked up in
+	// the external metadata of classes and plans, using a dotted JSON path
+	// such as externalMetadata.displayName.
+	FilterExternalMetadata = "externalMetadata"
 )
 
 // SecretTransform is a single transformation that is applied to the
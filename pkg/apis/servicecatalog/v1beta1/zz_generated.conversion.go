@@ -76,6 +76,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CatalogDiff)(nil), (*servicecatalog.CatalogDiff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CatalogDiff_To_servicecatalog_CatalogDiff(a.(*CatalogDiff), b.(*servicecatalog.CatalogDiff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.CatalogDiff)(nil), (*CatalogDiff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_CatalogDiff_To_v1beta1_CatalogDiff(a.(*servicecatalog.CatalogDiff), b.(*CatalogDiff), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CatalogRestrictions)(nil), (*servicecatalog.CatalogRestrictions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_CatalogRestrictions_To_servicecatalog_CatalogRestrictions(a.(*CatalogRestrictions), b.(*servicecatalog.CatalogRestrictions), scope)
 	}); err != nil {
@@ -106,6 +116,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ClusterOAuth2AuthConfig)(nil), (*servicecatalog.ClusterOAuth2AuthConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ClusterOAuth2AuthConfig_To_servicecatalog_ClusterOAuth2AuthConfig(a.(*ClusterOAuth2AuthConfig), b.(*servicecatalog.ClusterOAuth2AuthConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.ClusterOAuth2AuthConfig)(nil), (*ClusterOAuth2AuthConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_ClusterOAuth2AuthConfig_To_v1beta1_ClusterOAuth2AuthConfig(a.(*servicecatalog.ClusterOAuth2AuthConfig), b.(*ClusterOAuth2AuthConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ClusterObjectReference)(nil), (*servicecatalog.ClusterObjectReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ClusterObjectReference_To_servicecatalog_ClusterObjectReference(a.(*ClusterObjectReference), b.(*servicecatalog.ClusterObjectReference), scope)
 	}); err != nil {
@@ -116,6 +136,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ClusterServiceAccountTokenAuthConfig)(nil), (*servicecatalog.ClusterServiceAccountTokenAuthConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ClusterServiceAccountTokenAuthConfig_To_servicecatalog_ClusterServiceAccountTokenAuthConfig(a.(*ClusterServiceAccountTokenAuthConfig), b.(*servicecatalog.ClusterServiceAccountTokenAuthConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.ClusterServiceAccountTokenAuthConfig)(nil), (*ClusterServiceAccountTokenAuthConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_ClusterServiceAccountTokenAuthConfig_To_v1beta1_ClusterServiceAccountTokenAuthConfig(a.(*servicecatalog.ClusterServiceAccountTokenAuthConfig), b.(*ClusterServiceAccountTokenAuthConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ClusterServiceBroker)(nil), (*servicecatalog.ClusterServiceBroker)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ClusterServiceBroker_To_servicecatalog_ClusterServiceBroker(a.(*ClusterServiceBroker), b.(*servicecatalog.ClusterServiceBroker), scope)
 	}); err != nil {
@@ -246,6 +276,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ClusterTLSAuthConfig)(nil), (*servicecatalog.ClusterTLSAuthConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ClusterTLSAuthConfig_To_servicecatalog_ClusterTLSAuthConfig(a.(*ClusterTLSAuthConfig), b.(*servicecatalog.ClusterTLSAuthConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.ClusterTLSAuthConfig)(nil), (*ClusterTLSAuthConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_ClusterTLSAuthConfig_To_v1beta1_ClusterTLSAuthConfig(a.(*servicecatalog.ClusterTLSAuthConfig), b.(*ClusterTLSAuthConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CommonServiceBrokerSpec)(nil), (*servicecatalog.CommonServiceBrokerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_CommonServiceBrokerSpec_To_servicecatalog_CommonServiceBrokerSpec(a.(*CommonServiceBrokerSpec), b.(*servicecatalog.CommonServiceBrokerSpec), scope)
 	}); err != nil {
@@ -316,6 +356,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MaintenanceInfo)(nil), (*servicecatalog.MaintenanceInfo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_MaintenanceInfo_To_servicecatalog_MaintenanceInfo(a.(*MaintenanceInfo), b.(*servicecatalog.MaintenanceInfo), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.MaintenanceInfo)(nil), (*MaintenanceInfo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_MaintenanceInfo_To_v1beta1_MaintenanceInfo(a.(*servicecatalog.MaintenanceInfo), b.(*MaintenanceInfo), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OAuth2AuthConfig)(nil), (*servicecatalog.OAuth2AuthConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OAuth2AuthConfig_To_servicecatalog_OAuth2AuthConfig(a.(*OAuth2AuthConfig), b.(*servicecatalog.OAuth2AuthConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.OAuth2AuthConfig)(nil), (*OAuth2AuthConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_OAuth2AuthConfig_To_v1beta1_OAuth2AuthConfig(a.(*servicecatalog.OAuth2AuthConfig), b.(*OAuth2AuthConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ObjectReference)(nil), (*servicecatalog.ObjectReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ObjectReference_To_servicecatalog_ObjectReference(a.(*ObjectReference), b.(*servicecatalog.ObjectReference), scope)
 	}); err != nil {
@@ -386,6 +446,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ServiceAccountTokenAuthConfig)(nil), (*servicecatalog.ServiceAccountTokenAuthConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ServiceAccountTokenAuthConfig_To_servicecatalog_ServiceAccountTokenAuthConfig(a.(*ServiceAccountTokenAuthConfig), b.(*servicecatalog.ServiceAccountTokenAuthConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.ServiceAccountTokenAuthConfig)(nil), (*ServiceAccountTokenAuthConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_ServiceAccountTokenAuthConfig_To_v1beta1_ServiceAccountTokenAuthConfig(a.(*servicecatalog.ServiceAccountTokenAuthConfig), b.(*ServiceAccountTokenAuthConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ServiceBinding)(nil), (*servicecatalog.ServiceBinding)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ServiceBinding_To_servicecatalog_ServiceBinding(a.(*ServiceBinding), b.(*servicecatalog.ServiceBinding), scope)
 	}); err != nil {
@@ -426,6 +496,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ServiceBindingRotationStatus)(nil), (*servicecatalog.ServiceBindingRotationStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ServiceBindingRotationStatus_To_servicecatalog_ServiceBindingRotationStatus(a.(*ServiceBindingRotationStatus), b.(*servicecatalog.ServiceBindingRotationStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.ServiceBindingRotationStatus)(nil), (*ServiceBindingRotationStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_ServiceBindingRotationStatus_To_v1beta1_ServiceBindingRotationStatus(a.(*servicecatalog.ServiceBindingRotationStatus), b.(*ServiceBindingRotationStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ServiceBindingSpec)(nil), (*servicecatalog.ServiceBindingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ServiceBindingSpec_To_servicecatalog_ServiceBindingSpec(a.(*ServiceBindingSpec), b.(*servicecatalog.ServiceBindingSpec), scope)
 	}); err != nil {
@@ -486,6 +566,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ServiceBrokerRateLimit)(nil), (*servicecatalog.ServiceBrokerRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ServiceBrokerRateLimit_To_servicecatalog_ServiceBrokerRateLimit(a.(*ServiceBrokerRateLimit), b.(*servicecatalog.ServiceBrokerRateLimit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.ServiceBrokerRateLimit)(nil), (*ServiceBrokerRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_ServiceBrokerRateLimit_To_v1beta1_ServiceBrokerRateLimit(a.(*servicecatalog.ServiceBrokerRateLimit), b.(*ServiceBrokerRateLimit), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ServiceBrokerSpec)(nil), (*servicecatalog.ServiceBrokerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ServiceBrokerSpec_To_servicecatalog_ServiceBrokerSpec(a.(*ServiceBrokerSpec), b.(*servicecatalog.ServiceBrokerSpec), scope)
 	}); err != nil {
@@ -646,6 +736,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*TLSAuthConfig)(nil), (*servicecatalog.TLSAuthConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_TLSAuthConfig_To_servicecatalog_TLSAuthConfig(a.(*TLSAuthConfig), b.(*servicecatalog.TLSAuthConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.TLSAuthConfig)(nil), (*TLSAuthConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_TLSAuthConfig_To_v1beta1_TLSAuthConfig(a.(*servicecatalog.TLSAuthConfig), b.(*TLSAuthConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*UserInfo)(nil), (*servicecatalog.UserInfo)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_UserInfo_To_servicecatalog_UserInfo(a.(*UserInfo), b.(*servicecatalog.UserInfo), scope)
 	}); err != nil {
@@ -745,6 +845,36 @@ func Convert_servicecatalog_BearerTokenAuthConfig_To_v1beta1_BearerTokenAuthConf
 	return autoConvert_servicecatalog_BearerTokenAuthConfig_To_v1beta1_BearerTokenAuthConfig(in, out, s)
 }
 
+func autoConvert_v1beta1_CatalogDiff_To_servicecatalog_CatalogDiff(in *CatalogDiff, out *servicecatalog.CatalogDiff, s conversion.Scope) error {
+	out.AddedServiceClasses = in.AddedServiceClasses
+	out.RemovedServiceClasses = in.RemovedServiceClasses
+	out.UpdatedServiceClasses = in.UpdatedServiceClasses
+	out.AddedServicePlans = in.AddedServicePlans
+	out.RemovedServicePlans = in.RemovedServicePlans
+	out.UpdatedServicePlans = in.UpdatedServicePlans
+	return nil
+}
+
+// Convert_v1beta1_CatalogDiff_To_servicecatalog_CatalogDiff is an autogenerated conversion function.
+func Convert_v1beta1_CatalogDiff_To_servicecatalog_CatalogDiff(in *CatalogDiff, out *servicecatalog.CatalogDiff, s conversion.Scope) error {
+	return autoConvert_v1beta1_CatalogDiff_To_servicecatalog_CatalogDiff(in, out, s)
+}
+
+func autoConvert_servicecatalog_CatalogDiff_To_v1beta1_CatalogDiff(in *servicecatalog.CatalogDiff, out *CatalogDiff, s conversion.Scope) error {
+	out.AddedServiceClasses = in.AddedServiceClasses
+	out.RemovedServiceClasses = in.RemovedServiceClasses
+	out.UpdatedServiceClasses = in.UpdatedServiceClasses
+	out.AddedServicePlans = in.AddedServicePlans
+	out.RemovedServicePlans = in.RemovedServicePlans
+	out.UpdatedServicePlans = in.UpdatedServicePlans
+	return nil
+}
+
+// Convert_servicecatalog_CatalogDiff_To_v1beta1_CatalogDiff is an autogenerated conversion function.
+func Convert_servicecatalog_CatalogDiff_To_v1beta1_CatalogDiff(in *servicecatalog.CatalogDiff, out *CatalogDiff, s conversion.Scope) error {
+	return autoConvert_servicecatalog_CatalogDiff_To_v1beta1_CatalogDiff(in, out, s)
+}
+
 func autoConvert_v1beta1_CatalogRestrictions_To_servicecatalog_CatalogRestrictions(in *CatalogRestrictions, out *servicecatalog.CatalogRestrictions, s conversion.Scope) error {
 	out.ServiceClass = *(*[]string)(unsafe.Pointer(&in.ServiceClass))
 	out.ServicePlan = *(*[]string)(unsafe.Pointer(&in.ServicePlan))
@@ -807,6 +937,26 @@ func Convert_servicecatalog_ClusterBearerTokenAuthConfig_To_v1beta1_ClusterBeare
 	return autoConvert_servicecatalog_ClusterBearerTokenAuthConfig_To_v1beta1_ClusterBearerTokenAuthConfig(in, out, s)
 }
 
+func autoConvert_v1beta1_ClusterOAuth2AuthConfig_To_servicecatalog_ClusterOAuth2AuthConfig(in *ClusterOAuth2AuthConfig, out *servicecatalog.ClusterOAuth2AuthConfig, s conversion.Scope) error {
+	out.SecretRef = (*servicecatalog.ObjectReference)(unsafe.Pointer(in.SecretRef))
+	return nil
+}
+
+// Convert_v1beta1_ClusterOAuth2AuthConfig_To_servicecatalog_ClusterOAuth2AuthConfig is an autogenerated conversion function.
+func Convert_v1beta1_ClusterOAuth2AuthConfig_To_servicecatalog_ClusterOAuth2AuthConfig(in *ClusterOAuth2AuthConfig, out *servicecatalog.ClusterOAuth2AuthConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterOAuth2AuthConfig_To_servicecatalog_ClusterOAuth2AuthConfig(in, out, s)
+}
+
+func autoConvert_servicecatalog_ClusterOAuth2AuthConfig_To_v1beta1_ClusterOAuth2AuthConfig(in *servicecatalog.ClusterOAuth2AuthConfig, out *ClusterOAuth2AuthConfig, s conversion.Scope) error {
+	out.SecretRef = (*ObjectReference)(unsafe.Pointer(in.SecretRef))
+	return nil
+}
+
+// Convert_servicecatalog_ClusterOAuth2AuthConfig_To_v1beta1_ClusterOAuth2AuthConfig is an autogenerated conversion function.
+func Convert_servicecatalog_ClusterOAuth2AuthConfig_To_v1beta1_ClusterOAuth2AuthConfig(in *servicecatalog.ClusterOAuth2AuthConfig, out *ClusterOAuth2AuthConfig, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ClusterOAuth2AuthConfig_To_v1beta1_ClusterOAuth2AuthConfig(in, out, s)
+}
+
 func autoConvert_v1beta1_ClusterObjectReference_To_servicecatalog_ClusterObjectReference(in *ClusterObjectReference, out *servicecatalog.ClusterObjectReference, s conversion.Scope) error {
 	out.Name = in.Name
 	return nil
@@ -827,6 +977,30 @@ func Convert_servicecatalog_ClusterObjectReference_To_v1beta1_ClusterObjectRefer
 	return autoConvert_servicecatalog_ClusterObjectReference_To_v1beta1_ClusterObjectReference(in, out, s)
 }
 
+func autoConvert_v1beta1_ClusterServiceAccountTokenAuthConfig_To_servicecatalog_ClusterServiceAccountTokenAuthConfig(in *ClusterServiceAccountTokenAuthConfig, out *servicecatalog.ClusterServiceAccountTokenAuthConfig, s conversion.Scope) error {
+	out.ServiceAccountRef = (*servicecatalog.ObjectReference)(unsafe.Pointer(in.ServiceAccountRef))
+	out.Audience = in.Audience
+	out.ExpirationSeconds = (*int64)(unsafe.Pointer(in.ExpirationSeconds))
+	return nil
+}
+
+// Convert_v1beta1_ClusterServiceAccountTokenAuthConfig_To_servicecatalog_ClusterServiceAccountTokenAuthConfig is an autogenerated conversion function.
+func Convert_v1beta1_ClusterServiceAccountTokenAuthConfig_To_servicecatalog_ClusterServiceAccountTokenAuthConfig(in *ClusterServiceAccountTokenAuthConfig, out *servicecatalog.ClusterServiceAccountTokenAuthConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterServiceAccountTokenAuthConfig_To_servicecatalog_ClusterServiceAccountTokenAuthConfig(in, out, s)
+}
+
+func autoConvert_servicecatalog_ClusterServiceAccountTokenAuthConfig_To_v1beta1_ClusterServiceAccountTokenAuthConfig(in *servicecatalog.ClusterServiceAccountTokenAuthConfig, out *ClusterServiceAccountTokenAuthConfig, s conversion.Scope) error {
+	out.ServiceAccountRef = (*ObjectReference)(unsafe.Pointer(in.ServiceAccountRef))
+	out.Audience = in.Audience
+	out.ExpirationSeconds = (*int64)(unsafe.Pointer(in.ExpirationSeconds))
+	return nil
+}
+
+// Convert_servicecatalog_ClusterServiceAccountTokenAuthConfig_To_v1beta1_ClusterServiceAccountTokenAuthConfig is an autogenerated conversion function.
+func Convert_servicecatalog_ClusterServiceAccountTokenAuthConfig_To_v1beta1_ClusterServiceAccountTokenAuthConfig(in *servicecatalog.ClusterServiceAccountTokenAuthConfig, out *ClusterServiceAccountTokenAuthConfig, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ClusterServiceAccountTokenAuthConfig_To_v1beta1_ClusterServiceAccountTokenAuthConfig(in, out, s)
+}
+
 func autoConvert_v1beta1_ClusterServiceBroker_To_servicecatalog_ClusterServiceBroker(in *ClusterServiceBroker, out *servicecatalog.ClusterServiceBroker, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_ClusterServiceBrokerSpec_To_servicecatalog_ClusterServiceBrokerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -862,6 +1036,9 @@ func Convert_servicecatalog_ClusterServiceBroker_To_v1beta1_ClusterServiceBroker
 func autoConvert_v1beta1_ClusterServiceBrokerAuthInfo_To_servicecatalog_ClusterServiceBrokerAuthInfo(in *ClusterServiceBrokerAuthInfo, out *servicecatalog.ClusterServiceBrokerAuthInfo, s conversion.Scope) error {
 	out.Basic = (*servicecatalog.ClusterBasicAuthConfig)(unsafe.Pointer(in.Basic))
 	out.Bearer = (*servicecatalog.ClusterBearerTokenAuthConfig)(unsafe.Pointer(in.Bearer))
+	out.TLS = (*servicecatalog.ClusterTLSAuthConfig)(unsafe.Pointer(in.TLS))
+	out.OAuth2 = (*servicecatalog.ClusterOAuth2AuthConfig)(unsafe.Pointer(in.OAuth2))
+	out.ServiceAccountToken = (*servicecatalog.ClusterServiceAccountTokenAuthConfig)(unsafe.Pointer(in.ServiceAccountToken))
 	return nil
 }
 
@@ -873,6 +1050,9 @@ func Convert_v1beta1_ClusterServiceBrokerAuthInfo_To_servicecatalog_ClusterServi
 func autoConvert_servicecatalog_ClusterServiceBrokerAuthInfo_To_v1beta1_ClusterServiceBrokerAuthInfo(in *servicecatalog.ClusterServiceBrokerAuthInfo, out *ClusterServiceBrokerAuthInfo, s conversion.Scope) error {
 	out.Basic = (*ClusterBasicAuthConfig)(unsafe.Pointer(in.Basic))
 	out.Bearer = (*ClusterBearerTokenAuthConfig)(unsafe.Pointer(in.Bearer))
+	out.TLS = (*ClusterTLSAuthConfig)(unsafe.Pointer(in.TLS))
+	out.OAuth2 = (*ClusterOAuth2AuthConfig)(unsafe.Pointer(in.OAuth2))
+	out.ServiceAccountToken = (*ClusterServiceAccountTokenAuthConfig)(unsafe.Pointer(in.ServiceAccountToken))
 	return nil
 }
 
@@ -1167,6 +1347,26 @@ func Convert_servicecatalog_ClusterServicePlanStatus_To_v1beta1_ClusterServicePl
 	return autoConvert_servicecatalog_ClusterServicePlanStatus_To_v1beta1_ClusterServicePlanStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_ClusterTLSAuthConfig_To_servicecatalog_ClusterTLSAuthConfig(in *ClusterTLSAuthConfig, out *servicecatalog.ClusterTLSAuthConfig, s conversion.Scope) error {
+	out.SecretRef = (*servicecatalog.ObjectReference)(unsafe.Pointer(in.SecretRef))
+	return nil
+}
+
+// Convert_v1beta1_ClusterTLSAuthConfig_To_servicecatalog_ClusterTLSAuthConfig is an autogenerated conversion function.
+func Convert_v1beta1_ClusterTLSAuthConfig_To_servicecatalog_ClusterTLSAuthConfig(in *ClusterTLSAuthConfig, out *servicecatalog.ClusterTLSAuthConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterTLSAuthConfig_To_servicecatalog_ClusterTLSAuthConfig(in, out, s)
+}
+
+func autoConvert_servicecatalog_ClusterTLSAuthConfig_To_v1beta1_ClusterTLSAuthConfig(in *servicecatalog.ClusterTLSAuthConfig, out *ClusterTLSAuthConfig, s conversion.Scope) error {
+	out.SecretRef = (*ObjectReference)(unsafe.Pointer(in.SecretRef))
+	return nil
+}
+
+// Convert_servicecatalog_ClusterTLSAuthConfig_To_v1beta1_ClusterTLSAuthConfig is an autogenerated conversion function.
+func Convert_servicecatalog_ClusterTLSAuthConfig_To_v1beta1_ClusterTLSAuthConfig(in *servicecatalog.ClusterTLSAuthConfig, out *ClusterTLSAuthConfig, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ClusterTLSAuthConfig_To_v1beta1_ClusterTLSAuthConfig(in, out, s)
+}
+
 func autoConvert_v1beta1_CommonServiceBrokerSpec_To_servicecatalog_CommonServiceBrokerSpec(in *CommonServiceBrokerSpec, out *servicecatalog.CommonServiceBrokerSpec, s conversion.Scope) error {
 	out.URL = in.URL
 	out.InsecureSkipTLSVerify = in.InsecureSkipTLSVerify
@@ -1175,6 +1375,8 @@ func autoConvert_v1beta1_CommonServiceBrokerSpec_To_servicecatalog_CommonService
 	out.RelistDuration = (*v1.Duration)(unsafe.Pointer(in.RelistDuration))
 	out.RelistRequests = in.RelistRequests
 	out.CatalogRestrictions = (*servicecatalog.CatalogRestrictions)(unsafe.Pointer(in.CatalogRestrictions))
+	out.RateLimit = (*servicecatalog.ServiceBrokerRateLimit)(unsafe.Pointer(in.RateLimit))
+	out.HealthCheckPath = in.HealthCheckPath
 	return nil
 }
 
@@ -1191,6 +1393,8 @@ func autoConvert_servicecatalog_CommonServiceBrokerSpec_To_v1beta1_CommonService
 	out.RelistDuration = (*v1.Duration)(unsafe.Pointer(in.RelistDuration))
 	out.RelistRequests = in.RelistRequests
 	out.CatalogRestrictions = (*CatalogRestrictions)(unsafe.Pointer(in.CatalogRestrictions))
+	out.RateLimit = (*ServiceBrokerRateLimit)(unsafe.Pointer(in.RateLimit))
+	out.HealthCheckPath = in.HealthCheckPath
 	return nil
 }
 
@@ -1205,6 +1409,9 @@ func autoConvert_v1beta1_CommonServiceBrokerStatus_To_servicecatalog_CommonServi
 	out.OperationStartTime = (*v1.Time)(unsafe.Pointer(in.OperationStartTime))
 	out.LastCatalogRetrievalTime = (*v1.Time)(unsafe.Pointer(in.LastCatalogRetrievalTime))
 	out.LastConditionState = in.LastConditionState
+	out.LastCatalogDiff = (*servicecatalog.CatalogDiff)(unsafe.Pointer(in.LastCatalogDiff))
+	out.LastHealthCheckTime = (*v1.Time)(unsafe.Pointer(in.LastHealthCheckTime))
+	out.CatalogHistoryHash = in.CatalogHistoryHash
 	return nil
 }
 
@@ -1219,6 +1426,9 @@ func autoConvert_servicecatalog_CommonServiceBrokerStatus_To_v1beta1_CommonServi
 	out.OperationStartTime = (*v1.Time)(unsafe.Pointer(in.OperationStartTime))
 	out.LastCatalogRetrievalTime = (*v1.Time)(unsafe.Pointer(in.LastCatalogRetrievalTime))
 	out.LastConditionState = in.LastConditionState
+	out.LastCatalogDiff = (*CatalogDiff)(unsafe.Pointer(in.LastCatalogDiff))
+	out.LastHealthCheckTime = (*v1.Time)(unsafe.Pointer(in.LastHealthCheckTime))
+	out.CatalogHistoryHash = in.CatalogHistoryHash
 	return nil
 }
 
@@ -1233,6 +1443,7 @@ func autoConvert_v1beta1_CommonServiceClassSpec_To_servicecatalog_CommonServiceC
 	out.Description = in.Description
 	out.Bindable = in.Bindable
 	out.BindingRetrievable = in.BindingRetrievable
+	out.InstanceRetrievable = in.InstanceRetrievable
 	out.PlanUpdatable = in.PlanUpdatable
 	out.ExternalMetadata = (*runtime.RawExtension)(unsafe.Pointer(in.ExternalMetadata))
 	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
@@ -1252,6 +1463,7 @@ func autoConvert_servicecatalog_CommonServiceClassSpec_To_v1beta1_CommonServiceC
 	out.Description = in.Description
 	out.Bindable = in.Bindable
 	out.BindingRetrievable = in.BindingRetrievable
+	out.InstanceRetrievable = in.InstanceRetrievable
 	out.PlanUpdatable = in.PlanUpdatable
 	out.ExternalMetadata = (*runtime.RawExtension)(unsafe.Pointer(in.ExternalMetadata))
 	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
@@ -1297,6 +1509,7 @@ func autoConvert_v1beta1_CommonServicePlanSpec_To_servicecatalog_CommonServicePl
 	out.ServiceBindingCreateParameterSchema = (*runtime.RawExtension)(unsafe.Pointer(in.ServiceBindingCreateParameterSchema))
 	out.ServiceBindingCreateResponseSchema = (*runtime.RawExtension)(unsafe.Pointer(in.ServiceBindingCreateResponseSchema))
 	out.DefaultProvisionParameters = (*runtime.RawExtension)(unsafe.Pointer(in.DefaultProvisionParameters))
+	out.MaintenanceInfo = (*servicecatalog.MaintenanceInfo)(unsafe.Pointer(in.MaintenanceInfo))
 	return nil
 }
 
@@ -1317,6 +1530,7 @@ func autoConvert_servicecatalog_CommonServicePlanSpec_To_v1beta1_CommonServicePl
 	out.ServiceBindingCreateParameterSchema = (*runtime.RawExtension)(unsafe.Pointer(in.ServiceBindingCreateParameterSchema))
 	out.ServiceBindingCreateResponseSchema = (*runtime.RawExtension)(unsafe.Pointer(in.ServiceBindingCreateResponseSchema))
 	out.DefaultProvisionParameters = (*runtime.RawExtension)(unsafe.Pointer(in.DefaultProvisionParameters))
+	out.MaintenanceInfo = (*MaintenanceInfo)(unsafe.Pointer(in.MaintenanceInfo))
 	return nil
 }
 
@@ -1365,6 +1579,48 @@ func Convert_servicecatalog_LocalObjectReference_To_v1beta1_LocalObjectReference
 	return autoConvert_servicecatalog_LocalObjectReference_To_v1beta1_LocalObjectReference(in, out, s)
 }
 
+func autoConvert_v1beta1_MaintenanceInfo_To_servicecatalog_MaintenanceInfo(in *MaintenanceInfo, out *servicecatalog.MaintenanceInfo, s conversion.Scope) error {
+	out.Version = in.Version
+	out.Description = in.Description
+	return nil
+}
+
+// Convert_v1beta1_MaintenanceInfo_To_servicecatalog_MaintenanceInfo is an autogenerated conversion function.
+func Convert_v1beta1_MaintenanceInfo_To_servicecatalog_MaintenanceInfo(in *MaintenanceInfo, out *servicecatalog.MaintenanceInfo, s conversion.Scope) error {
+	return autoConvert_v1beta1_MaintenanceInfo_To_servicecatalog_MaintenanceInfo(in, out, s)
+}
+
+func autoConvert_servicecatalog_MaintenanceInfo_To_v1beta1_MaintenanceInfo(in *servicecatalog.MaintenanceInfo, out *MaintenanceInfo, s conversion.Scope) error {
+	out.Version = in.Version
+	out.Description = in.Description
+	return nil
+}
+
+// Convert_servicecatalog_MaintenanceInfo_To_v1beta1_MaintenanceInfo is an autogenerated conversion function.
+func Convert_servicecatalog_MaintenanceInfo_To_v1beta1_MaintenanceInfo(in *servicecatalog.MaintenanceInfo, out *MaintenanceInfo, s conversion.Scope) error {
+	return autoConvert_servicecatalog_MaintenanceInfo_To_v1beta1_MaintenanceInfo(in, out, s)
+}
+
+func autoConvert_v1beta1_OAuth2AuthConfig_To_servicecatalog_OAuth2AuthConfig(in *OAuth2AuthConfig, out *servicecatalog.OAuth2AuthConfig, s conversion.Scope) error {
+	out.SecretRef = (*servicecatalog.LocalObjectReference)(unsafe.Pointer(in.SecretRef))
+	return nil
+}
+
+// Convert_v1beta1_OAuth2AuthConfig_To_servicecatalog_OAuth2AuthConfig is an autogenerated conversion function.
+func Convert_v1beta1_OAuth2AuthConfig_To_servicecatalog_OAuth2AuthConfig(in *OAuth2AuthConfig, out *servicecatalog.OAuth2AuthConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_OAuth2AuthConfig_To_servicecatalog_OAuth2AuthConfig(in, out, s)
+}
+
+func autoConvert_servicecatalog_OAuth2AuthConfig_To_v1beta1_OAuth2AuthConfig(in *servicecatalog.OAuth2AuthConfig, out *OAuth2AuthConfig, s conversion.Scope) error {
+	out.SecretRef = (*LocalObjectReference)(unsafe.Pointer(in.SecretRef))
+	return nil
+}
+
+// Convert_servicecatalog_OAuth2AuthConfig_To_v1beta1_OAuth2AuthConfig is an autogenerated conversion function.
+func Convert_servicecatalog_OAuth2AuthConfig_To_v1beta1_OAuth2AuthConfig(in *servicecatalog.OAuth2AuthConfig, out *OAuth2AuthConfig, s conversion.Scope) error {
+	return autoConvert_servicecatalog_OAuth2AuthConfig_To_v1beta1_OAuth2AuthConfig(in, out, s)
+}
+
 func autoConvert_v1beta1_ObjectReference_To_servicecatalog_ObjectReference(in *ObjectReference, out *servicecatalog.ObjectReference, s conversion.Scope) error {
 	out.Namespace = in.Namespace
 	out.Name = in.Name
@@ -1539,6 +1795,30 @@ func Convert_servicecatalog_SecretTransform_To_v1beta1_SecretTransform(in *servi
 	return autoConvert_servicecatalog_SecretTransform_To_v1beta1_SecretTransform(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceAccountTokenAuthConfig_To_servicecatalog_ServiceAccountTokenAuthConfig(in *ServiceAccountTokenAuthConfig, out *servicecatalog.ServiceAccountTokenAuthConfig, s conversion.Scope) error {
+	out.ServiceAccountRef = (*servicecatalog.LocalObjectReference)(unsafe.Pointer(in.ServiceAccountRef))
+	out.Audience = in.Audience
+	out.ExpirationSeconds = (*int64)(unsafe.Pointer(in.ExpirationSeconds))
+	return nil
+}
+
+// Convert_v1beta1_ServiceAccountTokenAuthConfig_To_servicecatalog_ServiceAccountTokenAuthConfig is an autogenerated conversion function.
+func Convert_v1beta1_ServiceAccountTokenAuthConfig_To_servicecatalog_ServiceAccountTokenAuthConfig(in *ServiceAccountTokenAuthConfig, out *servicecatalog.ServiceAccountTokenAuthConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceAccountTokenAuthConfig_To_servicecatalog_ServiceAccountTokenAuthConfig(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceAccountTokenAuthConfig_To_v1beta1_ServiceAccountTokenAuthConfig(in *servicecatalog.ServiceAccountTokenAuthConfig, out *ServiceAccountTokenAuthConfig, s conversion.Scope) error {
+	out.ServiceAccountRef = (*LocalObjectReference)(unsafe.Pointer(in.ServiceAccountRef))
+	out.Audience = in.Audience
+	out.ExpirationSeconds = (*int64)(unsafe.Pointer(in.ExpirationSeconds))
+	return nil
+}
+
+// Convert_servicecatalog_ServiceAccountTokenAuthConfig_To_v1beta1_ServiceAccountTokenAuthConfig is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceAccountTokenAuthConfig_To_v1beta1_ServiceAccountTokenAuthConfig(in *servicecatalog.ServiceAccountTokenAuthConfig, out *ServiceAccountTokenAuthConfig, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceAccountTokenAuthConfig_To_v1beta1_ServiceAccountTokenAuthConfig(in, out, s)
+}
+
 func autoConvert_v1beta1_ServiceBinding_To_servicecatalog_ServiceBinding(in *ServiceBinding, out *servicecatalog.ServiceBinding, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_ServiceBindingSpec_To_servicecatalog_ServiceBindingSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1645,6 +1925,40 @@ func Convert_servicecatalog_ServiceBindingPropertiesState_To_v1beta1_ServiceBind
 	return autoConvert_servicecatalog_ServiceBindingPropertiesState_To_v1beta1_ServiceBindingPropertiesState(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceBindingRotationStatus_To_servicecatalog_ServiceBindingRotationStatus(in *ServiceBindingRotationStatus, out *servicecatalog.ServiceBindingRotationStatus, s conversion.Scope) error {
+	out.RotationRequests = in.RotationRequests
+	out.Phase = servicecatalog.ServiceBindingRotationPhase(in.Phase)
+	out.PreviousExternalID = in.PreviousExternalID
+	out.ExternalID = in.ExternalID
+	out.StartTime = (*v1.Time)(unsafe.Pointer(in.StartTime))
+	out.PreviousUnbindTime = (*v1.Time)(unsafe.Pointer(in.PreviousUnbindTime))
+	out.CompletionTime = (*v1.Time)(unsafe.Pointer(in.CompletionTime))
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1beta1_ServiceBindingRotationStatus_To_servicecatalog_ServiceBindingRotationStatus is an autogenerated conversion function.
+func Convert_v1beta1_ServiceBindingRotationStatus_To_servicecatalog_ServiceBindingRotationStatus(in *ServiceBindingRotationStatus, out *servicecatalog.ServiceBindingRotationStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceBindingRotationStatus_To_servicecatalog_ServiceBindingRotationStatus(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceBindingRotationStatus_To_v1beta1_ServiceBindingRotationStatus(in *servicecatalog.ServiceBindingRotationStatus, out *ServiceBindingRotationStatus, s conversion.Scope) error {
+	out.RotationRequests = in.RotationRequests
+	out.Phase = ServiceBindingRotationPhase(in.Phase)
+	out.PreviousExternalID = in.PreviousExternalID
+	out.ExternalID = in.ExternalID
+	out.StartTime = (*v1.Time)(unsafe.Pointer(in.StartTime))
+	out.PreviousUnbindTime = (*v1.Time)(unsafe.Pointer(in.PreviousUnbindTime))
+	out.CompletionTime = (*v1.Time)(unsafe.Pointer(in.CompletionTime))
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_servicecatalog_ServiceBindingRotationStatus_To_v1beta1_ServiceBindingRotationStatus is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceBindingRotationStatus_To_v1beta1_ServiceBindingRotationStatus(in *servicecatalog.ServiceBindingRotationStatus, out *ServiceBindingRotationStatus, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceBindingRotationStatus_To_v1beta1_ServiceBindingRotationStatus(in, out, s)
+}
+
 func autoConvert_v1beta1_ServiceBindingSpec_To_servicecatalog_ServiceBindingSpec(in *ServiceBindingSpec, out *servicecatalog.ServiceBindingSpec, s conversion.Scope) error {
 	if err := Convert_v1beta1_LocalObjectReference_To_servicecatalog_LocalObjectReference(&in.InstanceRef, &out.InstanceRef, s); err != nil {
 		return err
@@ -1655,6 +1969,7 @@ func autoConvert_v1beta1_ServiceBindingSpec_To_servicecatalog_ServiceBindingSpec
 	out.SecretTransforms = *(*[]servicecatalog.SecretTransform)(unsafe.Pointer(&in.SecretTransforms))
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*servicecatalog.UserInfo)(unsafe.Pointer(in.UserInfo))
+	out.RotationRequests = in.RotationRequests
 	return nil
 }
 
@@ -1673,6 +1988,7 @@ func autoConvert_servicecatalog_ServiceBindingSpec_To_v1beta1_ServiceBindingSpec
 	out.SecretTransforms = *(*[]SecretTransform)(unsafe.Pointer(&in.SecretTransforms))
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*UserInfo)(unsafe.Pointer(in.UserInfo))
+	out.RotationRequests = in.RotationRequests
 	return nil
 }
 
@@ -1692,6 +2008,10 @@ func autoConvert_v1beta1_ServiceBindingStatus_To_servicecatalog_ServiceBindingSt
 	out.ExternalProperties = (*servicecatalog.ServiceBindingPropertiesState)(unsafe.Pointer(in.ExternalProperties))
 	out.OrphanMitigationInProgress = in.OrphanMitigationInProgress
 	out.UnbindStatus = servicecatalog.ServiceBindingUnbindStatus(in.UnbindStatus)
+	out.LastRetrievalTime = (*v1.Time)(unsafe.Pointer(in.LastRetrievalTime))
+	out.RotationStatus = (*servicecatalog.ServiceBindingRotationStatus)(unsafe.Pointer(in.RotationStatus))
+	out.ExpiresAt = (*v1.Time)(unsafe.Pointer(in.ExpiresAt))
+	out.RenewBefore = (*v1.Time)(unsafe.Pointer(in.RenewBefore))
 	out.LastConditionState = in.LastConditionState
 	return nil
 }
@@ -1712,6 +2032,10 @@ func autoConvert_servicecatalog_ServiceBindingStatus_To_v1beta1_ServiceBindingSt
 	out.ExternalProperties = (*ServiceBindingPropertiesState)(unsafe.Pointer(in.ExternalProperties))
 	out.OrphanMitigationInProgress = in.OrphanMitigationInProgress
 	out.UnbindStatus = ServiceBindingUnbindStatus(in.UnbindStatus)
+	out.LastRetrievalTime = (*v1.Time)(unsafe.Pointer(in.LastRetrievalTime))
+	out.RotationStatus = (*ServiceBindingRotationStatus)(unsafe.Pointer(in.RotationStatus))
+	out.ExpiresAt = (*v1.Time)(unsafe.Pointer(in.ExpiresAt))
+	out.RenewBefore = (*v1.Time)(unsafe.Pointer(in.RenewBefore))
 	out.LastConditionState = in.LastConditionState
 	return nil
 }
@@ -1756,6 +2080,9 @@ func Convert_servicecatalog_ServiceBroker_To_v1beta1_ServiceBroker(in *serviceca
 func autoConvert_v1beta1_ServiceBrokerAuthInfo_To_servicecatalog_ServiceBrokerAuthInfo(in *ServiceBrokerAuthInfo, out *servicecatalog.ServiceBrokerAuthInfo, s conversion.Scope) error {
 	out.Basic = (*servicecatalog.BasicAuthConfig)(unsafe.Pointer(in.Basic))
 	out.Bearer = (*servicecatalog.BearerTokenAuthConfig)(unsafe.Pointer(in.Bearer))
+	out.TLS = (*servicecatalog.TLSAuthConfig)(unsafe.Pointer(in.TLS))
+	out.OAuth2 = (*servicecatalog.OAuth2AuthConfig)(unsafe.Pointer(in.OAuth2))
+	out.ServiceAccountToken = (*servicecatalog.ServiceAccountTokenAuthConfig)(unsafe.Pointer(in.ServiceAccountToken))
 	return nil
 }
 
@@ -1767,6 +2094,9 @@ func Convert_v1beta1_ServiceBrokerAuthInfo_To_servicecatalog_ServiceBrokerAuthIn
 func autoConvert_servicecatalog_ServiceBrokerAuthInfo_To_v1beta1_ServiceBrokerAuthInfo(in *servicecatalog.ServiceBrokerAuthInfo, out *ServiceBrokerAuthInfo, s conversion.Scope) error {
 	out.Basic = (*BasicAuthConfig)(unsafe.Pointer(in.Basic))
 	out.Bearer = (*BearerTokenAuthConfig)(unsafe.Pointer(in.Bearer))
+	out.TLS = (*TLSAuthConfig)(unsafe.Pointer(in.TLS))
+	out.OAuth2 = (*OAuth2AuthConfig)(unsafe.Pointer(in.OAuth2))
+	out.ServiceAccountToken = (*ServiceAccountTokenAuthConfig)(unsafe.Pointer(in.ServiceAccountToken))
 	return nil
 }
 
@@ -1825,6 +2155,30 @@ func Convert_servicecatalog_ServiceBrokerList_To_v1beta1_ServiceBrokerList(in *s
 	return autoConvert_servicecatalog_ServiceBrokerList_To_v1beta1_ServiceBrokerList(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceBrokerRateLimit_To_servicecatalog_ServiceBrokerRateLimit(in *ServiceBrokerRateLimit, out *servicecatalog.ServiceBrokerRateLimit, s conversion.Scope) error {
+	out.QPS = in.QPS
+	out.Burst = in.Burst
+	out.MaxInFlight = in.MaxInFlight
+	return nil
+}
+
+// Convert_v1beta1_ServiceBrokerRateLimit_To_servicecatalog_ServiceBrokerRateLimit is an autogenerated conversion function.
+func Convert_v1beta1_ServiceBrokerRateLimit_To_servicecatalog_ServiceBrokerRateLimit(in *ServiceBrokerRateLimit, out *servicecatalog.ServiceBrokerRateLimit, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceBrokerRateLimit_To_servicecatalog_ServiceBrokerRateLimit(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceBrokerRateLimit_To_v1beta1_ServiceBrokerRateLimit(in *servicecatalog.ServiceBrokerRateLimit, out *ServiceBrokerRateLimit, s conversion.Scope) error {
+	out.QPS = in.QPS
+	out.Burst = in.Burst
+	out.MaxInFlight = in.MaxInFlight
+	return nil
+}
+
+// Convert_servicecatalog_ServiceBrokerRateLimit_To_v1beta1_ServiceBrokerRateLimit is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceBrokerRateLimit_To_v1beta1_ServiceBrokerRateLimit(in *servicecatalog.ServiceBrokerRateLimit, out *ServiceBrokerRateLimit, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceBrokerRateLimit_To_v1beta1_ServiceBrokerRateLimit(in, out, s)
+}
+
 func autoConvert_v1beta1_ServiceBrokerSpec_To_servicecatalog_ServiceBrokerSpec(in *ServiceBrokerSpec, out *servicecatalog.ServiceBrokerSpec, s conversion.Scope) error {
 	if err := Convert_v1beta1_CommonServiceBrokerSpec_To_servicecatalog_CommonServiceBrokerSpec(&in.CommonServiceBrokerSpec, &out.CommonServiceBrokerSpec, s); err != nil {
 		return err
@@ -2069,6 +2423,7 @@ func autoConvert_v1beta1_ServiceInstancePropertiesState_To_servicecatalog_Servic
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParameterChecksum = in.ParameterChecksum
 	out.UserInfo = (*servicecatalog.UserInfo)(unsafe.Pointer(in.UserInfo))
+	out.MaintenanceInfo = (*servicecatalog.MaintenanceInfo)(unsafe.Pointer(in.MaintenanceInfo))
 	return nil
 }
 
@@ -2085,6 +2440,7 @@ func autoConvert_servicecatalog_ServiceInstancePropertiesState_To_v1beta1_Servic
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParameterChecksum = in.ParameterChecksum
 	out.UserInfo = (*UserInfo)(unsafe.Pointer(in.UserInfo))
+	out.MaintenanceInfo = (*MaintenanceInfo)(unsafe.Pointer(in.MaintenanceInfo))
 	return nil
 }
 
@@ -2106,6 +2462,7 @@ func autoConvert_v1beta1_ServiceInstanceSpec_To_servicecatalog_ServiceInstanceSp
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*servicecatalog.UserInfo)(unsafe.Pointer(in.UserInfo))
 	out.UpdateRequests = in.UpdateRequests
+	out.MaintenanceInfo = (*servicecatalog.MaintenanceInfo)(unsafe.Pointer(in.MaintenanceInfo))
 	return nil
 }
 
@@ -2127,6 +2484,7 @@ func autoConvert_servicecatalog_ServiceInstanceSpec_To_v1beta1_ServiceInstanceSp
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*UserInfo)(unsafe.Pointer(in.UserInfo))
 	out.UpdateRequests = in.UpdateRequests
+	out.MaintenanceInfo = (*MaintenanceInfo)(unsafe.Pointer(in.MaintenanceInfo))
 	return nil
 }
 
@@ -2141,6 +2499,8 @@ func autoConvert_v1beta1_ServiceInstanceStatus_To_servicecatalog_ServiceInstance
 	out.OrphanMitigationInProgress = in.OrphanMitigationInProgress
 	out.LastOperation = (*string)(unsafe.Pointer(in.LastOperation))
 	out.DashboardURL = (*string)(unsafe.Pointer(in.DashboardURL))
+	out.LastRetrievalTime = (*v1.Time)(unsafe.Pointer(in.LastRetrievalTime))
+	out.BrokerReportedParametersChecksum = in.BrokerReportedParametersChecksum
 	out.CurrentOperation = servicecatalog.ServiceInstanceOperation(in.CurrentOperation)
 	out.ReconciledGeneration = in.ReconciledGeneration
 	out.ObservedGeneration = in.ObservedGeneration
@@ -2167,6 +2527,8 @@ func autoConvert_servicecatalog_ServiceInstanceStatus_To_v1beta1_ServiceInstance
 	out.OrphanMitigationInProgress = in.OrphanMitigationInProgress
 	out.LastOperation = (*string)(unsafe.Pointer(in.LastOperation))
 	out.DashboardURL = (*string)(unsafe.Pointer(in.DashboardURL))
+	out.LastRetrievalTime = (*v1.Time)(unsafe.Pointer(in.LastRetrievalTime))
+	out.BrokerReportedParametersChecksum = in.BrokerReportedParametersChecksum
 	out.CurrentOperation = ServiceInstanceOperation(in.CurrentOperation)
 	out.ReconciledGeneration = in.ReconciledGeneration
 	out.ObservedGeneration = in.ObservedGeneration
@@ -2297,6 +2659,26 @@ func Convert_servicecatalog_ServicePlanStatus_To_v1beta1_ServicePlanStatus(in *s
 	return autoConvert_servicecatalog_ServicePlanStatus_To_v1beta1_ServicePlanStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_TLSAuthConfig_To_servicecatalog_TLSAuthConfig(in *TLSAuthConfig, out *servicecatalog.TLSAuthConfig, s conversion.Scope) error {
+	out.SecretRef = (*servicecatalog.LocalObjectReference)(unsafe.Pointer(in.SecretRef))
+	return nil
+}
+
+// Convert_v1beta1_TLSAuthConfig_To_servicecatalog_TLSAuthConfig is an autogenerated conversion function.
+func Convert_v1beta1_TLSAuthConfig_To_servicecatalog_TLSAuthConfig(in *TLSAuthConfig, out *servicecatalog.TLSAuthConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_TLSAuthConfig_To_servicecatalog_TLSAuthConfig(in, out, s)
+}
+
+func autoConvert_servicecatalog_TLSAuthConfig_To_v1beta1_TLSAuthConfig(in *servicecatalog.TLSAuthConfig, out *TLSAuthConfig, s conversion.Scope) error {
+	out.SecretRef = (*LocalObjectReference)(unsafe.Pointer(in.SecretRef))
+	return nil
+}
+
+// Convert_servicecatalog_TLSAuthConfig_To_v1beta1_TLSAuthConfig is an autogenerated conversion function.
+func Convert_servicecatalog_TLSAuthConfig_To_v1beta1_TLSAuthConfig(in *servicecatalog.TLSAuthConfig, out *TLSAuthConfig, s conversion.Scope) error {
+	return autoConvert_servicecatalog_TLSAuthConfig_To_v1beta1_TLSAuthConfig(in, out, s)
+}
+
 func autoConvert_v1beta1_UserInfo_To_servicecatalog_UserInfo(in *UserInfo, out *servicecatalog.UserInfo, s conversion.Scope) error {
 	out.Username = in.Username
 	out.UID = in.UID
@@ -61,6 +61,23 @@ var validServiceBindingUnbindStatusValues = func() []string {
 	return validValues
 }()
 
+var validServiceBindingRotationPhases = map[sc.ServiceBindingRotationPhase]bool{
+	sc.ServiceBindingRotationPhaseBinding:     true,
+	sc.ServiceBindingRotationPhaseGracePeriod: true,
+	sc.ServiceBindingRotationPhaseCompleted:   true,
+	sc.ServiceBindingRotationPhaseFailed:      true,
+}
+
+var validServiceBindingRotationPhaseValues = func() []string {
+	validValues := make([]string, len(validServiceBindingRotationPhases))
+	i := 0
+	for phase := range validServiceBindingRotationPhases {
+		validValues[i] = string(phase)
+		i++
+	}
+	return validValues
+}()
+
 // ValidateServiceBinding validates a ServiceBinding and returns a list of errors.
 func ValidateServiceBinding(binding *sc.ServiceBinding) field.ErrorList {
 	return internalValidateServiceBinding(binding, true)
@@ -95,6 +112,8 @@ func validateServiceBindingSpec(spec *sc.ServiceBindingSpec, fldPath *field.Path
 		allErrs = append(allErrs, validateParametersFromSource(spec.ParametersFrom, fldPath)...)
 	}
 
+	allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(spec.RotationRequests, fldPath.Child("rotationRequests"))...)
+
 	return allErrs
 }
 
@@ -151,6 +170,10 @@ func validateServiceBindingStatus(status *sc.ServiceBindingStatus, fldPath *fiel
 		allErrs = append(allErrs, validateServiceBindingPropertiesState(status.ExternalProperties, fldPath.Child("externalProperties"), create)...)
 	}
 
+	if status.RotationStatus != nil {
+		allErrs = append(allErrs, validateServiceBindingRotationStatus(status.RotationStatus, fldPath.Child("rotationStatus"))...)
+	}
+
 	if create {
 		if status.UnbindStatus != sc.ServiceBindingUnbindStatusNotRequired {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("unbindStatus"), status.UnbindStatus, `unbindStatus must be "NotRequired" on create`))
@@ -197,6 +220,30 @@ func validateServiceBindingPropertiesState(propertiesState *sc.ServiceBindingPro
 	return allErrs
 }
 
+func validateServiceBindingRotationStatus(rotationStatus *sc.ServiceBindingRotationStatus, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !validServiceBindingRotationPhases[rotationStatus.Phase] {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("phase"), rotationStatus.Phase, validServiceBindingRotationPhaseValues))
+	}
+
+	switch rotationStatus.Phase {
+	case sc.ServiceBindingRotationPhaseCompleted:
+		if rotationStatus.PreviousExternalID != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("previousExternalID"), `previousExternalID must not be present when phase is "Completed"`))
+		}
+	case sc.ServiceBindingRotationPhaseFailed:
+		// The previous external ID is kept until the binding has been
+		// rolled back to it.
+	default:
+		if rotationStatus.PreviousExternalID == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("previousExternalID"), `previousExternalID is required when phase is not "Completed" or "Failed"`))
+		}
+	}
+
+	return allErrs
+}
+
 func validateServiceBindingCreate(binding *sc.ServiceBinding) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if binding.Status.ReconciledGeneration >= binding.Generation {
@@ -239,6 +286,11 @@ func ValidateServiceBindingUpdate(new *sc.ServiceBinding, old *sc.ServiceBinding
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, internalValidateServiceBindingUpdateAllowed(new, old)...)
 	allErrs = append(allErrs, internalValidateServiceBinding(new, false)...)
+
+	if new.Spec.RotationRequests < old.Spec.RotationRequests {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("rotationRequests"), new.Spec.RotationRequests, "new rotationRequests value must not be less than the old one"))
+	}
+
 	return allErrs
 }
 
@@ -582,6 +582,64 @@ func TestValidateServiceBinding(t *testing.T) {
 			}(),
 			valid: false,
 		},
+		{
+			name: "negative rotationRequests",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.RotationRequests = -1
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "valid rotation in grace period",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.RotationRequests = 1
+				b.Status.RotationStatus = &servicecatalog.ServiceBindingRotationStatus{
+					RotationRequests:   1,
+					Phase:              servicecatalog.ServiceBindingRotationPhaseGracePeriod,
+					PreviousExternalID: "old-binding-id",
+				}
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "invalid rotation phase",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Status.RotationStatus = &servicecatalog.ServiceBindingRotationStatus{
+					Phase:              "Unknown",
+					PreviousExternalID: "old-binding-id",
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "rotation in progress without previous external ID",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Status.RotationStatus = &servicecatalog.ServiceBindingRotationStatus{
+					Phase: servicecatalog.ServiceBindingRotationPhaseBinding,
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "completed rotation with previous external ID",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Status.RotationStatus = &servicecatalog.ServiceBindingRotationStatus{
+					Phase:              servicecatalog.ServiceBindingRotationPhaseCompleted,
+					PreviousExternalID: "old-binding-id",
+				}
+				return b
+			}(),
+			valid: false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -657,3 +715,50 @@ func TestInternalValidateServiceBindingUpdateAllowed(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateServiceBindingUpdateRotationRequests(t *testing.T) {
+	cases := []struct {
+		name        string
+		oldRequests int64
+		newRequests int64
+		valid       bool
+	}{
+		{
+			name:        "unchanged",
+			oldRequests: 1,
+			newRequests: 1,
+			valid:       true,
+		},
+		{
+			name:        "incremented",
+			oldRequests: 1,
+			newRequests: 2,
+			valid:       true,
+		},
+		{
+			name:        "decremented",
+			oldRequests: 2,
+			newRequests: 1,
+			valid:       false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			oldBinding := validServiceBinding()
+			oldBinding.Generation = 1
+			oldBinding.Status.ReconciledGeneration = 1
+			oldBinding.Spec.RotationRequests = tc.oldRequests
+
+			newBinding := oldBinding.DeepCopy()
+			newBinding.Spec.RotationRequests = tc.newRequests
+
+			errs := ValidateServiceBindingUpdate(newBinding, oldBinding)
+			if len(errs) != 0 && tc.valid {
+				t.Errorf("unexpected error: %v", errs)
+			} else if len(errs) == 0 && !tc.valid {
+				t.Error("unexpected success")
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ package validation
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,6 +33,13 @@ import (
 // broker names.
 var validateCommonServiceBrokerName = apivalidation.NameIsDNSSubdomain
 
+// The bounds of the lifetime of service account tokens accepted by the
+// TokenRequest API.
+const (
+	minServiceAccountTokenExpirationSeconds = 10 * 60
+	maxServiceAccountTokenExpirationSeconds = 1 << 32
+)
+
 // ValidateClusterServiceBroker implements the validation rules for a
 // ClusterServiceBroker.
 func ValidateClusterServiceBroker(broker *sc.ClusterServiceBroker) field.ErrorList {
@@ -81,6 +90,60 @@ func validateClusterServiceBrokerSpec(spec *sc.ClusterServiceBrokerSpec, fldPath
 					field.Required(fldPath.Child("authInfo", "bearer", "secretRef"), "a basic auth secret is required"),
 				)
 			}
+		} else if spec.AuthInfo.TLS != nil {
+			secretRef := spec.AuthInfo.TLS.SecretRef
+			if secretRef != nil {
+				for _, msg := range apivalidation.ValidateNamespaceName(secretRef.Namespace, false /* prefix */) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "tls", "secretRef", "namespace"), secretRef.Namespace, msg))
+				}
+				for _, msg := range apivalidation.NameIsDNSSubdomain(secretRef.Name, false /* prefix */) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "tls", "secretRef", "name"), secretRef.Name, msg))
+				}
+			} else {
+				allErrs = append(
+					allErrs,
+					field.Required(fldPath.Child("authInfo", "tls", "secretRef"), "a tls secret is required"),
+				)
+			}
+		} else if spec.AuthInfo.OAuth2 != nil {
+			secretRef := spec.AuthInfo.OAuth2.SecretRef
+			if secretRef != nil {
+				for _, msg := range apivalidation.ValidateNamespaceName(secretRef.Namespace, false /* prefix */) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "oauth2", "secretRef", "namespace"), secretRef.Namespace, msg))
+				}
+				for _, msg := range apivalidation.NameIsDNSSubdomain(secretRef.Name, false /* prefix */) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "oauth2", "secretRef", "name"), secretRef.Name, msg))
+				}
+			} else {
+				allErrs = append(
+					allErrs,
+					field.Required(fldPath.Child("authInfo", "oauth2", "secretRef"), "an oauth2 secret is required"),
+				)
+			}
+		} else if spec.AuthInfo.ServiceAccountToken != nil {
+			serviceAccountRef := spec.AuthInfo.ServiceAccountToken.ServiceAccountRef
+			if serviceAccountRef != nil {
+				for _, msg := range apivalidation.ValidateNamespaceName(serviceAccountRef.Namespace, false /* prefix */) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "serviceAccountToken", "serviceAccountRef", "namespace"), serviceAccountRef.Namespace, msg))
+				}
+				for _, msg := range apivalidation.ValidateServiceAccountName(serviceAccountRef.Name, false /* prefix */) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "serviceAccountToken", "serviceAccountRef", "name"), serviceAccountRef.Name, msg))
+				}
+			} else {
+				allErrs = append(
+					allErrs,
+					field.Required(fldPath.Child("authInfo", "serviceAccountToken", "serviceAccountRef"), "a service account is required"),
+				)
+			}
+			// Without an audience, the tokens would be accepted by the API
+			// server, so the broker could act as the service account
+			if spec.AuthInfo.ServiceAccountToken.Audience == "" {
+				allErrs = append(
+					allErrs,
+					field.Required(fldPath.Child("authInfo", "serviceAccountToken", "audience"), "a token audience is required"),
+				)
+			}
+			allErrs = append(allErrs, validateServiceAccountTokenExpiration(spec.AuthInfo.ServiceAccountToken.ExpirationSeconds, fldPath.Child("authInfo", "serviceAccountToken", "expirationSeconds"))...)
 		} else {
 			// Authentication
 			allErrs = append(
@@ -99,6 +162,22 @@ func validateClusterServiceBrokerSpec(spec *sc.ClusterServiceBrokerSpec, fldPath
 	return allErrs
 }
 
+// validateServiceAccountTokenExpiration checks that the requested lifetime of
+// service account tokens is accepted by the TokenRequest API.
+func validateServiceAccountTokenExpiration(expirationSeconds *int64, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if expirationSeconds == nil {
+		return allErrs
+	}
+	if *expirationSeconds < minServiceAccountTokenExpirationSeconds {
+		allErrs = append(allErrs, field.Invalid(fldPath, *expirationSeconds, fmt.Sprintf("must be at least %d seconds", minServiceAccountTokenExpirationSeconds)))
+	}
+	if *expirationSeconds > maxServiceAccountTokenExpirationSeconds {
+		allErrs = append(allErrs, field.Invalid(fldPath, *expirationSeconds, fmt.Sprintf("must be at most %d seconds", maxServiceAccountTokenExpirationSeconds)))
+	}
+	return allErrs
+}
+
 // ValidateServiceBroker implements the validation rules for a
 // ServiceBroker.
 func ValidateServiceBroker(broker *sc.ServiceBroker) field.ErrorList {
@@ -143,6 +222,51 @@ func validateServiceBrokerSpec(spec *sc.ServiceBrokerSpec, fldPath *field.Path)
 					field.Required(fldPath.Child("authInfo", "bearer", "secretRef"), "a basic auth secret is required"),
 				)
 			}
+		} else if spec.AuthInfo.TLS != nil {
+			secretRef := spec.AuthInfo.TLS.SecretRef
+			if secretRef != nil {
+				for _, msg := range apivalidation.NameIsDNSSubdomain(secretRef.Name, false /* prefix */) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "tls", "secretRef", "name"), secretRef.Name, msg))
+				}
+			} else {
+				allErrs = append(
+					allErrs,
+					field.Required(fldPath.Child("authInfo", "tls", "secretRef"), "a tls secret is required"),
+				)
+			}
+		} else if spec.AuthInfo.OAuth2 != nil {
+			secretRef := spec.AuthInfo.OAuth2.SecretRef
+			if secretRef != nil {
+				for _, msg := range apivalidation.NameIsDNSSubdomain(secretRef.Name, false /* prefix */) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "oauth2", "secretRef", "name"), secretRef.Name, msg))
+				}
+			} else {
+				allErrs = append(
+					allErrs,
+					field.Required(fldPath.Child("authInfo", "oauth2", "secretRef"), "an oauth2 secret is required"),
+				)
+			}
+		} else if spec.AuthInfo.ServiceAccountToken != nil {
+			serviceAccountRef := spec.AuthInfo.ServiceAccountToken.ServiceAccountRef
+			if serviceAccountRef != nil {
+				for _, msg := range apivalidation.ValidateServiceAccountName(serviceAccountRef.Name, false /* prefix */) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "serviceAccountToken", "serviceAccountRef", "name"), serviceAccountRef.Name, msg))
+				}
+			} else {
+				allErrs = append(
+					allErrs,
+					field.Required(fldPath.Child("authInfo", "serviceAccountToken", "serviceAccountRef"), "a service account is required"),
+				)
+			}
+			// Without an audience, the tokens would be accepted by the API
+			// server, so the broker could act as the service account
+			if spec.AuthInfo.ServiceAccountToken.Audience == "" {
+				allErrs = append(
+					allErrs,
+					field.Required(fldPath.Child("authInfo", "serviceAccountToken", "audience"), "a token audience is required"),
+				)
+			}
+			allErrs = append(allErrs, validateServiceAccountTokenExpiration(spec.AuthInfo.ServiceAccountToken.ExpirationSeconds, fldPath.Child("authInfo", "serviceAccountToken", "expirationSeconds"))...)
 		} else {
 			// Authentication
 			allErrs = append(
@@ -181,9 +305,10 @@ func validateCommonServiceBrokerSpec(spec *sc.CommonServiceBrokerSpec, fldPath *
 	}
 
 	isValidRelistBehavior := spec.RelistBehavior == sc.ServiceBrokerRelistBehaviorDuration ||
-		spec.RelistBehavior == sc.ServiceBrokerRelistBehaviorManual
+		spec.RelistBehavior == sc.ServiceBrokerRelistBehaviorManual ||
+		spec.RelistBehavior == sc.ServiceBrokerRelistBehaviorProxy
 	if !isValidRelistBehavior {
-		errMsg := "relist behavior must be \"Manual\" or \"Duration\""
+		errMsg := "relist behavior must be \"Manual\", \"Duration\" or \"Proxy\""
 		commonErrs = append(
 			commonErrs,
 			field.Required(fldPath.Child("relistBehavior"), errMsg),
@@ -246,9 +371,37 @@ func validateCommonServiceBrokerSpec(spec *sc.CommonServiceBrokerSpec, fldPath *
 		}
 	}
 
+	if spec.RateLimit != nil {
+		commonErrs = append(commonErrs, validateServiceBrokerRateLimit(spec.RateLimit, fldPath.Child("rateLimit"))...)
+	}
+
+	if spec.HealthCheckPath != "" {
+		if u, err := url.Parse(spec.HealthCheckPath); err != nil || u.Scheme != "" || u.Host != "" || !strings.HasPrefix(spec.HealthCheckPath, "/") {
+			commonErrs = append(commonErrs, field.Invalid(fldPath.Child("healthCheckPath"), spec.HealthCheckPath, "healthCheckPath must be an absolute path"))
+		}
+	}
+
 	return commonErrs
 }
 
+func validateServiceBrokerRateLimit(rateLimit *sc.ServiceBrokerRateLimit, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if rateLimit.QPS < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("qps"), rateLimit.QPS, "qps must not be negative"))
+	}
+	if rateLimit.Burst < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("burst"), rateLimit.Burst, "burst must not be negative"))
+	} else if rateLimit.Burst > 0 && rateLimit.QPS == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("burst"), rateLimit.Burst, "burst can only be set along with qps"))
+	}
+	if rateLimit.MaxInFlight < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxInFlight"), rateLimit.MaxInFlight, "maxInFlight must not be negative"))
+	}
+
+	return allErrs
+}
+
 // ValidateClusterServiceBrokerUpdate checks that when changing from an older broker to a newer broker is okay ?
 func ValidateClusterServiceBrokerUpdate(new *sc.ClusterServiceBroker, old *sc.ClusterServiceBroker) field.ErrorList {
 	allErrs := validateCommonServiceBrokerUpdate(&new.Spec.CommonServiceBrokerSpec, &old.Spec.CommonServiceBrokerSpec)
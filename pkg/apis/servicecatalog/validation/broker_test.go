@@ -26,6 +26,8 @@ import (
 )
 
 func TestValidateClusterServiceBroker(t *testing.T) {
+	tokenExpirationSeconds := int64(3600)
+	shortTokenExpirationSeconds := int64(60)
 	cases := []struct {
 		name   string
 		broker *servicecatalog.ClusterServiceBroker
@@ -97,6 +99,54 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 			},
 			valid: true,
 		},
+		{
+			name: "valid clusterservicebroker - tls auth - secret",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						TLS: &servicecatalog.ClusterTLSAuthConfig{
+							SecretRef: &servicecatalog.ObjectReference{
+								Namespace: "test-ns",
+								Name:      "test-secret",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "valid clusterservicebroker - oauth2 auth - secret",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						OAuth2: &servicecatalog.ClusterOAuth2AuthConfig{
+							SecretRef: &servicecatalog.ObjectReference{
+								Namespace: "test-ns",
+								Name:      "test-secret",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: true,
+		},
 		{
 			name: "invalid clusterservicebroker - clusterservicebroker with namespace",
 			broker: &servicecatalog.ClusterServiceBroker{
@@ -206,6 +256,208 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "invalid clusterservicebroker - tls auth - secret missing name",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						TLS: &servicecatalog.ClusterTLSAuthConfig{
+							SecretRef: &servicecatalog.ObjectReference{
+								Namespace: "test-ns",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - oauth2 auth - secret missing name",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						OAuth2: &servicecatalog.ClusterOAuth2AuthConfig{
+							SecretRef: &servicecatalog.ObjectReference{
+								Namespace: "test-ns",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - tls auth - secret missing",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						TLS: &servicecatalog.ClusterTLSAuthConfig{},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - oauth2 auth - secret missing",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						OAuth2: &servicecatalog.ClusterOAuth2AuthConfig{},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "valid clusterservicebroker - service account token auth",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						ServiceAccountToken: &servicecatalog.ClusterServiceAccountTokenAuthConfig{
+							ServiceAccountRef: &servicecatalog.ObjectReference{
+								Namespace: "test-ns",
+								Name:      "test-broker-client",
+							},
+							Audience:          "https://broker.example.com",
+							ExpirationSeconds: &tokenExpirationSeconds,
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid clusterservicebroker - service account token auth - service account missing",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						ServiceAccountToken: &servicecatalog.ClusterServiceAccountTokenAuthConfig{},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - service account token auth - service account missing namespace",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						ServiceAccountToken: &servicecatalog.ClusterServiceAccountTokenAuthConfig{
+							ServiceAccountRef: &servicecatalog.ObjectReference{
+								Name: "test-broker-client",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - service account token auth - audience missing",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						ServiceAccountToken: &servicecatalog.ClusterServiceAccountTokenAuthConfig{
+							ServiceAccountRef: &servicecatalog.ObjectReference{
+								Namespace: "test-ns",
+								Name:      "test-broker-client",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - service account token auth - expiration too short",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						ServiceAccountToken: &servicecatalog.ClusterServiceAccountTokenAuthConfig{
+							ServiceAccountRef: &servicecatalog.ObjectReference{
+								Namespace: "test-ns",
+								Name:      "test-broker-client",
+							},
+							Audience:          "https://broker.example.com",
+							ExpirationSeconds: &shortTokenExpirationSeconds,
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
 		{
 			name: "invalid clusterservicebroker - CABundle present with InsecureSkipTLSVerify",
 			broker: &servicecatalog.ClusterServiceBroker{
@@ -306,6 +558,22 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 			},
 			valid: true,
 		},
+		{
+			name: "valid clusterservicebroker - proxy behavior with RelistDuration",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorProxy,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: true,
+		},
 		{
 			name: "invalid clusterservicebroker - relistBehavior is invalid",
 			broker: &servicecatalog.ClusterServiceBroker{
@@ -354,23 +622,232 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 			valid: false,
 		},
 		{
-			name: "invalid clusterservicebroker - negative relistDuration value",
+			name: "invalid clusterservicebroker - negative relistDuration value",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: -15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "valid clusterservicebroker - rateLimit",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						RateLimit:      &servicecatalog.ServiceBrokerRateLimit{QPS: 5, Burst: 10, MaxInFlight: 2},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "valid clusterservicebroker - rateLimit with only maxInFlight",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						RateLimit:      &servicecatalog.ServiceBrokerRateLimit{MaxInFlight: 2},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid clusterservicebroker - negative rateLimit.qps",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						RateLimit:      &servicecatalog.ServiceBrokerRateLimit{QPS: -1},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - rateLimit.burst without qps",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						RateLimit:      &servicecatalog.ServiceBrokerRateLimit{Burst: 10},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - negative rateLimit.maxInFlight",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						RateLimit:      &servicecatalog.ServiceBrokerRateLimit{MaxInFlight: -1},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "valid clusterservicebroker - healthCheckPath",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:             "http://example.com",
+						RelistBehavior:  servicecatalog.ServiceBrokerRelistBehaviorManual,
+						HealthCheckPath: "/healthz",
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid clusterservicebroker - relative healthCheckPath",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:             "http://example.com",
+						RelistBehavior:  servicecatalog.ServiceBrokerRelistBehaviorManual,
+						HealthCheckPath: "healthz",
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - healthCheckPath with host",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:             "http://example.com",
+						RelistBehavior:  servicecatalog.ServiceBrokerRelistBehaviorManual,
+						HealthCheckPath: "//example.com/healthz",
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "valid clusterservicebroker - catalogRequirements.serviceClass",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{
+								"name==foobar",
+							},
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "valid clusterservicebroker - complex catalogRequirements.serviceClass",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{
+								"name==foobar",
+								"spec.externalName in (foobar, bazboof, wizzbang)",
+							},
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid clusterservicebroker - catalogRequirements.serviceClass",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{
+								"invalid restriction",
+							},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - catalogRequirements.serviceClass - invalid property",
 			broker: &servicecatalog.ClusterServiceBroker{
 				ObjectMeta: metav1.ObjectMeta{
-					Name: "test-clusterservicebroker",
+					Name: "test-broker",
 				},
 				Spec: servicecatalog.ClusterServiceBrokerSpec{
 					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
 						URL:            "http://example.com",
-						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
-						RelistDuration: &metav1.Duration{Duration: -15 * time.Minute},
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{
+								"spec.invalidProperty==foobar",
+							},
+						},
 					},
 				},
 			},
 			valid: false,
 		},
 		{
-			name: "valid clusterservicebroker - catalogRequirements.serviceClass",
+			name: "valid clusterservicebroker - catalogRequirements.servicePlan",
 			broker: &servicecatalog.ClusterServiceBroker{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-broker",
@@ -380,7 +857,7 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 						URL:            "http://example.com",
 						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
 						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
-							ServiceClass: []string{
+							ServicePlan: []string{
 								"name==foobar",
 							},
 						},
@@ -390,7 +867,7 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 			valid: true,
 		},
 		{
-			name: "valid clusterservicebroker - complex catalogRequirements.serviceClass",
+			name: "valid clusterservicebroker - complex catalogRequirements.servicePlan",
 			broker: &servicecatalog.ClusterServiceBroker{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-broker",
@@ -400,7 +877,7 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 						URL:            "http://example.com",
 						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
 						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
-							ServiceClass: []string{
+							ServicePlan: []string{
 								"name==foobar",
 								"spec.externalName in (foobar, bazboof, wizzbang)",
 							},
@@ -411,7 +888,7 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 			valid: true,
 		},
 		{
-			name: "invalid clusterservicebroker - catalogRequirements.serviceClass",
+			name: "invalid clusterservicebroker - catalogRequirements.servicePlan",
 			broker: &servicecatalog.ClusterServiceBroker{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-broker",
@@ -421,7 +898,7 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 						URL:            "http://example.com",
 						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
 						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
-							ServiceClass: []string{
+							ServicePlan: []string{
 								"invalid restriction",
 							},
 						},
@@ -431,7 +908,7 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 			valid: false,
 		},
 		{
-			name: "invalid clusterservicebroker - catalogRequirements.serviceClass - invalid property",
+			name: "invalid clusterservicebroker - catalogRequirements.servicePlan - invalid property",
 			broker: &servicecatalog.ClusterServiceBroker{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-broker",
@@ -441,8 +918,8 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 						URL:            "http://example.com",
 						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
 						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
-							ServiceClass: []string{
-								"spec.invalidProperty==foobar",
+							ServicePlan: []string{
+								"spec.invalidProperty notin (foo, bar)",
 							},
 						},
 					},
@@ -451,7 +928,7 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 			valid: false,
 		},
 		{
-			name: "valid clusterservicebroker - catalogRequirements.servicePlan",
+			name: "valid clusterservicebroker - catalogRequirements on tags, bindable and externalMetadata",
 			broker: &servicecatalog.ClusterServiceBroker{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-broker",
@@ -461,8 +938,15 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 						URL:            "http://example.com",
 						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
 						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{
+								"spec.tags in (production)",
+								"spec.bindable=true",
+								"spec.planUpdatable=true",
+								"externalMetadata.displayName=MySQL",
+							},
 							ServicePlan: []string{
-								"name==foobar",
+								"spec.bindable=true",
+								"externalMetadata.tier notin (bronze)",
 							},
 						},
 					},
@@ -471,7 +955,7 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 			valid: true,
 		},
 		{
-			name: "valid clusterservicebroker - complex catalogRequirements.servicePlan",
+			name: "invalid clusterservicebroker - catalogRequirements.servicePlan - tags are only valid for classes",
 			broker: &servicecatalog.ClusterServiceBroker{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-broker",
@@ -482,17 +966,16 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
 						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
 							ServicePlan: []string{
-								"name==foobar",
-								"spec.externalName in (foobar, bazboof, wizzbang)",
+								"spec.tags in (production)",
 							},
 						},
 					},
 				},
 			},
-			valid: true,
+			valid: false,
 		},
 		{
-			name: "invalid clusterservicebroker - catalogRequirements.servicePlan",
+			name: "valid clusterservicebroker - catalogRequirements with match operators",
 			broker: &servicecatalog.ClusterServiceBroker{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-broker",
@@ -502,8 +985,33 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 						URL:            "http://example.com",
 						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
 						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{
+								"spec.externalName matches ^azure-sql-.*",
+								"spec.tags notmatches (preview|beta)",
+							},
 							ServicePlan: []string{
-								"invalid restriction",
+								"spec.externalName startswith standard-",
+								"externalMetadata.tier endswith gold",
+							},
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid clusterservicebroker - catalogRequirements.serviceClass - malformed regular expression",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							ServiceClass: []string{
+								"spec.externalName matches ^azure-sql-[",
 							},
 						},
 					},
@@ -512,7 +1020,7 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 			valid: false,
 		},
 		{
-			name: "invalid clusterservicebroker - catalogRequirements.servicePlan - invalid property",
+			name: "invalid clusterservicebroker - catalogRequirements.servicePlan - match on invalid property",
 			broker: &servicecatalog.ClusterServiceBroker{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-broker",
@@ -523,7 +1031,7 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
 						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
 							ServicePlan: []string{
-								"spec.invalidProperty notin (foo, bar)",
+								"spec.tags matches ^prod",
 							},
 						},
 					},
@@ -679,6 +1187,7 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 }
 
 func TestValidateServiceBroker(t *testing.T) {
+	shortTokenExpirationSeconds := int64(60)
 	cases := []struct {
 		name   string
 		broker *servicecatalog.ServiceBroker
@@ -751,6 +1260,54 @@ func TestValidateServiceBroker(t *testing.T) {
 			},
 			valid: true,
 		},
+		{
+			name: "valid servicebroker - tls auth - secret",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-clusterservicebroker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
+						TLS: &servicecatalog.TLSAuthConfig{
+							SecretRef: &servicecatalog.LocalObjectReference{
+								Name: "test-secret",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "valid servicebroker - oauth2 auth - secret",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-clusterservicebroker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
+						OAuth2: &servicecatalog.OAuth2AuthConfig{
+							SecretRef: &servicecatalog.LocalObjectReference{
+								Name: "test-secret",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: true,
+		},
 		{
 			name: "invalid servicebroker - servicebroker without namespace",
 			broker: &servicecatalog.ServiceBroker{
@@ -811,6 +1368,147 @@ func TestValidateServiceBroker(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "invalid servicebroker - tls auth - secret missing name",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-clusterservicebroker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
+						TLS: &servicecatalog.TLSAuthConfig{
+							SecretRef: &servicecatalog.LocalObjectReference{},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid servicebroker - oauth2 auth - secret missing name",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-clusterservicebroker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
+						OAuth2: &servicecatalog.OAuth2AuthConfig{
+							SecretRef: &servicecatalog.LocalObjectReference{},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "valid servicebroker - service account token auth",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-clusterservicebroker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
+						ServiceAccountToken: &servicecatalog.ServiceAccountTokenAuthConfig{
+							ServiceAccountRef: &servicecatalog.LocalObjectReference{
+								Name: "test-broker-client",
+							},
+							Audience: "https://broker.example.com",
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid servicebroker - service account token auth - service account missing name",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-clusterservicebroker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
+						ServiceAccountToken: &servicecatalog.ServiceAccountTokenAuthConfig{
+							ServiceAccountRef: &servicecatalog.LocalObjectReference{},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid servicebroker - service account token auth - audience missing",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-clusterservicebroker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
+						ServiceAccountToken: &servicecatalog.ServiceAccountTokenAuthConfig{
+							ServiceAccountRef: &servicecatalog.LocalObjectReference{
+								Name: "test-broker-client",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid servicebroker - service account token auth - expiration too short",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-clusterservicebroker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
+						ServiceAccountToken: &servicecatalog.ServiceAccountTokenAuthConfig{
+							ServiceAccountRef: &servicecatalog.LocalObjectReference{
+								Name: "test-broker-client",
+							},
+							Audience:          "https://broker.example.com",
+							ExpirationSeconds: &shortTokenExpirationSeconds,
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
 		{
 			name: "invalid servicebroker - CABundle present with InsecureSkipTLSVerify",
 			broker: &servicecatalog.ServiceBroker{
@@ -984,6 +1682,40 @@ func TestValidateServiceBroker(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "valid servicebroker - rateLimit",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-servicebroker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						RateLimit:      &servicecatalog.ServiceBrokerRateLimit{QPS: 5, Burst: 10, MaxInFlight: 2},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid servicebroker - negative rateLimit.burst",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-servicebroker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						RateLimit:      &servicecatalog.ServiceBrokerRateLimit{QPS: 5, Burst: -1},
+					},
+				},
+			},
+			valid: false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -106,6 +106,10 @@ func validateServiceInstanceSpec(spec *sc.ServiceInstanceSpec, fldPath *field.Pa
 
 	allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(spec.UpdateRequests, fldPath.Child("updateRequests"))...)
 
+	if spec.MaintenanceInfo != nil {
+		allErrs = append(allErrs, validateMaintenanceInfo(spec.MaintenanceInfo, fldPath.Child("maintenanceInfo"))...)
+	}
+
 	return allErrs
 }
 
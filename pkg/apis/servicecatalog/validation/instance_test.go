@@ -255,6 +255,24 @@ func TestValidateServiceInstance(t *testing.T) {
 			}(),
 			valid: true, // plan may be picked by defaultserviceplan admission controller
 		},
+		{
+			name: "valid maintenanceInfo",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.MaintenanceInfo = &servicecatalog.MaintenanceInfo{Version: "1.2.0"}
+				return i
+			}(),
+			valid: true,
+		},
+		{
+			name: "maintenanceInfo without version",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.MaintenanceInfo = &servicecatalog.MaintenanceInfo{}
+				return i
+			}(),
+			valid: false,
+		},
 		{
 			name: "valid parametersFrom",
 			instance: func() *servicecatalog.ServiceInstance {
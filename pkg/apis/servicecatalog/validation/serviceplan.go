@@ -100,6 +100,10 @@ func validateCommonServicePlanSpec(spec sc.CommonServicePlanSpec, fldPath *field
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("externalName"), spec.ExternalName, msg))
 	}
 
+	if spec.MaintenanceInfo != nil {
+		allErrs = append(allErrs, validateMaintenanceInfo(spec.MaintenanceInfo, fldPath.Child("maintenanceInfo"))...)
+	}
+
 	return allErrs
 
 }
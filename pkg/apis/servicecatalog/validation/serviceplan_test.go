@@ -158,6 +158,24 @@ func TestValidateClusterServicePlan(t *testing.T) {
 			}(),
 			valid: false,
 		},
+		{
+			name: "valid maintenanceInfo",
+			clusterServicePlan: func() *servicecatalog.ClusterServicePlan {
+				s := validClusterServicePlan()
+				s.Spec.MaintenanceInfo = &servicecatalog.MaintenanceInfo{Version: "2.0.0"}
+				return s
+			}(),
+			valid: true,
+		},
+		{
+			name: "maintenanceInfo without version",
+			clusterServicePlan: func() *servicecatalog.ClusterServicePlan {
+				s := validClusterServicePlan()
+				s.Spec.MaintenanceInfo = &servicecatalog.MaintenanceInfo{}
+				return s
+			}(),
+			valid: false,
+		},
 		{
 			name: "missing serviceclass reference",
 			clusterServicePlan: func() *servicecatalog.ClusterServicePlan {
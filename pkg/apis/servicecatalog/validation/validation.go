@@ -46,3 +46,13 @@ func validateParametersFromSource(parametersFrom []sc.ParametersFromSource, fldP
 
 	return allErrs
 }
+
+func validateMaintenanceInfo(maintenanceInfo *sc.MaintenanceInfo, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if maintenanceInfo.Version == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("version"), "version is required"))
+	}
+
+	return allErrs
+}
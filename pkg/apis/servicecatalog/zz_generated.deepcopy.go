@@ -119,6 +119,22 @@ func (in *BearerTokenAuthConfig) DeepCopy() *BearerTokenAuthConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogDiff) DeepCopyInto(out *CatalogDiff) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogDiff.
+func (in *CatalogDiff) DeepCopy() *CatalogDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CatalogRestrictions) DeepCopyInto(out *CatalogRestrictions) {
 	*out = *in
@@ -187,6 +203,27 @@ func (in *ClusterBearerTokenAuthConfig) DeepCopy() *ClusterBearerTokenAuthConfig
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOAuth2AuthConfig) DeepCopyInto(out *ClusterOAuth2AuthConfig) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(ObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOAuth2AuthConfig.
+func (in *ClusterOAuth2AuthConfig) DeepCopy() *ClusterOAuth2AuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOAuth2AuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterObjectReference) DeepCopyInto(out *ClusterObjectReference) {
 	*out = *in
@@ -203,6 +240,32 @@ func (in *ClusterObjectReference) DeepCopy() *ClusterObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterServiceAccountTokenAuthConfig) DeepCopyInto(out *ClusterServiceAccountTokenAuthConfig) {
+	*out = *in
+	if in.ServiceAccountRef != nil {
+		in, out := &in.ServiceAccountRef, &out.ServiceAccountRef
+		*out = new(ObjectReference)
+		**out = **in
+	}
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterServiceAccountTokenAuthConfig.
+func (in *ClusterServiceAccountTokenAuthConfig) DeepCopy() *ClusterServiceAccountTokenAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterServiceAccountTokenAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterServiceBroker) DeepCopyInto(out *ClusterServiceBroker) {
 	*out = *in
@@ -244,6 +307,21 @@ func (in *ClusterServiceBrokerAuthInfo) DeepCopyInto(out *ClusterServiceBrokerAu
 		*out = new(ClusterBearerTokenAuthConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ClusterTLSAuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(ClusterOAuth2AuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountToken != nil {
+		in, out := &in.ServiceAccountToken, &out.ServiceAccountToken
+		*out = new(ClusterServiceAccountTokenAuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -520,6 +598,27 @@ func (in *ClusterServicePlanStatus) DeepCopy() *ClusterServicePlanStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTLSAuthConfig) DeepCopyInto(out *ClusterTLSAuthConfig) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(ObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTLSAuthConfig.
+func (in *ClusterTLSAuthConfig) DeepCopy() *ClusterTLSAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTLSAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonServiceBrokerSpec) DeepCopyInto(out *CommonServiceBrokerSpec) {
 	*out = *in
@@ -538,6 +637,11 @@ func (in *CommonServiceBrokerSpec) DeepCopyInto(out *CommonServiceBrokerSpec) {
 		*out = new(CatalogRestrictions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(ServiceBrokerRateLimit)
+		**out = **in
+	}
 	return
 }
 
@@ -569,6 +673,15 @@ func (in *CommonServiceBrokerStatus) DeepCopyInto(out *CommonServiceBrokerStatus
 		in, out := &in.LastCatalogRetrievalTime, &out.LastCatalogRetrievalTime
 		*out = (*in).DeepCopy()
 	}
+	if in.LastCatalogDiff != nil {
+		in, out := &in.LastCatalogDiff, &out.LastCatalogDiff
+		*out = new(CatalogDiff)
+		**out = **in
+	}
+	if in.LastHealthCheckTime != nil {
+		in, out := &in.LastHealthCheckTime, &out.LastHealthCheckTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -672,6 +785,11 @@ func (in *CommonServicePlanSpec) DeepCopyInto(out *CommonServicePlanSpec) {
 		*out = new(runtime.RawExtension)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaintenanceInfo != nil {
+		in, out := &in.MaintenanceInfo, &out.MaintenanceInfo
+		*out = new(MaintenanceInfo)
+		**out = **in
+	}
 	return
 }
 
@@ -737,6 +855,43 @@ func (in *LocalObjectReference) DeepCopy() *LocalObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceInfo) DeepCopyInto(out *MaintenanceInfo) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceInfo.
+func (in *MaintenanceInfo) DeepCopy() *MaintenanceInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2AuthConfig) DeepCopyInto(out *OAuth2AuthConfig) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2AuthConfig.
+func (in *OAuth2AuthConfig) DeepCopy() *OAuth2AuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2AuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
 	*out = *in
@@ -874,6 +1029,32 @@ func (in *SecretTransform) DeepCopy() *SecretTransform {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountTokenAuthConfig) DeepCopyInto(out *ServiceAccountTokenAuthConfig) {
+	*out = *in
+	if in.ServiceAccountRef != nil {
+		in, out := &in.ServiceAccountRef, &out.ServiceAccountRef
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountTokenAuthConfig.
+func (in *ServiceAccountTokenAuthConfig) DeepCopy() *ServiceAccountTokenAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountTokenAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceBinding) DeepCopyInto(out *ServiceBinding) {
 	*out = *in
@@ -978,6 +1159,34 @@ func (in *ServiceBindingPropertiesState) DeepCopy() *ServiceBindingPropertiesSta
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBindingRotationStatus) DeepCopyInto(out *ServiceBindingRotationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PreviousUnbindTime != nil {
+		in, out := &in.PreviousUnbindTime, &out.PreviousUnbindTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBindingRotationStatus.
+func (in *ServiceBindingRotationStatus) DeepCopy() *ServiceBindingRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBindingRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceBindingSpec) DeepCopyInto(out *ServiceBindingSpec) {
 	*out = *in
@@ -1048,6 +1257,23 @@ func (in *ServiceBindingStatus) DeepCopyInto(out *ServiceBindingStatus) {
 		*out = new(ServiceBindingPropertiesState)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LastRetrievalTime != nil {
+		in, out := &in.LastRetrievalTime, &out.LastRetrievalTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RotationStatus != nil {
+		in, out := &in.RotationStatus, &out.RotationStatus
+		*out = new(ServiceBindingRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -1102,6 +1328,21 @@ func (in *ServiceBrokerAuthInfo) DeepCopyInto(out *ServiceBrokerAuthInfo) {
 		*out = new(BearerTokenAuthConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSAuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(OAuth2AuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountToken != nil {
+		in, out := &in.ServiceAccountToken, &out.ServiceAccountToken
+		*out = new(ServiceAccountTokenAuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1165,6 +1406,22 @@ func (in *ServiceBrokerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBrokerRateLimit) DeepCopyInto(out *ServiceBrokerRateLimit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBrokerRateLimit.
+func (in *ServiceBrokerRateLimit) DeepCopy() *ServiceBrokerRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBrokerRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceBrokerSpec) DeepCopyInto(out *ServiceBrokerSpec) {
 	*out = *in
@@ -1390,6 +1647,11 @@ func (in *ServiceInstancePropertiesState) DeepCopyInto(out *ServiceInstancePrope
 		*out = new(UserInfo)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaintenanceInfo != nil {
+		in, out := &in.MaintenanceInfo, &out.MaintenanceInfo
+		*out = new(MaintenanceInfo)
+		**out = **in
+	}
 	return
 }
 
@@ -1444,6 +1706,11 @@ func (in *ServiceInstanceSpec) DeepCopyInto(out *ServiceInstanceSpec) {
 		*out = new(UserInfo)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaintenanceInfo != nil {
+		in, out := &in.MaintenanceInfo, &out.MaintenanceInfo
+		*out = new(MaintenanceInfo)
+		**out = **in
+	}
 	return
 }
 
@@ -1477,6 +1744,10 @@ func (in *ServiceInstanceStatus) DeepCopyInto(out *ServiceInstanceStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.LastRetrievalTime != nil {
+		in, out := &in.LastRetrievalTime, &out.LastRetrievalTime
+		*out = (*in).DeepCopy()
+	}
 	if in.OperationStartTime != nil {
 		in, out := &in.OperationStartTime, &out.OperationStartTime
 		*out = (*in).DeepCopy()
@@ -1605,6 +1876,27 @@ func (in *ServicePlanStatus) DeepCopy() *ServicePlanStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSAuthConfig) DeepCopyInto(out *TLSAuthConfig) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSAuthConfig.
+func (in *TLSAuthConfig) DeepCopy() *TLSAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserInfo) DeepCopyInto(out *UserInfo) {
 	*out = *in
@@ -0,0 +1,144 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cataloghistory
+
+import (
+	"reflect"
+	"sort"
+
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+)
+
+// ChangeType is the type of a change between two catalogs.
+type ChangeType string
+
+const (
+	// ChangeAdded means the entry only exists in the newer catalog.
+	ChangeAdded ChangeType = "added"
+	// ChangeRemoved means the entry only exists in the older catalog.
+	ChangeRemoved ChangeType = "removed"
+	// ChangeUpdated means the entry exists in both catalogs, but differs.
+	ChangeUpdated ChangeType = "updated"
+)
+
+// EntryKind is the kind of a catalog entry.
+type EntryKind string
+
+const (
+	// EntryClass is a service of the catalog.
+	EntryClass EntryKind = "class"
+	// EntryPlan is a plan of a service of the catalog.
+	EntryPlan EntryKind = "plan"
+)
+
+// Change is a change of a single class or plan between two catalogs.
+type Change struct {
+	Type ChangeType `json:"type"`
+	Kind EntryKind  `json:"kind"`
+	// Name is the name of the class, or the name of the class and the name
+	// of the plan separated by a slash.
+	Name string `json:"name"`
+	// ID is the OSB ID of the class or plan.
+	ID string `json:"id"`
+}
+
+// Diff returns the changes of the classes and plans from one catalog to
+// another. Classes and plans are matched by their OSB ID. Classes are listed
+// before plans, and entries of the same kind are sorted by name.
+func Diff(from, to *osb.CatalogResponse) []Change {
+	changes := []Change{}
+
+	fromServices := servicesByID(from)
+	toServices := servicesByID(to)
+	fromPlans := plansByID(from)
+	toPlans := plansByID(to)
+
+	for id, service := range toServices {
+		old, ok := fromServices[id]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Type: ChangeAdded, Kind: EntryClass, Name: service.Name, ID: id})
+		case !reflect.DeepEqual(withoutPlans(old), withoutPlans(service)):
+			changes = append(changes, Change{Type: ChangeUpdated, Kind: EntryClass, Name: service.Name, ID: id})
+		}
+	}
+	for id, service := range fromServices {
+		if _, ok := toServices[id]; !ok {
+			changes = append(changes, Change{Type: ChangeRemoved, Kind: EntryClass, Name: service.Name, ID: id})
+		}
+	}
+
+	for id, plan := range toPlans {
+		old, ok := fromPlans[id]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Type: ChangeAdded, Kind: EntryPlan, Name: plan.name, ID: id})
+		case !reflect.DeepEqual(old.plan, plan.plan):
+			changes = append(changes, Change{Type: ChangeUpdated, Kind: EntryPlan, Name: plan.name, ID: id})
+		}
+	}
+	for id, plan := range fromPlans {
+		if _, ok := toPlans[id]; !ok {
+			changes = append(changes, Change{Type: ChangeRemoved, Kind: EntryPlan, Name: plan.name, ID: id})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return changes[i].Kind == EntryClass
+		}
+		if changes[i].Name != changes[j].Name {
+			return changes[i].Name < changes[j].Name
+		}
+		return changes[i].ID < changes[j].ID
+	})
+	return changes
+}
+
+type namedPlan struct {
+	name string
+	plan osb.Plan
+}
+
+func servicesByID(catalog *osb.CatalogResponse) map[string]osb.Service {
+	services := map[string]osb.Service{}
+	if catalog == nil {
+		return services
+	}
+	for _, service := range catalog.Services {
+		services[service.ID] = service
+	}
+	return services
+}
+
+func plansByID(catalog *osb.CatalogResponse) map[string]namedPlan {
+	plans := map[string]namedPlan{}
+	if catalog == nil {
+		return plans
+	}
+	for _, service := range catalog.Services {
+		for _, plan := range service.Plans {
+			plans[plan.ID] = namedPlan{name: service.Name + "/" + plan.Name, plan: plan}
+		}
+	}
+	return plans
+}
+
+func withoutPlans(service osb.Service) osb.Service {
+	service.Plans = nil
+	return service
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cataloghistory stores snapshots of the catalogs fetched from
+// brokers in ConfigMaps, one ConfigMap per revision of a broker's catalog.
+package cataloghistory
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+)
+
+const (
+	// RevisionLabel is the label holding the revision of a catalog snapshot.
+	// Revisions of a broker's catalog start at 1.
+	RevisionLabel = v1beta1.GroupName + "/catalog-revision"
+
+	// ScopeLabel is the label holding the kind of broker whose catalog is in
+	// a snapshot, ClusterServiceBrokerScope or ServiceBrokerScope. The
+	// snapshots of a ClusterServiceBroker and a ServiceBroker with the same
+	// name may be in the same namespace.
+	ScopeLabel = v1beta1.GroupName + "/catalog-scope"
+
+	// ClusterServiceBrokerScope is the scope of the snapshots of the catalog
+	// of a ClusterServiceBroker.
+	ClusterServiceBrokerScope = "clusterservicebroker"
+
+	// ServiceBrokerScope is the scope of the snapshots of the catalog of a
+	// ServiceBroker.
+	ServiceBrokerScope = "servicebroker"
+
+	// HashAnnotation is the annotation holding the SHA-256 hash of the
+	// catalog in a snapshot.
+	HashAnnotation = v1beta1.GroupName + "/catalog-hash"
+
+	// CatalogKey is the key of the gzip compressed JSON catalog in the binary
+	// data of a snapshot.
+	CatalogKey = "catalog.json.gz"
+
+	// DefaultNamespace is the default namespace of the snapshots of the
+	// catalogs of ClusterServiceBrokers.
+	DefaultNamespace = "default"
+)
+
+// ClusterServiceBrokerLabels returns the labels identifying the snapshots of
+// the catalog of a ClusterServiceBroker.
+func ClusterServiceBrokerLabels(brokerName string) map[string]string {
+	return map[string]string{
+		ScopeLabel: ClusterServiceBrokerScope,
+		v1beta1.GroupName + "/" + v1beta1.FilterSpecClusterServiceBrokerName: brokerName,
+	}
+}
+
+// ServiceBrokerLabels returns the labels identifying the snapshots of the
+// catalog of a ServiceBroker.
+func ServiceBrokerLabels(brokerName string) map[string]string {
+	return map[string]string{
+		ScopeLabel: ServiceBrokerScope,
+		v1beta1.GroupName + "/" + v1beta1.FilterSpecServiceBrokerName: brokerName,
+	}
+}
+
+// Selector returns the label selector matching all snapshots with the given
+// broker labels.
+func Selector(brokerLabels map[string]string) string {
+	return LabelSelector(brokerLabels).String()
+}
+
+// LabelSelector returns Selector as a labels.Selector, e.g. to list the
+// snapshots with a lister.
+func LabelSelector(brokerLabels map[string]string) labels.Selector {
+	selector := labels.SelectorFromSet(brokerLabels)
+	hasRevision, _ := labels.NewRequirement(RevisionLabel, selection.Exists, nil)
+	return selector.Add(*hasRevision)
+}
+
+// Name returns the name of the ConfigMap holding the given revision of the
+// catalog of a broker, e.g. clusterservicebroker.<name>.catalog.v<revision>.
+func Name(scope, brokerName string, revision int) string {
+	return fmt.Sprintf("%s.%s.catalog.v%d", scope, brokerName, revision)
+}
+
+// Hash returns the hash of a catalog stored in the HashAnnotation of its
+// snapshots.
+func Hash(catalog *osb.CatalogResponse) (string, error) {
+	catalogAsJSON, err := json.Marshal(catalog)
+	if err != nil {
+		return "", err
+	}
+	return hash(catalogAsJSON), nil
+}
+
+func hash(catalogAsJSON []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(catalogAsJSON))
+}
+
+// NewSnapshot returns the ConfigMap holding the given revision of the catalog
+// of a broker. brokerLabels are the labels returned by
+// ClusterServiceBrokerLabels or ServiceBrokerLabels.
+func NewSnapshot(namespace, brokerName string, brokerLabels map[string]string, revision int, catalog *osb.CatalogResponse) (*corev1.ConfigMap, error) {
+	catalogAsJSON, err := json.Marshal(catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	if _, err := w.Write(catalogAsJSON); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	snapshotLabels := map[string]string{
+		RevisionLabel: strconv.Itoa(revision),
+	}
+	for k, v := range brokerLabels {
+		snapshotLabels[k] = v
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      Name(brokerLabels[ScopeLabel], brokerName, revision),
+			Namespace: namespace,
+			Labels:    snapshotLabels,
+			Annotations: map[string]string{
+				HashAnnotation: hash(catalogAsJSON),
+			},
+		},
+		BinaryData: map[string][]byte{
+			CatalogKey: compressed.Bytes(),
+		},
+	}, nil
+}
+
+// Revision returns the revision of the catalog held by a snapshot.
+func Revision(snapshot *corev1.ConfigMap) (int, error) {
+	revision, err := strconv.Atoi(snapshot.Labels[RevisionLabel])
+	if err != nil || revision < 1 {
+		return 0, fmt.Errorf("ConfigMap %s/%s has an invalid %s label %q", snapshot.Namespace, snapshot.Name, RevisionLabel, snapshot.Labels[RevisionLabel])
+	}
+	return revision, nil
+}
+
+// Catalog returns the catalog held by a snapshot.
+func Catalog(snapshot *corev1.ConfigMap) (*osb.CatalogResponse, error) {
+	compressed, ok := snapshot.BinaryData[CatalogKey]
+	if !ok {
+		return nil, fmt.Errorf("ConfigMap %s/%s does not hold a catalog", snapshot.Namespace, snapshot.Name)
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing the catalog in ConfigMap %s/%s: %v", snapshot.Namespace, snapshot.Name, err)
+	}
+	defer r.Close()
+	catalogAsJSON, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing the catalog in ConfigMap %s/%s: %v", snapshot.Namespace, snapshot.Name, err)
+	}
+
+	catalog := &osb.CatalogResponse{}
+	if err := json.Unmarshal(catalogAsJSON, catalog); err != nil {
+		return nil, fmt.Errorf("error unmarshalling the catalog in ConfigMap %s/%s: %v", snapshot.Namespace, snapshot.Name, err)
+	}
+	return catalog, nil
+}
+
+// SortByRevision returns the snapshots with a valid revision, oldest
+// revision first.
+func SortByRevision(snapshots []corev1.ConfigMap) []corev1.ConfigMap {
+	type revisionedSnapshot struct {
+		revision int
+		snapshot corev1.ConfigMap
+	}
+
+	valid := []revisionedSnapshot{}
+	for _, snapshot := range snapshots {
+		revision, err := Revision(&snapshot)
+		if err != nil {
+			continue
+		}
+		valid = append(valid, revisionedSnapshot{revision: revision, snapshot: snapshot})
+	}
+	sort.SliceStable(valid, func(i, j int) bool {
+		return valid[i].revision < valid[j].revision
+	})
+
+	sorted := make([]corev1.ConfigMap, 0, len(valid))
+	for _, s := range valid {
+		sorted = append(sorted, s.snapshot)
+	}
+	return sorted
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cataloghistory
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+)
+
+func testCatalog() *osb.CatalogResponse {
+	return &osb.CatalogResponse{
+		Services: []osb.Service{
+			{
+				ID:          "mysql-id",
+				Name:        "mysql",
+				Description: "MySQL",
+				Plans: []osb.Plan{
+					{ID: "small-id", Name: "small", Description: "A small database"},
+					{ID: "large-id", Name: "large", Description: "A large database"},
+				},
+			},
+			{
+				ID:          "redis-id",
+				Name:        "redis",
+				Description: "Redis",
+				Plans: []osb.Plan{
+					{ID: "cache-id", Name: "cache", Description: "A cache"},
+				},
+			},
+		},
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	catalog := testCatalog()
+	snapshot, err := NewSnapshot("catalog", "ups-broker", ClusterServiceBrokerLabels("ups-broker"), 3, catalog)
+	if err != nil {
+		t.Fatalf("unexpected error creating snapshot: %v", err)
+	}
+
+	if e, a := "clusterservicebroker.ups-broker.catalog.v3", snapshot.Name; e != a {
+		t.Fatalf("unexpected name; expected %v, got %v", e, a)
+	}
+	selector, err := labels.Parse(Selector(ClusterServiceBrokerLabels("ups-broker")))
+	if err != nil {
+		t.Fatalf("unexpected error parsing selector: %v", err)
+	}
+	if !selector.Matches(labels.Set(snapshot.Labels)) {
+		t.Fatalf("expected selector %v to match snapshot labels %v", selector, snapshot.Labels)
+	}
+	if selector.Matches(labels.Set(ClusterServiceBrokerLabels("ups-broker"))) {
+		t.Fatalf("expected selector %v to require the revision label", selector)
+	}
+
+	hash, err := Hash(catalog)
+	if err != nil {
+		t.Fatalf("unexpected error hashing catalog: %v", err)
+	}
+	if e, a := hash, snapshot.Annotations[HashAnnotation]; e != a {
+		t.Fatalf("unexpected hash; expected %v, got %v", e, a)
+	}
+
+	revision, err := Revision(snapshot)
+	if err != nil {
+		t.Fatalf("unexpected error getting revision: %v", err)
+	}
+	if e, a := 3, revision; e != a {
+		t.Fatalf("unexpected revision; expected %v, got %v", e, a)
+	}
+
+	decoded, err := Catalog(snapshot)
+	if err != nil {
+		t.Fatalf("unexpected error decoding catalog: %v", err)
+	}
+	if !reflect.DeepEqual(catalog, decoded) {
+		t.Fatalf("unexpected catalog; expected %+v, got %+v", catalog, decoded)
+	}
+}
+
+func TestSnapshotScope(t *testing.T) {
+	clusterSnapshot, err := NewSnapshot("catalog", "ups-broker", ClusterServiceBrokerLabels("ups-broker"), 1, testCatalog())
+	if err != nil {
+		t.Fatalf("unexpected error creating snapshot: %v", err)
+	}
+	snapshot, err := NewSnapshot("catalog", "ups-broker", ServiceBrokerLabels("ups-broker"), 1, testCatalog())
+	if err != nil {
+		t.Fatalf("unexpected error creating snapshot: %v", err)
+	}
+
+	if e, a := "servicebroker.ups-broker.catalog.v1", snapshot.Name; e != a {
+		t.Fatalf("unexpected name; expected %v, got %v", e, a)
+	}
+	if clusterSnapshot.Name == snapshot.Name {
+		t.Fatalf("expected the snapshots of a ClusterServiceBroker and a ServiceBroker with the same name to have different names, got %v", snapshot.Name)
+	}
+	if LabelSelector(ClusterServiceBrokerLabels("ups-broker")).Matches(labels.Set(snapshot.Labels)) {
+		t.Fatalf("expected the ClusterServiceBroker selector not to match the labels %v of a ServiceBroker snapshot", snapshot.Labels)
+	}
+	if LabelSelector(ServiceBrokerLabels("ups-broker")).Matches(labels.Set(clusterSnapshot.Labels)) {
+		t.Fatalf("expected the ServiceBroker selector not to match the labels %v of a ClusterServiceBroker snapshot", clusterSnapshot.Labels)
+	}
+}
+
+func TestCatalogInvalidSnapshot(t *testing.T) {
+	snapshot := &corev1.ConfigMap{
+		BinaryData: map[string][]byte{CatalogKey: []byte("not gzip")},
+	}
+	if _, err := Catalog(snapshot); err == nil {
+		t.Fatal("expected an error decoding an invalid snapshot")
+	}
+	if _, err := Catalog(&corev1.ConfigMap{}); err == nil {
+		t.Fatal("expected an error decoding a snapshot without a catalog")
+	}
+}
+
+func TestSortByRevision(t *testing.T) {
+	snapshot := func(name, revision string) corev1.ConfigMap {
+		return corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{RevisionLabel: revision},
+			},
+		}
+	}
+
+	sorted := SortByRevision([]corev1.ConfigMap{
+		snapshot("ten", "10"),
+		snapshot("invalid", "latest"),
+		snapshot("two", "2"),
+		snapshot("zero", "0"),
+		snapshot("nine", "9"),
+	})
+
+	names := []string{}
+	for _, s := range sorted {
+		names = append(names, s.Name)
+	}
+	if e, a := []string{"two", "nine", "ten"}, names; !reflect.DeepEqual(e, a) {
+		t.Fatalf("unexpected order; expected %v, got %v", e, a)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	from := testCatalog()
+	to := testCatalog()
+	// update the mysql class and remove its large plan
+	to.Services[0].Description = "MySQL database"
+	to.Services[0].Plans = to.Services[0].Plans[:1]
+	// update the redis cache plan and add a plan
+	to.Services[1].Plans[0].Description = "A bigger cache"
+	to.Services[1].Plans = append(to.Services[1].Plans, osb.Plan{ID: "persistent-id", Name: "persistent"})
+	// add a class
+	to.Services = append(to.Services, osb.Service{
+		ID:    "mongodb-id",
+		Name:  "mongodb",
+		Plans: []osb.Plan{{ID: "shared-id", Name: "shared"}},
+	})
+
+	expected := []Change{
+		{Type: ChangeAdded, Kind: EntryClass, Name: "mongodb", ID: "mongodb-id"},
+		{Type: ChangeUpdated, Kind: EntryClass, Name: "mysql", ID: "mysql-id"},
+		{Type: ChangeAdded, Kind: EntryPlan, Name: "mongodb/shared", ID: "shared-id"},
+		{Type: ChangeRemoved, Kind: EntryPlan, Name: "mysql/large", ID: "large-id"},
+		{Type: ChangeUpdated, Kind: EntryPlan, Name: "redis/cache", ID: "cache-id"},
+		{Type: ChangeAdded, Kind: EntryPlan, Name: "redis/persistent", ID: "persistent-id"},
+	}
+	if changes := Diff(from, to); !reflect.DeepEqual(expected, changes) {
+		t.Fatalf("unexpected changes; expected %+v, got %+v", expected, changes)
+	}
+
+	if changes := Diff(from, from); len(changes) != 0 {
+		t.Fatalf("expected no changes between identical catalogs, got %+v", changes)
+	}
+
+	removed := Diff(from, nil)
+	if e, a := 5, len(removed); e != a {
+		t.Fatalf("unexpected number of changes; expected %v, got %v", e, a)
+	}
+	for _, change := range removed {
+		if change.Type != ChangeRemoved {
+			t.Fatalf("expected only removals, got %+v", change)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"k8s.io/klog"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/metrics"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+)
+
+// circuitState is the state of the circuit breaker of a broker. The values
+// are exposed by the broker_circuit_breaker_state metric.
+type circuitState int
+
+const (
+	// circuitClosed means that requests are sent to the broker.
+	circuitClosed circuitState = iota
+	// circuitHalfOpen means that a single request is sent to the broker to
+	// probe whether it has recovered, while other requests are suspended.
+	circuitHalfOpen
+	// circuitOpen means that requests to the broker are suspended.
+	circuitOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitClosed:
+		return "closed"
+	case circuitHalfOpen:
+		return "half-open"
+	default:
+		return "open"
+	}
+}
+
+// brokerUnavailableError is returned for requests that were not sent because
+// the circuit breaker of the broker is open.
+type brokerUnavailableError struct {
+	broker   string
+	failures int
+	retryAt  time.Time
+}
+
+func (e *brokerUnavailableError) Error() string {
+	return fmt.Sprintf("broker %q is unavailable after %d consecutive requests failed to reach it; requests are suspended until %s", e.broker, e.failures, e.retryAt.Format(time.RFC3339))
+}
+
+// isBrokerUnavailableError returns whether the given error was returned for a
+// request that was not sent because the circuit breaker of the broker is open.
+func isBrokerUnavailableError(err error) bool {
+	_, ok := err.(*brokerUnavailableError)
+	return ok
+}
+
+// isBrokerUnreachableError returns whether the given error returned for a
+// request means that the broker could not be reached, as opposed to the broker
+// rejecting the request.
+func isBrokerUnreachableError(err error) bool {
+	if httpErr, ok := osb.IsHTTPError(err); ok {
+		switch httpErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	_, ok := err.(*url.Error)
+	return ok
+}
+
+// brokerCircuitBreaker suspends the requests to a broker after a number of
+// consecutive requests failed to reach it, so that the workers of the
+// controller don't wait for the requests to time out.
+type brokerCircuitBreaker struct {
+	broker           string
+	failureThreshold int
+	openDuration     time.Duration
+	// stateChanged is called when the circuit opens or closes.
+	stateChanged func()
+	now          func() time.Time
+
+	mu       sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+}
+
+// newBrokerCircuitBreaker creates a closed brokerCircuitBreaker for the given
+// broker.
+func newBrokerCircuitBreaker(broker string, failureThreshold int, openDuration time.Duration, stateChanged func()) *brokerCircuitBreaker {
+	metrics.BrokerCircuitBreakerState.WithLabelValues(broker).Set(float64(circuitClosed))
+	return &brokerCircuitBreaker{
+		broker:           broker,
+		failureThreshold: failureThreshold,
+		openDuration:     openDuration,
+		stateChanged:     stateChanged,
+		now:              time.Now,
+	}
+}
+
+// State returns the current state of the circuit.
+func (b *brokerCircuitBreaker) State() circuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// allow returns a brokerUnavailableError if requests to the broker are
+// suspended. Once the circuit has been open for the open duration, a single
+// request is allowed to probe the broker.
+func (b *brokerCircuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		retryAt := b.openedAt.Add(b.openDuration)
+		if b.now().Before(retryAt) {
+			return &brokerUnavailableError{broker: b.broker, failures: b.failures, retryAt: retryAt}
+		}
+		klog.V(4).Infof("Probing whether broker %q has recovered", b.broker)
+		b.setState(circuitHalfOpen)
+	case circuitHalfOpen:
+		// The probe is still in flight
+		return &brokerUnavailableError{broker: b.broker, failures: b.failures, retryAt: b.openedAt.Add(b.openDuration)}
+	}
+	return nil
+}
+
+// record updates the circuit with the result of an allowed request.
+func (b *brokerCircuitBreaker) record(err error) {
+	b.mu.Lock()
+	changed := b.recordLocked(err)
+	b.mu.Unlock()
+
+	if changed && b.stateChanged != nil {
+		b.stateChanged()
+	}
+}
+
+func (b *brokerCircuitBreaker) recordLocked(err error) bool {
+	if isBrokerThrottledError(err) {
+		// The request was not sent, so the next request probes the broker
+		// instead.
+		if b.state == circuitHalfOpen {
+			b.setState(circuitOpen)
+		}
+		return false
+	}
+
+	unreachable := isBrokerUnreachableError(err)
+	switch b.state {
+	case circuitClosed:
+		if !unreachable {
+			b.failures = 0
+			return false
+		}
+		b.failures++
+		if b.failures < b.failureThreshold {
+			return false
+		}
+		klog.Warningf("Suspending requests to broker %q for %v after %d consecutive requests failed to reach it: %v", b.broker, b.openDuration, b.failures, err)
+		b.openedAt = b.now()
+		b.setState(circuitOpen)
+		return true
+	case circuitHalfOpen:
+		if unreachable {
+			klog.V(4).Infof("Broker %q has not recovered: %v", b.broker, err)
+			b.failures++
+			b.openedAt = b.now()
+			b.setState(circuitOpen)
+			return false
+		}
+		klog.Infof("Resuming requests to broker %q", b.broker)
+		b.failures = 0
+		b.setState(circuitClosed)
+		return true
+	}
+	// The request was sent before the circuit opened
+	return false
+}
+
+func (b *brokerCircuitBreaker) setState(state circuitState) {
+	b.state = state
+	metrics.BrokerCircuitBreakerState.WithLabelValues(b.broker).Set(float64(state))
+}
+
+// circuitBreakerClient is an osb.Client sending the requests of the wrapped
+// client to the broker only while the circuit breaker of the broker allows it.
+type circuitBreakerClient struct {
+	client  osb.Client
+	breaker *brokerCircuitBreaker
+}
+
+var _ osb.ContextClient = &circuitBreakerClient{}
+
+func (c *circuitBreakerClient) WithContext(ctx context.Context) osb.Client {
+	return &circuitBreakerClient{client: osb.WithContext(ctx, c.client), breaker: c.breaker}
+}
+
+func (c *circuitBreakerClient) GetCatalog() (*osb.CatalogResponse, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	response, err := c.client.GetCatalog()
+	c.breaker.record(err)
+	return response, err
+}
+
+func (c *circuitBreakerClient) ProvisionInstance(r *osb.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	response, err := c.client.ProvisionInstance(r)
+	c.breaker.record(err)
+	return response, err
+}
+
+func (c *circuitBreakerClient) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	response, err := c.client.UpdateInstance(r)
+	c.breaker.record(err)
+	return response, err
+}
+
+func (c *circuitBreakerClient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	response, err := c.client.DeprovisionInstance(r)
+	c.breaker.record(err)
+	return response, err
+}
+
+func (c *circuitBreakerClient) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	response, err := c.client.PollLastOperation(r)
+	c.breaker.record(err)
+	return response, err
+}
+
+func (c *circuitBreakerClient) PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	response, err := c.client.PollBindingLastOperation(r)
+	c.breaker.record(err)
+	return response, err
+}
+
+func (c *circuitBreakerClient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	response, err := c.client.Bind(r)
+	c.breaker.record(err)
+	return response, err
+}
+
+func (c *circuitBreakerClient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	response, err := c.client.Unbind(r)
+	c.breaker.record(err)
+	return response, err
+}
+
+func (c *circuitBreakerClient) GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	response, err := c.client.GetBinding(r)
+	c.breaker.record(err)
+	return response, err
+}
+
+func (c *circuitBreakerClient) GetInstance(r *osb.GetInstanceRequest) (*osb.GetInstanceResponse, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	response, err := c.client.GetInstance(r)
+	c.breaker.record(err)
+	return response, err
+}
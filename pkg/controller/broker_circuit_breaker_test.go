@@ -0,0 +1,184 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/fake"
+)
+
+func TestBrokerCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	changes := 0
+	breaker := newBrokerCircuitBreaker("test-broker", 2, time.Minute, func() { changes++ })
+	breaker.now = func() time.Time { return now }
+	unreachable := osb.HTTPStatusCodeError{StatusCode: http.StatusServiceUnavailable}
+
+	// A successful request resets the count of consecutive failures
+	breaker.record(unreachable)
+	breaker.record(nil)
+	breaker.record(unreachable)
+	if e, a := circuitClosed, breaker.State(); e != a {
+		t.Fatalf("unexpected state: expected %v, got %v", e, a)
+	}
+
+	breaker.record(unreachable)
+	if e, a := circuitOpen, breaker.State(); e != a {
+		t.Fatalf("unexpected state: expected %v, got %v", e, a)
+	}
+	if err := breaker.allow(); !isBrokerUnavailableError(err) {
+		t.Fatalf("expected requests to be suspended, got %v", err)
+	}
+
+	// A single request probes the broker once the open duration elapsed
+	now = now.Add(time.Minute)
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("expected the probe to be allowed, got %v", err)
+	}
+	if err := breaker.allow(); !isBrokerUnavailableError(err) {
+		t.Fatalf("expected requests to be suspended during the probe, got %v", err)
+	}
+	breaker.record(unreachable)
+	if e, a := circuitOpen, breaker.State(); e != a {
+		t.Fatalf("unexpected state after a failed probe: expected %v, got %v", e, a)
+	}
+
+	now = now.Add(time.Minute)
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("expected the probe to be allowed, got %v", err)
+	}
+	// Any response of the broker shows that it is reachable again
+	breaker.record(osb.HTTPStatusCodeError{StatusCode: http.StatusBadRequest})
+	if e, a := circuitClosed, breaker.State(); e != a {
+		t.Fatalf("unexpected state after a successful probe: expected %v, got %v", e, a)
+	}
+	if e, a := 2, changes; e != a {
+		t.Fatalf("expected the state change to be reported when the circuit opened and closed, got %d changes", a)
+	}
+}
+
+func TestIsBrokerUnreachableError(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "no error",
+			err:      nil,
+			expected: false,
+		},
+		{
+			name:     "connection refused",
+			err:      &url.Error{Op: "Get", URL: "http://broker", Err: errors.New("connection refused")},
+			expected: true,
+		},
+		{
+			name:     "service unavailable",
+			err:      osb.HTTPStatusCodeError{StatusCode: http.StatusServiceUnavailable},
+			expected: true,
+		},
+		{
+			name:     "gateway timeout",
+			err:      osb.HTTPStatusCodeError{StatusCode: http.StatusGatewayTimeout},
+			expected: true,
+		},
+		{
+			name:     "internal server error",
+			err:      osb.HTTPStatusCodeError{StatusCode: http.StatusInternalServerError},
+			expected: false,
+		},
+		{
+			name:     "other error",
+			err:      errors.New("oops"),
+			expected: false,
+		},
+	}
+	for _, tc := range cases {
+		if e, a := tc.expected, isBrokerUnreachableError(tc.err); e != a {
+			t.Errorf("%s: expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
+func TestCircuitBreakerClient(t *testing.T) {
+	fakeClient := fakeosb.NewFakeClient(fakeosb.FakeClientConfiguration{
+		CatalogReaction: &fakeosb.CatalogReaction{
+			Error: osb.HTTPStatusCodeError{StatusCode: http.StatusBadGateway},
+		},
+	})
+	client := &circuitBreakerClient{
+		client:  fakeClient,
+		breaker: newBrokerCircuitBreaker("test-broker", 2, time.Minute, nil),
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetCatalog(); isBrokerUnavailableError(err) {
+			t.Fatalf("request %d: expected the request to be sent, got %v", i, err)
+		}
+	}
+	if _, err := client.GetCatalog(); !isBrokerUnavailableError(err) {
+		t.Fatalf("expected the request to fail fast, got %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClient.Actions(), 2)
+}
+
+// TestReconcileClusterServiceBrokerCircuitOpen tests that a broker whose
+// circuit breaker is open reports that requests to it are suspended.
+func TestReconcileClusterServiceBrokerCircuitOpen(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{
+		CatalogReaction: &fakeosb.CatalogReaction{
+			Error: osb.HTTPStatusCodeError{StatusCode: http.StatusServiceUnavailable},
+		},
+	})
+	testController.brokerClientManager.EnableCircuitBreaker(1, time.Minute, testController.brokerCircuitStateChanged)
+
+	broker := getTestClusterServiceBroker()
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err == nil {
+		t.Fatal("Should have failed to get the catalog.")
+	}
+	if !testController.brokerClientManager.CircuitOpen(NewClusterServiceBrokerKey(broker.Name)) {
+		t.Fatal("Expected the circuit of the broker to be open")
+	}
+	if e, a := 1, testController.clusterServiceBrokerQueue.Len(); e != a {
+		t.Fatalf("Expected the broker to be queued when its circuit opened, got %d queued items", a)
+	}
+	fakeCatalogClient.ClearActions()
+
+	err := reconcileClusterServiceBroker(t, testController, broker)
+	if !isBrokerUnavailableError(err) {
+		t.Fatalf("Expected a broker unavailable error, got %v", err)
+	}
+	// The catalog request of the first reconciliation is the only one sent
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+
+	actions := fakeCatalogClient.Actions()
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[0], broker)
+	assertClusterServiceBrokerReadyFalse(t, updatedClusterServiceBroker)
+	condition := updatedClusterServiceBroker.(*v1beta1.ClusterServiceBroker).Status.Conditions[0]
+	if e, a := errorBrokerUnavailableReason, condition.Reason; e != a {
+		t.Fatalf("Unexpected ready condition reason: expected %q, got %q", e, a)
+	}
+}
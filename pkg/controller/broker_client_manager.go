@@ -17,12 +17,18 @@ limitations under the License.
 package controller
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
 	"reflect"
 	"sync"
+	"time"
 
-	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
 	"k8s.io/klog"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/metrics"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
 )
 
 // BrokerKey defines a key which points to a broker (cluster wide or namespaced)
@@ -60,12 +66,25 @@ func NewClusterServiceBrokerKey(name string) BrokerKey {
 	}
 }
 
-// BrokerClientManager stores OSB client instances per broker
+// CatalogDigest identifies the last catalog of a broker that was fully
+// reconciled, along with the number of classes and plans it produced.
+type CatalogDigest struct {
+	Hash           string
+	ServiceClasses int
+	ServicePlans   int
+}
+
+// BrokerClientManager stores OSB client instances per broker, along with the
+// digest of the last catalog reconciled for the broker
 type BrokerClientManager struct {
 	mu      sync.RWMutex
 	clients map[BrokerKey]clientWithConfig
 
 	brokerClientCreateFunc osb.CreateFunc
+
+	circuitBreakerFailureThreshold int
+	circuitBreakerOpenDuration     time.Duration
+	circuitStateChanged            func(BrokerKey)
 }
 
 // NewBrokerClientManager creates BrokerClientManager instance
@@ -76,20 +95,79 @@ func NewBrokerClientManager(brokerClientCreateFunc osb.CreateFunc) *BrokerClient
 	}
 }
 
+// EnableCircuitBreaker makes the clients created from now on suspend the
+// requests to their broker for the given duration after the given number of
+// consecutive requests failed to reach it. The given func is called with the
+// key of the broker whenever its circuit opens or closes.
+func (m *BrokerClientManager) EnableCircuitBreaker(failureThreshold int, openDuration time.Duration, stateChanged func(BrokerKey)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.circuitBreakerFailureThreshold = failureThreshold
+	m.circuitBreakerOpenDuration = openDuration
+	m.circuitStateChanged = stateChanged
+}
+
 // UpdateBrokerClient creates new broker client if necessary (the ClientConfig has changed or there is no client for the broker),
-// the method returns created or stored osb.Client instance.
-func (m *BrokerClientManager) UpdateBrokerClient(brokerKey BrokerKey, clientConfig *osb.ClientConfiguration) (osb.Client, error) {
+// the method returns created or stored osb.Client instance. The returned client enforces the given rate limit, if any.
+func (m *BrokerClientManager) UpdateBrokerClient(brokerKey BrokerKey, clientConfig *osb.ClientConfiguration, rateLimit *v1beta1.ServiceBrokerRateLimit) (osb.Client, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	existing, found := m.clients[brokerKey]
+	updated := existing
 
 	if !found || configHasChanged(existing.clientConfig, clientConfig) {
 		klog.V(4).Infof("Updating OSB client for broker %q, URL: %s", brokerKey.String(), clientConfig.URL)
-		return m.createClient(brokerKey, clientConfig)
+		client, err := m.createClient(clientConfig)
+		if err != nil {
+			return nil, err
+		}
+		healthProbe, err := newBrokerHealthProbe(clientConfig)
+		if err != nil {
+			return nil, err
+		}
+		// The new client keeps the throttle of the old one, so that the
+		// requests still in flight count towards the rate limit.
+		updated = clientWithConfig{
+			brokerClient: client,
+			healthProbe:  healthProbe,
+			clientConfig: clientConfig,
+			rateLimit:    existing.rateLimit,
+			throttle:     existing.throttle,
+		}
+		// The circuit breaker starts over, as the broker may be reachable
+		// with the new config.
+		if m.circuitBreakerFailureThreshold > 0 {
+			updated.circuitBreaker = newBrokerCircuitBreaker(brokerKey.String(), m.circuitBreakerFailureThreshold, m.circuitBreakerOpenDuration, func() {
+				if m.circuitStateChanged != nil {
+					m.circuitStateChanged(brokerKey)
+				}
+			})
+		}
+	}
+
+	if !found || !reflect.DeepEqual(existing.rateLimit, rateLimit) {
+		klog.V(4).Infof("Updating rate limit of OSB client for broker %q: %+v", brokerKey.String(), rateLimit)
+		updated.rateLimit = rateLimit
+		updated.throttle = nil
+		if rateLimit != nil {
+			updated.throttle = newBrokerThrottle(brokerKey.String(), rateLimit)
+		}
 	}
 
-	return existing.OSBClient, nil
+	if updated == existing {
+		return existing.OSBClient, nil
+	}
+	updated.OSBClient = updated.brokerClient
+	if updated.throttle != nil {
+		updated.OSBClient = &throttledClient{client: updated.OSBClient, throttle: updated.throttle}
+	}
+	if updated.circuitBreaker != nil {
+		updated.OSBClient = &circuitBreakerClient{client: updated.OSBClient, breaker: updated.circuitBreaker}
+	}
+	m.clients[brokerKey] = updated
+	return updated.OSBClient, nil
 }
 
 // RemoveBrokerClient removes broker client broker
@@ -99,6 +177,22 @@ func (m *BrokerClientManager) RemoveBrokerClient(brokerKey BrokerKey) {
 
 	klog.V(4).Infof("Removing OSB client for broker %q", brokerKey.String())
 	delete(m.clients, brokerKey)
+	metrics.BrokerCircuitBreakerState.DeleteLabelValues(brokerKey.String())
+	metrics.BrokerUp.DeleteLabelValues(brokerKey.String())
+}
+
+// CircuitOpen returns whether the requests to a broker specified by the
+// brokerKey are suspended by its circuit breaker, or are about to be resumed
+// if the next request succeeds.
+func (m *BrokerClientManager) CircuitOpen(brokerKey BrokerKey) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	existing, found := m.clients[brokerKey]
+	if !found || existing.circuitBreaker == nil {
+		return false
+	}
+	return existing.circuitBreaker.State() != circuitClosed
 }
 
 // BrokerClient returns broker client for a broker specified by the brokerKey
@@ -110,24 +204,122 @@ func (m *BrokerClientManager) BrokerClient(brokerKey BrokerKey) (osb.Client, boo
 	return existing.OSBClient, found
 }
 
-func (m *BrokerClientManager) createClient(brokerKey BrokerKey, clientConfig *osb.ClientConfiguration) (osb.Client, error) {
-	client, err := m.brokerClientCreateFunc(clientConfig)
-	if err != nil {
-		return nil, err
+// CheckBrokerHealth sends a health check request to a broker specified by the
+// brokerKey, with the given path of the health endpoint of the broker, or to
+// its catalog endpoint if the path is empty. The request is subject to the
+// circuit breaker and rate limit of the broker, like the requests of its
+// client.
+func (m *BrokerClientManager) CheckBrokerHealth(brokerKey BrokerKey, healthCheckPath string) error {
+	m.mu.RLock()
+	existing, found := m.clients[brokerKey]
+	m.mu.RUnlock()
+	if !found {
+		return fmt.Errorf("there is no client for broker %q", brokerKey.String())
+	}
+
+	if existing.circuitBreaker != nil {
+		if err := existing.circuitBreaker.allow(); err != nil {
+			return err
+		}
+	}
+	err := existing.checkHealth(healthCheckPath)
+	if existing.circuitBreaker != nil {
+		existing.circuitBreaker.record(err)
+	}
+	return err
+}
+
+// LastCatalog returns the digest of the last catalog that was fully reconciled
+// for a broker specified by the brokerKey. The digest is forgotten whenever
+// the client of the broker is recreated.
+func (m *BrokerClientManager) LastCatalog(brokerKey BrokerKey) (CatalogDigest, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	existing, found := m.clients[brokerKey]
+	if !found || existing.lastCatalog == nil {
+		return CatalogDigest{}, false
 	}
+	return *existing.lastCatalog, true
+}
+
+// SetLastCatalog records the digest of the last catalog that was fully
+// reconciled for a broker specified by the brokerKey. A nil digest forgets
+// the recorded one.
+func (m *BrokerClientManager) SetLastCatalog(brokerKey BrokerKey, digest *CatalogDigest) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	m.clients[brokerKey] = clientWithConfig{
-		OSBClient:    client,
-		clientConfig: clientConfig,
+	existing, found := m.clients[brokerKey]
+	if !found {
+		return
 	}
-	return client, nil
+	existing.lastCatalog = digest
+	m.clients[brokerKey] = existing
+}
+
+func (m *BrokerClientManager) createClient(clientConfig *osb.ClientConfiguration) (osb.Client, error) {
+	// The OSB client adjusts the TLS config it is given to apply the CA bundle
+	// and InsecureSkipTLSVerify settings, so it gets its own copy and the
+	// stored config stays comparable to the ones built on later syncs.
+	createConfig := *clientConfig
+	createConfig.TLSConfig = clientConfig.TLSConfig.Clone()
+	return m.brokerClientCreateFunc(&createConfig)
 }
 
 func configHasChanged(cfg1 *osb.ClientConfiguration, cfg2 *osb.ClientConfiguration) bool {
-	return !reflect.DeepEqual(cfg1, cfg2)
+	if cfg1 == nil || cfg2 == nil {
+		return cfg1 != cfg2
+	}
+	if tlsConfigHasChanged(cfg1.TLSConfig, cfg2.TLSConfig) {
+		return true
+	}
+	c1, c2 := *cfg1, *cfg2
+	c1.TLSConfig, c2.TLSConfig = nil, nil
+	return !reflect.DeepEqual(&c1, &c2)
+}
+
+// tlsConfigHasChanged compares the client certificates of two TLS configs,
+// the only part of the TLS config set by the controller. A rotated
+// certificate secret results in a different certificate chain.
+func tlsConfigHasChanged(cfg1 *tls.Config, cfg2 *tls.Config) bool {
+	if cfg1 == nil || cfg2 == nil {
+		return cfg1 != cfg2
+	}
+	if len(cfg1.Certificates) != len(cfg2.Certificates) {
+		return true
+	}
+	for i := range cfg1.Certificates {
+		if !reflect.DeepEqual(cfg1.Certificates[i].Certificate, cfg2.Certificates[i].Certificate) {
+			return true
+		}
+	}
+	return false
 }
 
 type clientWithConfig struct {
-	OSBClient    osb.Client
-	clientConfig *osb.ClientConfiguration
+	// OSBClient is the client handed out for the broker, which is the
+	// brokerClient wrapped by a throttledClient if the broker is rate limited,
+	// and by a circuitBreakerClient if the circuit breaker is enabled.
+	OSBClient      osb.Client
+	brokerClient   osb.Client
+	healthProbe    *brokerHealthProbe
+	clientConfig   *osb.ClientConfiguration
+	rateLimit      *v1beta1.ServiceBrokerRateLimit
+	throttle       *brokerThrottle
+	circuitBreaker *brokerCircuitBreaker
+	lastCatalog    *CatalogDigest
+}
+
+// checkHealth sends a health check request with the health probe of the
+// broker, once its throttle allows it.
+func (c clientWithConfig) checkHealth(healthCheckPath string) error {
+	if c.throttle != nil {
+		release, err := c.throttle.acquire(context.Background())
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+	return c.healthProbe.check(healthCheckPath)
 }
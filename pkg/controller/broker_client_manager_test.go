@@ -17,10 +17,12 @@ limitations under the License.
 package controller_test
 
 import (
+	"crypto/tls"
 	"testing"
 
-	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/pkg/controller"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
 )
 
 func TestBrokerClientManager_CreateBrokerClient(t *testing.T) {
@@ -31,8 +33,8 @@ func TestBrokerClientManager_CreateBrokerClient(t *testing.T) {
 	manager := controller.NewBrokerClientManager(brokerClientFunc)
 
 	// WHEN
-	createdClient1, _ := manager.UpdateBrokerClient(controller.NewClusterServiceBrokerKey("broker1"), testOsbConfig("osb-1"))
-	createdClient2, _ := manager.UpdateBrokerClient(controller.NewServiceBrokerKey("prod", "broker1"), testOsbConfig("osb-2"))
+	createdClient1, _ := manager.UpdateBrokerClient(controller.NewClusterServiceBrokerKey("broker1"), testOsbConfig("osb-1"), nil)
+	createdClient2, _ := manager.UpdateBrokerClient(controller.NewServiceBrokerKey("prod", "broker1"), testOsbConfig("osb-2"), nil)
 	gotClient1, exists1 := manager.BrokerClient(controller.NewClusterServiceBrokerKey("broker1"))
 	gotClient2, exists2 := manager.BrokerClient(controller.NewServiceBrokerKey("prod", "broker1"))
 	_, exists3 := manager.BrokerClient(controller.NewServiceBrokerKey("stage", "broker1"))
@@ -71,8 +73,8 @@ func TestBrokerClientManager_RemoveBrokerClient(t *testing.T) {
 	manager := controller.NewBrokerClientManager(brokerClientFunc)
 
 	// WHEN
-	manager.UpdateBrokerClient(controller.NewClusterServiceBrokerKey("broker1"), testOsbConfig("osb-1"))
-	manager.UpdateBrokerClient(controller.NewServiceBrokerKey("prod", "broker1"), testOsbConfig("osb-2"))
+	manager.UpdateBrokerClient(controller.NewClusterServiceBrokerKey("broker1"), testOsbConfig("osb-1"), nil)
+	manager.UpdateBrokerClient(controller.NewServiceBrokerKey("prod", "broker1"), testOsbConfig("osb-2"), nil)
 	manager.RemoveBrokerClient(controller.NewClusterServiceBrokerKey("broker1"))
 	_, exists1 := manager.BrokerClient(controller.NewClusterServiceBrokerKey("broker1"))
 	_, exists2 := manager.BrokerClient(controller.NewServiceBrokerKey("prod", "broker1"))
@@ -108,11 +110,11 @@ func TestBrokerClientManager_UpdateBrokerClient(t *testing.T) {
 			Password: "password-changed",
 		},
 	}
-	manager.UpdateBrokerClient(controller.NewClusterServiceBrokerKey("broker1"), osbCfg)
-	manager.UpdateBrokerClient(controller.NewServiceBrokerKey("prod", "broker1"), testOsbConfig("osb-2"))
+	manager.UpdateBrokerClient(controller.NewClusterServiceBrokerKey("broker1"), osbCfg, nil)
+	manager.UpdateBrokerClient(controller.NewServiceBrokerKey("prod", "broker1"), testOsbConfig("osb-2"), nil)
 
 	// WHEN
-	manager.UpdateBrokerClient(controller.NewClusterServiceBrokerKey("broker1"), osbCfgWithPasswordChange)
+	manager.UpdateBrokerClient(controller.NewClusterServiceBrokerKey("broker1"), osbCfgWithPasswordChange, nil)
 
 	// THEN
 	gotClient, exists := manager.BrokerClient(controller.NewClusterServiceBrokerKey("broker1"))
@@ -124,6 +126,111 @@ func TestBrokerClientManager_UpdateBrokerClient(t *testing.T) {
 	}
 }
 
+func TestBrokerClientManager_UpdateBrokerClientCertificate(t *testing.T) {
+	// GIVEN
+	created := 0
+	brokerClientFunc := func(cfg *osb.ClientConfiguration) (osb.Client, error) {
+		created++
+		return osb.NewClient(cfg)
+	}
+	manager := controller.NewBrokerClientManager(brokerClientFunc)
+	osbCfgWithCert := func(cert string) *osb.ClientConfiguration {
+		cfg := testOsbConfig("osb-1")
+		cfg.Insecure = true
+		cfg.TLSConfig = &tls.Config{
+			Certificates: []tls.Certificate{{Certificate: [][]byte{[]byte(cert)}}},
+		}
+		return cfg
+	}
+	key := controller.NewClusterServiceBrokerKey("broker1")
+	manager.UpdateBrokerClient(key, osbCfgWithCert("cert-1"), nil)
+
+	// WHEN
+	manager.UpdateBrokerClient(key, osbCfgWithCert("cert-1"), nil)
+
+	// THEN
+	if created != 1 {
+		t.Fatalf("Broker client must not be recreated for an unchanged client certificate, got %d clients", created)
+	}
+
+	// WHEN
+	manager.UpdateBrokerClient(key, osbCfgWithCert("cert-2"), nil)
+
+	// THEN
+	if created != 2 {
+		t.Fatalf("Broker client must be recreated for a rotated client certificate, got %d clients", created)
+	}
+}
+
+func TestBrokerClientManager_UpdateBrokerClientRateLimit(t *testing.T) {
+	// GIVEN
+	created := 0
+	osbCl, _ := osb.NewClient(testOsbConfig("osb-1"))
+	brokerClientFunc := func(cfg *osb.ClientConfiguration) (osb.Client, error) {
+		created++
+		return osbCl, nil
+	}
+	manager := controller.NewBrokerClientManager(brokerClientFunc)
+	key := controller.NewClusterServiceBrokerKey("broker1")
+
+	// WHEN
+	limitedClient, _ := manager.UpdateBrokerClient(key, testOsbConfig("osb-1"), &v1beta1.ServiceBrokerRateLimit{QPS: 5})
+	sameClient, _ := manager.UpdateBrokerClient(key, testOsbConfig("osb-1"), &v1beta1.ServiceBrokerRateLimit{QPS: 5})
+	changedClient, _ := manager.UpdateBrokerClient(key, testOsbConfig("osb-1"), &v1beta1.ServiceBrokerRateLimit{QPS: 10})
+	unlimitedClient, _ := manager.UpdateBrokerClient(key, testOsbConfig("osb-1"), nil)
+	gotClient, _ := manager.BrokerClient(key)
+
+	// THEN
+	if limitedClient == osbCl {
+		t.Fatal("Broker client must be wrapped to enforce the rate limit")
+	}
+	if sameClient != limitedClient {
+		t.Fatal("Broker client must not be replaced for an unchanged rate limit")
+	}
+	if changedClient == limitedClient {
+		t.Fatal("Broker client must be replaced for a changed rate limit")
+	}
+	if unlimitedClient != osbCl || gotClient != osbCl {
+		t.Fatal("Broker client must not be wrapped without a rate limit")
+	}
+	if created != 1 {
+		t.Fatalf("Broker client must not be recreated for a changed rate limit, got %d clients", created)
+	}
+}
+
+func TestBrokerClientManager_LastCatalog(t *testing.T) {
+	// GIVEN
+	osbCl1, _ := osb.NewClient(testOsbConfig("osb-1"))
+	osbCl2, _ := osb.NewClient(testOsbConfig("osb-2"))
+	brokerClientFunc := clientFunc(osbCl1, osbCl2)
+	manager := controller.NewBrokerClientManager(brokerClientFunc)
+	brokerKey := controller.NewClusterServiceBrokerKey("broker1")
+	digest := controller.CatalogDigest{Hash: "hash", ServiceClasses: 1, ServicePlans: 2}
+
+	// WHEN
+	manager.SetLastCatalog(brokerKey, &digest)
+	_, existsWithoutClient := manager.LastCatalog(brokerKey)
+	manager.UpdateBrokerClient(brokerKey, testOsbConfig("osb-1"), nil)
+	manager.SetLastCatalog(brokerKey, &digest)
+	gotDigest, exists := manager.LastCatalog(brokerKey)
+	manager.UpdateBrokerClient(brokerKey, testOsbConfig("osb-2"), nil)
+	_, existsAfterUpdate := manager.LastCatalog(brokerKey)
+
+	// THEN
+	if existsWithoutClient {
+		t.Fatal("Catalog digest must not be recorded for a broker without a client")
+	}
+	if !exists {
+		t.Fatal("Catalog digest for 'broker1' does not exist")
+	}
+	if gotDigest != digest {
+		t.Fatalf("Wrong catalog digest for 'broker1': %+v", gotDigest)
+	}
+	if existsAfterUpdate {
+		t.Fatal("Catalog digest must be forgotten when the client is recreated")
+	}
+}
+
 func clientFunc(clients ...osb.Client) osb.CreateFunc {
 	var i = 0
 	return func(_ *osb.ClientConfiguration) (osb.Client, error) {
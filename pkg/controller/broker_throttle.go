@@ -0,0 +1,267 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/metrics"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+)
+
+// maxBrokerThrottleWait is the longest a request waits for the rate limit of
+// its broker. Waiting longer would tie up the workers of the controller, so
+// requests that would have to wait longer are requeued instead.
+const maxBrokerThrottleWait = time.Second
+
+// brokerThrottledError is returned for requests that were not sent because
+// they exceeded the rate limit of the broker.
+type brokerThrottledError struct {
+	broker string
+}
+
+func (e *brokerThrottledError) Error() string {
+	return fmt.Sprintf("request to broker %q exceeded its rate limit and will be retried", e.broker)
+}
+
+// isBrokerThrottledError returns whether the given error was returned for a
+// request that exceeded the rate limit of the broker.
+func isBrokerThrottledError(err error) bool {
+	_, ok := err.(*brokerThrottledError)
+	return ok
+}
+
+// brokerThrottle enforces the rate limit of a broker across all the requests
+// sent to it.
+type brokerThrottle struct {
+	broker string
+	// limiter limits the rate of requests, or is nil if it is not limited.
+	limiter *rate.Limiter
+	// inFlight holds a token for every request awaiting a response, or is
+	// nil if the number of concurrent requests is not limited.
+	inFlight chan struct{}
+	maxWait  time.Duration
+}
+
+// newBrokerThrottle creates a brokerThrottle enforcing the given rate limit
+// for the given broker.
+func newBrokerThrottle(broker string, rateLimit *v1beta1.ServiceBrokerRateLimit) *brokerThrottle {
+	t := &brokerThrottle{
+		broker:  broker,
+		maxWait: maxBrokerThrottleWait,
+	}
+	if rateLimit.QPS > 0 {
+		burst := rateLimit.Burst
+		if burst == 0 {
+			burst = rateLimit.QPS
+		}
+		t.limiter = rate.NewLimiter(rate.Limit(rateLimit.QPS), int(burst))
+	}
+	if rateLimit.MaxInFlight > 0 {
+		t.inFlight = make(chan struct{}, rateLimit.MaxInFlight)
+	}
+	return t
+}
+
+// acquire waits until a request can be sent to the broker, and returns the
+// func to call once the response was received. A brokerThrottledError is
+// returned if the request would have to wait longer than maxWait, and the
+// error of the context if it is done before the request can be sent.
+func (t *brokerThrottle) acquire(ctx context.Context) (func(), error) {
+	start := time.Now()
+	deadline := start.Add(t.maxWait)
+
+	var reservation *rate.Reservation
+	if t.limiter != nil {
+		reservation = t.limiter.ReserveN(start, 1)
+		delay := reservation.DelayFrom(start)
+		if !reservation.OK() || start.Add(delay).After(deadline) {
+			reservation.CancelAt(start)
+			return nil, t.throttled(start)
+		}
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				reservation.CancelAt(start)
+				t.observeWait(start)
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	if t.inFlight == nil {
+		t.observeWait(start)
+		return func() {}, nil
+	}
+	select {
+	case t.inFlight <- struct{}{}:
+	default:
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		select {
+		case t.inFlight <- struct{}{}:
+		case <-timer.C:
+			// The request is not sent, so it must not count against the
+			// rate limit of the requests that are.
+			if reservation != nil {
+				reservation.CancelAt(start)
+			}
+			return nil, t.throttled(start)
+		case <-ctx.Done():
+			if reservation != nil {
+				reservation.CancelAt(start)
+			}
+			t.observeWait(start)
+			return nil, ctx.Err()
+		}
+	}
+	t.observeWait(start)
+	return func() { <-t.inFlight }, nil
+}
+
+func (t *brokerThrottle) throttled(start time.Time) error {
+	t.observeWait(start)
+	metrics.OSBThrottledRequestCount.WithLabelValues(t.broker).Inc()
+	return &brokerThrottledError{broker: t.broker}
+}
+
+func (t *brokerThrottle) observeWait(start time.Time) {
+	metrics.OSBThrottleWaitSeconds.WithLabelValues(t.broker).Observe(time.Since(start).Seconds())
+}
+
+// throttledClient is an osb.Client sending the requests of the wrapped client
+// to the broker only as fast as the throttle of the broker allows.
+type throttledClient struct {
+	client   osb.Client
+	throttle *brokerThrottle
+	// ctx is the context of the requests, or nil if they have none.
+	ctx context.Context
+}
+
+var _ osb.ContextClient = &throttledClient{}
+
+func (c *throttledClient) WithContext(ctx context.Context) osb.Client {
+	return &throttledClient{client: osb.WithContext(ctx, c.client), throttle: c.throttle, ctx: ctx}
+}
+
+// acquire waits until the throttle of the broker allows a request, or the
+// context of the requests is done.
+func (c *throttledClient) acquire() (func(), error) {
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return c.throttle.acquire(ctx)
+}
+
+func (c *throttledClient) GetCatalog() (*osb.CatalogResponse, error) {
+	release, err := c.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.client.GetCatalog()
+}
+
+func (c *throttledClient) ProvisionInstance(r *osb.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	release, err := c.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.client.ProvisionInstance(r)
+}
+
+func (c *throttledClient) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	release, err := c.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.client.UpdateInstance(r)
+}
+
+func (c *throttledClient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	release, err := c.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.client.DeprovisionInstance(r)
+}
+
+func (c *throttledClient) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
+	release, err := c.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.client.PollLastOperation(r)
+}
+
+func (c *throttledClient) PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error) {
+	release, err := c.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.client.PollBindingLastOperation(r)
+}
+
+func (c *throttledClient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
+	release, err := c.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.client.Bind(r)
+}
+
+func (c *throttledClient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
+	release, err := c.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.client.Unbind(r)
+}
+
+func (c *throttledClient) GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error) {
+	release, err := c.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.client.GetBinding(r)
+}
+
+func (c *throttledClient) GetInstance(r *osb.GetInstanceRequest) (*osb.GetInstanceResponse, error) {
+	release, err := c.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.client.GetInstance(r)
+}
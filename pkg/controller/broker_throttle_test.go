@@ -0,0 +1,170 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/fake"
+)
+
+func TestBrokerThrottleRateLimit(t *testing.T) {
+	throttle := newBrokerThrottle("test-broker", &v1beta1.ServiceBrokerRateLimit{QPS: 1, Burst: 2})
+	throttle.maxWait = 0
+
+	for i := 0; i < 2; i++ {
+		release, err := throttle.acquire(context.Background())
+		if err != nil {
+			t.Fatalf("request %d within the burst: unexpected error: %v", i, err)
+		}
+		release()
+	}
+	if _, err := throttle.acquire(context.Background()); !isBrokerThrottledError(err) {
+		t.Fatalf("expected the request exceeding the burst to be throttled, got %v", err)
+	}
+
+	// Requests that can be sent within the maximum wait are delayed instead
+	throttle.maxWait = 2 * time.Second
+	start := time.Now()
+	release, err := throttle.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	release()
+	if waited := time.Since(start); waited < 500*time.Millisecond {
+		t.Fatalf("expected the request to wait for the rate limit, waited %v", waited)
+	}
+}
+
+func TestBrokerThrottleMaxInFlight(t *testing.T) {
+	throttle := newBrokerThrottle("test-broker", &v1beta1.ServiceBrokerRateLimit{MaxInFlight: 1})
+	throttle.maxWait = 10 * time.Millisecond
+
+	release, err := throttle.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := throttle.acquire(context.Background()); !isBrokerThrottledError(err) {
+		t.Fatalf("expected the request exceeding maxInFlight to be throttled, got %v", err)
+	}
+
+	release()
+	release, err = throttle.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("expected the request to be sent once the first one completed, got %v", err)
+	}
+	release()
+}
+
+func TestBrokerThrottleCancelsReservation(t *testing.T) {
+	throttle := newBrokerThrottle("test-broker", &v1beta1.ServiceBrokerRateLimit{QPS: 1, Burst: 1, MaxInFlight: 1})
+	throttle.maxWait = 10 * time.Millisecond
+
+	release, err := throttle.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The request waiting for the slot of the first one is not sent, so its
+	// reservation is returned to the rate limit
+	throttle.maxWait = 2 * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := throttle.acquire(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected the request to end with its context, got %v", err)
+	}
+
+	release()
+	throttle.maxWait = 1500 * time.Millisecond
+	start := time.Now()
+	release, err = throttle.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	release()
+	if waited := time.Since(start); waited > 1100*time.Millisecond {
+		t.Fatalf("expected the cancelled request not to count against the rate limit, waited %v", waited)
+	}
+}
+
+func TestThrottledClient(t *testing.T) {
+	fakeClient := fakeosb.NewFakeClient(fakeosb.FakeClientConfiguration{
+		CatalogReaction: &fakeosb.CatalogReaction{Response: &osb.CatalogResponse{}},
+	})
+	throttle := newBrokerThrottle("test-broker", &v1beta1.ServiceBrokerRateLimit{QPS: 1})
+	throttle.maxWait = 0
+	client := &throttledClient{client: fakeClient, throttle: throttle}
+
+	if _, err := client.GetCatalog(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetCatalog(); !isBrokerThrottledError(err) {
+		t.Fatalf("expected the second request to be throttled, got %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClient.Actions(), 1)
+}
+
+// TestReconcileServiceInstanceThrottled tests that a provision request
+// exceeding the rate limit of the broker is retried without recording a
+// failure.
+func TestReconcileServiceInstanceThrottled(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Response: &osb.ProvisionResponse{},
+		},
+	})
+
+	addGetNamespaceReaction(fakeKubeClient)
+
+	broker := getTestClusterServiceBroker()
+	broker.Spec.RateLimit = &v1beta1.ServiceBrokerRateLimit{MaxInFlight: 1}
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(broker)
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	instance = assertServiceInstanceProvisionInProgressAndUserSpecifiedFieldsClientActions(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+
+	// Keep the only request slot of the broker busy
+	brokerClient, err := testController.clusterServiceBrokerClient(broker)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	throttle := brokerClient.(*throttledClient).throttle
+	throttle.maxWait = 0
+	release, err := throttle.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = reconcileServiceInstance(t, testController, instance)
+	if !isBrokerThrottledError(err) {
+		t.Fatalf("expected a throttled error, got %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+	release()
+}
@@ -29,8 +29,6 @@ import (
 
 	"sync"
 
-	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
-	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	fakesc "github.com/kubernetes-sigs/service-catalog/pkg/client/clientset_generated/clientset/fake"
 	scinterface "github.com/kubernetes-sigs/service-catalog/pkg/client/clientset_generated/clientset/typed/servicecatalog/v1beta1"
@@ -38,6 +36,8 @@ import (
 	"github.com/kubernetes-sigs/service-catalog/pkg/controller"
 	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
 	"github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/clusterserviceclass/mutation"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/fake"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -136,6 +136,7 @@ func newControllerTest(t *testing.T) *controllerTest {
 	testController, err := controller.NewController(
 		k8sClient,
 		coreInformers.V1().Secrets(),
+		coreInformers.V1().ConfigMaps(),
 		scClient.ServicecatalogV1beta1(),
 		serviceCatalogSharedInformers.ClusterServiceBrokers(),
 		serviceCatalogSharedInformers.ServiceBrokers(),
@@ -154,6 +155,14 @@ func newControllerTest(t *testing.T) *controllerTest {
 		"DefaultClusterIDConfigMapName",
 		"DefaultClusterIDConfigMapNamespace",
 		60*time.Second,
+		0,
+		0,
+		0,
+		0,
+		controller.DefaultCatalogHistoryNamespace,
+		0,
+		0,
+		0,
 	)
 	if err != nil {
 		t.Fatal(err)
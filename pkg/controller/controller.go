@@ -19,14 +19,16 @@ package controller
 import (
 	"bytes"
 	"crypto/md5"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
-	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/klog"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -45,12 +47,14 @@ import (
 	"k8s.io/client-go/util/workqueue"
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/cataloghistory"
 	servicecatalogclientset "github.com/kubernetes-sigs/service-catalog/pkg/client/clientset_generated/clientset/typed/servicecatalog/v1beta1"
 	informers "github.com/kubernetes-sigs/service-catalog/pkg/client/informers_generated/externalversions/servicecatalog/v1beta1"
 	listers "github.com/kubernetes-sigs/service-catalog/pkg/client/listers_generated/servicecatalog/v1beta1"
 	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
 	"github.com/kubernetes-sigs/service-catalog/pkg/filter"
 	"github.com/kubernetes-sigs/service-catalog/pkg/pretty"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
 	v12 "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/listers/core/v1"
 )
@@ -72,12 +76,16 @@ const (
 	DefaultClusterIDConfigMapName string = "cluster-info"
 	// DefaultClusterIDConfigMapNamespace is the k8s namespace that the clusterid configmap will be stored in.
 	DefaultClusterIDConfigMapNamespace string = "default"
+	// DefaultCatalogHistoryNamespace is the k8s namespace that the catalog
+	// snapshots of ClusterServiceBrokers will be stored in.
+	DefaultCatalogHistoryNamespace string = cataloghistory.DefaultNamespace
 )
 
 // NewController returns a new Open Service Broker catalog controller.
 func NewController(
 	kubeClient kubernetes.Interface,
 	secretInformer v12.SecretInformer,
+	catalogHistoryInformer v12.ConfigMapInformer,
 	serviceCatalogClient servicecatalogclientset.ServicecatalogV1beta1Interface,
 	clusterServiceBrokerInformer informers.ClusterServiceBrokerInformer,
 	serviceBrokerInformer informers.ServiceBrokerInformer,
@@ -96,14 +104,29 @@ func NewController(
 	clusterIDConfigMapName string,
 	clusterIDConfigMapNamespace string,
 	osbAPITimeOut time.Duration,
+	instanceRetrievalInterval time.Duration,
+	bindingRetrievalInterval time.Duration,
+	bindingRotationGracePeriod time.Duration,
+	catalogHistoryLimit int,
+	catalogHistoryNamespace string,
+	brokerCircuitBreakerFailureThreshold int,
+	brokerCircuitBreakerOpenDuration time.Duration,
+	brokerHealthCheckInterval time.Duration,
 ) (Controller, error) {
 	controller := &controller{
 		kubeClient:                  kubeClient,
 		secretLister:                secretInformer.Lister(),
+		catalogHistoryLister:        catalogHistoryInformer.Lister(),
 		serviceCatalogClient:        serviceCatalogClient,
 		brokerRelistInterval:        brokerRelistInterval,
 		OSBAPIPreferredVersion:      osbAPIPreferredVersion,
 		OSBAPITimeOut:               osbAPITimeOut,
+		instanceRetrievalInterval:   instanceRetrievalInterval,
+		bindingRetrievalInterval:    bindingRetrievalInterval,
+		brokerHealthCheckInterval:   brokerHealthCheckInterval,
+		bindingRotationGracePeriod:  bindingRotationGracePeriod,
+		catalogHistoryLimit:         catalogHistoryLimit,
+		catalogHistoryNamespace:     catalogHistoryNamespace,
 		recorder:                    recorder,
 		reconciliationRetryDuration: reconciliationRetryDuration,
 		clusterServiceBrokerQueue:   workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration), "cluster-service-broker"),
@@ -121,6 +144,11 @@ func NewController(
 		brokerClientCreateFunc:      brokerClientCreateFunc,
 	}
 	controller.brokerClientManager = NewBrokerClientManager(brokerClientCreateFunc)
+	controller.oauth2Tokens = newOAuth2TokenCache(osbAPITimeOut)
+	controller.serviceAccountTokens = newServiceAccountTokenCache(kubeClient)
+	if brokerCircuitBreakerFailureThreshold > 0 {
+		controller.brokerClientManager.EnableCircuitBreaker(brokerCircuitBreakerFailureThreshold, brokerCircuitBreakerOpenDuration, controller.brokerCircuitStateChanged)
+	}
 
 	controller.clusterServiceBrokerLister = clusterServiceBrokerInformer.Lister()
 	clusterServiceBrokerInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -128,6 +156,10 @@ func NewController(
 		UpdateFunc: controller.clusterServiceBrokerUpdate,
 		DeleteFunc: controller.clusterServiceBrokerDelete,
 	})
+	if err := clusterServiceBrokerInformer.Informer().AddIndexers(cache.Indexers{brokerAuthSecretIndex: clusterServiceBrokerAuthSecretIndexFunc}); err != nil {
+		return nil, err
+	}
+	controller.clusterServiceBrokerIndexer = clusterServiceBrokerInformer.Informer().GetIndexer()
 
 	controller.clusterServiceClassLister = clusterServiceClassInformer.Lister()
 	clusterServiceClassInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -164,6 +196,10 @@ func NewController(
 			UpdateFunc: controller.serviceBrokerUpdate,
 			DeleteFunc: controller.serviceBrokerDelete,
 		})
+		if err := serviceBrokerInformer.Informer().AddIndexers(cache.Indexers{brokerAuthSecretIndex: serviceBrokerAuthSecretIndexFunc}); err != nil {
+			return nil, err
+		}
+		controller.serviceBrokerIndexer = serviceBrokerInformer.Informer().GetIndexer()
 		controller.serviceClassLister = serviceClassInformer.Lister()
 		serviceClassInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 			AddFunc:    controller.serviceClassAdd,
@@ -177,6 +213,14 @@ func NewController(
 			DeleteFunc: controller.servicePlanDelete,
 		})
 	}
+	// Secrets referenced by the auth info of brokers are watched so that
+	// their clients use rotated credentials right away.
+	secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    controller.secretAdd,
+		UpdateFunc: controller.secretUpdate,
+		DeleteFunc: controller.secretDelete,
+	})
+
 	controller.instanceOperationRetryQueue.instances = make(map[string]backoffEntry)
 	controller.instanceOperationRetryQueue.rateLimiter = workqueue.NewItemExponentialFailureRateLimiter(minBrokerOperationRetryDelay, maxBrokerOperationRetryDelay)
 
@@ -190,6 +234,9 @@ type Controller interface {
 	// workers specifies the number of goroutines, per resource, processing work
 	// from the resource workqueues
 	Run(workers int, stopCh <-chan struct{})
+	// ResourceStateCollector returns the collector of the metrics of the
+	// state of the instances and bindings reconciled by the controller.
+	ResourceStateCollector() prometheus.Collector
 }
 
 // controller is a concrete Controller.
@@ -197,7 +244,9 @@ type controller struct {
 	kubeClient                  kubernetes.Interface
 	serviceCatalogClient        servicecatalogclientset.ServicecatalogV1beta1Interface
 	clusterServiceBrokerLister  listers.ClusterServiceBrokerLister
+	clusterServiceBrokerIndexer cache.Indexer
 	serviceBrokerLister         listers.ServiceBrokerLister
+	serviceBrokerIndexer        cache.Indexer
 	clusterServiceClassLister   listers.ClusterServiceClassLister
 	serviceClassLister          listers.ServiceClassLister
 	instanceLister              listers.ServiceInstanceLister
@@ -205,9 +254,16 @@ type controller struct {
 	clusterServicePlanLister    listers.ClusterServicePlanLister
 	servicePlanLister           listers.ServicePlanLister
 	secretLister                v1.SecretLister
+	catalogHistoryLister        v1.ConfigMapLister
 	brokerRelistInterval        time.Duration
 	OSBAPIPreferredVersion      string
 	OSBAPITimeOut               time.Duration
+	instanceRetrievalInterval   time.Duration
+	bindingRetrievalInterval    time.Duration
+	brokerHealthCheckInterval   time.Duration
+	bindingRotationGracePeriod  time.Duration
+	catalogHistoryLimit         int
+	catalogHistoryNamespace     string
 	recorder                    record.EventRecorder
 	reconciliationRetryDuration time.Duration
 	clusterServiceBrokerQueue   workqueue.RateLimitingInterface
@@ -238,6 +294,11 @@ type controller struct {
 	instanceOperationRetryQueue instanceOperationBackoff
 	// BrokerClientManager holds all OSB clients for brokers.
 	brokerClientManager *BrokerClientManager
+	// oauth2Tokens caches the access tokens of brokers using OAuth2 auth.
+	oauth2Tokens *oauth2TokenCache
+	// serviceAccountTokens caches the tokens of brokers using service
+	// account token auth.
+	serviceAccountTokens *serviceAccountTokenCache
 
 	brokerClientCreateFunc osb.CreateFunc
 }
@@ -716,6 +777,36 @@ func (c *controller) getAuthCredentialsFromClusterServiceBroker(broker *v1beta1.
 		return &osb.AuthConfig{
 			BearerConfig: bearerConfig,
 		}, nil
+	} else if authInfo.OAuth2 != nil {
+		secretRef := authInfo.OAuth2.SecretRef
+		secret, err := c.secretLister.Secrets(secretRef.Namespace).Get(secretRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		creds, err := getOAuth2Credentials(secret)
+		if err != nil {
+			return nil, err
+		}
+		token, err := c.oauth2Tokens.Token(creds)
+		if err != nil {
+			return nil, err
+		}
+		return &osb.AuthConfig{
+			BearerConfig: &osb.BearerConfig{Token: token},
+		}, nil
+	} else if authInfo.ServiceAccountToken != nil {
+		serviceAccountRef := authInfo.ServiceAccountToken.ServiceAccountRef
+		token, err := c.serviceAccountTokens.Token(newServiceAccountTokenKey(serviceAccountRef.Namespace, serviceAccountRef.Name, authInfo.ServiceAccountToken.Audience, authInfo.ServiceAccountToken.ExpirationSeconds))
+		if err != nil {
+			return nil, err
+		}
+		return &osb.AuthConfig{
+			BearerConfig: &osb.BearerConfig{Token: token},
+		}, nil
+	} else if authInfo.TLS != nil {
+		// The client certificate is presented by the transport, see
+		// getClientCertificateFromClusterServiceBroker
+		return nil, nil
 	}
 	return nil, fmt.Errorf("empty auth info or unsupported auth mode: %v", authInfo)
 }
@@ -754,10 +845,72 @@ func (c *controller) getAuthCredentialsFromServiceBroker(broker *v1beta1.Service
 		return &osb.AuthConfig{
 			BearerConfig: bearerConfig,
 		}, nil
+	} else if authInfo.OAuth2 != nil {
+		secretRef := authInfo.OAuth2.SecretRef
+		secret, err := c.secretLister.Secrets(broker.Namespace).Get(secretRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		creds, err := getOAuth2Credentials(secret)
+		if err != nil {
+			return nil, err
+		}
+		token, err := c.oauth2Tokens.Token(creds)
+		if err != nil {
+			return nil, err
+		}
+		return &osb.AuthConfig{
+			BearerConfig: &osb.BearerConfig{Token: token},
+		}, nil
+	} else if authInfo.ServiceAccountToken != nil {
+		serviceAccountRef := authInfo.ServiceAccountToken.ServiceAccountRef
+		token, err := c.serviceAccountTokens.Token(newServiceAccountTokenKey(broker.Namespace, serviceAccountRef.Name, authInfo.ServiceAccountToken.Audience, authInfo.ServiceAccountToken.ExpirationSeconds))
+		if err != nil {
+			return nil, err
+		}
+		return &osb.AuthConfig{
+			BearerConfig: &osb.BearerConfig{Token: token},
+		}, nil
+	} else if authInfo.TLS != nil {
+		// The client certificate is presented by the transport, see
+		// getClientCertificateFromServiceBroker
+		return nil, nil
 	}
 	return nil, fmt.Errorf("empty auth info or unsupported auth mode: %v", authInfo)
 }
 
+// getClientCertificateFromClusterServiceBroker returns the client certificate
+// to present to the broker, if it authenticates with mutual TLS, or returns an
+// error. If the broker uses another auth mode, nil is returned.
+func (c *controller) getClientCertificateFromClusterServiceBroker(broker *v1beta1.ClusterServiceBroker) (*tls.Certificate, error) {
+	if broker.Spec.AuthInfo == nil || broker.Spec.AuthInfo.TLS == nil {
+		return nil, nil
+	}
+
+	secretRef := broker.Spec.AuthInfo.TLS.SecretRef
+	secret, err := c.secretLister.Secrets(secretRef.Namespace).Get(secretRef.Name)
+	if err != nil {
+		return nil, err
+	}
+	return getClientCertificate(secret)
+}
+
+// getClientCertificateFromServiceBroker returns the client certificate to
+// present to the broker, if it authenticates with mutual TLS, or returns an
+// error. If the broker uses another auth mode, nil is returned.
+func (c *controller) getClientCertificateFromServiceBroker(broker *v1beta1.ServiceBroker) (*tls.Certificate, error) {
+	if broker.Spec.AuthInfo == nil || broker.Spec.AuthInfo.TLS == nil {
+		return nil, nil
+	}
+
+	secretRef := broker.Spec.AuthInfo.TLS.SecretRef
+	secret, err := c.secretLister.Secrets(broker.Namespace).Get(secretRef.Name)
+	if err != nil {
+		return nil, err
+	}
+	return getClientCertificate(secret)
+}
+
 func getBasicAuthConfig(secret *corev1.Secret) (*osb.BasicAuthConfig, error) {
 	usernameBytes, ok := secret.Data["username"]
 	if !ok {
@@ -786,6 +939,48 @@ func getBearerConfig(secret *corev1.Secret) (*osb.BearerConfig, error) {
 	}, nil
 }
 
+func getOAuth2Credentials(secret *corev1.Secret) (oauth2Credentials, error) {
+	clientIDBytes, ok := secret.Data[v1beta1.OAuth2ClientIDKey]
+	if !ok {
+		return oauth2Credentials{}, fmt.Errorf("auth secret didn't contain %s", v1beta1.OAuth2ClientIDKey)
+	}
+
+	clientSecretBytes, ok := secret.Data[v1beta1.OAuth2ClientSecretKey]
+	if !ok {
+		return oauth2Credentials{}, fmt.Errorf("auth secret didn't contain %s", v1beta1.OAuth2ClientSecretKey)
+	}
+
+	tokenURLBytes, ok := secret.Data[v1beta1.OAuth2TokenURLKey]
+	if !ok {
+		return oauth2Credentials{}, fmt.Errorf("auth secret didn't contain %s", v1beta1.OAuth2TokenURLKey)
+	}
+
+	return oauth2Credentials{
+		TokenURL:     strings.TrimSpace(string(tokenURLBytes)),
+		ClientID:     string(clientIDBytes),
+		ClientSecret: string(clientSecretBytes),
+		Scopes:       strings.Join(strings.Fields(string(secret.Data[v1beta1.OAuth2ScopesKey])), " "),
+	}, nil
+}
+
+func getClientCertificate(secret *corev1.Secret) (*tls.Certificate, error) {
+	certBytes, ok := secret.Data[corev1.TLSCertKey]
+	if !ok {
+		return nil, fmt.Errorf("auth secret didn't contain %s", corev1.TLSCertKey)
+	}
+
+	keyBytes, ok := secret.Data[corev1.TLSPrivateKeyKey]
+	if !ok {
+		return nil, fmt.Errorf("auth secret didn't contain %s", corev1.TLSPrivateKeyKey)
+	}
+
+	cert, err := tls.X509KeyPair(certBytes, keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("auth secret contains an invalid client certificate: %v", err)
+	}
+	return &cert, nil
+}
+
 // convertAndFilterCatalogToNamespacedTypes converts a service broker catalog
 // into an array of ServiceClasses and an array of ServicePlans and filters
 // these through the restrictions provided. The ServiceClasses and
@@ -824,6 +1019,8 @@ func convertAndFilterCatalogToNamespacedTypes(namespace string, in *osb.CatalogR
 			serviceClass.Spec.BindingRetrievable = svc.BindingsRetrievable
 		}
 
+		serviceClass.Spec.InstanceRetrievable = svc.InstancesRetrievable
+
 		if svc.Metadata != nil {
 			metadata, err := json.Marshal(svc.Metadata)
 			if err != nil {
@@ -954,6 +1151,8 @@ func convertAndFilterCatalog(in *osb.CatalogResponse, restrictions *v1beta1.Cata
 			serviceClass.Spec.BindingRetrievable = svc.BindingsRetrievable
 		}
 
+		serviceClass.Spec.InstanceRetrievable = svc.InstancesRetrievable
+
 		if svc.Metadata != nil {
 			metadata, err := json.Marshal(svc.Metadata)
 			if err != nil {
@@ -1107,6 +1306,8 @@ func convertCommonServicePlan(plan osb.Plan, commonServicePlanSpec *v1beta1.Comm
 		commonServicePlanSpec.ExternalMetadata = &runtime.RawExtension{Raw: metadata}
 	}
 
+	commonServicePlanSpec.MaintenanceInfo = convertMaintenanceInfo(plan.MaintenanceInfo)
+
 	if schemas := plan.Schemas; schemas != nil {
 		if instanceSchemas := schemas.ServiceInstance; instanceSchemas != nil {
 			if instanceCreateSchema := instanceSchemas.Create; instanceCreateSchema != nil && instanceCreateSchema.Parameters != nil {
@@ -1194,6 +1395,8 @@ func convertClusterServicePlans(plans []osb.Plan, serviceClassID string, existin
 			servicePlans[i].Spec.ExternalMetadata = &runtime.RawExtension{Raw: metadata}
 		}
 
+		servicePlans[i].Spec.MaintenanceInfo = convertMaintenanceInfo(plan.MaintenanceInfo)
+
 		if schemas := plan.Schemas; schemas != nil {
 			if instanceSchemas := schemas.ServiceInstance; instanceSchemas != nil {
 				if instanceCreateSchema := instanceSchemas.Create; instanceCreateSchema != nil && instanceCreateSchema.Parameters != nil {
@@ -1242,6 +1445,21 @@ func convertClusterServicePlans(plans []osb.Plan, serviceClassID string, existin
 	return servicePlans, nil
 }
 
+// convertMaintenanceInfo converts the maintenance info of a plan in the
+// broker's catalog into the service-catalog representation.
+func convertMaintenanceInfo(maintenanceInfo *osb.MaintenanceInfo) *v1beta1.MaintenanceInfo {
+	if maintenanceInfo == nil {
+		return nil
+	}
+	converted := &v1beta1.MaintenanceInfo{
+		Version: maintenanceInfo.Version,
+	}
+	if maintenanceInfo.Description != nil {
+		converted.Description = *maintenanceInfo.Description
+	}
+	return converted
+}
+
 // isServiceInstanceConditionTrue returns whether the given instance has a given condition
 // with status true.
 func isServiceInstanceConditionTrue(instance *v1beta1.ServiceInstance, conditionType v1beta1.ServiceInstanceConditionType) bool {
@@ -1286,6 +1504,26 @@ func NewClientConfigurationForBroker(meta metav1.ObjectMeta, commonSpec *v1beta1
 	return clientConfig
 }
 
+// isBrokerAuthenticationError returns whether the given error returned by a
+// broker indicates that it rejected the credentials of the request.
+func isBrokerAuthenticationError(err error) bool {
+	httpErr, ok := osb.IsHTTPError(err)
+	if !ok {
+		return false
+	}
+	return httpErr.StatusCode == http.StatusUnauthorized || httpErr.StatusCode == http.StatusForbidden
+}
+
+// brokerCircuitStateChanged queues the broker whose circuit breaker opened or
+// closed, so that its status reflects the circuit.
+func (c *controller) brokerCircuitStateChanged(brokerKey BrokerKey) {
+	if brokerKey.IsClusterScoped() {
+		c.clusterServiceBrokerQueue.Add(brokerKey.name)
+		return
+	}
+	c.serviceBrokerQueue.Add(brokerKey.String())
+}
+
 // reconciliationRetryDurationExceeded returns whether the given operation
 // start time has exceeded the controller's set reconciliation retry duration.
 func (c *controller) reconciliationRetryDurationExceeded(operationStartTime *metav1.Time) bool {
@@ -1509,6 +1747,151 @@ func shouldReconcileServiceBrokerCommon(pcb *pretty.ContextBuilder, brokerMeta *
 	return true
 }
 
+// catalogHash returns a hash of the content of the catalog of a broker at the
+// given generation of the broker. The generation is part of the hash because
+// the spec of the broker, e.g. its catalog restrictions, affects how the
+// catalog is reconciled. The catalog is always fetched in full: the hash is
+// compared once it has been received, not sent to the broker.
+func catalogHash(catalog *osb.CatalogResponse, generation int64) (string, error) {
+	hash, err := cataloghistory.Hash(catalog)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d-%s", generation, hash), nil
+}
+
+// isServiceBrokerCatalogUnchanged returns the hash of the given catalog of a
+// broker, and whether the catalog is identical to the last one that was
+// reconciled while the broker is still ready and the classes and plans the
+// catalog produced are still in place. countEntries returns the number of
+// classes and plans of the broker that are still in its catalog; it is only
+// called when the catalog is unchanged.
+func (c *controller) isServiceBrokerCatalogUnchanged(pcb *pretty.ContextBuilder, brokerKey BrokerKey, brokerMeta *metav1.ObjectMeta, brokerStatus *v1beta1.CommonServiceBrokerStatus, catalog *osb.CatalogResponse, countEntries func() (int, int, error)) (string, bool) {
+	hash, err := catalogHash(catalog, brokerMeta.Generation)
+	if err != nil {
+		klog.Warning(pcb.Messagef("Error computing the hash of the catalog: %v", err))
+		return "", false
+	}
+
+	last, ok := c.brokerClientManager.LastCatalog(brokerKey)
+	if !ok || last.Hash != hash {
+		return hash, false
+	}
+
+	ready := false
+	for _, condition := range brokerStatus.Conditions {
+		if condition.Type == v1beta1.ServiceBrokerConditionReady {
+			ready = condition.Status == v1beta1.ConditionTrue
+			break
+		}
+	}
+	if !ready {
+		return hash, false
+	}
+
+	serviceClassCount, servicePlanCount, err := countEntries()
+	if err != nil {
+		return hash, false
+	}
+	return hash, serviceClassCount == last.ServiceClasses && servicePlanCount == last.ServicePlans
+}
+
+// catalogEntryChange describes what reconciling a class or plan from a
+// broker's catalog did to the existing resource.
+type catalogEntryChange int
+
+const (
+	catalogEntryUnchanged catalogEntryChange = iota
+	catalogEntryAdded
+	catalogEntryUpdated
+)
+
+// maxCatalogDiffNamesLength bounds the length of every list of class or plan
+// names in the event summarizing a catalog diff, so that the event stays small
+// when a large catalog changes.
+const maxCatalogDiffNamesLength = 100
+
+// catalogDiffBuilder counts the classes and plans changed while reconciling a
+// broker's catalog, and collects their external names for the event
+// summarizing the changes.
+type catalogDiffBuilder struct {
+	diff v1beta1.CatalogDiff
+
+	addedServiceClasses, removedServiceClasses, updatedServiceClasses []string
+	addedServicePlans, removedServicePlans, updatedServicePlans       []string
+}
+
+// classChanged records the change made to a class of the catalog.
+func (b *catalogDiffBuilder) classChanged(change catalogEntryChange, externalName string) {
+	switch change {
+	case catalogEntryAdded:
+		b.diff.AddedServiceClasses++
+		b.addedServiceClasses = append(b.addedServiceClasses, externalName)
+	case catalogEntryUpdated:
+		b.diff.UpdatedServiceClasses++
+		b.updatedServiceClasses = append(b.updatedServiceClasses, externalName)
+	}
+}
+
+// classRemoved records a class marked as removed from the catalog.
+func (b *catalogDiffBuilder) classRemoved(externalName string) {
+	b.diff.RemovedServiceClasses++
+	b.removedServiceClasses = append(b.removedServiceClasses, externalName)
+}
+
+// planChanged records the change made to a plan of the catalog.
+func (b *catalogDiffBuilder) planChanged(change catalogEntryChange, externalName string) {
+	switch change {
+	case catalogEntryAdded:
+		b.diff.AddedServicePlans++
+		b.addedServicePlans = append(b.addedServicePlans, externalName)
+	case catalogEntryUpdated:
+		b.diff.UpdatedServicePlans++
+		b.updatedServicePlans = append(b.updatedServicePlans, externalName)
+	}
+}
+
+// planRemoved records a plan marked as removed from the catalog.
+func (b *catalogDiffBuilder) planRemoved(externalName string) {
+	b.diff.RemovedServicePlans++
+	b.removedServicePlans = append(b.removedServicePlans, externalName)
+}
+
+// message returns the message of the event that summarizes the changes made
+// while reconciling a broker's catalog.
+func (b *catalogDiffBuilder) message() string {
+	return fmt.Sprintf(
+		"%s Service classes: %s added, %s removed, %s updated. Service plans: %s added, %s removed, %s updated.",
+		successFetchedCatalogMessage,
+		catalogDiffNames(b.addedServiceClasses), catalogDiffNames(b.removedServiceClasses), catalogDiffNames(b.updatedServiceClasses),
+		catalogDiffNames(b.addedServicePlans), catalogDiffNames(b.removedServicePlans), catalogDiffNames(b.updatedServicePlans),
+	)
+}
+
+// catalogDiffNames returns the number of the given classes or plans followed
+// by their sorted names. The names are truncated to maxCatalogDiffNamesLength,
+// and the ones left out are only counted.
+func catalogDiffNames(names []string) string {
+	if len(names) == 0 {
+		return "0"
+	}
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+
+	listed := sorted[0]
+	i := 1
+	for ; i < len(sorted); i++ {
+		if len(listed)+len(", ")+len(sorted[i]) > maxCatalogDiffNamesLength {
+			break
+		}
+		listed += ", " + sorted[i]
+	}
+	if i < len(sorted) {
+		listed += fmt.Sprintf(" and %d more", len(sorted)-i)
+	}
+	return fmt.Sprintf("%d (%s)", len(names), listed)
+}
+
 func toJSON(obj interface{}) string {
 	bytes, _ := json.Marshal(obj)
 	return string(bytes)
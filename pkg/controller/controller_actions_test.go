@@ -20,8 +20,8 @@ import (
 	"fmt"
 	"reflect"
 
-	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	fakeosb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/fake"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/testing"
 )
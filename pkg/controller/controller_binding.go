@@ -18,20 +18,26 @@ package controller
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"reflect"
+	"time"
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
 	"github.com/kubernetes-sigs/service-catalog/pkg/pretty"
+	"github.com/kubernetes-sigs/service-catalog/pkg/tracing"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
 
-	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/uuid"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/jsonpath"
@@ -62,6 +68,23 @@ const (
 	bindingInFlightMessage           string = "Binding request for ServiceBinding in-flight to Broker"
 	unbindingInFlightReason          string = "UnbindingRequestInFlight"
 	unbindingInFlightMessage         string = "Unbind request for ServiceBinding in-flight to Broker"
+	secretResyncedReason             string = "SecretResynced"
+	secretResyncedMessage            string = "The Secret did not match the credentials reported by the broker and was rewritten"
+	secretInSyncReason               string = "InSync"
+	secretInSyncMessage              string = "The Secret matches the credentials reported by the broker"
+	errorRetrievingBindingReason     string = "ErrorRetrievingBinding"
+	rotatingCredentialsReason        string = "RotatingCredentials"
+	rotatingCredentialsMessage       string = "Creating a new binding at the broker to rotate the credentials"
+	credentialsRotatedReason         string = "CredentialsRotated"
+	credentialsRotatedMessage        string = "The Secret holds the rotated credentials; the previous binding will be unbound after the grace period"
+	rotationCompletedReason          string = "RotationCompleted"
+	rotationCompletedMessage         string = "The previous binding has been unbound"
+	errorUnbindPreviousCallReason    string = "UnbindPreviousBindingCallFailed"
+	renewingCredentialsReason        string = "RenewingCredentials"
+	renewingCredentialsMessage       string = "The broker asked for the binding to be renewed; creating a new binding at the broker to rotate the credentials"
+	errorBindingMetadataReason       string = "InvalidBindingMetadata"
+	rotationFailedReason             string = "RotationFailed"
+	rotationFailedMessage            string = "The credentials could not be rotated; the Secret keeps the credentials of the previous binding"
 )
 
 // bindingControllerKind contains the schema.GroupVersionKind for this controller type.
@@ -92,6 +115,17 @@ func (c *controller) bindingAdd(obj interface{}) {
 	c.bindingQueue.Add(key)
 }
 
+// enqueueBindingAfter adds the binding key to the work queue after the
+// specified duration elapses
+func (c *controller) enqueueBindingAfter(obj interface{}, d time.Duration) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		klog.Errorf("Couldn't get key for object %+v: %v", obj, err)
+		return
+	}
+	c.bindingQueue.AddAfter(key, d)
+}
+
 func (c *controller) bindingUpdate(oldObj, newObj interface{}) {
 	// Bindings with ongoing asynchronous operations will be manually added
 	// to the polling queue by the reconciler. They should be ignored here in
@@ -131,6 +165,15 @@ func (c *controller) reconcileServiceBindingKey(key string) error {
 	return c.reconcileServiceBinding(binding)
 }
 
+func isServiceBindingReady(binding *v1beta1.ServiceBinding) bool {
+	for _, condition := range binding.Status.Conditions {
+		if condition.Type == v1beta1.ServiceBindingConditionReady && condition.Status == v1beta1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
 func isServiceBindingFailed(binding *v1beta1.ServiceBinding) bool {
 	for _, condition := range binding.Status.Conditions {
 		if condition.Type == v1beta1.ServiceBindingConditionFailed && condition.Status == v1beta1.ConditionTrue {
@@ -161,21 +204,22 @@ func (c *controller) reconcileServiceBinding(binding *v1beta1.ServiceBinding) er
 	klog.V(6).Info(pcb.Messagef(`beginning to process resourceVersion: %v`, binding.ResourceVersion))
 
 	reconciliationAction := getReconciliationActionForServiceBinding(binding)
+	ctx, span := tracing.Start(context.Background(), "reconcileServiceBinding"+string(reconciliationAction), serviceBindingSpanAttributes(binding)...)
 	switch reconciliationAction {
 	case reconcileAdd:
-		return c.reconcileServiceBindingAdd(binding)
+		return tracing.EndWithError(span, c.reconcileServiceBindingAdd(ctx, binding))
 	case reconcileDelete:
-		return c.reconcileServiceBindingDelete(binding)
+		return tracing.EndWithError(span, c.reconcileServiceBindingDelete(ctx, binding))
 	case reconcilePoll:
-		return c.pollServiceBinding(binding)
+		return tracing.EndWithError(span, c.pollServiceBinding(ctx, binding))
 	default:
-		return fmt.Errorf(pcb.Messagef("Unknown reconciliation action %v", reconciliationAction))
+		return tracing.EndWithError(span, fmt.Errorf(pcb.Messagef("Unknown reconciliation action %v", reconciliationAction)))
 	}
 }
 
 // reconcileServiceBindingAdd is responsible for handling the creation of new
 // service bindings.
-func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding) error {
+func (c *controller) reconcileServiceBindingAdd(ctx context.Context, binding *v1beta1.ServiceBinding) error {
 	pcb := pretty.NewBindingContextBuilder(binding)
 
 	if !c.isServiceBindingStatusInitialized(binding) {
@@ -187,14 +231,28 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 		return nil
 	}
 
+	if isServiceBindingRotationBindFailed(binding) || isServiceBindingRotationRollingBack(binding) {
+		return c.rollBackServiceBindingRotation(binding)
+	}
+
 	if isServiceBindingFailed(binding) {
 		klog.V(4).Info(pcb.Message("not processing event; status showed that it has failed"))
 		return nil
 	}
 
+	if isServiceBindingRotationRequested(binding) || isServiceBindingRenewalDue(binding) || isServiceBindingRotationStarting(binding) {
+		return c.startServiceBindingRotation(binding)
+	}
+
 	if binding.Status.ReconciledGeneration == binding.Generation {
 		klog.V(4).Info(pcb.Message("Not processing event; reconciled generation showed there is no work to do"))
-		return nil
+		if isServiceBindingRotationInGracePeriod(binding) {
+			return c.completeServiceBindingRotation(ctx, binding)
+		}
+		if renewBefore := binding.Status.RenewBefore; renewBefore != nil && isServiceBindingReady(binding) {
+			c.enqueueBindingAfter(binding, time.Until(renewBefore.Time))
+		}
+		return c.resyncServiceBindingIfDue(ctx, binding)
 	}
 
 	klog.V(4).Info(pcb.Message("Processing"))
@@ -299,8 +357,11 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 		return nil
 	}
 
-	response, err := brokerClient.Bind(request)
+	response, err := osb.WithContext(ctx, brokerClient).Bind(request)
 	if err != nil {
+		if isBrokerThrottledError(err) {
+			return err
+		}
 		if httpErr, ok := osb.IsHTTPError(err); ok {
 			msg := fmt.Sprintf("ServiceBroker returned failure; bind operation will not be retried: %v", err.Error())
 			readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorBindCallReason, msg)
@@ -314,8 +375,12 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 			return c.processBindFailure(binding, nil, failedCond, true)
 		}
 
+		reason := errorBindCallReason
+		if isBrokerUnavailableError(err) {
+			reason = errorBrokerUnavailableReason
+		}
 		msg := fmt.Sprintf(`Error creating ServiceBinding for %s: %s`, prettyName, err)
-		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorBindCallReason, msg)
+		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, reason, msg)
 
 		if c.reconciliationRetryDurationExceeded(binding.Status.OperationStartTime) {
 			msg := "Stopping reconciliation retries, too much time has elapsed"
@@ -335,6 +400,7 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 	// request, so this is what the Broker knows about the state of the
 	// binding.
 	binding.Status.ExternalProperties = binding.Status.InProgressProperties
+	c.setServiceBindingMetadata(binding, response.Metadata, true)
 
 	err = c.injectServiceBinding(binding, response.Credentials)
 	if err != nil {
@@ -355,7 +421,7 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 	return c.processBindSuccess(binding)
 }
 
-func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBinding) error {
+func (c *controller) reconcileServiceBindingDelete(ctx context.Context, binding *v1beta1.ServiceBinding) error {
 	var err error
 	pcb := pretty.NewBindingContextBuilder(binding)
 
@@ -385,10 +451,15 @@ func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBindi
 		return c.processServiceBindingGracefulDeletionSuccess(binding)
 	}
 
-	if err := c.ejectServiceBinding(binding); err != nil {
-		msg := fmt.Sprintf(`Error ejecting binding. Error deleting secret: %s`, err)
-		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorEjectingBindReason, msg)
-		return c.processServiceBindingOperationError(binding, readyCond)
+	// The Secret of a binding whose new binding is being orphan mitigated
+	// during a rotation still holds the credentials of the previous binding.
+	mitigatingRotation := binding.DeletionTimestamp == nil && isServiceBindingRotationPreviousBindingPending(binding)
+	if !mitigatingRotation {
+		if err := c.ejectServiceBinding(binding); err != nil {
+			msg := fmt.Sprintf(`Error ejecting binding. Error deleting secret: %s`, err)
+			readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorEjectingBindReason, msg)
+			return c.processServiceBindingOperationError(binding, readyCond)
+		}
 	}
 
 	if binding.DeletionTimestamp == nil {
@@ -466,17 +537,35 @@ func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBindi
 		prettyBrokerName = pretty.FromServiceInstanceOfServiceClassAtBrokerName(instance, serviceClass, brokerName)
 	}
 
+	// Orphan mitigation only unbinds the new binding of a rotation, whose
+	// failure rolls the binding back to the previous one.
+	if !mitigatingRotation && isServiceBindingRotationPreviousBindingPending(binding) {
+		if err := c.unbindPreviousServiceBinding(binding, instance, osb.WithContext(ctx, brokerClient)); err != nil {
+			msg := fmt.Sprintf(`Error unbinding the binding whose credentials were rotated from %s: %s`, prettyBrokerName, err)
+			readyCond := newServiceBindingReadyCondition(v1beta1.ConditionUnknown, errorUnbindPreviousCallReason, msg)
+			return c.processServiceBindingOperationError(binding, readyCond)
+		}
+		setServiceBindingRotationCompleted(binding)
+	}
+
 	request, err := c.prepareUnbindRequest(binding, instance)
 	if err != nil {
 		return c.handleServiceBindingReconciliationError(binding, err)
 	}
 
-	response, err := brokerClient.Unbind(request)
+	response, err := osb.WithContext(ctx, brokerClient).Unbind(request)
 	if err != nil {
+		if isBrokerThrottledError(err) {
+			return err
+		}
+		reason := errorUnbindCallReason
+		if isBrokerUnavailableError(err) {
+			reason = errorBrokerUnavailableReason
+		}
 		msg := fmt.Sprintf(
 			`Error unbinding from %s: %s`, prettyBrokerName, err,
 		)
-		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionUnknown, errorUnbindCallReason, msg)
+		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionUnknown, reason, msg)
 
 		if c.reconciliationRetryDurationExceeded(binding.Status.OperationStartTime) {
 			msg := "Stopping reconciliation retries, too much time has elapsed"
@@ -530,16 +619,9 @@ func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, crede
 		binding.Namespace, binding.Spec.SecretName, len(credentials),
 	))
 
-	if err := c.transformCredentials(binding.Spec.SecretTransforms, credentials); err != nil {
-		return fmt.Errorf(`Unexpected error while transforming credentials for ServiceBinding "%s/%s": %v`, binding.Namespace, binding.Name, err)
-	}
-
-	secretData := make(map[string][]byte)
-	for k, v := range credentials {
-		var err error
-		if secretData[k], err = serialize(v); err != nil {
-			return fmt.Errorf("Unable to serialize value for credential key %q (value is intentionally not logged): %s", k, err)
-		}
+	secretData, err := c.buildServiceBindingSecretData(binding, credentials)
+	if err != nil {
+		return err
 	}
 
 	// Creating/updating the Secret
@@ -591,6 +673,493 @@ func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, crede
 	return err
 }
 
+// isServiceBindingRotationRequested returns whether the user has requested the
+// credentials of a ready binding to be rotated and no rotation is in progress.
+func isServiceBindingRotationRequested(binding *v1beta1.ServiceBinding) bool {
+	if binding.Status.CurrentOperation != "" || !isServiceBindingReady(binding) {
+		return false
+	}
+	rotation := binding.Status.RotationStatus
+	if rotation == nil {
+		return binding.Spec.RotationRequests > 0
+	}
+	return isServiceBindingRotationDone(rotation) &&
+		binding.Spec.RotationRequests > rotation.RotationRequests
+}
+
+// isServiceBindingRenewalDue returns whether the broker has asked for the
+// credentials of a ready binding to be renewed by now and no rotation is in
+// progress. Credentials whose renewal failed are not renewed again until the
+// user requests a rotation, and credentials are not renewed again for a
+// renew_before time that precedes the start of the latest rotation.
+func isServiceBindingRenewalDue(binding *v1beta1.ServiceBinding) bool {
+	if binding.Status.CurrentOperation != "" || !isServiceBindingReady(binding) ||
+		binding.Status.ReconciledGeneration != binding.Generation {
+		return false
+	}
+	renewBefore := binding.Status.RenewBefore
+	if renewBefore == nil || renewBefore.After(time.Now()) {
+		return false
+	}
+	rotation := binding.Status.RotationStatus
+	if rotation == nil {
+		return true
+	}
+	return rotation.Phase == v1beta1.ServiceBindingRotationPhaseCompleted &&
+		(rotation.StartTime == nil || renewBefore.After(rotation.StartTime.Time))
+}
+
+// isServiceBindingRotationDone returns whether the rotation has completed, or
+// has failed and the binding has been rolled back to the previous binding.
+func isServiceBindingRotationDone(rotation *v1beta1.ServiceBindingRotationStatus) bool {
+	switch rotation.Phase {
+	case v1beta1.ServiceBindingRotationPhaseCompleted:
+		return true
+	case v1beta1.ServiceBindingRotationPhaseFailed:
+		return rotation.PreviousExternalID == ""
+	}
+	return false
+}
+
+// isServiceBindingRotationStarting returns whether a rotation of the
+// credentials of the binding has been started, but the binding has not been
+// given a new external ID yet.
+func isServiceBindingRotationStarting(binding *v1beta1.ServiceBinding) bool {
+	rotation := binding.Status.RotationStatus
+	return rotation != nil &&
+		rotation.Phase == v1beta1.ServiceBindingRotationPhaseBinding &&
+		rotation.PreviousExternalID == binding.Spec.ExternalID
+}
+
+// isServiceBindingRotationBinding returns whether the binding has been given
+// a new external ID to rotate its credentials, and the new binding has yet to
+// be created at the broker.
+func isServiceBindingRotationBinding(binding *v1beta1.ServiceBinding) bool {
+	rotation := binding.Status.RotationStatus
+	return rotation != nil &&
+		rotation.Phase == v1beta1.ServiceBindingRotationPhaseBinding &&
+		rotation.PreviousExternalID != binding.Spec.ExternalID
+}
+
+// isServiceBindingRotationBindFailed returns whether the new binding of a
+// rotation of the credentials of the binding could not be created at the
+// broker, and any orphan mitigation for it has finished.
+func isServiceBindingRotationBindFailed(binding *v1beta1.ServiceBinding) bool {
+	return isServiceBindingRotationBinding(binding) &&
+		isServiceBindingFailed(binding) &&
+		!binding.Status.OrphanMitigationInProgress
+}
+
+// isServiceBindingRotationRollingBack returns whether the rotation of the
+// credentials of the binding has failed, and the binding has yet to be rolled
+// back to the previous binding.
+func isServiceBindingRotationRollingBack(binding *v1beta1.ServiceBinding) bool {
+	rotation := binding.Status.RotationStatus
+	return rotation != nil &&
+		rotation.Phase == v1beta1.ServiceBindingRotationPhaseFailed &&
+		rotation.PreviousExternalID != ""
+}
+
+// isServiceBindingRotationInGracePeriod returns whether the Secret of the
+// binding holds rotated credentials and the previous binding has yet to be
+// unbound.
+func isServiceBindingRotationInGracePeriod(binding *v1beta1.ServiceBinding) bool {
+	rotation := binding.Status.RotationStatus
+	return rotation != nil && rotation.Phase == v1beta1.ServiceBindingRotationPhaseGracePeriod
+}
+
+// isServiceBindingRotationPreviousBindingPending returns whether the binding
+// whose credentials are being rotated still exists at the broker alongside
+// the binding identified by the external ID of the ServiceBinding.
+func isServiceBindingRotationPreviousBindingPending(binding *v1beta1.ServiceBinding) bool {
+	rotation := binding.Status.RotationStatus
+	return rotation != nil &&
+		rotation.PreviousExternalID != "" &&
+		rotation.PreviousExternalID != binding.Spec.ExternalID
+}
+
+// setServiceBindingMetadata records the lifetime of the binding reported by
+// the broker. Times that cannot be parsed are ignored, and so is a
+// renew_before time that has already passed when the binding has just been
+// created: renewing its credentials right away would create bindings at the
+// broker over and over. The Status is *not* recorded in the registry.
+func (c *controller) setServiceBindingMetadata(toUpdate *v1beta1.ServiceBinding, metadata *osb.BindingMetadata, created bool) {
+	toUpdate.Status.ExpiresAt = nil
+	toUpdate.Status.RenewBefore = nil
+	if metadata == nil {
+		return
+	}
+	toUpdate.Status.ExpiresAt = c.parseServiceBindingMetadataTime(toUpdate, "expires_at", metadata.ExpiresAt)
+	toUpdate.Status.RenewBefore = c.parseServiceBindingMetadataTime(toUpdate, "renew_before", metadata.RenewBefore)
+	if created && toUpdate.Status.RenewBefore != nil && !toUpdate.Status.RenewBefore.After(time.Now()) {
+		pcb := pretty.NewBindingContextBuilder(toUpdate)
+		msg := fmt.Sprintf("Ignoring the renew_before metadata of the binding returned by the broker: %s has already passed", metadata.RenewBefore)
+		klog.Warning(pcb.Message(msg))
+		c.recorder.Event(toUpdate, corev1.EventTypeWarning, errorBindingMetadataReason, msg)
+		toUpdate.Status.RenewBefore = nil
+	}
+}
+
+// parseServiceBindingMetadataTime parses a time of the binding metadata
+// reported by the broker, which is expected to be in ISO 8601 format.
+func (c *controller) parseServiceBindingMetadataTime(binding *v1beta1.ServiceBinding, field, value string) *metav1.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		pcb := pretty.NewBindingContextBuilder(binding)
+		msg := fmt.Sprintf("Ignoring the %s metadata of the binding returned by the broker: %v", field, err)
+		klog.Warning(pcb.Message(msg))
+		c.recorder.Event(binding, corev1.EventTypeWarning, errorBindingMetadataReason, msg)
+		return nil
+	}
+	mt := metav1.NewTime(t)
+	return &mt
+}
+
+// setServiceBindingRotationCompleted marks the rotation of the credentials of
+// the binding as completed. The Status is *not* recorded in the registry.
+func setServiceBindingRotationCompleted(toUpdate *v1beta1.ServiceBinding) {
+	now := metav1.Now()
+	toUpdate.Status.RotationStatus.Phase = v1beta1.ServiceBindingRotationPhaseCompleted
+	toUpdate.Status.RotationStatus.PreviousExternalID = ""
+	toUpdate.Status.RotationStatus.PreviousUnbindTime = nil
+	toUpdate.Status.RotationStatus.CompletionTime = &now
+}
+
+// startServiceBindingRotation starts rotating the credentials of a binding.
+// The rotation is first recorded in the status of the binding, along with the
+// external ID of the binding being replaced and the one chosen for the new
+// binding. The binding is then given the new external ID, which makes the
+// controller create a new binding at the broker and inject its credentials
+// into the Secret.
+func (c *controller) startServiceBindingRotation(binding *v1beta1.ServiceBinding) error {
+	pcb := pretty.NewBindingContextBuilder(binding)
+
+	if !isServiceBindingRotationStarting(binding) {
+		klog.V(4).Info(pcb.Messagef("Starting rotation of credentials for rotation request %d", binding.Spec.RotationRequests))
+
+		toUpdate := binding.DeepCopy()
+		now := metav1.Now()
+		toUpdate.Status.RotationStatus = &v1beta1.ServiceBindingRotationStatus{
+			RotationRequests:   binding.Spec.RotationRequests,
+			Phase:              v1beta1.ServiceBindingRotationPhaseBinding,
+			PreviousExternalID: binding.Spec.ExternalID,
+			ExternalID:         string(uuid.NewUUID()),
+			StartTime:          &now,
+		}
+		// The rotation request is handled by the rotation rather than by
+		// binding again, and the spec of the binding cannot be updated
+		// while a change to it is being processed.
+		toUpdate.Status.ReconciledGeneration = toUpdate.Generation
+		if _, err := c.updateServiceBindingStatus(toUpdate); err != nil {
+			return err
+		}
+		if isServiceBindingRotationRequested(binding) {
+			c.recorder.Event(binding, corev1.EventTypeNormal, rotatingCredentialsReason, rotatingCredentialsMessage)
+		} else {
+			c.recorder.Event(binding, corev1.EventTypeNormal, renewingCredentialsReason, renewingCredentialsMessage)
+		}
+		// The status update requeues the binding, which gives it a new
+		// external ID in the next iteration
+		return nil
+	}
+
+	toUpdate := binding.DeepCopy()
+	toUpdate.Spec.ExternalID = binding.Status.RotationStatus.ExternalID
+	klog.V(4).Info(pcb.Messagef("Replacing external ID %q with %q", binding.Spec.ExternalID, toUpdate.Spec.ExternalID))
+	if _, err := c.serviceCatalogClient.ServiceBindings(toUpdate.Namespace).Update(toUpdate); err != nil {
+		klog.Errorf(pcb.Messagef("Error updating the external ID: %v", err))
+		return err
+	}
+	return nil
+}
+
+// rollBackServiceBindingRotation rolls back a rotation of the credentials of a
+// binding whose new binding could not be created at the broker. The failure is
+// first recorded in the status of the binding, which is ready again since its
+// Secret still holds the credentials of the previous binding. The binding is
+// then given the external ID of the previous binding back, after which the
+// rotation is done.
+func (c *controller) rollBackServiceBindingRotation(binding *v1beta1.ServiceBinding) error {
+	pcb := pretty.NewBindingContextBuilder(binding)
+	rotation := binding.Status.RotationStatus
+
+	switch {
+	case rotation.Phase == v1beta1.ServiceBindingRotationPhaseBinding:
+		reason := ""
+		for _, condition := range binding.Status.Conditions {
+			if condition.Type == v1beta1.ServiceBindingConditionFailed {
+				reason = condition.Message
+			}
+		}
+		msg := fmt.Sprintf("%s: %s", rotationFailedMessage, reason)
+		klog.Warning(pcb.Message(msg))
+
+		toUpdate := binding.DeepCopy()
+		toUpdate.Status.RotationStatus.Phase = v1beta1.ServiceBindingRotationPhaseFailed
+		toUpdate.Status.RotationStatus.Message = reason
+		setServiceBindingCondition(toUpdate, v1beta1.ServiceBindingConditionFailed, v1beta1.ConditionFalse, rotationFailedReason, msg)
+		setServiceBindingCondition(toUpdate, v1beta1.ServiceBindingConditionReady, v1beta1.ConditionTrue, rotationFailedReason, msg)
+		toUpdate.Status.UnbindStatus = v1beta1.ServiceBindingUnbindStatusRequired
+		if _, err := c.updateServiceBindingStatus(toUpdate); err != nil {
+			return err
+		}
+		c.recorder.Event(binding, corev1.EventTypeWarning, rotationFailedReason, msg)
+		return nil
+
+	case binding.Spec.ExternalID != rotation.PreviousExternalID:
+		toUpdate := binding.DeepCopy()
+		toUpdate.Spec.ExternalID = rotation.PreviousExternalID
+		klog.V(4).Info(pcb.Messagef("Restoring external ID %q in place of %q", toUpdate.Spec.ExternalID, binding.Spec.ExternalID))
+		if _, err := c.serviceCatalogClient.ServiceBindings(toUpdate.Namespace).Update(toUpdate); err != nil {
+			klog.Error(pcb.Messagef("Error updating the external ID: %v", err))
+			return err
+		}
+		return nil
+
+	default:
+		toUpdate := binding.DeepCopy()
+		now := metav1.Now()
+		toUpdate.Status.RotationStatus.PreviousExternalID = ""
+		toUpdate.Status.RotationStatus.CompletionTime = &now
+		// The previous binding still exists at the broker, so restoring
+		// its external ID must not bind again.
+		toUpdate.Status.ReconciledGeneration = toUpdate.Generation
+		_, err := c.updateServiceBindingStatus(toUpdate)
+		return err
+	}
+}
+
+// completeServiceBindingRotation unbinds the binding whose credentials were
+// rotated once the rotation grace period has elapsed, and marks the rotation
+// as completed.
+func (c *controller) completeServiceBindingRotation(ctx context.Context, binding *v1beta1.ServiceBinding) error {
+	pcb := pretty.NewBindingContextBuilder(binding)
+
+	rotation := binding.Status.RotationStatus
+	if rotation.PreviousUnbindTime != nil {
+		if remaining := time.Until(rotation.PreviousUnbindTime.Time); remaining > 0 {
+			c.enqueueBindingAfter(binding, remaining)
+			return nil
+		}
+	}
+
+	instance, err := c.instanceLister.ServiceInstances(binding.Namespace).Get(binding.Spec.InstanceRef.Name)
+	if err != nil {
+		return err
+	}
+
+	var brokerClient osb.Client
+	if instance.Spec.ClusterServiceClassSpecified() {
+		_, _, brokerClient, err = c.getClusterServiceClassAndClusterServiceBrokerForServiceBinding(instance, binding)
+	} else {
+		_, _, brokerClient, err = c.getServiceClassAndServiceBrokerForServiceBinding(instance, binding)
+	}
+	if err != nil {
+		return err
+	}
+
+	toUpdate := binding.DeepCopy()
+	if err := c.unbindPreviousServiceBinding(toUpdate, instance, osb.WithContext(ctx, brokerClient)); err != nil {
+		msg := fmt.Sprintf("Error unbinding the binding whose credentials were rotated: %v", err)
+		klog.Warning(pcb.Message(msg))
+		c.recorder.Event(binding, corev1.EventTypeWarning, errorUnbindPreviousCallReason, msg)
+		// Retry with backoff
+		return errors.New(msg)
+	}
+
+	setServiceBindingRotationCompleted(toUpdate)
+	if _, err := c.updateServiceBindingStatus(toUpdate); err != nil {
+		return err
+	}
+	c.recorder.Event(binding, corev1.EventTypeNormal, rotationCompletedReason, rotationCompletedMessage)
+	return nil
+}
+
+// unbindPreviousServiceBinding unbinds the binding whose credentials were
+// replaced by the rotation of the credentials of the given binding.
+func (c *controller) unbindPreviousServiceBinding(binding *v1beta1.ServiceBinding, instance *v1beta1.ServiceInstance, brokerClient osb.Client) error {
+	if instance.Status.ExternalProperties == nil {
+		return fmt.Errorf("the plan of %s has not been set yet", pretty.ServiceInstanceName(instance))
+	}
+
+	request, err := c.prepareUnbindRequest(binding, instance)
+	if err != nil {
+		return err
+	}
+	request.BindingID = binding.Status.RotationStatus.PreviousExternalID
+	// The unbinding of the previous binding is not tracked as an operation of
+	// the binding, so it cannot be polled.
+	request.AcceptsIncomplete = false
+
+	if _, err := brokerClient.Unbind(request); err != nil {
+		if httpErr, ok := osb.IsHTTPError(err); ok && httpErr.StatusCode == http.StatusGone {
+			// The previous binding is already gone
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// buildServiceBindingSecretData applies the secret transforms of the binding
+// to the given credentials and serializes them into the data of the Secret of
+// the binding.
+func (c *controller) buildServiceBindingSecretData(binding *v1beta1.ServiceBinding, credentials map[string]interface{}) (map[string][]byte, error) {
+	if err := c.transformCredentials(binding.Spec.SecretTransforms, credentials); err != nil {
+		return nil, fmt.Errorf(`Unexpected error while transforming credentials for ServiceBinding "%s/%s": %v`, binding.Namespace, binding.Name, err)
+	}
+
+	secretData := make(map[string][]byte)
+	for k, v := range credentials {
+		var err error
+		if secretData[k], err = serialize(v); err != nil {
+			return nil, fmt.Errorf("Unable to serialize value for credential key %q (value is intentionally not logged): %s", k, err)
+		}
+	}
+	return secretData, nil
+}
+
+// resyncServiceBindingIfDue fetches the credentials of a ready binding from
+// the broker if its class supports retrieving bindings and the retrieval
+// interval has elapsed since the last retrieval. If the Secret of the binding
+// does not match the credentials, either because the broker rotated them or
+// because the Secret was modified, the Secret is rewritten and the Drifted
+// condition is set. The binding is requeued for its next retrieval.
+func (c *controller) resyncServiceBindingIfDue(ctx context.Context, binding *v1beta1.ServiceBinding) error {
+	if c.bindingRetrievalInterval <= 0 || !isServiceBindingReady(binding) {
+		return nil
+	}
+
+	pcb := pretty.NewBindingContextBuilder(binding)
+
+	instance, err := c.instanceLister.ServiceInstances(binding.Namespace).Get(binding.Spec.InstanceRef.Name)
+	if err != nil {
+		klog.V(4).Info(pcb.Messagef("Not retrieving binding: %v", err))
+		return nil
+	}
+
+	var brokerClient osb.Client
+	var bindingRetrievable bool
+	if instance.Spec.ClusterServiceClassSpecified() {
+		serviceClass, _, bClient, err := c.getClusterServiceClassAndClusterServiceBrokerForServiceBinding(instance, binding)
+		if err != nil {
+			klog.V(4).Info(pcb.Messagef("Not retrieving binding: %v", err))
+			return nil
+		}
+		brokerClient = bClient
+		bindingRetrievable = serviceClass.Spec.BindingRetrievable
+	} else if instance.Spec.ServiceClassSpecified() {
+		serviceClass, _, bClient, err := c.getServiceClassAndServiceBrokerForServiceBinding(instance, binding)
+		if err != nil {
+			klog.V(4).Info(pcb.Messagef("Not retrieving binding: %v", err))
+			return nil
+		}
+		brokerClient = bClient
+		bindingRetrievable = serviceClass.Spec.BindingRetrievable
+	}
+
+	if !bindingRetrievable {
+		return nil
+	}
+
+	if binding.Status.LastRetrievalTime != nil {
+		if remaining := c.bindingRetrievalInterval - time.Since(binding.Status.LastRetrievalTime.Time); remaining > 0 {
+			c.enqueueBindingAfter(binding, remaining)
+			return nil
+		}
+	}
+
+	klog.V(4).Info(pcb.Message("Retrieving binding from the broker"))
+
+	toUpdate := binding.DeepCopy()
+	now := metav1.Now()
+	toUpdate.Status.LastRetrievalTime = &now
+
+	response, err := osb.WithContext(ctx, brokerClient).GetBinding(&osb.GetBindingRequest{
+		InstanceID: instance.Spec.ExternalID,
+		BindingID:  binding.Spec.ExternalID,
+	})
+	if err != nil {
+		if isBrokerThrottledError(err) {
+			return err
+		}
+		msg := fmt.Sprintf("Error retrieving the binding from the broker: %v", err)
+		klog.Warning(pcb.Message(msg))
+		c.recorder.Event(binding, corev1.EventTypeWarning, errorRetrievingBindingReason, msg)
+		// Record the retrieval time anyway so that the broker is not asked
+		// again before the retrieval interval has elapsed.
+		_, err := c.updateServiceBindingStatus(toUpdate)
+		return err
+	}
+
+	c.setServiceBindingMetadata(toUpdate, response.Metadata, false)
+
+	inSync, err := c.isServiceBindingSecretInSync(binding, response.Credentials)
+	if err != nil {
+		msg := fmt.Sprintf("Error comparing the Secret against the credentials reported by the broker: %v", err)
+		klog.Warning(pcb.Message(msg))
+		c.recorder.Event(binding, corev1.EventTypeWarning, errorRetrievingBindingReason, msg)
+		_, err := c.updateServiceBindingStatus(toUpdate)
+		return err
+	}
+
+	if inSync {
+		setServiceBindingCondition(toUpdate, v1beta1.ServiceBindingConditionDrifted, v1beta1.ConditionFalse, secretInSyncReason, secretInSyncMessage)
+	} else {
+		klog.Info(pcb.Message(secretResyncedMessage))
+		if err := c.injectServiceBinding(binding, response.Credentials); err != nil {
+			msg := fmt.Sprintf("Error resyncing the Secret: %v", err)
+			klog.Warning(pcb.Message(msg))
+			c.recorder.Event(binding, corev1.EventTypeWarning, errorInjectingBindResultReason, msg)
+			// Retry with backoff without recording the retrieval.
+			return errors.New(msg)
+		}
+		setServiceBindingCondition(toUpdate, v1beta1.ServiceBindingConditionDrifted, v1beta1.ConditionTrue, secretResyncedReason, secretResyncedMessage)
+		c.recorder.Event(binding, corev1.EventTypeWarning, secretResyncedReason, secretResyncedMessage)
+	}
+
+	// The status update requeues the binding, which schedules the next
+	// retrieval.
+	_, err = c.updateServiceBindingStatus(toUpdate)
+	return err
+}
+
+// isServiceBindingSecretInSync returns whether the Secret of the binding
+// holds the data that injecting the given credentials would produce.
+func (c *controller) isServiceBindingSecretInSync(binding *v1beta1.ServiceBinding, credentials map[string]interface{}) (bool, error) {
+	// The secret transforms modify the credentials in place, and the
+	// credentials are injected as they are if the Secret needs rewriting.
+	transformed := make(map[string]interface{}, len(credentials))
+	for k, v := range credentials {
+		transformed[k] = v
+	}
+	secretData, err := c.buildServiceBindingSecretData(binding, transformed)
+	if err != nil {
+		return false, err
+	}
+
+	secret, err := c.kubeClient.CoreV1().Secrets(binding.Namespace).Get(binding.Spec.SecretName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if len(secret.Data) != len(secretData) {
+		return false, nil
+	}
+	for k, v := range secretData {
+		if existing, ok := secret.Data[k]; !ok || !bytes.Equal(existing, v) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 func (c *controller) transformCredentials(transforms []v1beta1.SecretTransform, credentials map[string]interface{}) error {
 	for _, t := range transforms {
 		switch {
@@ -908,7 +1477,7 @@ func (c *controller) finishPollingServiceBinding(binding *v1beta1.ServiceBinding
 	return nil
 }
 
-func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
+func (c *controller) pollServiceBinding(ctx context.Context, binding *v1beta1.ServiceBinding) error {
 	pcb := pretty.NewBindingContextBuilder(binding)
 	klog.V(4).Infof(pcb.Message("Processing"))
 
@@ -939,8 +1508,11 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 
 	klog.V(5).Info(pcb.Message("Polling last operation"))
 
-	response, err := brokerClient.PollBindingLastOperation(request)
+	response, err := osb.WithContext(ctx, brokerClient).PollBindingLastOperation(request)
 	if err != nil {
+		if isBrokerThrottledError(err) {
+			return c.handleServiceBindingPollingError(binding, err)
+		}
 		// If the operation was for delete and we receive a http.StatusGone,
 		// this is considered a success as per the spec.
 		if osb.IsGoneError(err) && deleting {
@@ -957,7 +1529,11 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 		// just need to record an event.
 		s := fmt.Sprintf("Error polling last operation: %v", err)
 		klog.V(4).Info(pcb.Message(s))
-		c.recorder.Event(binding, corev1.EventTypeWarning, errorPollingLastOperationReason, s)
+		reason := errorPollingLastOperationReason
+		if isBrokerUnavailableError(err) {
+			reason = errorBrokerUnavailableReason
+		}
+		c.recorder.Event(binding, corev1.EventTypeWarning, reason, s)
 
 		if c.reconciliationRetryDurationExceeded(binding.Status.OperationStartTime) {
 			return c.processServiceBindingPollingFailureRetryTimeout(binding, nil)
@@ -1016,8 +1592,11 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 		}
 
 		// TODO(mkibbe): Break this logic out so that GET and inject are retried separately on error
-		getBindingResponse, err := brokerClient.GetBinding(getBindingRequest)
+		getBindingResponse, err := osb.WithContext(ctx, brokerClient).GetBinding(getBindingRequest)
 		if err != nil {
+			if isBrokerThrottledError(err) {
+				return c.handleServiceBindingPollingError(binding, err)
+			}
 			reason := errorFetchingBindingFailedReason
 			msg := fmt.Sprintf("Could not do a GET on binding resource: %v", err)
 			readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, reason, msg)
@@ -1030,6 +1609,8 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 			return c.finishPollingServiceBinding(binding)
 		}
 
+		c.setServiceBindingMetadata(binding, getBindingResponse.Metadata, true)
+
 		if err := c.injectServiceBinding(binding, getBindingResponse.Credentials); err != nil {
 			reason := errorInjectingBindResultReason
 			msg := fmt.Sprintf("Error injecting bind results: %v", err)
@@ -1271,6 +1852,11 @@ func (c *controller) prepareBindRequest(
 		Context:      requestContext,
 	}
 
+	if isServiceBindingRotationBinding(binding) {
+		predecessorBindingID := binding.Status.RotationStatus.PreviousExternalID
+		request.PredecessorBindingID = &predecessorBindingID
+	}
+
 	// Asynchronous binding operations are currently ALPHA and not
 	// enabled by default. To use this feature, you must enable the
 	// AsyncBindingOperations feature gate. This may be easily set
@@ -1441,16 +2027,33 @@ func (c *controller) processServiceBindingOperationError(binding *v1beta1.Servic
 // has successfully been created at the broker and has had its credentials
 // injected in the cluster.
 func (c *controller) processBindSuccess(binding *v1beta1.ServiceBinding) error {
+	var bindStartTime *metav1.Time
+	if binding.Status.CurrentOperation == v1beta1.ServiceBindingOperationBind {
+		bindStartTime = binding.Status.OperationStartTime
+	}
 	setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionReady, v1beta1.ConditionTrue, successInjectedBindResultReason, successInjectedBindResultMessage)
 	currentReconciledGeneration := binding.Status.ReconciledGeneration
 	clearServiceBindingCurrentOperation(binding)
 	rollbackBindingReconciledGenerationOnDeletion(binding, currentReconciledGeneration)
 
+	rotated := false
+	if isServiceBindingRotationBinding(binding) {
+		rotation := binding.Status.RotationStatus
+		unbindTime := metav1.NewTime(time.Now().Add(c.bindingRotationGracePeriod))
+		rotation.Phase = v1beta1.ServiceBindingRotationPhaseGracePeriod
+		rotation.PreviousUnbindTime = &unbindTime
+		rotated = true
+	}
+
 	if _, err := c.updateServiceBindingStatus(binding); err != nil {
 		return err
 	}
 
+	c.observeServiceBindingTimeToReady(binding, bindStartTime)
 	c.recorder.Event(binding, corev1.EventTypeNormal, successInjectedBindResultReason, successInjectedBindResultMessage)
+	if rotated {
+		c.recorder.Event(binding, corev1.EventTypeNormal, credentialsRotatedReason, credentialsRotatedMessage)
+	}
 	return nil
 }
 
@@ -1637,8 +2240,13 @@ func (c *controller) handleServiceBindingPollingError(binding *v1beta1.ServiceBi
 }
 
 func getServiceBindingLastConditionState(status v1beta1.ServiceBindingStatus) string {
-	if len(status.Conditions) > 0 {
-		condition := status.Conditions[len(status.Conditions)-1]
+	for i := len(status.Conditions) - 1; i >= 0; i-- {
+		condition := status.Conditions[i]
+		// Drifted does not reflect the outcome of an operation, so it is
+		// left out of the aggregated state.
+		if condition.Type == v1beta1.ServiceBindingConditionDrifted {
+			continue
+		}
 		if condition.Status == v1beta1.ConditionTrue {
 			return string(condition.Type)
 		}
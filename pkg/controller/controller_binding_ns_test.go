@@ -17,16 +17,17 @@ limitations under the License.
 package controller
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
 
-	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
-	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	v1beta1informers "github.com/kubernetes-sigs/service-catalog/pkg/client/informers_generated/externalversions/servicecatalog/v1beta1"
 	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/fake"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -1085,7 +1086,7 @@ func TestPollServiceBindingNamespacedRefs(t *testing.T) {
 
 			bindingKey := tc.binding.Namespace + "/" + tc.binding.Name
 
-			err := testController.pollServiceBinding(tc.binding)
+			err := testController.pollServiceBinding(context.Background(), tc.binding)
 			if tc.shouldError && err == nil {
 				t.Fatalf("expected error when polling service binding but there was none")
 			} else if !tc.shouldError && err != nil {
@@ -17,6 +17,7 @@ limitations under the License.
 package controller
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,8 +27,6 @@ import (
 	"testing"
 	"time"
 
-	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
-	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
 	scmeta "github.com/kubernetes-sigs/service-catalog/pkg/api/meta"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	v1beta1informers "github.com/kubernetes-sigs/service-catalog/pkg/client/informers_generated/externalversions/servicecatalog/v1beta1"
@@ -42,6 +41,8 @@ import (
 
 	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
 	"github.com/kubernetes-sigs/service-catalog/test/fake"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/fake"
 	clientgofake "k8s.io/client-go/kubernetes/fake"
 	clientgotesting "k8s.io/client-go/testing"
 )
@@ -713,6 +714,674 @@ func TestReconcileServiceBindingWithSecretTransform(t *testing.T) {
 	}
 }
 
+// TestReconcileServiceBindingResync tests that the credentials of a ready
+// binding of a class whose bindings are retrievable are fetched from the
+// broker once the retrieval interval has elapsed, and that the Secret of the
+// binding is rewritten if it does not match them.
+func TestReconcileServiceBindingResync(t *testing.T) {
+	cases := []struct {
+		name              string
+		lastRetrievalTime *metav1.Time
+		secretData        map[string][]byte
+		expectedRetrieval bool
+		expectedDrifted   v1beta1.ConditionStatus
+		expectedSecretOp  string
+	}{
+		{
+			name:              "in sync",
+			secretData:        map[string][]byte{"a": []byte("b")},
+			expectedRetrieval: true,
+			expectedDrifted:   v1beta1.ConditionFalse,
+		},
+		{
+			name:              "credentials rotated",
+			secretData:        map[string][]byte{"a": []byte("old")},
+			expectedRetrieval: true,
+			expectedDrifted:   v1beta1.ConditionTrue,
+			expectedSecretOp:  "update",
+		},
+		{
+			name:              "secret tampered with",
+			secretData:        map[string][]byte{"a": []byte("b"), "c": []byte("d")},
+			expectedRetrieval: true,
+			expectedDrifted:   v1beta1.ConditionTrue,
+			expectedSecretOp:  "update",
+		},
+		{
+			name:              "secret deleted",
+			expectedRetrieval: true,
+			expectedDrifted:   v1beta1.ConditionTrue,
+			expectedSecretOp:  "create",
+		},
+		{
+			name:              "retrieved recently",
+			lastRetrievalTime: &metav1.Time{Time: time.Now()},
+			secretData:        map[string][]byte{"a": []byte("b")},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+				GetBindingReaction: &fakeosb.GetBindingReaction{
+					Response: &osb.GetBindingResponse{
+						Credentials: map[string]interface{}{
+							"a": "b",
+						},
+					},
+				},
+			})
+			testController.bindingRetrievalInterval = time.Hour
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestBindingRetrievableClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+			sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+			binding := getTestServiceBinding()
+			binding.Status.ReconciledGeneration = binding.Generation
+			binding.Status.Conditions = []v1beta1.ServiceBindingCondition{
+				*newServiceBindingReadyCondition(v1beta1.ConditionTrue, successInjectedBindResultReason, successInjectedBindResultMessage),
+			}
+			binding.Status.LastRetrievalTime = tc.lastRetrievalTime
+
+			if tc.secretData != nil {
+				addGetSecretReaction(fakeKubeClient, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            testServiceBindingSecretName,
+						Namespace:       testNamespace,
+						OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(binding, bindingControllerKind)},
+					},
+					Data: tc.secretData,
+				})
+			} else {
+				addGetSecretNotFoundReaction(fakeKubeClient)
+			}
+
+			if err := reconcileServiceBinding(t, testController, binding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			brokerActions := fakeClusterServiceBrokerClient.Actions()
+			actions := fakeCatalogClient.Actions()
+			if !tc.expectedRetrieval {
+				assertNumberOfBrokerActions(t, brokerActions, 0)
+				assertNumberOfActions(t, actions, 0)
+				return
+			}
+
+			assertNumberOfBrokerActions(t, brokerActions, 1)
+			assertGetBinding(t, brokerActions[0], &osb.GetBindingRequest{
+				InstanceID: testServiceInstanceGUID,
+				BindingID:  testServiceBindingGUID,
+			})
+
+			kubeActions := fakeKubeClient.Actions()
+			if tc.expectedSecretOp == "" {
+				assertNumberOfActions(t, kubeActions, 1)
+				assertActionEquals(t, kubeActions[0], "get", "secrets")
+			} else {
+				assertNumberOfActions(t, kubeActions, 3)
+				assertActionEquals(t, kubeActions[2], tc.expectedSecretOp, "secrets")
+				secret := kubeActions[2].(clientgotesting.CreateAction).GetObject().(*corev1.Secret)
+				if e, a := map[string][]byte{"a": []byte("b")}, secret.Data; !reflect.DeepEqual(e, a) {
+					t.Fatalf("unexpected secret data: %s", expectedGot(e, a))
+				}
+			}
+
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+			if updatedServiceBinding.Status.LastRetrievalTime == nil {
+				t.Fatal("expected the last retrieval time to be set")
+			}
+			assertServiceBindingCondition(t, updatedServiceBinding, v1beta1.ServiceBindingConditionDrifted, tc.expectedDrifted)
+			if e, a := string(v1beta1.ServiceBindingConditionReady), updatedServiceBinding.Status.LastConditionState; e != a {
+				t.Fatalf("unexpected last condition state: %s", expectedGot(e, a))
+			}
+		})
+	}
+}
+
+// TestReconcileServiceBindingRotationStart tests that incrementing the
+// rotation requests of a ready binding records the start of the rotation, and
+// that the binding is given a new external ID once the rotation has started.
+func TestReconcileServiceBindingRotationStart(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+	binding := getTestServiceBinding()
+	binding.Generation = 2
+	binding.Status.ReconciledGeneration = 1
+	binding.Spec.RotationRequests = 1
+	binding.Status.Conditions = []v1beta1.ServiceBindingCondition{
+		*newServiceBindingReadyCondition(v1beta1.ConditionTrue, successInjectedBindResultReason, successInjectedBindResultMessage),
+	}
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	rotation := updatedServiceBinding.Status.RotationStatus
+	if rotation == nil {
+		t.Fatal("expected the rotation status to be set")
+	}
+	if e, a := v1beta1.ServiceBindingRotationPhaseBinding, rotation.Phase; e != a {
+		t.Fatalf("unexpected rotation phase: %s", expectedGot(e, a))
+	}
+	if e, a := testServiceBindingGUID, rotation.PreviousExternalID; e != a {
+		t.Fatalf("unexpected previous external ID: %s", expectedGot(e, a))
+	}
+	if rotation.ExternalID == "" || rotation.ExternalID == testServiceBindingGUID {
+		t.Fatalf("expected a new external ID to be chosen, got %q", rotation.ExternalID)
+	}
+	if e, a := binding.Generation, updatedServiceBinding.Status.ReconciledGeneration; e != a {
+		t.Fatalf("unexpected reconciled generation: %s", expectedGot(e, a))
+	}
+
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileServiceBinding(t, testController, updatedServiceBinding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+	actions = fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding = assertUpdate(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	if e, a := rotation.ExternalID, updatedServiceBinding.Spec.ExternalID; e != a {
+		t.Fatalf("unexpected external ID: %s", expectedGot(e, a))
+	}
+}
+
+// TestReconcileServiceBindingRotationBind tests that the new binding created
+// by a rotation replaces the credentials in the Secret, and that the previous
+// binding is kept for the grace period.
+func TestReconcileServiceBindingRotationBind(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		BindReaction: &fakeosb.BindReaction{
+			Response: &osb.BindResponse{
+				Credentials: map[string]interface{}{
+					"a": "rotated",
+				},
+				Metadata: &osb.BindingMetadata{
+					ExpiresAt:   "2030-01-02T00:00:00Z",
+					RenewBefore: "2030-01-01T00:00:00Z",
+				},
+			},
+		},
+	})
+	testController.bindingRotationGracePeriod = time.Hour
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+	binding := getTestServiceBinding()
+	binding.Generation = 3
+	binding.Status.ReconciledGeneration = 2
+	binding.Spec.ExternalID = "new-binding-id"
+	binding.Spec.RotationRequests = 1
+	binding.Status.CurrentOperation = v1beta1.ServiceBindingOperationBind
+	startTime := metav1.Now()
+	binding.Status.OperationStartTime = &startTime
+	binding.Status.InProgressProperties = &v1beta1.ServiceBindingPropertiesState{}
+	binding.Status.RotationStatus = &v1beta1.ServiceBindingRotationStatus{
+		RotationRequests:   1,
+		Phase:              v1beta1.ServiceBindingRotationPhaseBinding,
+		PreviousExternalID: testServiceBindingGUID,
+		ExternalID:         "new-binding-id",
+	}
+
+	addGetNamespaceReaction(fakeKubeClient)
+	addGetSecretReaction(fakeKubeClient, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            testServiceBindingSecretName,
+			Namespace:       testNamespace,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(binding, bindingControllerKind)},
+		},
+		Data: map[string][]byte{"a": []byte("b")},
+	})
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	bindRequest := brokerActions[0].Request.(*osb.BindRequest)
+	if e, a := "new-binding-id", bindRequest.BindingID; e != a {
+		t.Fatalf("unexpected binding ID: %s", expectedGot(e, a))
+	}
+	if bindRequest.PredecessorBindingID == nil || *bindRequest.PredecessorBindingID != testServiceBindingGUID {
+		t.Fatalf("unexpected predecessor binding ID: %v", bindRequest.PredecessorBindingID)
+	}
+
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 3)
+	assertActionEquals(t, kubeActions[2], "update", "secrets")
+	secret := kubeActions[2].(clientgotesting.UpdateAction).GetObject().(*corev1.Secret)
+	if e, a := "rotated", string(secret.Data["a"]); e != a {
+		t.Fatalf("unexpected secret data: %s", expectedGot(e, a))
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	assertServiceBindingReadyTrue(t, updatedServiceBinding)
+	rotation := updatedServiceBinding.Status.RotationStatus
+	if e, a := v1beta1.ServiceBindingRotationPhaseGracePeriod, rotation.Phase; e != a {
+		t.Fatalf("unexpected rotation phase: %s", expectedGot(e, a))
+	}
+	if rotation.PreviousUnbindTime == nil || rotation.PreviousUnbindTime.Before(&startTime) {
+		t.Fatalf("unexpected previous unbind time: %v", rotation.PreviousUnbindTime)
+	}
+	expectedExpiresAt := time.Date(2030, time.January, 2, 0, 0, 0, 0, time.UTC)
+	if a := updatedServiceBinding.Status.ExpiresAt; a == nil || !a.Time.Equal(expectedExpiresAt) {
+		t.Fatalf("unexpected expiration time: %s", expectedGot(expectedExpiresAt, a))
+	}
+	expectedRenewBefore := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if a := updatedServiceBinding.Status.RenewBefore; a == nil || !a.Time.Equal(expectedRenewBefore) {
+		t.Fatalf("unexpected renewal time: %s", expectedGot(expectedRenewBefore, a))
+	}
+
+	events := getRecordedEvents(testController)
+	expectedEvents := []string{
+		normalEventBuilder(successInjectedBindResultReason).msg(successInjectedBindResultMessage).String(),
+		normalEventBuilder(credentialsRotatedReason).msg(credentialsRotatedMessage).String(),
+	}
+	if err := checkEvents(events, expectedEvents); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileServiceBindingRotationBindFailure tests that a rotation whose
+// new binding fails is rolled back to the previous binding, which keeps its
+// credentials in the Secret, with and without orphan mitigation of the new
+// binding.
+func TestReconcileServiceBindingRotationBindFailure(t *testing.T) {
+	cases := []struct {
+		name                     string
+		statusCode               int
+		expectedOrphanMitigation bool
+	}{
+		{
+			name:       "bind failure",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:                     "bind failure with orphan mitigation",
+			statusCode:               http.StatusInternalServerError,
+			expectedOrphanMitigation: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+				BindReaction: &fakeosb.BindReaction{
+					Error: osb.HTTPStatusCodeError{
+						StatusCode: tc.statusCode,
+					},
+				},
+				UnbindReaction: &fakeosb.UnbindReaction{
+					Response: &osb.UnbindResponse{},
+				},
+			})
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+			sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+			binding := getTestServiceBinding()
+			binding.Generation = 3
+			binding.Status.ReconciledGeneration = 2
+			binding.Spec.ExternalID = "new-binding-id"
+			binding.Spec.RotationRequests = 1
+			binding.Status.UnbindStatus = v1beta1.ServiceBindingUnbindStatusRequired
+			binding.Status.CurrentOperation = v1beta1.ServiceBindingOperationBind
+			startTime := metav1.Now()
+			binding.Status.OperationStartTime = &startTime
+			binding.Status.InProgressProperties = &v1beta1.ServiceBindingPropertiesState{}
+			binding.Status.RotationStatus = &v1beta1.ServiceBindingRotationStatus{
+				RotationRequests:   1,
+				Phase:              v1beta1.ServiceBindingRotationPhaseBinding,
+				PreviousExternalID: testServiceBindingGUID,
+				ExternalID:         "new-binding-id",
+			}
+
+			addGetNamespaceReaction(fakeKubeClient)
+
+			if err := reconcileServiceBinding(t, testController, binding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+			actions := fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+			assertServiceBindingCondition(t, updatedServiceBinding, v1beta1.ServiceBindingConditionFailed, v1beta1.ConditionTrue)
+			assertServiceBindingOrphanMitigationSet(t, updatedServiceBinding, tc.expectedOrphanMitigation)
+
+			if tc.expectedOrphanMitigation {
+				fakeKubeClient.ClearActions()
+				fakeCatalogClient.ClearActions()
+
+				if err := reconcileServiceBinding(t, testController, updatedServiceBinding); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				// Only the new binding is unbound, and the Secret holding
+				// the credentials of the previous binding is kept.
+				brokerActions := fakeClusterServiceBrokerClient.Actions()
+				assertNumberOfBrokerActions(t, brokerActions, 2)
+				assertUnbind(t, brokerActions[1], &osb.UnbindRequest{
+					BindingID:  "new-binding-id",
+					InstanceID: testServiceInstanceGUID,
+					ServiceID:  testClusterServiceClassGUID,
+					PlanID:     testClusterServicePlanGUID,
+				})
+				for _, action := range fakeKubeClient.Actions() {
+					if action.GetVerb() == "delete" {
+						t.Fatalf("unexpected action: %v", action)
+					}
+				}
+
+				actions = fakeCatalogClient.Actions()
+				assertNumberOfActions(t, actions, 1)
+				updatedServiceBinding = assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+				assertServiceBindingOrphanMitigationSet(t, updatedServiceBinding, false)
+				if e, a := testServiceBindingGUID, updatedServiceBinding.Status.RotationStatus.PreviousExternalID; e != a {
+					t.Fatalf("unexpected previous external ID: %s", expectedGot(e, a))
+				}
+			}
+
+			// The failure of the rotation is recorded, and the binding is
+			// ready with the credentials of the previous binding.
+			fakeCatalogClient.ClearActions()
+			brokerActionCount := len(fakeClusterServiceBrokerClient.Actions())
+			getRecordedEvents(testController)
+
+			if err := reconcileServiceBinding(t, testController, updatedServiceBinding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), brokerActionCount)
+			actions = fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceBinding = assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+			assertServiceBindingReadyTrue(t, updatedServiceBinding)
+			assertServiceBindingCondition(t, updatedServiceBinding, v1beta1.ServiceBindingConditionFailed, v1beta1.ConditionFalse, rotationFailedReason)
+			if e, a := v1beta1.ServiceBindingUnbindStatusRequired, updatedServiceBinding.Status.UnbindStatus; e != a {
+				t.Fatalf("unexpected unbind status: %s", expectedGot(e, a))
+			}
+			rotation := updatedServiceBinding.Status.RotationStatus
+			if e, a := v1beta1.ServiceBindingRotationPhaseFailed, rotation.Phase; e != a {
+				t.Fatalf("unexpected rotation phase: %s", expectedGot(e, a))
+			}
+			if rotation.Message == "" {
+				t.Fatal("expected the failure of the rotation to be recorded")
+			}
+			events := getRecordedEvents(testController)
+			assertNumEvents(t, events, 1)
+			if !strings.HasPrefix(events[0], warningEventBuilder(rotationFailedReason).String()) {
+				t.Fatalf("unexpected event: %v", events[0])
+			}
+
+			// The binding is given the external ID of the previous binding
+			// back.
+			fakeCatalogClient.ClearActions()
+
+			if err := reconcileServiceBinding(t, testController, updatedServiceBinding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			actions = fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceBinding = assertUpdate(t, actions[0], binding).(*v1beta1.ServiceBinding)
+			if e, a := testServiceBindingGUID, updatedServiceBinding.Spec.ExternalID; e != a {
+				t.Fatalf("unexpected external ID: %s", expectedGot(e, a))
+			}
+
+			// The rotation is done without binding again.
+			fakeCatalogClient.ClearActions()
+			updatedServiceBinding.Generation++
+
+			if err := reconcileServiceBinding(t, testController, updatedServiceBinding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), brokerActionCount)
+			actions = fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceBinding = assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+			if e, a := updatedServiceBinding.Generation, updatedServiceBinding.Status.ReconciledGeneration; e != a {
+				t.Fatalf("unexpected reconciled generation: %s", expectedGot(e, a))
+			}
+			rotation = updatedServiceBinding.Status.RotationStatus
+			if rotation.PreviousExternalID != "" || rotation.CompletionTime == nil {
+				t.Fatalf("expected the rotation to be done, got %+v", rotation)
+			}
+			if isServiceBindingRotationRequested(updatedServiceBinding) || isServiceBindingRenewalDue(updatedServiceBinding) {
+				t.Fatal("expected no rotation to be started again")
+			}
+		})
+	}
+}
+
+// TestReconcileServiceBindingRenewal tests that the credentials of a binding
+// are rotated once the renewal time reported by the broker is reached.
+func TestReconcileServiceBindingRenewal(t *testing.T) {
+	cases := []struct {
+		name            string
+		renewBefore     time.Time
+		rotationStart   time.Time
+		expectedRenewal bool
+	}{
+		{
+			name:        "renewal not due",
+			renewBefore: time.Now().Add(time.Hour),
+		},
+		{
+			name:            "renewal due",
+			renewBefore:     time.Now().Add(-time.Minute),
+			expectedRenewal: true,
+		},
+		{
+			name:            "renewal due since the latest rotation",
+			renewBefore:     time.Now().Add(-time.Minute),
+			rotationStart:   time.Now().Add(-time.Hour),
+			expectedRenewal: true,
+		},
+		{
+			name:          "renewal due before the latest rotation",
+			renewBefore:   time.Now().Add(-time.Hour),
+			rotationStart: time.Now().Add(-time.Minute),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+			sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+			binding := getTestServiceBinding()
+			binding.Generation = 1
+			binding.Status.ReconciledGeneration = 1
+			binding.Status.Conditions = []v1beta1.ServiceBindingCondition{
+				*newServiceBindingReadyCondition(v1beta1.ConditionTrue, successInjectedBindResultReason, successInjectedBindResultMessage),
+			}
+			renewBefore := metav1.NewTime(tc.renewBefore)
+			binding.Status.RenewBefore = &renewBefore
+			if !tc.rotationStart.IsZero() {
+				startTime := metav1.NewTime(tc.rotationStart)
+				binding.Status.RotationStatus = &v1beta1.ServiceBindingRotationStatus{
+					Phase:     v1beta1.ServiceBindingRotationPhaseCompleted,
+					StartTime: &startTime,
+				}
+			}
+
+			if err := reconcileServiceBinding(t, testController, binding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+			actions := fakeCatalogClient.Actions()
+			if !tc.expectedRenewal {
+				assertNumberOfActions(t, actions, 0)
+				return
+			}
+
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+			rotation := updatedServiceBinding.Status.RotationStatus
+			if rotation == nil {
+				t.Fatal("expected the rotation status to be set")
+			}
+			if e, a := v1beta1.ServiceBindingRotationPhaseBinding, rotation.Phase; e != a {
+				t.Fatalf("unexpected rotation phase: %s", expectedGot(e, a))
+			}
+			if e, a := testServiceBindingGUID, rotation.PreviousExternalID; e != a {
+				t.Fatalf("unexpected previous external ID: %s", expectedGot(e, a))
+			}
+
+			events := getRecordedEvents(testController)
+			expectedEvent := normalEventBuilder(renewingCredentialsReason).msg(renewingCredentialsMessage)
+			if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestSetServiceBindingMetadataPastRenewBefore tests that a renew_before time
+// that has already passed is ignored when a binding has just been created,
+// but not when an existing binding is retrieved.
+func TestSetServiceBindingMetadataPastRenewBefore(t *testing.T) {
+	_, _, _, testController, _ := newTestController(t, noFakeActions())
+	renewBefore := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	metadata := &osb.BindingMetadata{RenewBefore: renewBefore}
+
+	binding := getTestServiceBinding()
+	testController.setServiceBindingMetadata(binding, metadata, true)
+	if binding.Status.RenewBefore != nil {
+		t.Fatalf("expected the renewal time to be ignored, got %v", binding.Status.RenewBefore)
+	}
+	events := getRecordedEvents(testController)
+	expectedEvent := warningEventBuilder(errorBindingMetadataReason).msgf("Ignoring the renew_before metadata of the binding returned by the broker: %s has already passed", renewBefore)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+
+	binding = getTestServiceBinding()
+	testController.setServiceBindingMetadata(binding, metadata, false)
+	if binding.Status.RenewBefore == nil {
+		t.Fatal("expected the renewal time of a retrieved binding to be kept")
+	}
+}
+
+// TestReconcileServiceBindingRotationComplete tests that the previous binding
+// is unbound once the rotation grace period has elapsed.
+func TestReconcileServiceBindingRotationComplete(t *testing.T) {
+	cases := []struct {
+		name             string
+		unbindTime       time.Time
+		expectedUnbind   bool
+		expectedComplete bool
+	}{
+		{
+			name:       "grace period not elapsed",
+			unbindTime: time.Now().Add(time.Hour),
+		},
+		{
+			name:             "grace period elapsed",
+			unbindTime:       time.Now().Add(-time.Minute),
+			expectedUnbind:   true,
+			expectedComplete: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+				UnbindReaction: &fakeosb.UnbindReaction{
+					Response: &osb.UnbindResponse{},
+				},
+			})
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+			sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+			binding := getTestServiceBinding()
+			binding.Generation = 3
+			binding.Status.ReconciledGeneration = 3
+			binding.Spec.ExternalID = "new-binding-id"
+			binding.Spec.RotationRequests = 1
+			binding.Status.Conditions = []v1beta1.ServiceBindingCondition{
+				*newServiceBindingReadyCondition(v1beta1.ConditionTrue, successInjectedBindResultReason, successInjectedBindResultMessage),
+			}
+			unbindTime := metav1.NewTime(tc.unbindTime)
+			binding.Status.RotationStatus = &v1beta1.ServiceBindingRotationStatus{
+				RotationRequests:   1,
+				Phase:              v1beta1.ServiceBindingRotationPhaseGracePeriod,
+				PreviousExternalID: testServiceBindingGUID,
+				PreviousUnbindTime: &unbindTime,
+			}
+
+			if err := reconcileServiceBinding(t, testController, binding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			brokerActions := fakeClusterServiceBrokerClient.Actions()
+			actions := fakeCatalogClient.Actions()
+			if !tc.expectedUnbind {
+				assertNumberOfBrokerActions(t, brokerActions, 0)
+				assertNumberOfActions(t, actions, 0)
+				return
+			}
+
+			assertNumberOfBrokerActions(t, brokerActions, 1)
+			assertUnbind(t, brokerActions[0], &osb.UnbindRequest{
+				BindingID:  testServiceBindingGUID,
+				InstanceID: testServiceInstanceGUID,
+				ServiceID:  testClusterServiceClassGUID,
+				PlanID:     testClusterServicePlanGUID,
+			})
+
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+			rotation := updatedServiceBinding.Status.RotationStatus
+			if e, a := v1beta1.ServiceBindingRotationPhaseCompleted, rotation.Phase; e != a {
+				t.Fatalf("unexpected rotation phase: %s", expectedGot(e, a))
+			}
+			if rotation.PreviousExternalID != "" {
+				t.Fatalf("expected the previous external ID to be cleared, got %q", rotation.PreviousExternalID)
+			}
+			if rotation.CompletionTime == nil {
+				t.Fatal("expected the completion time to be set")
+			}
+		})
+	}
+}
+
 // TestReconcileBindingNonbindableClusterServiceClass tests reconcileBinding to ensure a
 // binding for an instance that references a non-bindable service class and a
 // non-bindable plan fails as expected.
@@ -3965,7 +4634,7 @@ func TestPollServiceBinding(t *testing.T) {
 
 			bindingKey := tc.binding.Namespace + "/" + tc.binding.Name
 
-			err := testController.pollServiceBinding(tc.binding)
+			err := testController.pollServiceBinding(context.Background(), tc.binding)
 			if tc.shouldError && err == nil {
 				t.Fatalf("expected error when polling service binding but there was none")
 			} else if !tc.shouldError && err != nil {
@@ -0,0 +1,270 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/metrics"
+	"github.com/kubernetes-sigs/service-catalog/pkg/pretty"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+)
+
+const (
+	successHealthCheckReason     string = "HealthCheckSucceeded"
+	successHealthCheckMessage    string = "The broker responded to the health check"
+	errorHealthCheckFailedReason string = "HealthCheckFailed"
+)
+
+// brokerHealthCheckDelay returns how long until the health of a broker with
+// the given status is due to be checked, or zero if it is due now.
+func (c *controller) brokerHealthCheckDelay(status *v1beta1.CommonServiceBrokerStatus) time.Duration {
+	if status.LastHealthCheckTime == nil {
+		return 0
+	}
+	if remaining := c.brokerHealthCheckInterval - time.Since(status.LastHealthCheckTime.Time); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// brokerHealthProbe checks the health of a broker with plain HTTP requests,
+// which are sent with the TLS settings, authentication and timeout of the OSB
+// client of the broker. The health endpoint of a broker is not part of the
+// Open Service Broker API, so it is not requested with the OSB client.
+type brokerHealthProbe struct {
+	config     *osb.ClientConfiguration
+	httpClient *http.Client
+}
+
+// newBrokerHealthProbe creates a brokerHealthProbe for the broker the given
+// client configuration is for. The transport is set up like the one of the
+// clients created by osb.NewClient.
+func newBrokerHealthProbe(config *osb.ClientConfiguration) (*brokerHealthProbe, error) {
+	tlsConfig := &tls.Config{}
+	if config.TLSConfig != nil {
+		tlsConfig = config.TLSConfig.Clone()
+	}
+	if config.Insecure {
+		tlsConfig.InsecureSkipVerify = true
+	}
+	if len(config.CAData) != 0 {
+		if tlsConfig.RootCAs == nil {
+			tlsConfig.RootCAs = x509.NewCertPool()
+		}
+		tlsConfig.RootCAs.AppendCertsFromPEM(config.CAData)
+	}
+	if tlsConfig.InsecureSkipVerify && tlsConfig.RootCAs != nil {
+		return nil, errors.New("Cannot specify root CAs and to skip TLS verification")
+	}
+
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			DualStack: true,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       tlsConfig,
+	}
+	return &brokerHealthProbe{
+		config: config,
+		httpClient: &http.Client{
+			Timeout:   time.Duration(config.TimeoutSeconds) * time.Second,
+			Transport: transport,
+		},
+	}, nil
+}
+
+// check sends a GET request to the given health endpoint of the broker,
+// relative to the URL of the broker, or a HEAD request to the catalog
+// endpoint if no health endpoint is given. An osb.HTTPStatusCodeError is
+// returned if the broker does not respond with a 2xx status.
+func (p *brokerHealthProbe) check(healthCheckPath string) error {
+	brokerURL := strings.TrimRight(p.config.URL, "/")
+	method, url := http.MethodHead, brokerURL+"/v2/catalog"
+	if healthCheckPath != "" {
+		method, url = http.MethodGet, brokerURL+"/"+strings.TrimLeft(healthCheckPath, "/")
+	}
+	request, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set(osb.APIVersionHeader, p.config.APIVersion.HeaderValue())
+	if auth := p.config.AuthConfig; auth != nil {
+		if auth.BasicAuthConfig != nil {
+			request.SetBasicAuth(auth.BasicAuthConfig.Username, auth.BasicAuthConfig.Password)
+		} else if auth.BearerConfig != nil {
+			request.Header.Set("Authorization", "Bearer "+auth.BearerConfig.Token)
+		}
+	}
+
+	response, err := p.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		io.Copy(ioutil.Discard, response.Body)
+		response.Body.Close()
+	}()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return osb.HTTPStatusCodeError{StatusCode: response.StatusCode}
+	}
+	return nil
+}
+
+// checkBrokerHealth checks the health of a broker with its health probe, and
+// records the outcome in the Healthy condition and LastHealthCheckTime of
+// the given status and in the broker_up metric. The error of the health
+// check is returned if the broker is down.
+func (c *controller) checkBrokerHealth(brokerKey BrokerKey, healthCheckPath string, status *v1beta1.CommonServiceBrokerStatus) error {
+	err := c.brokerClientManager.CheckBrokerHealth(brokerKey, healthCheckPath)
+	if isBrokerThrottledError(err) {
+		return err
+	}
+
+	now := metav1.Now()
+	status.LastHealthCheckTime = &now
+	if err != nil {
+		reason := errorHealthCheckFailedReason
+		if isBrokerUnavailableError(err) {
+			reason = errorBrokerUnavailableReason
+		}
+		setServiceBrokerCondition(status, v1beta1.ServiceBrokerConditionHealthy, v1beta1.ConditionFalse, reason, fmt.Sprintf("The health check failed: %v", err), now)
+		metrics.BrokerUp.WithLabelValues(brokerKey.String()).Set(0)
+		return err
+	}
+
+	setServiceBrokerCondition(status, v1beta1.ServiceBrokerConditionHealthy, v1beta1.ConditionTrue, successHealthCheckReason, successHealthCheckMessage, now)
+	metrics.BrokerUp.WithLabelValues(brokerKey.String()).Set(1)
+	return nil
+}
+
+// setServiceBrokerCondition sets the condition of the given type in the given
+// status, adding it if the status does not have one yet.
+func setServiceBrokerCondition(status *v1beta1.CommonServiceBrokerStatus,
+	conditionType v1beta1.ServiceBrokerConditionType,
+	conditionStatus v1beta1.ConditionStatus,
+	reason, message string,
+	t metav1.Time) {
+
+	newCondition := v1beta1.ServiceBrokerCondition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: t,
+	}
+	for i, cond := range status.Conditions {
+		if cond.Type == conditionType {
+			if cond.Status == conditionStatus {
+				newCondition.LastTransitionTime = cond.LastTransitionTime
+			}
+			status.Conditions[i] = newCondition
+			status.LastConditionState = getServiceBrokerLastConditionState(*status)
+			return
+		}
+	}
+	status.Conditions = append(status.Conditions, newCondition)
+	status.LastConditionState = getServiceBrokerLastConditionState(*status)
+}
+
+// checkClusterServiceBrokerHealthIfDue checks the health of a broker if the
+// health check interval has elapsed since its last health check, whether or
+// not the broker is ready, and requeues the broker for its next health check.
+// It returns whether the health of the broker was checked.
+func (c *controller) checkClusterServiceBrokerHealthIfDue(broker *v1beta1.ClusterServiceBroker) (bool, error) {
+	if c.brokerHealthCheckInterval <= 0 {
+		return false, nil
+	}
+	if delay := c.brokerHealthCheckDelay(&broker.Status.CommonServiceBrokerStatus); delay > 0 {
+		c.clusterServiceBrokerQueue.AddAfter(broker.Name, delay)
+		return false, nil
+	}
+
+	pcb := pretty.NewClusterServiceBrokerContextBuilder(broker)
+	klog.V(4).Info(pcb.Message("Checking the health of the broker"))
+
+	toUpdate := broker.DeepCopy()
+	if err := c.checkBrokerHealth(NewClusterServiceBrokerKey(broker.Name), broker.Spec.HealthCheckPath, &toUpdate.Status.CommonServiceBrokerStatus); err != nil {
+		if isBrokerThrottledError(err) {
+			return false, err
+		}
+		s := fmt.Sprintf("Error checking the health of the broker: %v", err)
+		klog.Warning(pcb.Message(s))
+		c.recorder.Event(broker, corev1.EventTypeWarning, errorHealthCheckFailedReason, s)
+	}
+
+	// The status update requeues the broker, which schedules the next
+	// health check.
+	if _, err := c.serviceCatalogClient.ClusterServiceBrokers().UpdateStatus(toUpdate); err != nil {
+		klog.Error(pcb.Messagef("Error updating the healthy condition: %v", err))
+		return true, err
+	}
+	return true, nil
+}
+
+// checkServiceBrokerHealthIfDue checks the health of a broker if the health
+// check interval has elapsed since its last health check, whether or not the
+// broker is ready, and requeues the broker for its next health check. It
+// returns whether the health of the broker was checked.
+func (c *controller) checkServiceBrokerHealthIfDue(broker *v1beta1.ServiceBroker) (bool, error) {
+	if c.brokerHealthCheckInterval <= 0 {
+		return false, nil
+	}
+	if delay := c.brokerHealthCheckDelay(&broker.Status.CommonServiceBrokerStatus); delay > 0 {
+		c.serviceBrokerQueue.AddAfter(broker.Namespace+"/"+broker.Name, delay)
+		return false, nil
+	}
+
+	pcb := pretty.NewServiceBrokerContextBuilder(broker)
+	klog.V(4).Info(pcb.Message("Checking the health of the broker"))
+
+	toUpdate := broker.DeepCopy()
+	if err := c.checkBrokerHealth(NewServiceBrokerKey(broker.Namespace, broker.Name), broker.Spec.HealthCheckPath, &toUpdate.Status.CommonServiceBrokerStatus); err != nil {
+		if isBrokerThrottledError(err) {
+			return false, err
+		}
+		s := fmt.Sprintf("Error checking the health of the broker: %v", err)
+		klog.Warning(pcb.Message(s))
+		c.recorder.Event(broker, corev1.EventTypeWarning, errorHealthCheckFailedReason, s)
+	}
+
+	if _, err := c.serviceCatalogClient.ServiceBrokers(broker.Namespace).UpdateStatus(toUpdate); err != nil {
+		klog.Error(pcb.Messagef("Error updating the healthy condition: %v", err))
+		return true, err
+	}
+	return true, nil
+}
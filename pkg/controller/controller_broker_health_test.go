@@ -0,0 +1,282 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/metrics"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/fake"
+)
+
+func getServiceBrokerCondition(status v1beta1.CommonServiceBrokerStatus, conditionType v1beta1.ServiceBrokerConditionType) *v1beta1.ServiceBrokerCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == conditionType {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// healthCheckRequest is a request received by a fakeHealthEndpoint.
+type healthCheckRequest struct {
+	method     string
+	path       string
+	apiVersion string
+}
+
+// fakeHealthEndpoint is a broker responding to all requests with a fixed
+// status code, and recording them.
+type fakeHealthEndpoint struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []healthCheckRequest
+}
+
+func newFakeHealthEndpoint(statusCode int) *fakeHealthEndpoint {
+	e := &fakeHealthEndpoint{}
+	e.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		e.requests = append(e.requests, healthCheckRequest{method: r.Method, path: r.URL.Path, apiVersion: r.Header.Get("X-Broker-API-Version")})
+		w.WriteHeader(statusCode)
+	}))
+	return e
+}
+
+func (e *fakeHealthEndpoint) Requests() []healthCheckRequest {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]healthCheckRequest(nil), e.requests...)
+}
+
+// TestReconcileClusterServiceBrokerHealthCheck tests that the health of a
+// ready broker is checked without fetching its catalog.
+func TestReconcileClusterServiceBrokerHealthCheck(t *testing.T) {
+	endpoint := newFakeHealthEndpoint(http.StatusOK)
+	defer endpoint.Close()
+
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{})
+	testController.brokerHealthCheckInterval = time.Minute
+
+	broker := getTestClusterServiceBrokerWithStatus(v1beta1.ConditionTrue)
+	broker.Spec.URL = endpoint.URL
+	broker.Spec.HealthCheckPath = "/healthz"
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The health check is not an OSB request
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+	requests := endpoint.Requests()
+	if e, a := 1, len(requests); e != a {
+		t.Fatalf("unexpected number of health check requests: expected %v, got %v", e, a)
+	}
+	if e, a := (healthCheckRequest{method: http.MethodGet, path: "/healthz", apiVersion: "2.13"}), requests[0]; e != a {
+		t.Fatalf("unexpected health check request: expected %+v, got %+v", e, a)
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedBroker := assertUpdateStatus(t, actions[0], broker).(*v1beta1.ClusterServiceBroker)
+	assertClusterServiceBrokerReadyTrue(t, updatedBroker)
+	condition := getServiceBrokerCondition(updatedBroker.Status.CommonServiceBrokerStatus, v1beta1.ServiceBrokerConditionHealthy)
+	if condition == nil || condition.Status != v1beta1.ConditionTrue || condition.Reason != successHealthCheckReason {
+		t.Fatalf("expected a true Healthy condition, got %+v", condition)
+	}
+	if updatedBroker.Status.LastHealthCheckTime == nil {
+		t.Fatal("expected the health check time to be recorded")
+	}
+	if e, a := string(v1beta1.ServiceBrokerConditionReady), updatedBroker.Status.LastConditionState; e != a {
+		t.Fatalf("unexpected last condition state: expected %q, got %q", e, a)
+	}
+
+	// The broker is not checked again until the interval has elapsed
+	fakeCatalogClient.ClearActions()
+	if err := reconcileClusterServiceBroker(t, testController, updatedBroker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := 1, len(endpoint.Requests()); e != a {
+		t.Fatalf("unexpected number of health check requests: expected %v, got %v", e, a)
+	}
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+}
+
+// TestReconcileClusterServiceBrokerHealthCheckFailed tests that a ready
+// broker failing its health check is reported as unhealthy.
+func TestReconcileClusterServiceBrokerHealthCheckFailed(t *testing.T) {
+	endpoint := newFakeHealthEndpoint(http.StatusServiceUnavailable)
+	defer endpoint.Close()
+
+	_, fakeCatalogClient, _, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{})
+	testController.brokerHealthCheckInterval = time.Minute
+
+	broker := getTestClusterServiceBrokerWithStatus(v1beta1.ConditionTrue)
+	broker.Spec.URL = endpoint.URL
+	lastCheck := metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	broker.Status.LastHealthCheckTime = &lastCheck
+	broker.Status.Conditions = append(broker.Status.Conditions, v1beta1.ServiceBrokerCondition{
+		Type:               v1beta1.ServiceBrokerConditionHealthy,
+		Status:             v1beta1.ConditionTrue,
+		Reason:             successHealthCheckReason,
+		LastTransitionTime: lastCheck,
+	})
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedBroker := assertUpdateStatus(t, actions[0], broker).(*v1beta1.ClusterServiceBroker)
+	condition := getServiceBrokerCondition(updatedBroker.Status.CommonServiceBrokerStatus, v1beta1.ServiceBrokerConditionHealthy)
+	if condition == nil || condition.Status != v1beta1.ConditionFalse || condition.Reason != errorHealthCheckFailedReason {
+		t.Fatalf("expected a false Healthy condition, got %+v", condition)
+	}
+	if !condition.LastTransitionTime.After(lastCheck.Time) {
+		t.Fatal("expected the transition time of the Healthy condition to be updated")
+	}
+	if e, a := 2, len(updatedBroker.Status.Conditions); e != a {
+		t.Fatalf("expected the Healthy condition to be replaced, got %d conditions", a)
+	}
+
+	events := getRecordedEvents(testController)
+	expectedEvent := warningEventBuilder(errorHealthCheckFailedReason).msg("Error checking the health of the broker:").msg("Status: 503")
+	if err := checkEventPrefixes(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileClusterServiceBrokerHealthCheckNotReady tests that the health
+// of a broker is checked before its catalog is relisted, even when the broker
+// is not ready.
+func TestReconcileClusterServiceBrokerHealthCheckNotReady(t *testing.T) {
+	endpoint := newFakeHealthEndpoint(http.StatusOK)
+	defer endpoint.Close()
+
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{})
+	testController.brokerHealthCheckInterval = time.Minute
+
+	broker := getTestClusterServiceBrokerWithStatus(v1beta1.ConditionFalse)
+	broker.Spec.URL = endpoint.URL
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Without a health endpoint, the catalog endpoint is checked without
+	// fetching the catalog
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+	requests := endpoint.Requests()
+	if e, a := 1, len(requests); e != a {
+		t.Fatalf("unexpected number of health check requests: expected %v, got %v", e, a)
+	}
+	if e, a := http.MethodHead, requests[0].method; e != a {
+		t.Fatalf("unexpected health check method: expected %v, got %v", e, a)
+	}
+	if e, a := "/v2/catalog", requests[0].path; e != a {
+		t.Fatalf("unexpected health check path: expected %q, got %q", e, a)
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedBroker := assertUpdateStatus(t, actions[0], broker).(*v1beta1.ClusterServiceBroker)
+	condition := getServiceBrokerCondition(updatedBroker.Status.CommonServiceBrokerStatus, v1beta1.ServiceBrokerConditionHealthy)
+	if condition == nil || condition.Status != v1beta1.ConditionTrue {
+		t.Fatalf("expected a true Healthy condition, got %+v", condition)
+	}
+}
+
+// TestReconcileClusterServiceBrokerDeleteBrokerUp tests that the broker_up
+// series of a broker is deleted with the broker.
+func TestReconcileClusterServiceBrokerDeleteBrokerUp(t *testing.T) {
+	_, fakeCatalogClient, _, testController, _ := newTestController(t, getTestCatalogConfig())
+
+	broker := getTestClusterServiceBroker()
+	broker.DeletionTimestamp = &metav1.Time{}
+	broker.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
+	fakeCatalogClient.AddReactor(getClusterServiceBrokerReactor(broker))
+
+	brokerKey := NewClusterServiceBrokerKey(broker.Name)
+	metrics.BrokerUp.WithLabelValues(brokerKey.String()).Set(1)
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if metrics.BrokerUp.DeleteLabelValues(brokerKey.String()) {
+		t.Fatal("expected the broker_up series of the broker to be deleted")
+	}
+}
+
+// TestBrokerHealthProbeAuth tests that the health probe authenticates to the
+// broker like its OSB client.
+func TestBrokerHealthProbeAuth(t *testing.T) {
+	cases := []struct {
+		name          string
+		authConfig    *osb.AuthConfig
+		authorization string
+	}{
+		{
+			name:          "no auth",
+			authorization: "",
+		},
+		{
+			name:          "basic auth",
+			authConfig:    &osb.AuthConfig{BasicAuthConfig: &osb.BasicAuthConfig{Username: "foo", Password: "bar"}},
+			authorization: "Basic Zm9vOmJhcg==",
+		},
+		{
+			name:          "bearer auth",
+			authConfig:    &osb.AuthConfig{BearerConfig: &osb.BearerConfig{Token: "token"}},
+			authorization: "Bearer token",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var authorization string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorization = r.Header.Get("Authorization")
+			}))
+			defer server.Close()
+
+			config := osb.DefaultClientConfiguration()
+			config.URL = server.URL
+			config.AuthConfig = tc.authConfig
+			probe, err := newBrokerHealthProbe(config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := probe.check(""); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e, a := tc.authorization, authorization; e != a {
+				t.Fatalf("unexpected authorization header: expected %q, got %q", e, a)
+			}
+		})
+	}
+}
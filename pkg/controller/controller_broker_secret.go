@@ -0,0 +1,140 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// brokerAuthSecretIndex is the name of the index of the broker informers that
+// maps the "namespace/name" key of a Secret to the brokers whose auth info
+// references it.
+const brokerAuthSecretIndex = "authSecret"
+
+// clusterServiceBrokerAuthSecretIndexFunc indexes a ClusterServiceBroker by the
+// key of the Secret referenced by its auth info, if any.
+func clusterServiceBrokerAuthSecretIndexFunc(obj interface{}) ([]string, error) {
+	broker, ok := obj.(*v1beta1.ClusterServiceBroker)
+	if !ok || broker.Spec.AuthInfo == nil {
+		return nil, nil
+	}
+
+	var secretRef *v1beta1.ObjectReference
+	authInfo := broker.Spec.AuthInfo
+	switch {
+	case authInfo.Basic != nil:
+		secretRef = authInfo.Basic.SecretRef
+	case authInfo.Bearer != nil:
+		secretRef = authInfo.Bearer.SecretRef
+	case authInfo.TLS != nil:
+		secretRef = authInfo.TLS.SecretRef
+	case authInfo.OAuth2 != nil:
+		secretRef = authInfo.OAuth2.SecretRef
+	}
+	if secretRef == nil {
+		return nil, nil
+	}
+	return []string{secretRef.Namespace + "/" + secretRef.Name}, nil
+}
+
+// serviceBrokerAuthSecretIndexFunc indexes a ServiceBroker by the key of the
+// Secret referenced by its auth info, if any.
+func serviceBrokerAuthSecretIndexFunc(obj interface{}) ([]string, error) {
+	broker, ok := obj.(*v1beta1.ServiceBroker)
+	if !ok || broker.Spec.AuthInfo == nil {
+		return nil, nil
+	}
+
+	var secretRef *v1beta1.LocalObjectReference
+	authInfo := broker.Spec.AuthInfo
+	switch {
+	case authInfo.Basic != nil:
+		secretRef = authInfo.Basic.SecretRef
+	case authInfo.Bearer != nil:
+		secretRef = authInfo.Bearer.SecretRef
+	case authInfo.TLS != nil:
+		secretRef = authInfo.TLS.SecretRef
+	case authInfo.OAuth2 != nil:
+		secretRef = authInfo.OAuth2.SecretRef
+	}
+	if secretRef == nil {
+		return nil, nil
+	}
+	return []string{broker.Namespace + "/" + secretRef.Name}, nil
+}
+
+func (c *controller) secretAdd(obj interface{}) {
+	c.enqueueBrokersForSecret(obj)
+}
+
+func (c *controller) secretUpdate(oldObj, newObj interface{}) {
+	oldSecret, ok := oldObj.(*corev1.Secret)
+	if !ok {
+		return
+	}
+	newSecret, ok := newObj.(*corev1.Secret)
+	if !ok {
+		return
+	}
+	// Periodic resyncs deliver updates of unchanged secrets
+	if oldSecret.ResourceVersion == newSecret.ResourceVersion {
+		return
+	}
+	c.enqueueBrokersForSecret(newObj)
+}
+
+func (c *controller) secretDelete(obj interface{}) {
+	c.enqueueBrokersForSecret(obj)
+}
+
+// enqueueBrokersForSecret adds the brokers whose auth info references the
+// given secret to their queues, so that their clients are updated with the
+// new credentials, or their status reports the missing credentials.
+func (c *controller) enqueueBrokersForSecret(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		klog.Errorf("Couldn't get key for object %+v: %v", obj, err)
+		return
+	}
+
+	clusterServiceBrokers, err := c.clusterServiceBrokerIndexer.ByIndex(brokerAuthSecretIndex, key)
+	if err != nil {
+		klog.Errorf("Couldn't get the ClusterServiceBrokers referencing secret %q: %v", key, err)
+		return
+	}
+	for _, broker := range clusterServiceBrokers {
+		klog.V(4).Infof("Auth secret %q of ClusterServiceBroker %q changed", key, broker.(*v1beta1.ClusterServiceBroker).Name)
+		c.clusterServiceBrokerAdd(broker)
+	}
+
+	if c.serviceBrokerIndexer == nil {
+		return
+	}
+	serviceBrokers, err := c.serviceBrokerIndexer.ByIndex(brokerAuthSecretIndex, key)
+	if err != nil {
+		klog.Errorf("Couldn't get the ServiceBrokers referencing secret %q: %v", key, err)
+		return
+	}
+	for _, broker := range serviceBrokers {
+		klog.V(4).Infof("Auth secret %q of ServiceBroker %q changed", key, broker.(*v1beta1.ServiceBroker).Name)
+		c.serviceBrokerAdd(broker)
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	fakeosb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/fake"
+)
+
+func TestBrokerAuthSecretIndexFuncs(t *testing.T) {
+	cases := []struct {
+		name     string
+		broker   interface{}
+		expected []string
+	}{
+		{
+			name:   "cluster broker without auth",
+			broker: getTestClusterServiceBroker(),
+		},
+		{
+			name:     "cluster broker with basic auth",
+			broker:   getTestClusterServiceBrokerWithAuth(getTestClusterBrokerBasicAuthInfo()),
+			expected: []string{"test-ns/auth-secret"},
+		},
+		{
+			name:     "cluster broker with tls auth",
+			broker:   getTestClusterServiceBrokerWithAuth(getTestClusterBrokerTLSAuthInfo()),
+			expected: []string{"test-ns/auth-secret"},
+		},
+		{
+			name: "cluster broker with service account token auth",
+			broker: getTestClusterServiceBrokerWithAuth(&v1beta1.ClusterServiceBrokerAuthInfo{
+				ServiceAccountToken: &v1beta1.ClusterServiceAccountTokenAuthConfig{
+					ServiceAccountRef: &v1beta1.ObjectReference{Namespace: "test-ns", Name: "broker-client"},
+				},
+			}),
+		},
+		{
+			name:   "namespaced broker without auth",
+			broker: getTestServiceBroker(),
+		},
+		{
+			name:     "namespaced broker with bearer auth",
+			broker:   getTestServiceBrokerWithAuth(getTestBrokerBearerAuthInfo()),
+			expected: []string{testNamespace + "/auth-secret"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			indexFunc := clusterServiceBrokerAuthSecretIndexFunc
+			if _, ok := tc.broker.(*v1beta1.ServiceBroker); ok {
+				indexFunc = serviceBrokerAuthSecretIndexFunc
+			}
+			keys, err := indexFunc(tc.broker)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tc.expected, keys) {
+				t.Fatalf("unexpected keys: expected %v, got %v", tc.expected, keys)
+			}
+		})
+	}
+}
+
+func TestSecretUpdateEnqueuesBrokers(t *testing.T) {
+	_, _, _, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{})
+
+	broker := getTestClusterServiceBrokerWithAuth(getTestClusterBrokerBasicAuthInfo())
+	otherBroker := getTestClusterServiceBroker()
+	otherBroker.Name = "other-broker"
+	for _, b := range []*v1beta1.ClusterServiceBroker{broker, otherBroker} {
+		if err := testController.clusterServiceBrokerIndexer.Add(b); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	secret := getTestBasicAuthSecret()
+	secret.ObjectMeta = metav1.ObjectMeta{Namespace: "test-ns", Name: "auth-secret", ResourceVersion: "1"}
+
+	// A resync of an unchanged secret is ignored
+	testController.secretUpdate(secret, secret)
+	if e, a := 0, testController.clusterServiceBrokerQueue.Len(); e != a {
+		t.Fatalf("expected %d queued brokers, got %d", e, a)
+	}
+
+	rotated := secret.DeepCopy()
+	rotated.ResourceVersion = "2"
+	rotated.Data[v1beta1.BasicAuthPasswordKey] = []byte("rotated")
+	testController.secretUpdate(secret, rotated)
+	if e, a := 1, testController.clusterServiceBrokerQueue.Len(); e != a {
+		t.Fatalf("expected %d queued brokers, got %d", e, a)
+	}
+	key, _ := testController.clusterServiceBrokerQueue.Get()
+	if e, a := broker.Name, key; e != a {
+		t.Fatalf("expected broker %q to be queued, got %v", e, a)
+	}
+	testController.clusterServiceBrokerQueue.Done(key)
+
+	// Deleting the secret also enqueues the broker, to report the missing
+	// credentials
+	testController.secretDelete(cache.DeletedFinalStateUnknown{Key: "test-ns/auth-secret", Obj: rotated})
+	if e, a := 1, testController.clusterServiceBrokerQueue.Len(); e != a {
+		t.Fatalf("expected %d queued brokers, got %d", e, a)
+	}
+
+	unrelated := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "unrelated"}}
+	testController.secretAdd(unrelated)
+	if e, a := 1, testController.clusterServiceBrokerQueue.Len(); e != a {
+		t.Fatalf("expected %d queued brokers, got %d", e, a)
+	}
+}
+
+// TestReconcileReadyClusterServiceBrokerWithMissingAuthSecret checks that the
+// client of a broker that is not due for a relist is still updated, reporting
+// credentials that can't be read.
+func TestReconcileReadyClusterServiceBrokerWithMissingAuthSecret(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{})
+
+	broker := getTestClusterServiceBrokerWithStatus(v1beta1.ConditionTrue)
+	broker.Spec.AuthInfo = getTestClusterBrokerBasicAuthInfo()
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err == nil {
+		t.Fatal("Should have failed to get the auth credentials.")
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[0], broker)
+	assertClusterServiceBrokerReadyFalse(t, updatedClusterServiceBroker)
+	condition := updatedClusterServiceBroker.(*v1beta1.ClusterServiceBroker).Status.Conditions[0]
+	if e, a := errorAuthCredentialsReason, condition.Reason; e != a {
+		t.Fatalf("Unexpected ready condition reason: expected %q, got %q", e, a)
+	}
+}
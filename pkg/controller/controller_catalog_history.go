@@ -0,0 +1,130 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"k8s.io/klog"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/cataloghistory"
+	"github.com/kubernetes-sigs/service-catalog/pkg/pretty"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+)
+
+const (
+	errorRecordingCatalogSnapshotReason string = "ErrorRecordingCatalogSnapshot"
+	errorDeletingCatalogHistoryReason   string = "ErrorDeletingCatalogHistory"
+)
+
+// recordCatalogSnapshot stores the catalog of a broker in a ConfigMap as the
+// latest revision of the catalog history of the broker, unless the catalog is
+// identical to the latest revision. Revisions exceeding the catalog history
+// limit are deleted, oldest first. It returns the hash of the catalog, to be
+// kept in the status of the broker: the snapshots are not listed from the API
+// server while the hash matches lastHash, the hash kept from the previous
+// relist, and the latest snapshot is still in the ConfigMap lister. It does
+// nothing if the catalog history is disabled.
+func (c *controller) recordCatalogSnapshot(pcb *pretty.ContextBuilder, namespace, brokerName string, brokerLabels map[string]string, catalog *osb.CatalogResponse, lastHash string) (string, error) {
+	if c.catalogHistoryLimit <= 0 {
+		return "", nil
+	}
+
+	hash, err := cataloghistory.Hash(catalog)
+	if err != nil {
+		return "", err
+	}
+	if hash == lastHash && c.hasLatestCatalogSnapshot(namespace, brokerLabels, hash) {
+		klog.V(5).Info(pcb.Message("Catalog is unchanged since the latest revision of the catalog history"))
+		return hash, nil
+	}
+
+	configMaps := c.kubeClient.CoreV1().ConfigMaps(namespace)
+	list, err := configMaps.List(metav1.ListOptions{
+		LabelSelector: cataloghistory.Selector(brokerLabels),
+	})
+	if err != nil {
+		return "", err
+	}
+	snapshots := cataloghistory.SortByRevision(list.Items)
+
+	revision := 1
+	if len(snapshots) > 0 {
+		latest := snapshots[len(snapshots)-1]
+		if latest.Annotations[cataloghistory.HashAnnotation] == hash {
+			klog.V(5).Info(pcb.Messagef("Catalog is unchanged since revision %s of the catalog history", latest.Labels[cataloghistory.RevisionLabel]))
+			return hash, nil
+		}
+		latestRevision, _ := cataloghistory.Revision(&latest)
+		revision = latestRevision + 1
+	}
+
+	snapshot, err := cataloghistory.NewSnapshot(namespace, brokerName, brokerLabels, revision, catalog)
+	if err != nil {
+		return "", err
+	}
+	created, err := configMaps.Create(snapshot)
+	if err != nil {
+		return "", err
+	}
+	klog.V(4).Info(pcb.Messagef("Recorded revision %d of the catalog history in ConfigMap %s/%s", revision, namespace, created.Name))
+	snapshots = append(snapshots, *created)
+
+	for len(snapshots) > c.catalogHistoryLimit {
+		oldest := snapshots[0]
+		klog.V(5).Info(pcb.Messagef("Deleting ConfigMap %s/%s from the catalog history", namespace, oldest.Name))
+		if err := configMaps.Delete(oldest.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return "", err
+		}
+		snapshots = snapshots[1:]
+	}
+
+	return hash, nil
+}
+
+// hasLatestCatalogSnapshot returns whether the latest snapshot of the catalog
+// of a broker in the ConfigMap lister holds the catalog with the given hash.
+// The snapshots may have been deleted since the hash was kept in the status
+// of the broker.
+func (c *controller) hasLatestCatalogSnapshot(namespace string, brokerLabels map[string]string, hash string) bool {
+	list, err := c.catalogHistoryLister.ConfigMaps(namespace).List(cataloghistory.LabelSelector(brokerLabels))
+	if err != nil || len(list) == 0 {
+		return false
+	}
+
+	snapshots := make([]corev1.ConfigMap, 0, len(list))
+	for _, snapshot := range list {
+		snapshots = append(snapshots, *snapshot)
+	}
+	snapshots = cataloghistory.SortByRevision(snapshots)
+	return len(snapshots) > 0 && snapshots[len(snapshots)-1].Annotations[cataloghistory.HashAnnotation] == hash
+}
+
+// deleteCatalogHistory deletes all revisions of the catalog history of a
+// broker. It does nothing if the catalog history is disabled.
+func (c *controller) deleteCatalogHistory(namespace string, brokerLabels map[string]string) error {
+	if c.catalogHistoryLimit <= 0 {
+		return nil
+	}
+
+	return c.kubeClient.CoreV1().ConfigMaps(namespace).DeleteCollection(
+		&metav1.DeleteOptions{},
+		metav1.ListOptions{LabelSelector: cataloghistory.Selector(brokerLabels)},
+	)
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/cataloghistory"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+)
+
+// TestReconcileClusterServiceBrokerCatalogHistory tests that a snapshot of
+// the catalog is recorded each time the catalog of a broker changes, and that
+// the history is bounded by the catalog history limit.
+func TestReconcileClusterServiceBrokerCatalogHistory(t *testing.T) {
+	_, _, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, getTestCatalogConfig())
+
+	fakeKubeClient := clientgofake.NewSimpleClientset()
+	testController.kubeClient = fakeKubeClient
+	testController.catalogHistoryLimit = 2
+
+	relist := func(catalog *osb.CatalogResponse) {
+		fakeClusterServiceBrokerClient.CatalogReaction = &fakeosb.CatalogReaction{Response: catalog}
+		if err := reconcileClusterServiceBroker(t, testController, getTestClusterServiceBroker()); err != nil {
+			t.Fatalf("This should not fail: %v", err)
+		}
+	}
+	assertRevisions := func(expected ...string) []corev1.ConfigMap {
+		list, err := fakeKubeClient.CoreV1().ConfigMaps(DefaultCatalogHistoryNamespace).List(metav1.ListOptions{
+			LabelSelector: cataloghistory.Selector(cataloghistory.ClusterServiceBrokerLabels(testClusterServiceBrokerName)),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error listing the catalog history: %v", err)
+		}
+		snapshots := cataloghistory.SortByRevision(list.Items)
+		names := []string{}
+		for _, snapshot := range snapshots {
+			names = append(names, snapshot.Name)
+		}
+		if !reflect.DeepEqual(expected, names) {
+			t.Fatalf("unexpected catalog history; expected %v, got %v", expected, names)
+		}
+		return snapshots
+	}
+
+	relist(getTestCatalog())
+	assertRevisions("clusterservicebroker.test-clusterservicebroker.catalog.v1")
+
+	// an unchanged catalog does not add a revision
+	relist(getTestCatalog())
+	assertRevisions("clusterservicebroker.test-clusterservicebroker.catalog.v1")
+
+	changed := getTestCatalog()
+	changed.Services[0].Description = "a changed test service"
+	relist(changed)
+	assertRevisions("clusterservicebroker.test-clusterservicebroker.catalog.v1", "clusterservicebroker.test-clusterservicebroker.catalog.v2")
+
+	// the oldest revision is deleted once the history limit is exceeded
+	changedAgain := getTestCatalog()
+	changedAgain.Services[0].Description = "a test service changed again"
+	relist(changedAgain)
+	snapshots := assertRevisions("clusterservicebroker.test-clusterservicebroker.catalog.v2", "clusterservicebroker.test-clusterservicebroker.catalog.v3")
+
+	catalog, err := cataloghistory.Catalog(&snapshots[1])
+	if err != nil {
+		t.Fatalf("unexpected error decoding the catalog: %v", err)
+	}
+	if !reflect.DeepEqual(changedAgain, catalog) {
+		t.Fatalf("unexpected catalog in the latest revision; expected %+v, got %+v", changedAgain, catalog)
+	}
+}
+
+// TestReconcileClusterServiceBrokerCatalogHistoryHash tests that the hash of
+// the latest revision is kept in the status of the broker, and that the
+// catalog history is not listed while the catalog matches that hash and the
+// latest snapshot is in the lister.
+func TestReconcileClusterServiceBrokerCatalogHistoryHash(t *testing.T) {
+	_, fakeCatalogClient, _, testController, _ := newTestController(t, getTestCatalogConfig())
+	fakeKubeClient := clientgofake.NewSimpleClientset()
+	testController.kubeClient = fakeKubeClient
+	testController.catalogHistoryLimit = 2
+
+	hash, err := cataloghistory.Hash(getTestCatalog())
+	if err != nil {
+		t.Fatalf("unexpected error hashing the catalog: %v", err)
+	}
+
+	if err := reconcileClusterServiceBroker(t, testController, getTestClusterServiceBroker()); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	recorded := false
+	for _, action := range fakeCatalogClient.Actions() {
+		update, ok := action.(clientgotesting.UpdateAction)
+		if !ok || action.GetSubresource() != "status" {
+			continue
+		}
+		broker, ok := update.GetObject().(*v1beta1.ClusterServiceBroker)
+		if ok && broker.Status.CatalogHistoryHash == hash {
+			recorded = true
+			break
+		}
+	}
+	if !recorded {
+		t.Fatalf("expected the catalog history hash %v to be recorded in the status of the broker", hash)
+	}
+
+	snapshot, err := fakeKubeClient.CoreV1().ConfigMaps(DefaultCatalogHistoryNamespace).Get(cataloghistory.Name(cataloghistory.ClusterServiceBrokerScope, testClusterServiceBrokerName, 1), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error getting the snapshot: %v", err)
+	}
+	testController.catalogHistoryLister = newTestCatalogHistoryLister(snapshot)
+
+	fakeKubeClient.ClearActions()
+	broker := getTestClusterServiceBroker()
+	broker.Status.CatalogHistoryHash = hash
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	assertNumberOfActions(t, fakeKubeClient.Actions(), 0)
+
+	// a deleted snapshot is recorded again although the hash matches
+	if err := fakeKubeClient.CoreV1().ConfigMaps(DefaultCatalogHistoryNamespace).Delete(snapshot.Name, &metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("unexpected error deleting the snapshot: %v", err)
+	}
+	testController.catalogHistoryLister = newTestCatalogHistoryLister()
+
+	fakeKubeClient.ClearActions()
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 2)
+	if !kubeActions[0].Matches("list", "configmaps") || !kubeActions[1].Matches("create", "configmaps") {
+		t.Fatalf("expected the catalog history to be listed and a snapshot to be created, got %+v", kubeActions)
+	}
+}
+
+// TestReconcileClusterServiceBrokerCatalogHistoryDisabled tests that no
+// snapshots are recorded when the catalog history is disabled.
+func TestReconcileClusterServiceBrokerCatalogHistoryDisabled(t *testing.T) {
+	fakeKubeClient, _, _, testController, _ := newTestController(t, getTestCatalogConfig())
+
+	if err := reconcileClusterServiceBroker(t, testController, getTestClusterServiceBroker()); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	assertNumberOfActions(t, fakeKubeClient.Actions(), 0)
+}
+
+// TestReconcileClusterServiceBrokerDeleteCatalogHistory tests that the
+// catalog history of a broker is deleted with the broker.
+func TestReconcileClusterServiceBrokerDeleteCatalogHistory(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, _, testController, _ := newTestController(t, getTestCatalogConfig())
+	testController.catalogHistoryLimit = 2
+
+	broker := getTestClusterServiceBroker()
+	broker.DeletionTimestamp = &metav1.Time{}
+	broker.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
+	fakeCatalogClient.AddReactor(getClusterServiceBrokerReactor(broker))
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 1)
+	deleteCollection, ok := kubeActions[0].(clientgotesting.DeleteCollectionAction)
+	if !ok {
+		t.Fatalf("expected a delete-collection action, got %+v", kubeActions[0])
+	}
+	if e, a := DefaultCatalogHistoryNamespace, deleteCollection.GetNamespace(); e != a {
+		t.Fatalf("unexpected namespace; expected %v, got %v", e, a)
+	}
+	if e, a := cataloghistory.Selector(cataloghistory.ClusterServiceBrokerLabels(testClusterServiceBrokerName)), deleteCollection.GetListRestrictions().Labels.String(); e != a {
+		t.Fatalf("unexpected label selector; expected %v, got %v", e, a)
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package controller
 
 import (
+	"crypto/tls"
 	"fmt"
 	"strings"
 	"time"
@@ -24,16 +25,18 @@ import (
 	"k8s.io/klog"
 
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 
-	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/cataloghistory"
 	"github.com/kubernetes-sigs/service-catalog/pkg/metrics"
 	"github.com/kubernetes-sigs/service-catalog/pkg/pretty"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
 )
 
 // the Message strings have a terminating period and space so they can
@@ -48,6 +51,9 @@ const (
 	errorDeletingClusterServicePlanReason    string = "ErrorDeletingClusterServicePlan"
 	errorDeletingClusterServicePlanMessage   string = "Error deleting cluster service plan."
 	errorAuthCredentialsReason               string = "ErrorGettingAuthCredentials"
+	errorAuthCredentialsMessage              string = "Error getting auth credentials."
+	errorAuthenticatingReason                string = "ErrorAuthenticating"
+	errorAuthenticatingMessage               string = "Error authenticating with broker."
 
 	successClusterServiceBrokerDeletedReason  string = "DeletedClusterServiceBrokerSuccessfully"
 	successClusterServiceBrokerDeletedMessage string = "The broker %v was deleted successfully."
@@ -60,6 +66,8 @@ const (
 	successFetchedCatalogReason           string = "FetchedCatalog"
 	successFetchedCatalogMessage          string = "Successfully fetched catalog entries from broker."
 	errorReconciliationRetryTimeoutReason string = "ErrorReconciliationRetryTimeout"
+	errorBrokerUnavailableReason          string = "BrokerUnavailable"
+	errorBrokerUnavailableMessage         string = "Requests to the broker are suspended."
 )
 
 func (c *controller) clusterServiceBrokerAdd(obj interface{}) {
@@ -128,17 +136,24 @@ func (c *controller) clusterServiceBrokerClient(broker *v1beta1.ClusterServiceBr
 	pcb := pretty.NewClusterServiceBrokerContextBuilder(broker)
 	klog.V(4).Info(pcb.Message("Updating broker client"))
 	authConfig, err := c.getAuthCredentialsFromClusterServiceBroker(broker)
+	var clientCert *tls.Certificate
+	if err == nil {
+		clientCert, err = c.getClientCertificateFromClusterServiceBroker(broker)
+	}
 	if err != nil {
 		s := fmt.Sprintf("Error getting broker auth credentials: %s", err)
 		klog.Info(pcb.Message(s))
 		c.recorder.Event(broker, corev1.EventTypeWarning, errorAuthCredentialsReason, s)
-		if err := c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorFetchingCatalogReason, errorFetchingCatalogMessage+s); err != nil {
+		if err := c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorAuthCredentialsReason, errorAuthCredentialsMessage+s); err != nil {
 			return nil, err
 		}
 		return nil, err
 	}
 	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig, c.OSBAPITimeOut)
-	brokerClient, err := c.brokerClientManager.UpdateBrokerClient(NewClusterServiceBrokerKey(broker.Name), clientConfig)
+	if clientCert != nil {
+		clientConfig.TLSConfig = &tls.Config{Certificates: []tls.Certificate{*clientCert}}
+	}
+	brokerClient, err := c.brokerClientManager.UpdateBrokerClient(NewClusterServiceBrokerKey(broker.Name), clientConfig, broker.Spec.RateLimit)
 	if err != nil {
 		s := fmt.Sprintf("Error creating client for broker %q: %s", broker.Name, err)
 		klog.Info(pcb.Message(s))
@@ -158,30 +173,56 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 	pcb := pretty.NewClusterServiceBrokerContextBuilder(broker)
 	klog.V(4).Infof(pcb.Message("Processing"))
 
+	var brokerClient osb.Client
+	if broker.DeletionTimestamp == nil {
+		// The client is updated on every sync, so that rotated credentials
+		// are used right away and missing credentials are reported.
+		var err error
+		brokerClient, err = c.clusterServiceBrokerClient(broker)
+		if err != nil {
+			return err
+		}
+
+		// The health of the broker is checked on its own schedule, whether
+		// or not its catalog is due to be relisted. The status update of a
+		// health check requeues the broker, which then relists it if due.
+		if checked, err := c.checkClusterServiceBrokerHealthIfDue(broker); checked || err != nil {
+			return err
+		}
+	}
+
 	// * If the broker's ready condition is true and the RelistBehavior has been
 	// set to Manual, do not reconcile it.
 	// * If the broker's ready condition is true and the relist interval has not
 	// elapsed, do not reconcile it.
-	if !shouldReconcileClusterServiceBroker(broker, time.Now(), c.brokerRelistInterval) {
+	// * If the circuit breaker of the broker is open, reconcile it so that
+	// its status reflects the circuit, and it is probed once the circuit
+	// half-opens.
+	if !shouldReconcileClusterServiceBroker(broker, time.Now(), c.brokerRelistInterval) &&
+		!c.brokerClientManager.CircuitOpen(NewClusterServiceBrokerKey(broker.Name)) {
 		return nil
 	}
 
 	if broker.DeletionTimestamp == nil { // Add or update
 		klog.V(4).Info(pcb.Message("Processing adding/update event"))
 
-		brokerClient, err := c.clusterServiceBrokerClient(broker)
-		if err != nil {
-			return err
-		}
-
 		// get the broker's catalog
 		now := metav1.Now()
 		brokerCatalog, err := brokerClient.GetCatalog()
 		if err != nil {
+			if isBrokerThrottledError(err) {
+				return err
+			}
 			s := fmt.Sprintf("Error getting broker catalog: %s", err)
 			klog.Warning(pcb.Message(s))
-			c.recorder.Eventf(broker, corev1.EventTypeWarning, errorFetchingCatalogReason, s)
-			if err := c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorFetchingCatalogReason, errorFetchingCatalogMessage+s); err != nil {
+			reason, message := errorFetchingCatalogReason, errorFetchingCatalogMessage
+			if isBrokerAuthenticationError(err) {
+				reason, message = errorAuthenticatingReason, errorAuthenticatingMessage
+			} else if isBrokerUnavailableError(err) {
+				reason, message = errorBrokerUnavailableReason, errorBrokerUnavailableMessage
+			}
+			c.recorder.Eventf(broker, corev1.EventTypeWarning, reason, s)
+			if err := c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, reason, message+s); err != nil {
 				return err
 			}
 			if broker.Status.OperationStartTime == nil {
@@ -221,6 +262,38 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 			broker = updated
 		}
 
+		catalogHistoryHash, err := c.recordCatalogSnapshot(pcb, c.catalogHistoryNamespace, broker.Name, cataloghistory.ClusterServiceBrokerLabels(broker.Name), brokerCatalog, broker.Status.CatalogHistoryHash)
+		if err != nil {
+			s := fmt.Sprintf("Error recording the catalog in the catalog history: %v", err)
+			klog.Warning(pcb.Message(s))
+			c.recorder.Event(broker, corev1.EventTypeWarning, errorRecordingCatalogSnapshotReason, s)
+		} else if catalogHistoryHash != broker.Status.CatalogHistoryHash {
+			toUpdate := broker.DeepCopy()
+			toUpdate.Status.CatalogHistoryHash = catalogHistoryHash
+			updated, err := c.serviceCatalogClient.ClusterServiceBrokers().UpdateStatus(toUpdate)
+			if err != nil {
+				klog.Error(pcb.Messagef("Error updating the catalog history hash: %v", err))
+				return err
+			}
+			broker = updated
+		}
+
+		// with the Proxy relist behavior, a catalog identical to the last one
+		// that was reconciled is neither converted nor reconciled again
+		brokerKey := NewClusterServiceBrokerKey(broker.Name)
+		hash := ""
+		if broker.Spec.RelistBehavior == v1beta1.ServiceBrokerRelistBehaviorProxy {
+			var unchanged bool
+			hash, unchanged = c.isServiceBrokerCatalogUnchanged(pcb, brokerKey, &broker.ObjectMeta, &broker.Status.CommonServiceBrokerStatus, brokerCatalog, func() (int, int, error) {
+				return c.countClusterServiceBrokerCatalogEntries(broker)
+			})
+			if unchanged {
+				klog.V(4).Info(pcb.Message("Catalog is unchanged since the last relist; not reconciling it"))
+				return c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionTrue, successFetchedCatalogReason, successFetchedCatalogMessage)
+			}
+			c.brokerClientManager.SetLastCatalog(brokerKey, nil)
+		}
+
 		// get the existing services and plans for this broker so that we can
 		// detect when services and plans are removed from the broker's
 		// catalog
@@ -246,6 +319,10 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 		}
 		klog.V(5).Info(pcb.Message("Successfully converted catalog payload from to service-catalog API"))
 
+		// count the classes and plans that are actually written so that the
+		// changes made to the catalog can be reported
+		diff := &catalogDiffBuilder{}
+
 		// reconcile the serviceClasses that were part of the broker's catalog
 		// payload
 		for _, payloadServiceClass := range payloadServiceClasses {
@@ -257,7 +334,8 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 			}
 
 			klog.V(4).Info(pcb.Messagef("Reconciling %s", pretty.ClusterServiceClassName(payloadServiceClass)))
-			if err := c.reconcileClusterServiceClassFromClusterServiceBrokerCatalog(broker, payloadServiceClass, existingServiceClass); err != nil {
+			change, err := c.reconcileClusterServiceClassFromClusterServiceBrokerCatalog(broker, payloadServiceClass, existingServiceClass)
+			if err != nil {
 				s := fmt.Sprintf(
 					"Error reconciling %s (broker %q): %s",
 					pretty.ClusterServiceClassName(payloadServiceClass), broker.Name, err,
@@ -271,6 +349,8 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 				return err
 			}
 
+			diff.classChanged(change, payloadServiceClass.Spec.ExternalName)
+
 			klog.V(5).Info(pcb.Messagef("Reconciled %s", pretty.ClusterServiceClassName(payloadServiceClass)))
 		}
 
@@ -302,6 +382,7 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 				}
 				return err
 			}
+			diff.classRemoved(existingServiceClass.Spec.ExternalName)
 		}
 
 		// reconcile the plans that were part of the broker's catalog payload
@@ -317,7 +398,8 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 				"ClusterServiceBroker %q: reconciling %s",
 				broker.Name, pretty.ClusterServicePlanName(payloadServicePlan),
 			)
-			if err := c.reconcileClusterServicePlanFromClusterServiceBrokerCatalog(broker, payloadServicePlan, existingServicePlan); err != nil {
+			change, err := c.reconcileClusterServicePlanFromClusterServiceBrokerCatalog(broker, payloadServicePlan, existingServicePlan)
+			if err != nil {
 				s := fmt.Sprintf(
 					"Error reconciling %s: %s",
 					pretty.ClusterServicePlanName(payloadServicePlan), err,
@@ -328,6 +410,7 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 					errorSyncingCatalogMessage+s)
 				return err
 			}
+			diff.planChanged(change, payloadServicePlan.Spec.ExternalName)
 			klog.V(5).Info(pcb.Messagef("Reconciled %s", pretty.ClusterServicePlanName(payloadServicePlan)))
 
 		}
@@ -361,15 +444,26 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 				}
 				return err
 			}
+			diff.planRemoved(existingServicePlan.Spec.ExternalName)
 		}
 
 		// everything worked correctly; update the broker's ready condition to
-		// status true
-		if err := c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionTrue, successFetchedCatalogReason, successFetchedCatalogMessage); err != nil {
+		// status true and record the changes made to the catalog
+		toUpdate := broker.DeepCopy()
+		toUpdate.Status.LastCatalogDiff = &diff.diff
+		if err := c.updateClusterServiceBrokerCondition(toUpdate, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionTrue, successFetchedCatalogReason, successFetchedCatalogMessage); err != nil {
 			return err
 		}
 
-		c.recorder.Event(broker, corev1.EventTypeNormal, successFetchedCatalogReason, successFetchedCatalogMessage)
+		c.recorder.Event(broker, corev1.EventTypeNormal, successFetchedCatalogReason, diff.message())
+
+		if hash != "" {
+			c.brokerClientManager.SetLastCatalog(brokerKey, &CatalogDigest{
+				Hash:           hash,
+				ServiceClasses: len(payloadServiceClasses),
+				ServicePlans:   len(payloadServicePlans),
+			})
+		}
 
 		// Update metrics with the number of serviceclasses and serviceplans from this broker
 		metrics.BrokerServiceClassCount.WithLabelValues(broker.Name).Set(float64(len(payloadServiceClasses)))
@@ -429,6 +523,12 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 			}
 		}
 
+		if err := c.deleteCatalogHistory(c.catalogHistoryNamespace, cataloghistory.ClusterServiceBrokerLabels(broker.Name)); err != nil {
+			s := fmt.Sprintf("Error deleting the catalog history: %v", err)
+			klog.Warning(pcb.Message(s))
+			c.recorder.Event(broker, corev1.EventTypeWarning, errorDeletingCatalogHistoryReason, s)
+		}
+
 		if err := c.updateClusterServiceBrokerCondition(
 			broker,
 			v1beta1.ServiceBrokerConditionReady,
@@ -448,6 +548,8 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 		// delete the metrics associated with this broker
 		metrics.BrokerServiceClassCount.DeleteLabelValues(broker.Name)
 		metrics.BrokerServicePlanCount.DeleteLabelValues(broker.Name)
+		brokerKey := NewClusterServiceBrokerKey(broker.Name)
+		metrics.BrokerUp.DeleteLabelValues(brokerKey.String())
 		return nil
 	}
 
@@ -459,7 +561,7 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 // listed. The serviceClass parameter is the serviceClass from the broker's
 // catalog payload. The existingServiceClass parameter is the serviceClass
 // that already exists for the given broker with this serviceClass' k8s name.
-func (c *controller) reconcileClusterServiceClassFromClusterServiceBrokerCatalog(broker *v1beta1.ClusterServiceBroker, serviceClass, existingServiceClass *v1beta1.ClusterServiceClass) error {
+func (c *controller) reconcileClusterServiceClassFromClusterServiceBrokerCatalog(broker *v1beta1.ClusterServiceBroker, serviceClass, existingServiceClass *v1beta1.ClusterServiceClass) (catalogEntryChange, error) {
 	pcb := pretty.NewClusterServiceBrokerContextBuilder(broker)
 	serviceClass.Spec.ClusterServiceBrokerName = broker.Name
 
@@ -469,7 +571,7 @@ func (c *controller) reconcileClusterServiceClassFromClusterServiceBrokerCatalog
 			// we expect _not_ to find a service class this way, so a not-
 			// found error is expected and legitimate.
 			if !errors.IsNotFound(err) {
-				return err
+				return catalogEntryUnchanged, err
 			}
 		} else {
 			// we do not expect to find an existing service class if we were
@@ -480,7 +582,7 @@ func (c *controller) reconcileClusterServiceClassFromClusterServiceBrokerCatalog
 					pretty.ClusterServiceClassName(serviceClass), otherServiceClass.Spec.ClusterServiceBrokerName,
 				)
 				klog.Error(pcb.Message(errMsg))
-				return fmt.Errorf(errMsg)
+				return catalogEntryUnchanged, fmt.Errorf(errMsg)
 			}
 		}
 
@@ -489,10 +591,10 @@ func (c *controller) reconcileClusterServiceClassFromClusterServiceBrokerCatalog
 		klog.V(5).Info(pcb.Messagef("Fresh %s; creating", pretty.ClusterServiceClassName(serviceClass)))
 		if _, err := c.serviceCatalogClient.ClusterServiceClasses().Create(serviceClass); err != nil {
 			klog.Error(pcb.Messagef("Error creating %s: %v", pretty.ClusterServiceClassName(serviceClass), err))
-			return err
+			return catalogEntryUnchanged, err
 		}
 
-		return nil
+		return catalogEntryAdded, nil
 	}
 
 	if existingServiceClass.Spec.ExternalID != serviceClass.Spec.ExternalID {
@@ -501,15 +603,14 @@ func (c *controller) reconcileClusterServiceClassFromClusterServiceBrokerCatalog
 			pretty.ClusterServiceClassName(serviceClass), existingServiceClass.Name, serviceClass.Name,
 		)
 		klog.Error(pcb.Message(errMsg))
-		return fmt.Errorf(errMsg)
+		return catalogEntryUnchanged, fmt.Errorf(errMsg)
 	}
 
-	klog.V(5).Info(pcb.Messagef("Found existing %s; updating", pretty.ClusterServiceClassName(serviceClass)))
-
 	// There was an existing service class -- project the update onto it and
-	// update it.
+	// update it if anything changed.
 	toUpdate := existingServiceClass.DeepCopy()
 	toUpdate.Spec.BindingRetrievable = serviceClass.Spec.BindingRetrievable
+	toUpdate.Spec.InstanceRetrievable = serviceClass.Spec.InstanceRetrievable
 	toUpdate.Spec.Bindable = serviceClass.Spec.Bindable
 	toUpdate.Spec.PlanUpdatable = serviceClass.Spec.PlanUpdatable
 	toUpdate.Spec.Tags = serviceClass.Spec.Tags
@@ -520,10 +621,20 @@ func (c *controller) reconcileClusterServiceClassFromClusterServiceBrokerCatalog
 
 	markAsServiceCatalogManagedResource(toUpdate, broker)
 
-	updatedServiceClass, err := c.serviceCatalogClient.ClusterServiceClasses().Update(toUpdate)
-	if err != nil {
-		klog.Error(pcb.Messagef("Error updating %s: %v", pretty.ClusterServiceClassName(serviceClass), err))
-		return err
+	change := catalogEntryUnchanged
+	updatedServiceClass := toUpdate
+	if !apiequality.Semantic.DeepEqual(existingServiceClass.Spec, toUpdate.Spec) ||
+		!apiequality.Semantic.DeepEqual(existingServiceClass.OwnerReferences, toUpdate.OwnerReferences) {
+		klog.V(5).Info(pcb.Messagef("Found existing %s; updating", pretty.ClusterServiceClassName(serviceClass)))
+		var err error
+		updatedServiceClass, err = c.serviceCatalogClient.ClusterServiceClasses().Update(toUpdate)
+		if err != nil {
+			klog.Error(pcb.Messagef("Error updating %s: %v", pretty.ClusterServiceClassName(serviceClass), err))
+			return catalogEntryUnchanged, err
+		}
+		change = catalogEntryUpdated
+	} else {
+		klog.V(5).Info(pcb.Messagef("Found existing %s; unchanged", pretty.ClusterServiceClassName(serviceClass)))
 	}
 
 	if updatedServiceClass.Status.RemovedFromBrokerCatalog {
@@ -535,18 +646,19 @@ func (c *controller) reconcileClusterServiceClassFromClusterServiceBrokerCatalog
 			klog.Warning(pcb.Message(s))
 			c.recorder.Eventf(broker, corev1.EventTypeWarning, errorSyncingCatalogReason, s)
 			if err := c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorSyncingCatalogReason, errorSyncingCatalogMessage+s); err != nil {
-				return err
+				return catalogEntryUnchanged, err
 			}
-			return err
+			return catalogEntryUnchanged, err
 		}
+		change = catalogEntryAdded
 	}
 
-	return nil
+	return change, nil
 }
 
 // reconcileClusterServicePlanFromClusterServiceBrokerCatalog reconciles a
 // ServicePlan after the ServiceClass's catalog has been re-listed.
-func (c *controller) reconcileClusterServicePlanFromClusterServiceBrokerCatalog(broker *v1beta1.ClusterServiceBroker, servicePlan, existingServicePlan *v1beta1.ClusterServicePlan) error {
+func (c *controller) reconcileClusterServicePlanFromClusterServiceBrokerCatalog(broker *v1beta1.ClusterServiceBroker, servicePlan, existingServicePlan *v1beta1.ClusterServicePlan) (catalogEntryChange, error) {
 	pcb := pretty.NewClusterServiceBrokerContextBuilder(broker)
 	servicePlan.Spec.ClusterServiceBrokerName = broker.Name
 
@@ -556,7 +668,7 @@ func (c *controller) reconcileClusterServicePlanFromClusterServiceBrokerCatalog(
 			// we expect _not_ to find a service class this way, so a not-
 			// found error is expected and legitimate.
 			if !errors.IsNotFound(err) {
-				return err
+				return catalogEntryUnchanged, err
 			}
 		} else {
 			// we do not expect to find an existing service class if we were
@@ -568,7 +680,7 @@ func (c *controller) reconcileClusterServicePlanFromClusterServiceBrokerCatalog(
 					pretty.ClusterServicePlanName(servicePlan), otherServicePlan.Spec.ClusterServiceBrokerName,
 				)
 				klog.Error(pcb.Message(errMsg))
-				return fmt.Errorf(errMsg)
+				return catalogEntryUnchanged, fmt.Errorf(errMsg)
 			}
 		}
 
@@ -578,10 +690,10 @@ func (c *controller) reconcileClusterServicePlanFromClusterServiceBrokerCatalog(
 		// not exist.  Create a new ClusterServicePlan.
 		if _, err := c.serviceCatalogClient.ClusterServicePlans().Create(servicePlan); err != nil {
 			klog.Error(pcb.Messagef("Error creating %s: %v", pretty.ClusterServicePlanName(servicePlan), err))
-			return err
+			return catalogEntryUnchanged, err
 		}
 
-		return nil
+		return catalogEntryAdded, nil
 	}
 
 	if existingServicePlan.Spec.ExternalID != servicePlan.Spec.ExternalID {
@@ -590,13 +702,11 @@ func (c *controller) reconcileClusterServicePlanFromClusterServiceBrokerCatalog(
 			pretty.ClusterServicePlanName(servicePlan), existingServicePlan.Spec.ExternalID, servicePlan.Spec.ExternalID,
 		)
 		klog.Error(pcb.Message(errMsg))
-		return fmt.Errorf(errMsg)
+		return catalogEntryUnchanged, fmt.Errorf(errMsg)
 	}
 
-	klog.V(5).Info(pcb.Messagef("Found existing %s; updating", pretty.ClusterServicePlanName(servicePlan)))
-
 	// There was an existing service plan -- project the update onto it and
-	// update it.
+	// update it if anything changed.
 	toUpdate := existingServicePlan.DeepCopy()
 	toUpdate.Spec.Description = servicePlan.Spec.Description
 	toUpdate.Spec.Bindable = servicePlan.Spec.Bindable
@@ -606,13 +716,24 @@ func (c *controller) reconcileClusterServicePlanFromClusterServiceBrokerCatalog(
 	toUpdate.Spec.InstanceCreateParameterSchema = servicePlan.Spec.InstanceCreateParameterSchema
 	toUpdate.Spec.InstanceUpdateParameterSchema = servicePlan.Spec.InstanceUpdateParameterSchema
 	toUpdate.Spec.ServiceBindingCreateParameterSchema = servicePlan.Spec.ServiceBindingCreateParameterSchema
+	toUpdate.Spec.MaintenanceInfo = servicePlan.Spec.MaintenanceInfo
 
 	markAsServiceCatalogManagedResource(toUpdate, broker)
 
-	updatedPlan, err := c.serviceCatalogClient.ClusterServicePlans().Update(toUpdate)
-	if err != nil {
-		klog.Error(pcb.Messagef("Error updating %s: %v", pretty.ClusterServicePlanName(servicePlan), err))
-		return err
+	change := catalogEntryUnchanged
+	updatedPlan := toUpdate
+	if !apiequality.Semantic.DeepEqual(existingServicePlan.Spec, toUpdate.Spec) ||
+		!apiequality.Semantic.DeepEqual(existingServicePlan.OwnerReferences, toUpdate.OwnerReferences) {
+		klog.V(5).Info(pcb.Messagef("Found existing %s; updating", pretty.ClusterServicePlanName(servicePlan)))
+		var err error
+		updatedPlan, err = c.serviceCatalogClient.ClusterServicePlans().Update(toUpdate)
+		if err != nil {
+			klog.Error(pcb.Messagef("Error updating %s: %v", pretty.ClusterServicePlanName(servicePlan), err))
+			return catalogEntryUnchanged, err
+		}
+		change = catalogEntryUpdated
+	} else {
+		klog.V(5).Info(pcb.Messagef("Found existing %s; unchanged", pretty.ClusterServicePlanName(servicePlan)))
 	}
 
 	if updatedPlan.Status.RemovedFromBrokerCatalog {
@@ -625,13 +746,14 @@ func (c *controller) reconcileClusterServicePlanFromClusterServiceBrokerCatalog(
 			klog.Error(pcb.Message(s))
 			c.recorder.Eventf(broker, corev1.EventTypeWarning, errorSyncingCatalogReason, s)
 			if err := c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorSyncingCatalogReason, errorSyncingCatalogMessage+s); err != nil {
-				return err
+				return catalogEntryUnchanged, err
 			}
-			return err
+			return catalogEntryUnchanged, err
 		}
+		change = catalogEntryAdded
 	}
 
-	return nil
+	return change, nil
 }
 
 // updateClusterServiceBrokerCondition updates the ready condition for the given Broker
@@ -718,6 +840,38 @@ func (c *controller) updateClusterServiceBrokerFinalizers(
 	return err
 }
 
+// countClusterServiceBrokerCatalogEntries returns the number of
+// ClusterServiceClasses and ClusterServicePlans of the broker that are still
+// in its catalog, as seen by the informers.
+func (c *controller) countClusterServiceBrokerCatalogEntries(broker *v1beta1.ClusterServiceBroker) (int, int, error) {
+	selector := labels.SelectorFromSet(labels.Set{
+		v1beta1.GroupName + "/" + v1beta1.FilterSpecClusterServiceBrokerName: broker.Name,
+	})
+	serviceClasses, err := c.clusterServiceClassLister.List(selector)
+	if err != nil {
+		return 0, 0, err
+	}
+	servicePlans, err := c.clusterServicePlanLister.List(selector)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	serviceClassCount := 0
+	for _, serviceClass := range serviceClasses {
+		if !serviceClass.Status.RemovedFromBrokerCatalog && isServiceCatalogManagedResource(serviceClass) {
+			serviceClassCount++
+		}
+	}
+	servicePlanCount := 0
+	for _, servicePlan := range servicePlans {
+		if !servicePlan.Status.RemovedFromBrokerCatalog && isServiceCatalogManagedResource(servicePlan) {
+			servicePlanCount++
+		}
+	}
+
+	return serviceClassCount, servicePlanCount, nil
+}
+
 func (c *controller) getCurrentServiceClassesAndPlansForBroker(broker *v1beta1.ClusterServiceBroker) ([]v1beta1.ClusterServiceClass, []v1beta1.ClusterServicePlan, error) {
 	pcb := pretty.NewClusterServiceBrokerContextBuilder(broker)
 
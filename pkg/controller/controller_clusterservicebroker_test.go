@@ -17,16 +17,17 @@ limitations under the License.
 package controller
 
 import (
+	"crypto/tls"
 	"errors"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
 
-	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
-	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
-
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/test/fake"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/fake"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -287,16 +288,27 @@ func TestReconcileClusterServiceBrokerExistingServiceClassAndServicePlan(t *test
 	}
 
 	actions := fakeCatalogClient.Actions()
-	assertNumberOfActions(t, actions, 6)
+	assertNumberOfActions(t, actions, 5)
 	assertList(t, actions[0], &v1beta1.ClusterServiceClass{}, listRestrictions)
 	assertList(t, actions[1], &v1beta1.ClusterServicePlan{}, listRestrictions)
-	assertUpdate(t, actions[2], testClusterServiceClass)
-	assertCreate(t, actions[3], testClusterServicePlan)
-	assertCreate(t, actions[4], testClusterServicePlanNonbindable)
+	// the existing class is identical to the one in the catalog, so it is
+	// not updated
+	assertCreate(t, actions[2], testClusterServicePlan)
+	assertCreate(t, actions[3], testClusterServicePlanNonbindable)
 
 	// 4 update action for broker status subresource
-	updatedClusterServiceBroker := assertUpdateStatus(t, actions[5], getTestClusterServiceBroker())
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[4], getTestClusterServiceBroker())
 	assertClusterServiceBrokerReadyTrue(t, updatedClusterServiceBroker)
+	assertClusterServiceBrokerLastCatalogDiff(t, updatedClusterServiceBroker, v1beta1.CatalogDiff{AddedServicePlans: 2})
+
+	events := getRecordedEvents(testController)
+	assertNumEvents(t, events, 1)
+	expectedEvent := normalEventBuilder(successFetchedCatalogReason).msg(successFetchedCatalogMessage).msg(
+		"Service classes: 0 added, 0 removed, 0 updated. Service plans: 2 (test-clusterserviceplan, test-unbindable-clusterserviceplan) added, 0 removed, 0 updated.",
+	)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
 
 	// verify no kube resources created
 	kubeActions := fakeKubeClient.Actions()
@@ -347,16 +359,19 @@ func TestReconcileClusterServiceBrokerRemovedClusterServiceClass(t *testing.T) {
 	}
 
 	actions := fakeCatalogClient.Actions()
-	assertNumberOfActions(t, actions, 7)
+	assertNumberOfActions(t, actions, 6)
 	assertList(t, actions[0], &v1beta1.ClusterServiceClass{}, listRestrictions)
 	assertList(t, actions[1], &v1beta1.ClusterServicePlan{}, listRestrictions)
-	assertUpdate(t, actions[2], testClusterServiceClass)
-	assertUpdateStatus(t, actions[3], testRemovedClusterServiceClass)
-	assertCreate(t, actions[4], testClusterServicePlan)
-	assertCreate(t, actions[5], testClusterServicePlanNonbindable)
+	assertUpdateStatus(t, actions[2], testRemovedClusterServiceClass)
+	assertCreate(t, actions[3], testClusterServicePlan)
+	assertCreate(t, actions[4], testClusterServicePlanNonbindable)
 
-	updatedClusterServiceBroker := assertUpdateStatus(t, actions[6], getTestClusterServiceBroker())
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[5], getTestClusterServiceBroker())
 	assertClusterServiceBrokerReadyTrue(t, updatedClusterServiceBroker)
+	assertClusterServiceBrokerLastCatalogDiff(t, updatedClusterServiceBroker, v1beta1.CatalogDiff{
+		RemovedServiceClasses: 1,
+		AddedServicePlans:     2,
+	})
 
 	// verify no kube resources created
 	kubeActions := fakeKubeClient.Actions()
@@ -417,18 +432,21 @@ func TestReconcileClusterServiceBrokerRemovedAndRestoredClusterServiceClass(t *t
 	}
 
 	actions := fakeCatalogClient.Actions()
-	assertNumberOfActions(t, actions, 8)
+	assertNumberOfActions(t, actions, 7)
 	assertList(t, actions[0], &v1beta1.ClusterServiceClass{}, listRestrictions)
 	assertList(t, actions[1], &v1beta1.ClusterServicePlan{}, listRestrictions)
-	assertUpdate(t, actions[2], testClusterServiceClass)
-	class := assertUpdateStatus(t, actions[3], testClusterServiceClass)
+	class := assertUpdateStatus(t, actions[2], testClusterServiceClass)
 	assertClassRemovedFromBrokerCatalogFalse(t, class)
-	assertUpdate(t, actions[4], testClusterServicePlan)
-	plan := assertUpdateStatus(t, actions[5], testClusterServicePlan)
+	assertUpdate(t, actions[3], testClusterServicePlan)
+	plan := assertUpdateStatus(t, actions[4], testClusterServicePlan)
 	assertPlanRemovedFromBrokerCatalogFalse(t, plan)
-	assertCreate(t, actions[6], testClusterServicePlanNonbindable)
-	updatedClusterServiceBroker := assertUpdateStatus(t, actions[7], getTestClusterServiceBroker())
+	assertCreate(t, actions[5], testClusterServicePlanNonbindable)
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[6], getTestClusterServiceBroker())
 	assertClusterServiceBrokerReadyTrue(t, updatedClusterServiceBroker)
+	assertClusterServiceBrokerLastCatalogDiff(t, updatedClusterServiceBroker, v1beta1.CatalogDiff{
+		AddedServiceClasses: 1,
+		AddedServicePlans:   2,
+	})
 
 	// verify no kube resources created
 	kubeActions := fakeKubeClient.Actions()
@@ -476,16 +494,19 @@ func TestReconcileClusterServiceBrokerRemovedClusterServicePlan(t *testing.T) {
 	}
 
 	actions := fakeCatalogClient.Actions()
-	assertNumberOfActions(t, actions, 7)
+	assertNumberOfActions(t, actions, 6)
 	assertList(t, actions[0], &v1beta1.ClusterServiceClass{}, listRestrictions)
 	assertList(t, actions[1], &v1beta1.ClusterServicePlan{}, listRestrictions)
-	assertUpdate(t, actions[2], testClusterServiceClass)
-	assertCreate(t, actions[3], testClusterServicePlan)
-	assertCreate(t, actions[4], testClusterServicePlanNonbindable)
-	assertUpdateStatus(t, actions[5], testRemovedClusterServicePlan)
+	assertCreate(t, actions[2], testClusterServicePlan)
+	assertCreate(t, actions[3], testClusterServicePlanNonbindable)
+	assertUpdateStatus(t, actions[4], testRemovedClusterServicePlan)
 
-	updatedClusterServiceBroker := assertUpdateStatus(t, actions[6], getTestClusterServiceBroker())
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[5], getTestClusterServiceBroker())
 	assertClusterServiceBrokerReadyTrue(t, updatedClusterServiceBroker)
+	assertClusterServiceBrokerLastCatalogDiff(t, updatedClusterServiceBroker, v1beta1.CatalogDiff{
+		AddedServicePlans:   2,
+		RemovedServicePlans: 1,
+	})
 
 	// verify no kube resources created
 	kubeActions := fakeKubeClient.Actions()
@@ -766,6 +787,40 @@ func TestReconcileClusterServiceBrokerErrorFetchingCatalog(t *testing.T) {
 	}
 }
 
+// TestReconcileClusterServiceBrokerErrorAuthenticating simulates broker
+// reconciliation where the broker rejects the credentials of the catalog
+// request, which is reported distinctly from other catalog errors.
+func TestReconcileClusterServiceBrokerErrorAuthenticating(t *testing.T) {
+	_, fakeCatalogClient, _, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{
+		CatalogReaction: &fakeosb.CatalogReaction{
+			Error: osb.HTTPStatusCodeError{StatusCode: http.StatusUnauthorized},
+		},
+	})
+
+	broker := getTestClusterServiceBroker()
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err == nil {
+		t.Fatal("Should have failed to get the catalog.")
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 2)
+
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[0], broker)
+	assertClusterServiceBrokerReadyFalse(t, updatedClusterServiceBroker)
+	condition := updatedClusterServiceBroker.(*v1beta1.ClusterServiceBroker).Status.Conditions[0]
+	if e, a := errorAuthenticatingReason, condition.Reason; e != a {
+		t.Fatalf("Unexpected ready condition reason: expected %q, got %q", e, a)
+	}
+
+	events := getRecordedEvents(testController)
+
+	expectedEvent := warningEventBuilder(errorAuthenticatingReason).msg("Error getting broker catalog:").msg("Status: 401; ErrorMessage: <nil>; Description: <nil>; ResponseError: <nil>")
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // TestReconcileClusterServiceBrokerZeroServices simulates broker reconciliation where
 // OSB client responds with zero services which is valid
 func TestReconcileClusterServiceBrokerZeroServices(t *testing.T) {
@@ -829,6 +884,90 @@ func TestReconcileClusterServiceBrokerZeroServices(t *testing.T) {
 	}
 }
 
+// TestReconcileClusterServiceBrokerProxyRelist tests that, with the Proxy
+// relist behavior, a relisted catalog identical to the last reconciled one is
+// only reconciled again when the classes and plans it produced have changed.
+func TestReconcileClusterServiceBrokerProxyRelist(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, getTestCatalogConfig())
+
+	broker := getTestClusterServiceBroker()
+	broker.Spec.RelistBehavior = v1beta1.ServiceBrokerRelistBehaviorProxy
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	// make the classes and plans created from the catalog visible to the
+	// informers, labeled as the mutating webhooks would label them
+	brokerLabels := map[string]string{
+		v1beta1.GroupName + "/" + v1beta1.FilterSpecClusterServiceBrokerName: broker.Name,
+	}
+	var createdPlan *v1beta1.ClusterServicePlan
+	for _, action := range fakeCatalogClient.Actions() {
+		create, ok := action.(clientgotesting.CreateAction)
+		if !ok {
+			continue
+		}
+		create.GetObject().(metav1.Object).SetLabels(brokerLabels)
+		switch obj := create.GetObject().(type) {
+		case *v1beta1.ClusterServiceClass:
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(obj)
+		case *v1beta1.ClusterServicePlan:
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(obj)
+			createdPlan = obj
+		}
+	}
+	if createdPlan == nil {
+		t.Fatal("expected the catalog to create plans")
+	}
+
+	// the unchanged catalog is reconciled again while the broker is not ready
+	fakeCatalogClient.ClearActions()
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 2)
+	if actions := fakeCatalogClient.Actions(); len(actions) <= 1 {
+		t.Fatalf("expected the catalog to be reconciled, got actions %+v", actions)
+	}
+
+	setServiceBrokerCondition(&broker.Status.CommonServiceBrokerStatus, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionTrue, successFetchedCatalogReason, successFetchedCatalogMessage, metav1.Now())
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	brokerActions = fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 3)
+	assertGetCatalog(t, brokerActions[2])
+
+	// the unchanged catalog only updates the broker status
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[0], broker)
+	assertClusterServiceBrokerReadyTrue(t, updatedClusterServiceBroker)
+
+	// a plan of the catalog is deleted
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Delete(createdPlan)
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	listRestrictions := clientgotesting.ListRestrictions{
+		Labels: labels.SelectorFromSet(labels.Set{
+			v1beta1.GroupName + "/" + v1beta1.FilterSpecClusterServiceBrokerName: "test-clusterservicebroker",
+		}),
+		Fields: fields.Everything(),
+	}
+	actions = fakeCatalogClient.Actions()
+	assertList(t, actions[0], &v1beta1.ClusterServiceClass{}, listRestrictions)
+	assertList(t, actions[1], &v1beta1.ClusterServicePlan{}, listRestrictions)
+}
+
 func TestReconcileClusterServiceBrokerWithAuth(t *testing.T) {
 	// The test cases here are testing the correctness of authentication with broker
 	//
@@ -879,9 +1018,28 @@ func TestReconcileClusterServiceBrokerWithAuth(t *testing.T) {
 			secret:        nil,
 			shouldSucceed: false,
 		},
+		{
+			name:          "tls auth - normal",
+			authInfo:      getTestClusterBrokerTLSAuthInfo(),
+			secret:        getTestTLSAuthSecret(t),
+			shouldSucceed: true,
+		},
+		{
+			name:          "tls auth - invalid secret",
+			authInfo:      getTestClusterBrokerTLSAuthInfo(),
+			secret:        getTestBearerAuthSecret(),
+			shouldSucceed: false,
+		},
+		{
+			name:          "tls auth - secret not found",
+			authInfo:      getTestClusterBrokerTLSAuthInfo(),
+			secret:        nil,
+			shouldSucceed: false,
+		},
 	}
 
 	for _, tc := range cases {
+		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			testReconcileClusterServiceBrokerWithAuth(t, tc.authInfo, tc.secret, tc.shouldSucceed)
@@ -890,22 +1048,26 @@ func TestReconcileClusterServiceBrokerWithAuth(t *testing.T) {
 }
 
 func testReconcileClusterServiceBrokerWithAuth(t *testing.T, authInfo *v1beta1.ClusterServiceBrokerAuthInfo, secret *corev1.Secret, shouldSucceed bool) {
-	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{})
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{})
 
 	broker := getTestClusterServiceBrokerWithAuth(authInfo)
+	// The controller reads the secrets referenced by brokers from the secret
+	// informer
+	var secrets []*corev1.Secret
 	if secret != nil {
-		fakeKubeClient.CoreV1().Secrets(secret.Namespace).Create(secret)
+		secret = secret.DeepCopy()
+		secret.Namespace, secret.Name = "test-ns", "auth-secret"
+		secrets = append(secrets, secret)
+	}
+	testController.secretLister = newTestSecretLister(secrets...)
+	var clientConfig *osb.ClientConfiguration
+	createFunc := testController.brokerClientManager.brokerClientCreateFunc
+	testController.brokerClientManager.brokerClientCreateFunc = func(config *osb.ClientConfiguration) (osb.Client, error) {
+		clientConfig = config
+		return createFunc(config)
 	}
-	testClusterServiceClass := getTestClusterServiceClass()
 	fakeClusterServiceBrokerClient.CatalogReaction = &fakeosb.CatalogReaction{
-		Response: &osb.CatalogResponse{
-			Services: []osb.Service{
-				{
-					ID:   testClusterServiceClass.Spec.ExternalID,
-					Name: testClusterServiceClass.Name,
-				},
-			},
-		},
+		Response: getTestCatalog(),
 	}
 
 	err := reconcileClusterServiceBroker(t, testController, broker)
@@ -922,11 +1084,30 @@ func testReconcileClusterServiceBrokerWithAuth(t *testing.T, authInfo *v1beta1.C
 		assertNumberOfBrokerActions(t, brokerActions, 0)
 	}
 
+	if shouldSucceed && authInfo.TLS != nil {
+		expectedCert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			t.Fatalf("unexpected error parsing the test certificate: %v", err)
+		}
+		if clientConfig == nil || clientConfig.TLSConfig == nil || len(clientConfig.TLSConfig.Certificates) != 1 {
+			t.Fatalf("expected the client configuration to hold a client certificate, got %+v", clientConfig)
+		}
+		cert := clientConfig.TLSConfig.Certificates[0]
+		if !reflect.DeepEqual(expectedCert.Certificate, cert.Certificate) {
+			t.Fatal("unexpected client certificate in the client configuration")
+		}
+		if !reflect.DeepEqual(expectedCert.PrivateKey, cert.PrivateKey) {
+			t.Fatal("unexpected private key in the client configuration")
+		}
+	}
+
 	actions := fakeCatalogClient.Actions()
 	if shouldSucceed {
-		assertNumberOfActions(t, actions, 2)
-		assertCreate(t, actions[0], testClusterServiceClass)
-		updatedClusterServiceBroker := assertUpdateStatus(t, actions[1], broker)
+		assertNumberOfActions(t, actions, 6)
+		assertCreate(t, actions[2], getTestClusterServiceClass())
+		assertCreate(t, actions[3], getTestClusterServicePlan())
+		assertCreate(t, actions[4], getTestClusterServicePlanNonbindable())
+		updatedClusterServiceBroker := assertUpdateStatus(t, actions[5], broker)
 		assertClusterServiceBrokerReadyTrue(t, updatedClusterServiceBroker)
 	} else {
 		assertNumberOfActions(t, actions, 1)
@@ -1361,7 +1542,7 @@ func TestReconcileClusterServicePlanFromClusterServiceBrokerCatalog(t *testing.T
 				sharedInformers.ClusterServicePlans().Informer().GetStore().Add(tc.listerServicePlan)
 			}
 
-			err := testController.reconcileClusterServicePlanFromClusterServiceBrokerCatalog(broker, tc.newServicePlan, tc.existingServicePlan)
+			_, err := testController.reconcileClusterServicePlanFromClusterServiceBrokerCatalog(broker, tc.newServicePlan, tc.existingServicePlan)
 			if err != nil {
 				if !tc.shouldError {
 					t.Fatalf("%v: unexpected error from method under test: %v", tc.name, err)
@@ -1394,6 +1575,8 @@ func TestReconcileUpdatesManagedClassesAndPlans(t *testing.T) {
 	_, fakeCatalogClient, _, testController, sharedInformers := newTestController(t, getTestCatalogConfig())
 
 	testClusterServiceClass := getTestClusterServiceClass()
+	// classes that did not change are not updated
+	testClusterServiceClass.Spec.Description = "an outdated description"
 	testClusterServicePlan := getTestClusterServicePlan()
 
 	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(testClusterServiceClass)
@@ -17,17 +17,21 @@ limitations under the License.
 package controller
 
 import (
+	"context"
 	stderrors "errors"
 	"fmt"
 	"net/url"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
-	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
 	"github.com/kubernetes-sigs/service-catalog/pkg/pretty"
+	"github.com/kubernetes-sigs/service-catalog/pkg/tracing"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -93,6 +97,15 @@ const (
 	deprovisioningInFlightMessage           string = "Deprovision request for ServiceInstance in-flight to Broker"
 	startingInstanceOrphanMitigationReason  string = "StartingInstanceOrphanMitigation"
 	startingInstanceOrphanMitigationMessage string = "The instance provision call failed with an ambiguous error; attempting to deprovision the instance in order to mitigate an orphaned resource"
+	upgradeAvailableReason                  string = "UpgradeAvailable"
+	upgradeAvailableMessage                 string = "The plan of the instance offers maintenance info version %q"
+	upToDateReason                          string = "UpToDate"
+	upToDateMessage                         string = "The instance is at the latest maintenance info version of its plan"
+	driftDetectedReason                     string = "DriftDetected"
+	driftDetectedMessage                    string = "The state of the instance reported by the broker differs from its spec: %s"
+	inSyncReason                            string = "InSync"
+	inSyncMessage                           string = "The state of the instance reported by the broker matches its spec"
+	errorRetrievingInstanceReason           string = "ErrorRetrievingInstance"
 
 	clusterIdentifierKey string = "clusterid"
 
@@ -324,20 +337,21 @@ func (c *controller) reconcileServiceInstance(instance *v1beta1.ServiceInstance)
 		return nil
 	}
 	reconciliationAction := getReconciliationActionForServiceInstance(instance)
+	ctx, span := tracing.Start(context.Background(), "reconcileServiceInstance"+string(reconciliationAction), serviceInstanceSpanAttributes(instance)...)
 	switch reconciliationAction {
 
 	// ERIK CP
 	case reconcileAdd:
-		return c.reconcileServiceInstanceAdd(instance)
+		return tracing.EndWithError(span, c.reconcileServiceInstanceAdd(ctx, instance))
 	case reconcileUpdate:
-		return c.reconcileServiceInstanceUpdate(instance)
+		return tracing.EndWithError(span, c.reconcileServiceInstanceUpdate(ctx, instance))
 	case reconcileDelete:
-		return c.reconcileServiceInstanceDelete(instance)
+		return tracing.EndWithError(span, c.reconcileServiceInstanceDelete(ctx, instance))
 	case reconcilePoll:
-		return c.pollServiceInstance(instance)
+		return tracing.EndWithError(span, c.pollServiceInstance(ctx, instance))
 	default:
 		pcb := pretty.NewInstanceContextBuilder(instance)
-		return fmt.Errorf(pcb.Messagef("Unknown reconciliation action %v", reconciliationAction))
+		return tracing.EndWithError(span, fmt.Errorf(pcb.Messagef("Unknown reconciliation action %v", reconciliationAction)))
 	}
 }
 
@@ -500,7 +514,7 @@ func (c *controller) removeInstanceFromRetryMap(instance *v1beta1.ServiceInstanc
 
 // reconcileServiceInstanceAdd is responsible for handling the provisioning
 // of new service instances.
-func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstance) error {
+func (c *controller) reconcileServiceInstanceAdd(ctx context.Context, instance *v1beta1.ServiceInstance) error {
 	pcb := pretty.NewInstanceContextBuilder(instance)
 
 	if !c.isServiceInstanceStatusInitialized(instance) {
@@ -612,8 +626,13 @@ func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstan
 	))
 
 	c.setRetryBackoffRequired(instance)
-	response, err := brokerClient.ProvisionInstance(request)
+	response, err := osb.WithContext(ctx, brokerClient).ProvisionInstance(request)
 	if err != nil {
+		if isBrokerThrottledError(err) {
+			// The request was not sent to the broker, so the provision is
+			// retried without recording a failure.
+			return err
+		}
 		if httpErr, ok := osb.IsHTTPError(err); ok {
 			msg := fmt.Sprintf(
 				"Error provisioning ServiceInstance of %s at ClusterServiceBroker %q: %s",
@@ -632,6 +651,9 @@ func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstan
 		}
 
 		reason := errorErrorCallingProvisionReason
+		if isBrokerUnavailableError(err) {
+			reason = errorBrokerUnavailableReason
+		}
 
 		// A timeout error is considered a retriable error, but we
 		// should initiate orphan mitigation.
@@ -664,12 +686,18 @@ func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstan
 
 // reconcileServiceInstanceUpdate is responsible for handling updating the plan
 // or parameters of a service instance.
-func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceInstance) error {
+func (c *controller) reconcileServiceInstanceUpdate(ctx context.Context, instance *v1beta1.ServiceInstance) error {
 	pcb := pretty.NewInstanceContextBuilder(instance)
 
 	if isServiceInstanceProcessedAlready(instance) {
 		klog.V(4).Info(pcb.Message("Not processing event because status showed there is no work to do"))
-		return nil
+		updated, err := c.updateServiceInstanceUpgradeAvailableCondition(instance)
+		if err != nil || updated {
+			// An updated instance will be automatically added back to the
+			// queue and processed again
+			return err
+		}
+		return c.retrieveServiceInstanceIfDue(ctx, instance)
 	}
 
 	// don't DOS the broker.  If we already did an update attempt that ended with a non-terminal
@@ -789,8 +817,11 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 	}
 
 	c.setRetryBackoffRequired(instance)
-	response, err := brokerClient.UpdateInstance(request)
+	response, err := osb.WithContext(ctx, brokerClient).UpdateInstance(request)
 	if err != nil {
+		if isBrokerThrottledError(err) {
+			return err
+		}
 		if httpErr, ok := osb.IsHTTPError(err); ok {
 			if isRetriableHTTPStatus(httpErr.StatusCode) {
 				msg := fmt.Sprintf("ServiceBroker returned a failure for update call; update will be retried: %v", httpErr)
@@ -806,6 +837,9 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 		}
 
 		reason := errorErrorCallingUpdateInstanceReason
+		if isBrokerUnavailableError(err) {
+			reason = errorBrokerUnavailableReason
+		}
 
 		if urlErr, ok := err.(*url.Error); ok && urlErr.Timeout() {
 			msg := fmt.Sprintf("Communication with the ServiceBroker timed out; update will be retried: %v", urlErr)
@@ -845,7 +879,7 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 
 // reconcileServiceInstanceDelete is responsible for handling any instance whose
 // deletion timestamp is set.
-func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceInstance) error {
+func (c *controller) reconcileServiceInstanceDelete(ctx context.Context, instance *v1beta1.ServiceInstance) error {
 	if finalizers := sets.NewString(instance.Finalizers...); !finalizers.Has(v1beta1.FinalizerServiceCatalog) {
 		return nil
 	}
@@ -964,8 +998,11 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 	}
 
 	klog.V(4).Info(pcb.Message("Sending deprovision request to broker"))
-	response, err := brokerClient.DeprovisionInstance(request)
+	response, err := osb.WithContext(ctx, brokerClient).DeprovisionInstance(request)
 	if err != nil {
+		if isBrokerThrottledError(err) {
+			return err
+		}
 		msg := fmt.Sprintf(
 			`Error deprovisioning, %s at ClusterServiceBroker %q: %v`,
 			prettyName, brokerName, err,
@@ -974,7 +1011,11 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 			msg = fmt.Sprintf("Deprovision call failed; received error response from broker: %v", httpErr)
 		}
 
-		readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionUnknown, errorDeprovisionCallFailedReason, msg)
+		reason := errorDeprovisionCallFailedReason
+		if isBrokerUnavailableError(err) {
+			reason = errorBrokerUnavailableReason
+		}
+		readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionUnknown, reason, msg)
 
 		if c.reconciliationRetryDurationExceeded(instance.Status.OperationStartTime) {
 			msg := "Stopping reconciliation retries because too much time has elapsed"
@@ -992,7 +1033,7 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 	return c.processDeprovisionSuccess(instance)
 }
 
-func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) error {
+func (c *controller) pollServiceInstance(ctx context.Context, instance *v1beta1.ServiceInstance) error {
 	pcb := pretty.NewInstanceContextBuilder(instance)
 	klog.V(4).Info(pcb.Message("Processing poll event"))
 
@@ -1023,8 +1064,11 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 
 	klog.V(5).Info(pcb.Message("Polling last operation"))
 
-	response, err := brokerClient.PollLastOperation(request)
+	response, err := osb.WithContext(ctx, brokerClient).PollLastOperation(request)
 	if err != nil {
+		if isBrokerThrottledError(err) {
+			return c.handleServiceInstancePollingError(instance, err)
+		}
 		// If the operation was for delete and we receive a http.StatusGone,
 		// this is considered a success as per the spec
 		if osb.IsGoneError(err) && deleting {
@@ -1035,6 +1079,9 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 		}
 
 		reason := errorPollingLastOperationReason
+		if isBrokerUnavailableError(err) {
+			reason = errorBrokerUnavailableReason
+		}
 		message := fmt.Sprintf("Error polling last operation: %v", err)
 		klog.V(4).Info(pcb.Message(message))
 		readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, reason, message)
@@ -1853,6 +1900,240 @@ func setServiceInstanceConditionInternal(toUpdate *v1beta1.ServiceInstance,
 	toUpdate.Status.Conditions = append(toUpdate.Status.Conditions, newCondition)
 }
 
+// getServiceInstancePlanMaintenanceInfo returns the maintenance info of the
+// plan referenced by the given instance, or nil if the plan has none.
+func (c *controller) getServiceInstancePlanMaintenanceInfo(instance *v1beta1.ServiceInstance) (*v1beta1.MaintenanceInfo, error) {
+	if instance.Spec.ClusterServicePlanRef != nil {
+		servicePlan, err := c.clusterServicePlanLister.Get(instance.Spec.ClusterServicePlanRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		return servicePlan.Spec.MaintenanceInfo, nil
+	}
+	if instance.Spec.ServicePlanRef != nil {
+		servicePlan, err := c.servicePlanLister.ServicePlans(instance.Namespace).Get(instance.Spec.ServicePlanRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		return servicePlan.Spec.MaintenanceInfo, nil
+	}
+	return nil, nil
+}
+
+// setServiceInstanceUpgradeAvailableCondition sets the UpgradeAvailable
+// condition of the instance by comparing the maintenance info the broker
+// knows the instance to be at with the maintenance info of its plan. The
+// condition is removed if the plan has no maintenance info. Returns true if
+// the status of the instance was changed.
+func setServiceInstanceUpgradeAvailableCondition(toUpdate *v1beta1.ServiceInstance, planMaintenanceInfo *v1beta1.MaintenanceInfo) bool {
+	var existing *v1beta1.ServiceInstanceCondition
+	for i, cond := range toUpdate.Status.Conditions {
+		if cond.Type == v1beta1.ServiceInstanceConditionUpgradeAvailable {
+			existing = &toUpdate.Status.Conditions[i]
+			break
+		}
+	}
+
+	if planMaintenanceInfo == nil {
+		if existing == nil {
+			return false
+		}
+		removeServiceInstanceCondition(toUpdate, v1beta1.ServiceInstanceConditionUpgradeAvailable)
+		return true
+	}
+
+	var currentMaintenanceInfo *v1beta1.MaintenanceInfo
+	if toUpdate.Status.ExternalProperties != nil {
+		currentMaintenanceInfo = toUpdate.Status.ExternalProperties.MaintenanceInfo
+	}
+
+	status := v1beta1.ConditionFalse
+	reason := upToDateReason
+	message := upToDateMessage
+	if !isMaintenanceInfoEqual(currentMaintenanceInfo, planMaintenanceInfo) {
+		status = v1beta1.ConditionTrue
+		reason = upgradeAvailableReason
+		message = fmt.Sprintf(upgradeAvailableMessage, planMaintenanceInfo.Version)
+	}
+
+	if existing != nil && existing.Status == status && existing.Reason == reason && existing.Message == message {
+		return false
+	}
+	setServiceInstanceCondition(toUpdate, v1beta1.ServiceInstanceConditionUpgradeAvailable, status, reason, message)
+	return true
+}
+
+// updateServiceInstanceUpgradeAvailableCondition brings the UpgradeAvailable
+// condition of an instance that has no further work to do in line with the
+// maintenance info of its plan, recording the status if it changed. Returns
+// true if the status of the instance was updated.
+func (c *controller) updateServiceInstanceUpgradeAvailableCondition(instance *v1beta1.ServiceInstance) (bool, error) {
+	pcb := pretty.NewInstanceContextBuilder(instance)
+
+	planMaintenanceInfo, err := c.getServiceInstancePlanMaintenanceInfo(instance)
+	if err != nil {
+		klog.V(4).Info(pcb.Messagef("Unable to determine the maintenance info of the plan: %v", err))
+		return false, nil
+	}
+
+	toUpdate := instance.DeepCopy()
+	if !setServiceInstanceUpgradeAvailableCondition(toUpdate, planMaintenanceInfo) {
+		return false, nil
+	}
+	if _, err := c.updateServiceInstanceStatus(toUpdate); err != nil {
+		return false, err
+	}
+
+	if isServiceInstanceConditionTrue(toUpdate, v1beta1.ServiceInstanceConditionUpgradeAvailable) {
+		c.recorder.Eventf(toUpdate, corev1.EventTypeNormal, upgradeAvailableReason, upgradeAvailableMessage, planMaintenanceInfo.Version)
+	}
+	return true, nil
+}
+
+// retrieveServiceInstanceIfDue fetches the state of a ready instance from the
+// broker if its class supports retrieving instances and the retrieval
+// interval has elapsed since the last retrieval. The dashboard URL and the
+// parameters reported by the broker are recorded in the status of the
+// instance, and the Drifted condition is set according to whether they
+// differ from the spec. The instance is requeued for its next retrieval.
+func (c *controller) retrieveServiceInstanceIfDue(ctx context.Context, instance *v1beta1.ServiceInstance) error {
+	if c.instanceRetrievalInterval <= 0 || !isServiceInstanceReady(instance) || instance.Status.ExternalProperties == nil {
+		return nil
+	}
+
+	pcb := pretty.NewInstanceContextBuilder(instance)
+
+	var brokerClient osb.Client
+	var serviceClassSpec v1beta1.CommonServiceClassSpec
+	var planExternalID string
+	if instance.Spec.ClusterServiceClassSpecified() {
+		serviceClass, _, bClient, err := c.getClusterServiceClassAndClusterServiceBroker(instance)
+		if err != nil {
+			klog.V(4).Info(pcb.Messagef("Not retrieving instance: %v", err))
+			return nil
+		}
+		brokerClient = bClient
+		serviceClassSpec = serviceClass.Spec.CommonServiceClassSpec
+		planExternalID = instance.Status.ExternalProperties.ClusterServicePlanExternalID
+	} else {
+		serviceClass, _, bClient, err := c.getServiceClassAndServiceBroker(instance)
+		if err != nil {
+			klog.V(4).Info(pcb.Messagef("Not retrieving instance: %v", err))
+			return nil
+		}
+		brokerClient = bClient
+		serviceClassSpec = serviceClass.Spec.CommonServiceClassSpec
+		planExternalID = instance.Status.ExternalProperties.ServicePlanExternalID
+	}
+
+	if !serviceClassSpec.InstanceRetrievable {
+		return nil
+	}
+
+	if instance.Status.LastRetrievalTime != nil {
+		if remaining := c.instanceRetrievalInterval - time.Since(instance.Status.LastRetrievalTime.Time); remaining > 0 {
+			c.enqueueInstanceAfter(instance, remaining)
+			return nil
+		}
+	}
+
+	klog.V(4).Info(pcb.Message("Retrieving instance from the broker"))
+
+	serviceID := serviceClassSpec.ExternalID
+	request := &osb.GetInstanceRequest{
+		InstanceID: instance.Spec.ExternalID,
+		ServiceID:  &serviceID,
+	}
+	if planExternalID != "" {
+		request.PlanID = &planExternalID
+	}
+
+	toUpdate := instance.DeepCopy()
+	now := metav1.Now()
+	toUpdate.Status.LastRetrievalTime = &now
+
+	response, err := osb.WithContext(ctx, brokerClient).GetInstance(request)
+	if err != nil {
+		if isBrokerThrottledError(err) {
+			return err
+		}
+		msg := fmt.Sprintf("Error retrieving the instance from the broker: %v", err)
+		klog.Warning(pcb.Message(msg))
+		c.recorder.Event(instance, corev1.EventTypeWarning, errorRetrievingInstanceReason, msg)
+		// Record the retrieval time anyway so that the broker is not asked
+		// again before the retrieval interval has elapsed.
+		_, err := c.updateServiceInstanceStatus(toUpdate)
+		return err
+	}
+
+	if response.DashboardURL != nil && *response.DashboardURL != "" {
+		toUpdate.Status.DashboardURL = response.DashboardURL
+	}
+
+	specParameters, _, err := buildParameters(c.kubeClient, instance.Namespace, instance.Spec.ParametersFrom, instance.Spec.Parameters)
+	if err != nil {
+		msg := fmt.Sprintf("Error building the parameters of the instance: %v", err)
+		klog.Warning(pcb.Message(msg))
+		c.recorder.Event(instance, corev1.EventTypeWarning, errorWithParametersReason, msg)
+		_, err := c.updateServiceInstanceStatus(toUpdate)
+		return err
+	}
+
+	if response.Parameters != nil {
+		checksum, err := generateChecksumOfParameters(response.Parameters)
+		if err != nil {
+			return err
+		}
+		toUpdate.Status.BrokerReportedParametersChecksum = checksum
+	}
+
+	drift := findServiceInstanceDrift(planExternalID, specParameters, response)
+	wasDrifted := isServiceInstanceConditionTrue(instance, v1beta1.ServiceInstanceConditionDrifted)
+	if len(drift) > 0 {
+		msg := fmt.Sprintf(driftDetectedMessage, strings.Join(drift, "; "))
+		setServiceInstanceCondition(toUpdate, v1beta1.ServiceInstanceConditionDrifted, v1beta1.ConditionTrue, driftDetectedReason, msg)
+		if !wasDrifted {
+			c.recorder.Event(instance, corev1.EventTypeWarning, driftDetectedReason, msg)
+		}
+	} else {
+		setServiceInstanceCondition(toUpdate, v1beta1.ServiceInstanceConditionDrifted, v1beta1.ConditionFalse, inSyncReason, inSyncMessage)
+	}
+
+	if _, err := c.updateServiceInstanceStatus(toUpdate); err != nil {
+		return err
+	}
+	// The status update requeues the instance, which schedules the next
+	// retrieval.
+	return nil
+}
+
+// findServiceInstanceDrift returns descriptions of the differences between
+// the state of an instance reported by the broker and the plan and
+// parameters of the instance. Only the parameters specified for the instance
+// are compared, as brokers may report additional, defaulted parameters.
+func findServiceInstanceDrift(planExternalID string, specParameters map[string]interface{}, response *osb.GetInstanceResponse) []string {
+	var drift []string
+
+	if response.PlanID != "" && planExternalID != "" && response.PlanID != planExternalID {
+		drift = append(drift, fmt.Sprintf("plan %q instead of %q", response.PlanID, planExternalID))
+	}
+
+	if response.Parameters != nil {
+		var driftedParameters []string
+		for k, v := range specParameters {
+			if reported, ok := response.Parameters[k]; !ok || !reflect.DeepEqual(v, reported) {
+				driftedParameters = append(driftedParameters, k)
+			}
+		}
+		if len(driftedParameters) > 0 {
+			sort.Strings(driftedParameters)
+			drift = append(drift, fmt.Sprintf("parameters %s", strings.Join(driftedParameters, ", ")))
+		}
+	}
+
+	return drift
+}
+
 // updateServiceInstanceReferences updates the refs for the given instance.
 func (c *controller) updateServiceInstanceReferences(toUpdate *v1beta1.ServiceInstance) (*v1beta1.ServiceInstance, error) {
 	pcb := pretty.NewInstanceContextBuilder(toUpdate)
@@ -2046,6 +2327,9 @@ func isServiceInstancePropertiesStateEqual(s1 *v1beta1.ServiceInstanceProperties
 	if s1.ParameterChecksum != s2.ParameterChecksum {
 		return false
 	}
+	if !isMaintenanceInfoEqual(s1.MaintenanceInfo, s2.MaintenanceInfo) {
+		return false
+	}
 	if s1.UserInfo != nil || s2.UserInfo != nil {
 		u1 := s1.UserInfo
 		u2 := s2.UserInfo
@@ -2328,6 +2612,15 @@ func (c *controller) innerPrepareProvisionRequest(instance *v1beta1.ServiceInsta
 		OriginatingIdentity: rh.originatingIdentity,
 	}
 
+	// Provision at the maintenance info requested by the user, falling back
+	// to the maintenance info of the plan.
+	maintenanceInfo := instance.Spec.MaintenanceInfo
+	if maintenanceInfo == nil {
+		maintenanceInfo = planCommon.MaintenanceInfo
+	}
+	request.MaintenanceInfo = convertToOSBMaintenanceInfo(maintenanceInfo)
+	rh.inProgressProperties.MaintenanceInfo = maintenanceInfo
+
 	return request, rh.inProgressProperties, nil
 }
 
@@ -2376,6 +2669,7 @@ func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInsta
 				request.Parameters = make(map[string]interface{})
 			}
 		}
+		prepareUpdateInstanceRequestMaintenanceInfo(instance, servicePlan.Spec.MaintenanceInfo, request, rh.inProgressProperties)
 
 	} else if instance.Spec.ServiceClassSpecified() {
 		serviceClass, servicePlan, _, _, err := c.getServiceClassPlanAndServiceBroker(instance)
@@ -2415,12 +2709,64 @@ func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInsta
 				request.Parameters = make(map[string]interface{})
 			}
 		}
+		prepareUpdateInstanceRequestMaintenanceInfo(instance, servicePlan.Spec.MaintenanceInfo, request, rh.inProgressProperties)
 
 	}
 
 	return request, rh.inProgressProperties, nil
 }
 
+// prepareUpdateInstanceRequestMaintenanceInfo sets the maintenance info to be
+// sent to the broker when updating the given instance. On a plan change the
+// maintenance info of the new plan is sent; otherwise the maintenance info
+// requested in the spec of the instance is sent if it differs from the one
+// the broker knows about.
+func prepareUpdateInstanceRequestMaintenanceInfo(instance *v1beta1.ServiceInstance, planMaintenanceInfo *v1beta1.MaintenanceInfo, request *osb.UpdateInstanceRequest, inProgressProperties *v1beta1.ServiceInstancePropertiesState) {
+	var currentMaintenanceInfo *v1beta1.MaintenanceInfo
+	if instance.Status.ExternalProperties != nil {
+		currentMaintenanceInfo = instance.Status.ExternalProperties.MaintenanceInfo
+	}
+	if request.PreviousValues != nil {
+		request.PreviousValues.MaintenanceInfo = convertToOSBMaintenanceInfo(currentMaintenanceInfo)
+	}
+
+	switch {
+	case request.PlanID != nil:
+		request.MaintenanceInfo = convertToOSBMaintenanceInfo(planMaintenanceInfo)
+		inProgressProperties.MaintenanceInfo = planMaintenanceInfo
+	case instance.Spec.MaintenanceInfo != nil && !isMaintenanceInfoEqual(instance.Spec.MaintenanceInfo, currentMaintenanceInfo):
+		request.MaintenanceInfo = convertToOSBMaintenanceInfo(instance.Spec.MaintenanceInfo)
+		inProgressProperties.MaintenanceInfo = instance.Spec.MaintenanceInfo
+	default:
+		inProgressProperties.MaintenanceInfo = currentMaintenanceInfo
+	}
+}
+
+// convertToOSBMaintenanceInfo converts the given maintenance info into the
+// representation sent to the broker.
+func convertToOSBMaintenanceInfo(maintenanceInfo *v1beta1.MaintenanceInfo) *osb.MaintenanceInfo {
+	if maintenanceInfo == nil {
+		return nil
+	}
+	converted := &osb.MaintenanceInfo{
+		Version: maintenanceInfo.Version,
+	}
+	if maintenanceInfo.Description != "" {
+		description := maintenanceInfo.Description
+		converted.Description = &description
+	}
+	return converted
+}
+
+// isMaintenanceInfoEqual checks whether two maintenance infos refer to the
+// same version.
+func isMaintenanceInfoEqual(m1 *v1beta1.MaintenanceInfo, m2 *v1beta1.MaintenanceInfo) bool {
+	if m1 == nil || m2 == nil {
+		return m1 == m2
+	}
+	return m1.Version == m2.Version
+}
+
 // prepareDeprovisionRequest creates a deprovision request object to be passed
 // to the broker client to deprovision the given instance.
 func (c *controller) prepareDeprovisionRequest(instance *v1beta1.ServiceInstance) (*osb.DeprovisionRequest, *v1beta1.ServiceInstancePropertiesState, error) {
@@ -2648,6 +2994,10 @@ func (c *controller) processServiceInstanceOperationError(instance *v1beta1.Serv
 // processProvisionSuccess handles the logging and updating of a
 // ServiceInstance that has successfully been provisioned at the broker.
 func (c *controller) processProvisionSuccess(instance *v1beta1.ServiceInstance, dashboardURL *string) error {
+	var provisionStartTime *metav1.Time
+	if instance.Status.CurrentOperation == v1beta1.ServiceInstanceOperationProvision {
+		provisionStartTime = instance.Status.OperationStartTime
+	}
 	setServiceInstanceDashboardURL(instance, dashboardURL)
 	setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionReady, v1beta1.ConditionTrue, successProvisionReason, successProvisionMessage)
 	instance.Status.ExternalProperties = instance.Status.InProgressProperties
@@ -2660,6 +3010,7 @@ func (c *controller) processProvisionSuccess(instance *v1beta1.ServiceInstance,
 	}
 
 	c.removeInstanceFromRetryMap(instance)
+	c.observeServiceInstanceTimeToReady(instance, provisionStartTime)
 	c.recorder.Eventf(instance, corev1.EventTypeNormal, successProvisionReason, successProvisionMessage)
 	return nil
 }
@@ -2953,8 +3304,14 @@ func setServiceInstanceLastOperation(instance *v1beta1.ServiceInstance, operatio
 }
 
 func getServiceInstanceLastConditionState(status v1beta1.ServiceInstanceStatus) string {
-	if len(status.Conditions) > 0 {
-		condition := status.Conditions[len(status.Conditions)-1]
+	for i := len(status.Conditions) - 1; i >= 0; i-- {
+		condition := status.Conditions[i]
+		// UpgradeAvailable and Drifted do not reflect the outcome of an
+		// operation, so they are left out of the aggregated state.
+		if condition.Type == v1beta1.ServiceInstanceConditionUpgradeAvailable ||
+			condition.Type == v1beta1.ServiceInstanceConditionDrifted {
+			continue
+		}
 		if condition.Status == v1beta1.ConditionTrue {
 			return string(condition.Type)
 		}
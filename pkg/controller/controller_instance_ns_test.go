@@ -17,17 +17,17 @@ limitations under the License.
 package controller
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
-	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
-	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
-
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
 	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/fake"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
@@ -226,7 +226,7 @@ func TestPollServiceInstanceInProgressProvisioningWithOperationNamespacedRefs(t
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	err = testController.pollServiceInstance(instance)
+	err = testController.pollServiceInstance(context.Background(), instance)
 	if err != nil {
 		t.Fatalf("pollServiceInstance failed: %s", err)
 	}
@@ -289,7 +289,7 @@ func TestPollServiceInstanceSuccessProvisioningWithOperationNamespacedRefs(t *te
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	err = testController.pollServiceInstance(instance)
+	err = testController.pollServiceInstance(context.Background(), instance)
 	if err != nil {
 		t.Fatalf("pollServiceInstance failed: %s", err)
 	}
@@ -349,7 +349,7 @@ func TestPollServiceInstanceFailureProvisioningWithOperationNamespacedRefs(t *te
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	err = testController.pollServiceInstance(instance)
+	err = testController.pollServiceInstance(context.Background(), instance)
 	if err != nil {
 		t.Fatalf("pollServiceInstance failed: %s", err)
 	}
@@ -612,7 +612,7 @@ func TestPollServiceInstanceInProgressDeprovisioningWithOperationNoFinalizerName
 				t.Fatalf("Expected polling queue to not have any record of test instance")
 			}
 
-			err = testController.pollServiceInstance(instance)
+			err = testController.pollServiceInstance(context.Background(), instance)
 			if err != nil {
 				t.Fatalf("pollServiceInstance failed: %s", err)
 			}
@@ -681,7 +681,7 @@ func TestPollServiceInstanceSuccessDeprovisioningWithOperationNoFinalizerNamespa
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	err = testController.pollServiceInstance(instance)
+	err = testController.pollServiceInstance(context.Background(), instance)
 	if err != nil {
 		t.Fatalf("pollServiceInstance failed: %s", err)
 	}
@@ -749,7 +749,7 @@ func TestPollServiceInstanceFailureDeprovisioningNamespacedRefs(t *testing.T) {
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	err = testController.pollServiceInstance(instance)
+	err = testController.pollServiceInstance(context.Background(), instance)
 	if err == nil {
 		t.Fatalf("Expected pollServiceInstance to return an error but there was none")
 	}
@@ -17,6 +17,7 @@ limitations under the License.
 package controller
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -27,9 +28,6 @@ import (
 	"testing"
 	"time"
 
-	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
-	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
-
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -43,6 +41,8 @@ import (
 	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
 	"github.com/kubernetes-sigs/service-catalog/test/fake"
 	sctestutil "github.com/kubernetes-sigs/service-catalog/test/util"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/fake"
 	corev1 "k8s.io/api/core/v1"
 	clientgotesting "k8s.io/client-go/testing"
 )
@@ -2574,7 +2574,7 @@ func TestPollServiceInstanceInProgressProvisioningWithOperation(t *testing.T) {
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	err := testController.pollServiceInstance(instance)
+	err := testController.pollServiceInstance(context.Background(), instance)
 	if err != nil {
 		t.Fatalf("pollServiceInstance failed: %s", err)
 	}
@@ -2631,7 +2631,7 @@ func TestPollServiceInstanceSuccessProvisioningWithOperation(t *testing.T) {
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	err := testController.pollServiceInstance(instance)
+	err := testController.pollServiceInstance(context.Background(), instance)
 	if err != nil {
 		t.Fatalf("pollServiceInstance failed: %s", err)
 	}
@@ -2685,7 +2685,7 @@ func TestPollServiceInstanceFailureProvisioningWithOperation(t *testing.T) {
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	err := testController.pollServiceInstance(instance)
+	err := testController.pollServiceInstance(context.Background(), instance)
 	if err != nil {
 		t.Fatalf("pollServiceInstance failed: %s", err)
 	}
@@ -2769,7 +2769,7 @@ func TestPollServiceInstanceInProgressDeprovisioningWithOperationNoFinalizer(t *
 				t.Fatalf("Expected polling queue to not have any record of test instance")
 			}
 
-			err := testController.pollServiceInstance(instance)
+			err := testController.pollServiceInstance(context.Background(), instance)
 			if err != nil {
 				t.Fatalf("pollServiceInstance failed: %s", err)
 			}
@@ -2832,7 +2832,7 @@ func TestPollServiceInstanceSuccessDeprovisioningWithOperationNoFinalizer(t *tes
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	err := testController.pollServiceInstance(instance)
+	err := testController.pollServiceInstance(context.Background(), instance)
 	if err != nil {
 		t.Fatalf("pollServiceInstance failed: %s", err)
 	}
@@ -2894,7 +2894,7 @@ func TestPollServiceInstanceFailureDeprovisioning(t *testing.T) {
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	err := testController.pollServiceInstance(instance)
+	err := testController.pollServiceInstance(context.Background(), instance)
 	if err == nil {
 		t.Fatalf("Expected pollServiceInstance to return an error but there was none")
 	}
@@ -2966,7 +2966,7 @@ func TestPollServiceInstanceFailureDeprovisioningWithReconciliationTimeout(t *te
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	err := testController.pollServiceInstance(instance)
+	err := testController.pollServiceInstance(context.Background(), instance)
 	if err != nil {
 		t.Fatalf("pollServiceInstance failed: %s", err)
 	}
@@ -3041,7 +3041,7 @@ func TestPollServiceInstanceStatusGoneDeprovisioningWithOperationNoFinalizer(t *
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	err := testController.pollServiceInstance(instance)
+	err := testController.pollServiceInstance(context.Background(), instance)
 	if err != nil {
 		t.Fatalf("pollServiceInstance failed: %s", err)
 	}
@@ -3103,7 +3103,7 @@ func TestPollServiceInstanceClusterServiceBrokerTemporaryError(t *testing.T) {
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	err := testController.pollServiceInstance(instance)
+	err := testController.pollServiceInstance(context.Background(), instance)
 
 	if err == nil {
 		t.Fatal("Expected pollServiceInstance to return error")
@@ -3165,7 +3165,7 @@ func TestPollServiceInstanceClusterServiceBrokerTerminalError(t *testing.T) {
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	err := testController.pollServiceInstance(instance)
+	err := testController.pollServiceInstance(context.Background(), instance)
 
 	if err != nil {
 		t.Fatalf("pollServiceInstance failed: %v", err)
@@ -3237,7 +3237,7 @@ func TestPollServiceInstanceSuccessDeprovisioningWithOperationWithFinalizer(t *t
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	err := testController.pollServiceInstance(instance)
+	err := testController.pollServiceInstance(context.Background(), instance)
 	if err != nil {
 		t.Fatalf("pollServiceInstance failed: %s", err)
 	}
@@ -3517,7 +3517,7 @@ func TestPollServiceInstanceSuccessOnFinalRetry(t *testing.T) {
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	if err := testController.pollServiceInstance(instance); err != nil {
+	if err := testController.pollServiceInstance(context.Background(), instance); err != nil {
 		t.Fatalf("pollServiceInstance failed: %s", err)
 	}
 
@@ -3572,7 +3572,7 @@ func TestPollServiceInstanceFailureOnFinalRetry(t *testing.T) {
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	if err := testController.pollServiceInstance(instance); err == nil {
+	if err := testController.pollServiceInstance(context.Background(), instance); err == nil {
 		t.Fatalf("Expected error to be returned in order to requeue instance for orphan mitigation")
 	}
 
@@ -4107,7 +4107,7 @@ func TestPollInstanceUsingOriginatingIdentity(t *testing.T) {
 				instance.Spec.UserInfo = testUserInfo
 			}
 
-			err := testController.pollServiceInstance(instance)
+			err := testController.pollServiceInstance(context.Background(), instance)
 			if err != nil {
 				t.Fatalf("Expected pollServiceInstance to not fail while in progress")
 			}
@@ -5296,6 +5296,324 @@ func TestReconcileServiceInstanceUpdatePlan(t *testing.T) {
 	}
 }
 
+// TestReconcileServiceInstanceUpgradeMaintenanceInfo tests that requesting
+// the maintenance info of the plan in the instance spec sends an upgrade to
+// the broker and records the new maintenance info once it succeeds.
+func TestReconcileServiceInstanceUpgradeMaintenanceInfo(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		UpdateInstanceReaction: &fakeosb.UpdateInstanceReaction{
+			Response: &osb.UpdateInstanceResponse{},
+		},
+	})
+
+	plan := getTestClusterServicePlan()
+	plan.Spec.MaintenanceInfo = &v1beta1.MaintenanceInfo{Version: "2.0.0"}
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(plan)
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.Generation = 2
+	instance.Status.ReconciledGeneration = 1
+	instance.Status.ObservedGeneration = 2
+	instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+	instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusRequired
+	instance.Status.CurrentOperation = v1beta1.ServiceInstanceOperationUpdate
+	instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{
+		ClusterServicePlanExternalName: testClusterServicePlanName,
+		ClusterServicePlanExternalID:   testClusterServicePlanGUID,
+		MaintenanceInfo:                &v1beta1.MaintenanceInfo{Version: "1.0.0"},
+	}
+	instance.Status.InProgressProperties = &v1beta1.ServiceInstancePropertiesState{
+		ClusterServicePlanExternalName: testClusterServicePlanName,
+		ClusterServicePlanExternalID:   testClusterServicePlanGUID,
+		MaintenanceInfo:                &v1beta1.MaintenanceInfo{Version: "2.0.0"},
+	}
+	instance.Spec.MaintenanceInfo = &v1beta1.MaintenanceInfo{Version: "2.0.0"}
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	assertUpdateInstance(t, brokerActions[0], &osb.UpdateInstanceRequest{
+		AcceptsIncomplete: true,
+		InstanceID:        testServiceInstanceGUID,
+		ServiceID:         testClusterServiceClassGUID,
+		Context:           testContext,
+		PreviousValues: &osb.PreviousValues{
+			PlanID:          testClusterServicePlanGUID,
+			ServiceID:       testClusterServiceClassGUID,
+			MaintenanceInfo: &osb.MaintenanceInfo{Version: "1.0.0"},
+		},
+		MaintenanceInfo: &osb.MaintenanceInfo{Version: "2.0.0"},
+	})
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 2)
+
+	updatedServiceInstance, ok := assertUpdateStatus(t, actions[1], instance).(*v1beta1.ServiceInstance)
+	if !ok {
+		t.Fatalf("couldn't convert to *v1beta1.ServiceInstance")
+	}
+	assertServiceInstanceReadyTrue(t, updatedServiceInstance)
+	if e, a := "2.0.0", updatedServiceInstance.Status.ExternalProperties.MaintenanceInfo.Version; e != a {
+		t.Fatalf("unexpected maintenance info version in external properties: expected %q, got %q", e, a)
+	}
+}
+
+// TestSetServiceInstanceUpgradeAvailableCondition tests that the
+// UpgradeAvailable condition reflects whether the maintenance info of the
+// plan differs from the one the broker knows the instance to be at.
+func TestSetServiceInstanceUpgradeAvailableCondition(t *testing.T) {
+	cases := []struct {
+		name                string
+		instanceVersion     string
+		existingCondition   *v1beta1.ServiceInstanceCondition
+		planMaintenanceInfo *v1beta1.MaintenanceInfo
+		expectedChange      bool
+		expectedStatus      v1beta1.ConditionStatus
+		expectedReason      string
+	}{
+		{
+			name:           "plan without maintenance info",
+			expectedChange: false,
+		},
+		{
+			name:              "plan without maintenance info removes condition",
+			existingCondition: newServiceInstanceCondition(v1beta1.ConditionTrue, v1beta1.ServiceInstanceConditionUpgradeAvailable, upgradeAvailableReason, ""),
+			expectedChange:    true,
+		},
+		{
+			name:                "instance without maintenance info",
+			planMaintenanceInfo: &v1beta1.MaintenanceInfo{Version: "1.0.0"},
+			expectedChange:      true,
+			expectedStatus:      v1beta1.ConditionTrue,
+			expectedReason:      upgradeAvailableReason,
+		},
+		{
+			name:                "newer maintenance info",
+			instanceVersion:     "1.0.0",
+			planMaintenanceInfo: &v1beta1.MaintenanceInfo{Version: "1.1.0"},
+			expectedChange:      true,
+			expectedStatus:      v1beta1.ConditionTrue,
+			expectedReason:      upgradeAvailableReason,
+		},
+		{
+			name:                "same maintenance info",
+			instanceVersion:     "1.1.0",
+			planMaintenanceInfo: &v1beta1.MaintenanceInfo{Version: "1.1.0"},
+			expectedChange:      true,
+			expectedStatus:      v1beta1.ConditionFalse,
+			expectedReason:      upToDateReason,
+		},
+		{
+			name:                "condition already up to date",
+			instanceVersion:     "1.1.0",
+			existingCondition:   newServiceInstanceCondition(v1beta1.ConditionFalse, v1beta1.ServiceInstanceConditionUpgradeAvailable, upToDateReason, upToDateMessage),
+			planMaintenanceInfo: &v1beta1.MaintenanceInfo{Version: "1.1.0"},
+			expectedChange:      false,
+			expectedStatus:      v1beta1.ConditionFalse,
+			expectedReason:      upToDateReason,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := getTestServiceInstanceWithClusterRefs()
+			instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{}
+			if tc.instanceVersion != "" {
+				instance.Status.ExternalProperties.MaintenanceInfo = &v1beta1.MaintenanceInfo{Version: tc.instanceVersion}
+			}
+			if tc.existingCondition != nil {
+				instance.Status.Conditions = []v1beta1.ServiceInstanceCondition{*tc.existingCondition}
+			}
+
+			if e, a := tc.expectedChange, setServiceInstanceUpgradeAvailableCondition(instance, tc.planMaintenanceInfo); e != a {
+				t.Fatalf("unexpected change: expected %v, got %v", e, a)
+			}
+
+			var condition *v1beta1.ServiceInstanceCondition
+			for i := range instance.Status.Conditions {
+				if instance.Status.Conditions[i].Type == v1beta1.ServiceInstanceConditionUpgradeAvailable {
+					condition = &instance.Status.Conditions[i]
+				}
+			}
+			if tc.expectedStatus == "" {
+				if condition != nil {
+					t.Fatalf("unexpected UpgradeAvailable condition: %+v", condition)
+				}
+				return
+			}
+			if condition == nil {
+				t.Fatal("expected an UpgradeAvailable condition")
+			}
+			if e, a := tc.expectedStatus, condition.Status; e != a {
+				t.Fatalf("unexpected condition status: expected %v, got %v", e, a)
+			}
+			if e, a := tc.expectedReason, condition.Reason; e != a {
+				t.Fatalf("unexpected condition reason: expected %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+// TestReconcileServiceInstanceRetrieval tests that a ready instance of a class
+// whose instances are retrievable is fetched from the broker once the
+// retrieval interval has elapsed, and that differences between the state
+// reported by the broker and the spec are reported by the Drifted condition.
+func TestReconcileServiceInstanceRetrieval(t *testing.T) {
+	cases := []struct {
+		name               string
+		lastRetrievalTime  *metav1.Time
+		response           *osb.GetInstanceResponse
+		expectedRetrieval  bool
+		expectedDrifted    v1beta1.ConditionStatus
+		expectedDriftEvent bool
+	}{
+		{
+			name: "in sync",
+			response: &osb.GetInstanceResponse{
+				PlanID:     testClusterServicePlanGUID,
+				Parameters: map[string]interface{}{"a": "1", "b": "defaulted"},
+			},
+			expectedRetrieval: true,
+			expectedDrifted:   v1beta1.ConditionFalse,
+		},
+		{
+			name: "drifted parameters",
+			response: &osb.GetInstanceResponse{
+				PlanID:     testClusterServicePlanGUID,
+				Parameters: map[string]interface{}{"a": "2"},
+			},
+			expectedRetrieval:  true,
+			expectedDrifted:    v1beta1.ConditionTrue,
+			expectedDriftEvent: true,
+		},
+		{
+			name: "drifted plan",
+			response: &osb.GetInstanceResponse{
+				PlanID:     "other-plan",
+				Parameters: map[string]interface{}{"a": "1"},
+			},
+			expectedRetrieval:  true,
+			expectedDrifted:    v1beta1.ConditionTrue,
+			expectedDriftEvent: true,
+		},
+		{
+			name:              "retrieved recently",
+			lastRetrievalTime: &metav1.Time{Time: time.Now()},
+			expectedRetrieval: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dashboardURL := "http://dashboard"
+			if tc.response != nil {
+				tc.response.DashboardURL = &dashboardURL
+			}
+			_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+				GetInstanceReaction: &fakeosb.GetInstanceReaction{
+					Response: tc.response,
+				},
+			})
+			testController.instanceRetrievalInterval = time.Hour
+
+			class := getTestClusterServiceClass()
+			class.Spec.InstanceRetrievable = true
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(class)
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+			instance := getTestServiceInstanceWithClusterRefs()
+			instance.Generation = 1
+			instance.Status.ObservedGeneration = 1
+			instance.Status.ReconciledGeneration = 1
+			instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+			instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusRequired
+			instance.Status.Conditions = []v1beta1.ServiceInstanceCondition{
+				*newServiceInstanceReadyCondition(v1beta1.ConditionTrue, successProvisionReason, successProvisionMessage),
+			}
+			instance.Spec.Parameters = &runtime.RawExtension{Raw: []byte(`{"a":"1"}`)}
+			instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{
+				ClusterServicePlanExternalName: testClusterServicePlanName,
+				ClusterServicePlanExternalID:   testClusterServicePlanGUID,
+				Parameters:                     &runtime.RawExtension{Raw: []byte(`{"a":"1"}`)},
+				ParameterChecksum:              "checksum",
+			}
+			instance.Status.LastRetrievalTime = tc.lastRetrievalTime
+
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			brokerActions := fakeClusterServiceBrokerClient.Actions()
+			actions := fakeCatalogClient.Actions()
+			if !tc.expectedRetrieval {
+				assertNumberOfBrokerActions(t, brokerActions, 0)
+				assertNumberOfActions(t, actions, 0)
+				return
+			}
+
+			assertNumberOfBrokerActions(t, brokerActions, 1)
+			serviceID := testClusterServiceClassGUID
+			planID := testClusterServicePlanGUID
+			assertGetInstance(t, brokerActions[0], &osb.GetInstanceRequest{
+				InstanceID: testServiceInstanceGUID,
+				ServiceID:  &serviceID,
+				PlanID:     &planID,
+			})
+
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceInstance, ok := assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+			if !ok {
+				t.Fatalf("couldn't convert to *v1beta1.ServiceInstance")
+			}
+			assertServiceInstanceReadyTrue(t, updatedServiceInstance)
+			if updatedServiceInstance.Status.LastRetrievalTime == nil {
+				t.Fatal("expected the last retrieval time to be set")
+			}
+			if e, a := dashboardURL, updatedServiceInstance.Status.DashboardURL; a == nil || e != *a {
+				t.Fatalf("unexpected dashboard URL: expected %q, got %v", e, a)
+			}
+			// The parameters reported by the broker are recorded apart from
+			// the parameters that were sent to the broker.
+			if e, a := instance.Status.ExternalProperties, updatedServiceInstance.Status.ExternalProperties; !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected external properties: expected %+v, got %+v", e, a)
+			}
+			if e, a := generateChecksumOfParametersOrFail(t, tc.response.Parameters), updatedServiceInstance.Status.BrokerReportedParametersChecksum; e != a {
+				t.Fatalf("unexpected checksum of the broker reported parameters: expected %q, got %q", e, a)
+			}
+			var drifted *v1beta1.ServiceInstanceCondition
+			for i := range updatedServiceInstance.Status.Conditions {
+				if updatedServiceInstance.Status.Conditions[i].Type == v1beta1.ServiceInstanceConditionDrifted {
+					drifted = &updatedServiceInstance.Status.Conditions[i]
+				}
+			}
+			if drifted == nil {
+				t.Fatal("expected a Drifted condition")
+			}
+			if e, a := tc.expectedDrifted, drifted.Status; e != a {
+				t.Fatalf("unexpected Drifted condition status: expected %v, got %v", e, a)
+			}
+
+			events := getRecordedEvents(testController)
+			if tc.expectedDriftEvent {
+				assertNumEvents(t, events, 1)
+				if !strings.HasPrefix(events[0], corev1.EventTypeWarning+" "+driftDetectedReason) {
+					t.Fatalf("unexpected event: %v", events[0])
+				}
+			} else {
+				assertNumEvents(t, events, 0)
+			}
+		})
+	}
+}
+
 // TestReconcileServiceInstanceWithUpdateCallFailure tests that when the update
 // call to the broker fails, the ready condition becomes false, and the
 // failure condition is not set.
@@ -5767,7 +6085,7 @@ func TestPollServiceInstanceAsyncInProgressUpdating(t *testing.T) {
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	err := testController.pollServiceInstance(instance)
+	err := testController.pollServiceInstance(context.Background(), instance)
 	if err != nil {
 		t.Fatalf("pollServiceInstance failed: %s", err)
 	}
@@ -5824,7 +6142,7 @@ func TestPollServiceInstanceAsyncSuccessUpdating(t *testing.T) {
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	err := testController.pollServiceInstance(instance)
+	err := testController.pollServiceInstance(context.Background(), instance)
 	if err != nil {
 		t.Fatalf("pollServiceInstance failed: %s", err)
 	}
@@ -5878,7 +6196,7 @@ func TestPollServiceInstanceAsyncFailureUpdating(t *testing.T) {
 		t.Fatalf("Expected polling queue to not have any record of test instance")
 	}
 
-	err := testController.pollServiceInstance(instance)
+	err := testController.pollServiceInstance(context.Background(), instance)
 	if err != nil {
 		t.Fatalf("pollServiceInstance failed: %s", err)
 	}
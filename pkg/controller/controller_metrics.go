@@ -0,0 +1,196 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/metrics"
+)
+
+// The conditions the instances and bindings are counted by. Besides the Ready
+// and Failed conditions, an instance or binding can be mitigating an orphan,
+// or have an asynchronous operation in progress.
+const (
+	readyStateCondition            = "Ready"
+	failedStateCondition           = "Failed"
+	orphanMitigationStateCondition = "OrphanMitigation"
+	asyncInProgressStateCondition  = "AsyncInProgress"
+)
+
+// resourceStateKey is the set of labels an instance or binding is counted by.
+type resourceStateKey struct {
+	namespace string
+	class     string
+	plan      string
+	broker    string
+	condition string
+	status    string
+}
+
+// resourceStateCollector collects the number of instances and bindings in each
+// state from the informer caches of the controller.
+type resourceStateCollector struct {
+	c *controller
+}
+
+var _ prometheus.Collector = resourceStateCollector{}
+
+// ResourceStateCollector returns the collector of the number of instances and
+// bindings in each state.
+func (c *controller) ResourceStateCollector() prometheus.Collector {
+	return resourceStateCollector{c: c}
+}
+
+func (rc resourceStateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- metrics.ServiceInstanceCountDesc
+	ch <- metrics.ServiceBindingCountDesc
+}
+
+func (rc resourceStateCollector) Collect(ch chan<- prometheus.Metric) {
+	c := rc.c
+
+	instances, err := c.instanceLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Error listing the ServiceInstances to collect their metrics: %v", err)
+		return
+	}
+	instanceCounts := map[resourceStateKey]int{}
+	for _, instance := range instances {
+		class, plan, broker := c.getServiceInstanceClassPlanAndBrokerNames(instance)
+		ready, failed := v1beta1.ConditionUnknown, v1beta1.ConditionFalse
+		for _, condition := range instance.Status.Conditions {
+			switch condition.Type {
+			case v1beta1.ServiceInstanceConditionReady:
+				ready = condition.Status
+			case v1beta1.ServiceInstanceConditionFailed:
+				failed = condition.Status
+			}
+		}
+		countResourceStates(instanceCounts, instance.Namespace, class, plan, broker, ready, failed, instance.Status.OrphanMitigationInProgress, instance.Status.AsyncOpInProgress)
+	}
+	collectResourceStateCounts(ch, metrics.ServiceInstanceCountDesc, instanceCounts)
+
+	bindings, err := c.bindingLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Error listing the ServiceBindings to collect their metrics: %v", err)
+		return
+	}
+	bindingCounts := map[resourceStateKey]int{}
+	for _, binding := range bindings {
+		var class, plan, broker string
+		if instance, err := c.instanceLister.ServiceInstances(binding.Namespace).Get(binding.Spec.InstanceRef.Name); err == nil {
+			class, plan, broker = c.getServiceInstanceClassPlanAndBrokerNames(instance)
+		}
+		ready, failed := v1beta1.ConditionUnknown, v1beta1.ConditionFalse
+		for _, condition := range binding.Status.Conditions {
+			switch condition.Type {
+			case v1beta1.ServiceBindingConditionReady:
+				ready = condition.Status
+			case v1beta1.ServiceBindingConditionFailed:
+				failed = condition.Status
+			}
+		}
+		countResourceStates(bindingCounts, binding.Namespace, class, plan, broker, ready, failed, binding.Status.OrphanMitigationInProgress, binding.Status.AsyncOpInProgress)
+	}
+	collectResourceStateCounts(ch, metrics.ServiceBindingCountDesc, bindingCounts)
+}
+
+// countResourceStates counts a resource once for each of the conditions it is
+// counted by, with the status of the condition.
+func countResourceStates(counts map[resourceStateKey]int, namespace, class, plan, broker string, ready, failed v1beta1.ConditionStatus, orphanMitigation, asyncInProgress bool) {
+	key := resourceStateKey{namespace: namespace, class: class, plan: plan, broker: broker}
+	for condition, status := range map[string]v1beta1.ConditionStatus{
+		readyStateCondition:            ready,
+		failedStateCondition:           failed,
+		orphanMitigationStateCondition: boolConditionStatus(orphanMitigation),
+		asyncInProgressStateCondition:  boolConditionStatus(asyncInProgress),
+	} {
+		key.condition, key.status = condition, string(status)
+		counts[key]++
+	}
+}
+
+func boolConditionStatus(b bool) v1beta1.ConditionStatus {
+	if b {
+		return v1beta1.ConditionTrue
+	}
+	return v1beta1.ConditionFalse
+}
+
+func collectResourceStateCounts(ch chan<- prometheus.Metric, desc *prometheus.Desc, counts map[resourceStateKey]int) {
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(count),
+			key.namespace, key.class, key.plan, key.broker, key.condition, key.status)
+	}
+}
+
+// getServiceInstanceClassPlanAndBrokerNames returns the external names of the
+// class and plan of an instance and the name of their broker, as far as the
+// references of the instance have been resolved.
+func (c *controller) getServiceInstanceClassPlanAndBrokerNames(instance *v1beta1.ServiceInstance) (class, plan, broker string) {
+	if ref := instance.Spec.ClusterServiceClassRef; ref != nil {
+		if serviceClass, err := c.clusterServiceClassLister.Get(ref.Name); err == nil {
+			class, broker = serviceClass.Spec.ExternalName, serviceClass.Spec.ClusterServiceBrokerName
+		}
+	}
+	if ref := instance.Spec.ClusterServicePlanRef; ref != nil {
+		if servicePlan, err := c.clusterServicePlanLister.Get(ref.Name); err == nil {
+			plan = servicePlan.Spec.ExternalName
+		}
+	}
+	if ref := instance.Spec.ServiceClassRef; ref != nil && c.serviceClassLister != nil {
+		if serviceClass, err := c.serviceClassLister.ServiceClasses(instance.Namespace).Get(ref.Name); err == nil {
+			class, broker = serviceClass.Spec.ExternalName, serviceClass.Spec.ServiceBrokerName
+		}
+	}
+	if ref := instance.Spec.ServicePlanRef; ref != nil && c.servicePlanLister != nil {
+		if servicePlan, err := c.servicePlanLister.ServicePlans(instance.Namespace).Get(ref.Name); err == nil {
+			plan = servicePlan.Spec.ExternalName
+		}
+	}
+	return class, plan, broker
+}
+
+// observeServiceInstanceTimeToReady records how long the provision of an
+// instance that started at the given time took to make it ready.
+func (c *controller) observeServiceInstanceTimeToReady(instance *v1beta1.ServiceInstance, operationStartTime *metav1.Time) {
+	if operationStartTime == nil {
+		return
+	}
+	_, _, broker := c.getServiceInstanceClassPlanAndBrokerNames(instance)
+	metrics.TimeToReadySeconds.WithLabelValues(string(v1beta1.ServiceInstanceOperationProvision), broker).Observe(time.Since(operationStartTime.Time).Seconds())
+}
+
+// observeServiceBindingTimeToReady records how long the bind of a binding
+// that started at the given time took to make it ready.
+func (c *controller) observeServiceBindingTimeToReady(binding *v1beta1.ServiceBinding, operationStartTime *metav1.Time) {
+	if operationStartTime == nil {
+		return
+	}
+	var broker string
+	if instance, err := c.instanceLister.ServiceInstances(binding.Namespace).Get(binding.Spec.InstanceRef.Name); err == nil {
+		_, _, broker = c.getServiceInstanceClassPlanAndBrokerNames(instance)
+	}
+	metrics.TimeToReadySeconds.WithLabelValues(string(v1beta1.ServiceBindingOperationBind), broker).Observe(time.Since(operationStartTime.Time).Seconds())
+}
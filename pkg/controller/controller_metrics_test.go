@@ -0,0 +1,131 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/metrics"
+)
+
+func TestResourceStateCollector(t *testing.T) {
+	_, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	ready := getTestServiceInstanceWithClusterRefs()
+	ready.Status.Conditions = []v1beta1.ServiceInstanceCondition{{Type: v1beta1.ServiceInstanceConditionReady, Status: v1beta1.ConditionTrue}}
+	provisioning := getTestServiceInstanceWithClusterRefs()
+	provisioning.Name = "test-provisioning-instance"
+	provisioning.Status.AsyncOpInProgress = true
+	unresolved := getTestServiceInstance()
+	unresolved.Name = "test-unresolved-instance"
+	for _, instance := range []*v1beta1.ServiceInstance{ready, provisioning, unresolved} {
+		sharedInformers.ServiceInstances().Informer().GetStore().Add(instance)
+	}
+
+	binding := getTestServiceBinding()
+	binding.Status.Conditions = []v1beta1.ServiceBindingCondition{{Type: v1beta1.ServiceBindingConditionFailed, Status: v1beta1.ConditionTrue}}
+	binding.Status.OrphanMitigationInProgress = true
+	sharedInformers.ServiceBindings().Informer().GetStore().Add(binding)
+
+	ch := make(chan prometheus.Metric, 100)
+	testController.ResourceStateCollector().Collect(ch)
+	close(ch)
+
+	counts := map[string]float64{}
+	for metric := range ch {
+		var m dto.Metric
+		if err := metric.Write(&m); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		name := "instance"
+		if metric.Desc() == metrics.ServiceBindingCountDesc {
+			name = "binding"
+		}
+		values := []string{name}
+		for _, label := range m.Label {
+			values = append(values, label.GetValue())
+		}
+		counts[strings.Join(values, "/")] = m.GetGauge().GetValue()
+	}
+
+	expected := map[string]float64{
+		// The labels are sorted by name: broker, class, condition, namespace,
+		// plan, status
+		"instance/" + testClusterServiceBrokerName + "/" + testClusterServiceClassName + "/Ready/" + testNamespace + "/" + testClusterServicePlanName + "/True":             1,
+		"instance/" + testClusterServiceBrokerName + "/" + testClusterServiceClassName + "/Ready/" + testNamespace + "/" + testClusterServicePlanName + "/Unknown":          1,
+		"instance/" + testClusterServiceBrokerName + "/" + testClusterServiceClassName + "/Failed/" + testNamespace + "/" + testClusterServicePlanName + "/False":           2,
+		"instance/" + testClusterServiceBrokerName + "/" + testClusterServiceClassName + "/OrphanMitigation/" + testNamespace + "/" + testClusterServicePlanName + "/False": 2,
+		"instance/" + testClusterServiceBrokerName + "/" + testClusterServiceClassName + "/AsyncInProgress/" + testNamespace + "/" + testClusterServicePlanName + "/False":  1,
+		"instance/" + testClusterServiceBrokerName + "/" + testClusterServiceClassName + "/AsyncInProgress/" + testNamespace + "/" + testClusterServicePlanName + "/True":   1,
+		"instance///Ready/" + testNamespace + "//Unknown":          1,
+		"instance///Failed/" + testNamespace + "//False":           1,
+		"instance///OrphanMitigation/" + testNamespace + "//False": 1,
+		"instance///AsyncInProgress/" + testNamespace + "//False":  1,
+		// The binding refers to the ready instance
+		"binding/" + testClusterServiceBrokerName + "/" + testClusterServiceClassName + "/Ready/" + testNamespace + "/" + testClusterServicePlanName + "/Unknown":         1,
+		"binding/" + testClusterServiceBrokerName + "/" + testClusterServiceClassName + "/Failed/" + testNamespace + "/" + testClusterServicePlanName + "/True":           1,
+		"binding/" + testClusterServiceBrokerName + "/" + testClusterServiceClassName + "/OrphanMitigation/" + testNamespace + "/" + testClusterServicePlanName + "/True": 1,
+		"binding/" + testClusterServiceBrokerName + "/" + testClusterServiceClassName + "/AsyncInProgress/" + testNamespace + "/" + testClusterServicePlanName + "/False": 1,
+	}
+	if len(counts) != len(expected) {
+		t.Errorf("expected %d series, got %d: %v", len(expected), len(counts), counts)
+	}
+	for series, e := range expected {
+		if a := counts[series]; e != a {
+			t.Errorf("unexpected count of %q: expected %v, got %v", series, e, a)
+		}
+	}
+}
+
+func TestObserveServiceInstanceTimeToReady(t *testing.T) {
+	_, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+
+	observer := metrics.TimeToReadySeconds.WithLabelValues(string(v1beta1.ServiceInstanceOperationProvision), testClusterServiceBrokerName)
+	sampleCount := func() uint64 {
+		var m dto.Metric
+		if err := observer.(prometheus.Metric).Write(&m); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return m.GetHistogram().GetSampleCount()
+	}
+	before := sampleCount()
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	// An instance that was not being provisioned is not observed
+	testController.observeServiceInstanceTimeToReady(instance, nil)
+	if e, a := before, sampleCount(); e != a {
+		t.Fatalf("expected no observation, got %d", a-e)
+	}
+
+	startTime := metav1.NewTime(time.Now().Add(-time.Minute))
+	testController.observeServiceInstanceTimeToReady(instance, &startTime)
+	if e, a := before+1, sampleCount(); e != a {
+		t.Fatalf("expected one observation, got %d", a-before)
+	}
+}
@@ -17,10 +17,12 @@ limitations under the License.
 package controller
 
 import (
+	"crypto/tls"
 	"fmt"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -28,10 +30,11 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog"
 
-	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/cataloghistory"
 	"github.com/kubernetes-sigs/service-catalog/pkg/metrics"
 	"github.com/kubernetes-sigs/service-catalog/pkg/pretty"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
 )
 
 // the Message strings have a terminating period and space so they can
@@ -116,19 +119,26 @@ func (c *controller) reconcileServiceBrokerKey(key string) error {
 func (c *controller) serviceBrokerClient(broker *v1beta1.ServiceBroker) (osb.Client, error) {
 	pcb := pretty.NewServiceBrokerContextBuilder(broker)
 	authConfig, err := c.getAuthCredentialsFromServiceBroker(broker)
+	var clientCert *tls.Certificate
+	if err == nil {
+		clientCert, err = c.getClientCertificateFromServiceBroker(broker)
+	}
 	if err != nil {
 		s := fmt.Sprintf("Error getting broker auth credentials: %s", err)
 		klog.Info(pcb.Message(s))
 		c.recorder.Event(broker, corev1.EventTypeWarning, errorAuthCredentialsReason, s)
-		if err := c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorFetchingCatalogReason, errorFetchingCatalogMessage+s); err != nil {
+		if err := c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorAuthCredentialsReason, errorAuthCredentialsMessage+s); err != nil {
 			return nil, err
 		}
 		return nil, err
 	}
 
 	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig, c.OSBAPITimeOut)
+	if clientCert != nil {
+		clientConfig.TLSConfig = &tls.Config{Certificates: []tls.Certificate{*clientCert}}
+	}
 
-	brokerClient, err := c.brokerClientManager.UpdateBrokerClient(NewServiceBrokerKey(broker.Namespace, broker.Name), clientConfig)
+	brokerClient, err := c.brokerClientManager.UpdateBrokerClient(NewServiceBrokerKey(broker.Namespace, broker.Name), clientConfig, broker.Spec.RateLimit)
 	if err != nil {
 		s := fmt.Sprintf("Error creating client for broker %q: %s", broker.Name, err)
 		klog.Info(pcb.Message(s))
@@ -149,30 +159,56 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 	pcb := pretty.NewServiceBrokerContextBuilder(broker)
 	klog.V(4).Infof(pcb.Message("Processing"))
 
+	var brokerClient osb.Client
+	if broker.DeletionTimestamp == nil {
+		// The client is updated on every sync, so that rotated credentials
+		// are used right away and missing credentials are reported.
+		var err error
+		brokerClient, err = c.serviceBrokerClient(broker)
+		if err != nil {
+			return err
+		}
+
+		// The health of the broker is checked on its own schedule, whether
+		// or not its catalog is due to be relisted. The status update of a
+		// health check requeues the broker, which then relists it if due.
+		if checked, err := c.checkServiceBrokerHealthIfDue(broker); checked || err != nil {
+			return err
+		}
+	}
+
 	// * If the broker's ready condition is true and the RelistBehavior has been
 	// set to Manual, do not reconcile it.
 	// * If the broker's ready condition is true and the relist interval has not
 	// elapsed, do not reconcile it.
-	if !shouldReconcileServiceBroker(broker, time.Now(), c.brokerRelistInterval) {
+	// * If the circuit breaker of the broker is open, reconcile it so that
+	// its status reflects the circuit, and it is probed once the circuit
+	// half-opens.
+	if !shouldReconcileServiceBroker(broker, time.Now(), c.brokerRelistInterval) &&
+		!c.brokerClientManager.CircuitOpen(NewServiceBrokerKey(broker.Namespace, broker.Name)) {
 		return nil
 	}
 
 	if broker.DeletionTimestamp == nil { // Add or update
 		klog.V(4).Info(pcb.Message("Processing adding/update event"))
 
-		brokerClient, err := c.serviceBrokerClient(broker)
-		if err != nil {
-			return err
-		}
-
 		// get the broker's catalog
 		now := metav1.Now()
 		brokerCatalog, err := brokerClient.GetCatalog()
 		if err != nil {
+			if isBrokerThrottledError(err) {
+				return err
+			}
 			s := fmt.Sprintf("Error getting broker catalog: %s", err)
 			klog.Warning(pcb.Message(s))
-			c.recorder.Eventf(broker, corev1.EventTypeWarning, errorFetchingCatalogReason, s)
-			if err := c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorFetchingCatalogReason, errorFetchingCatalogMessage+s); err != nil {
+			reason, message := errorFetchingCatalogReason, errorFetchingCatalogMessage
+			if isBrokerAuthenticationError(err) {
+				reason, message = errorAuthenticatingReason, errorAuthenticatingMessage
+			} else if isBrokerUnavailableError(err) {
+				reason, message = errorBrokerUnavailableReason, errorBrokerUnavailableMessage
+			}
+			c.recorder.Eventf(broker, corev1.EventTypeWarning, reason, s)
+			if err := c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, reason, message+s); err != nil {
 				return err
 			}
 			if broker.Status.OperationStartTime == nil {
@@ -212,6 +248,38 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 			}
 		}
 
+		catalogHistoryHash, err := c.recordCatalogSnapshot(pcb, broker.Namespace, broker.Name, cataloghistory.ServiceBrokerLabels(broker.Name), brokerCatalog, broker.Status.CatalogHistoryHash)
+		if err != nil {
+			s := fmt.Sprintf("Error recording the catalog in the catalog history: %v", err)
+			klog.Warning(pcb.Message(s))
+			c.recorder.Event(broker, corev1.EventTypeWarning, errorRecordingCatalogSnapshotReason, s)
+		} else if catalogHistoryHash != broker.Status.CatalogHistoryHash {
+			toUpdate := broker.DeepCopy()
+			toUpdate.Status.CatalogHistoryHash = catalogHistoryHash
+			updated, err := c.serviceCatalogClient.ServiceBrokers(broker.Namespace).UpdateStatus(toUpdate)
+			if err != nil {
+				klog.Error(pcb.Messagef("Error updating the catalog history hash: %v", err))
+				return err
+			}
+			broker = updated
+		}
+
+		// with the Proxy relist behavior, a catalog identical to the last one
+		// that was reconciled is neither converted nor reconciled again
+		brokerKey := NewServiceBrokerKey(broker.Namespace, broker.Name)
+		hash := ""
+		if broker.Spec.RelistBehavior == v1beta1.ServiceBrokerRelistBehaviorProxy {
+			var unchanged bool
+			hash, unchanged = c.isServiceBrokerCatalogUnchanged(pcb, brokerKey, &broker.ObjectMeta, &broker.Status.CommonServiceBrokerStatus, brokerCatalog, func() (int, int, error) {
+				return c.countServiceBrokerCatalogEntries(broker)
+			})
+			if unchanged {
+				klog.V(4).Info(pcb.Message("Catalog is unchanged since the last relist; not reconciling it"))
+				return c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionTrue, successFetchedCatalogReason, successFetchedCatalogMessage)
+			}
+			c.brokerClientManager.SetLastCatalog(brokerKey, nil)
+		}
+
 		// get the existing services and plans for this broker so that we can
 		// detect when services and plans are removed from the broker's
 		// catalog
@@ -239,6 +307,10 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 
 		klog.V(5).Info(pcb.Message("Successfully converted catalog payload from to service-catalog API"))
 
+		// count the classes and plans that are actually written so that the
+		// changes made to the catalog can be reported
+		diff := &catalogDiffBuilder{}
+
 		// reconcile the serviceClasses that were part of the broker's catalog
 		// payload
 		for _, payloadServiceClass := range payloadServiceClasses {
@@ -250,7 +322,8 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 			}
 
 			klog.V(4).Info(pcb.Messagef("Reconciling %s", pretty.ServiceClassName(payloadServiceClass)))
-			if err := c.reconcileServiceClassFromServiceBrokerCatalog(broker, payloadServiceClass, existingServiceClass); err != nil {
+			change, err := c.reconcileServiceClassFromServiceBrokerCatalog(broker, payloadServiceClass, existingServiceClass)
+			if err != nil {
 				s := fmt.Sprintf(
 					"Error reconciling %s (broker %q): %s",
 					pretty.ServiceClassName(payloadServiceClass), broker.Name, err,
@@ -264,6 +337,8 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 				return err
 			}
 
+			diff.classChanged(change, payloadServiceClass.Spec.ExternalName)
+
 			klog.V(5).Info(pcb.Messagef("Reconciled %s", pretty.ServiceClassName(payloadServiceClass)))
 		}
 
@@ -290,6 +365,7 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 				}
 				return err
 			}
+			diff.classRemoved(existingServiceClass.Spec.ExternalName)
 		}
 
 		// reconcile the plans that were part of the broker's catalog payload
@@ -305,7 +381,8 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 				"ServiceBroker %q: reconciling %s",
 				broker.Name, pretty.ServicePlanName(payloadServicePlan),
 			)
-			if err := c.reconcileServicePlanFromServiceBrokerCatalog(broker, payloadServicePlan, existingServicePlan); err != nil {
+			change, err := c.reconcileServicePlanFromServiceBrokerCatalog(broker, payloadServicePlan, existingServicePlan)
+			if err != nil {
 				s := fmt.Sprintf(
 					"Error reconciling %s: %s",
 					pretty.ServicePlanName(payloadServicePlan), err,
@@ -316,6 +393,7 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 					errorSyncingCatalogMessage+s)
 				return err
 			}
+			diff.planChanged(change, payloadServicePlan.Spec.ExternalName)
 			klog.V(5).Info(pcb.Messagef("Reconciled %s", pretty.ServicePlanName(payloadServicePlan)))
 
 		}
@@ -343,15 +421,26 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 				}
 				return err
 			}
+			diff.planRemoved(existingServicePlan.Spec.ExternalName)
 		}
 
 		// everything worked correctly; update the broker's ready condition to
-		// status true
-		if err := c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionTrue, successFetchedCatalogReason, successFetchedCatalogMessage); err != nil {
+		// status true and record the changes made to the catalog
+		toUpdate := broker.DeepCopy()
+		toUpdate.Status.LastCatalogDiff = &diff.diff
+		if err := c.updateServiceBrokerCondition(toUpdate, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionTrue, successFetchedCatalogReason, successFetchedCatalogMessage); err != nil {
 			return err
 		}
 
-		c.recorder.Event(broker, corev1.EventTypeNormal, successFetchedCatalogReason, successFetchedCatalogMessage)
+		c.recorder.Event(broker, corev1.EventTypeNormal, successFetchedCatalogReason, diff.message())
+
+		if hash != "" {
+			c.brokerClientManager.SetLastCatalog(brokerKey, &CatalogDigest{
+				Hash:           hash,
+				ServiceClasses: len(payloadServiceClasses),
+				ServicePlans:   len(payloadServicePlans),
+			})
+		}
 
 		// Update metrics with the number of serviceclass and serviceplans from this broker
 		metrics.BrokerServiceClassCount.WithLabelValues(broker.Name).Set(float64(len(payloadServiceClasses)))
@@ -411,6 +500,12 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 			}
 		}
 
+		if err := c.deleteCatalogHistory(broker.Namespace, cataloghistory.ServiceBrokerLabels(broker.Name)); err != nil {
+			s := fmt.Sprintf("Error deleting the catalog history: %v", err)
+			klog.Warning(pcb.Message(s))
+			c.recorder.Event(broker, corev1.EventTypeWarning, errorDeletingCatalogHistoryReason, s)
+		}
+
 		if err := c.updateServiceBrokerCondition(
 			broker,
 			v1beta1.ServiceBrokerConditionReady,
@@ -430,6 +525,8 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 		// delete the metrics associated with this broker
 		metrics.BrokerServiceClassCount.DeleteLabelValues(broker.Name)
 		metrics.BrokerServicePlanCount.DeleteLabelValues(broker.Name)
+		brokerKey := NewServiceBrokerKey(broker.Namespace, broker.Name)
+		metrics.BrokerUp.DeleteLabelValues(brokerKey.String())
 		return nil
 	}
 
@@ -441,7 +538,7 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 // listed. The serviceClass parameter is the serviceClass from the broker's
 // catalog payload. The existingServiceClass parameter is the serviceClass
 // that already exists for the given broker with this serviceClass' k8s name.
-func (c *controller) reconcileServiceClassFromServiceBrokerCatalog(broker *v1beta1.ServiceBroker, serviceClass, existingServiceClass *v1beta1.ServiceClass) error {
+func (c *controller) reconcileServiceClassFromServiceBrokerCatalog(broker *v1beta1.ServiceBroker, serviceClass, existingServiceClass *v1beta1.ServiceClass) (catalogEntryChange, error) {
 	pcb := pretty.NewServiceBrokerContextBuilder(broker)
 	serviceClass.Spec.ServiceBrokerName = broker.Name
 
@@ -451,7 +548,7 @@ func (c *controller) reconcileServiceClassFromServiceBrokerCatalog(broker *v1bet
 			// we expect _not_ to find a service class this way, so a not-
 			// found error is expected and legitimate.
 			if !errors.IsNotFound(err) {
-				return err
+				return catalogEntryUnchanged, err
 			}
 		} else {
 			// we do not expect to find an existing service class if we were
@@ -462,17 +559,17 @@ func (c *controller) reconcileServiceClassFromServiceBrokerCatalog(broker *v1bet
 					pretty.ServiceClassName(serviceClass), otherServiceClass.Spec.ServiceBrokerName,
 				)
 				klog.Error(pcb.Message(errMsg))
-				return fmt.Errorf(errMsg)
+				return catalogEntryUnchanged, fmt.Errorf(errMsg)
 			}
 		}
 
 		klog.V(5).Info(pcb.Messagef("Fresh %s; creating", pretty.ServiceClassName(serviceClass)))
 		if _, err := c.serviceCatalogClient.ServiceClasses(broker.Namespace).Create(serviceClass); err != nil {
 			klog.Error(pcb.Messagef("Error creating %s: %v", pretty.ServiceClassName(serviceClass), err))
-			return err
+			return catalogEntryUnchanged, err
 		}
 
-		return nil
+		return catalogEntryAdded, nil
 	}
 
 	if existingServiceClass.Spec.ExternalID != serviceClass.Spec.ExternalID {
@@ -481,15 +578,14 @@ func (c *controller) reconcileServiceClassFromServiceBrokerCatalog(broker *v1bet
 			pretty.ServiceClassName(serviceClass), existingServiceClass.Name, serviceClass.Name,
 		)
 		klog.Error(pcb.Message(errMsg))
-		return fmt.Errorf(errMsg)
+		return catalogEntryUnchanged, fmt.Errorf(errMsg)
 	}
 
-	klog.V(5).Info(pcb.Messagef("Found existing %s; updating", pretty.ServiceClassName(serviceClass)))
-
 	// There was an existing service class -- project the update onto it and
-	// update it.
+	// update it if anything changed.
 	toUpdate := existingServiceClass.DeepCopy()
 	toUpdate.Spec.BindingRetrievable = serviceClass.Spec.BindingRetrievable
+	toUpdate.Spec.InstanceRetrievable = serviceClass.Spec.InstanceRetrievable
 	toUpdate.Spec.Bindable = serviceClass.Spec.Bindable
 	toUpdate.Spec.PlanUpdatable = serviceClass.Spec.PlanUpdatable
 	toUpdate.Spec.Tags = serviceClass.Spec.Tags
@@ -498,10 +594,19 @@ func (c *controller) reconcileServiceClassFromServiceBrokerCatalog(broker *v1bet
 	toUpdate.Spec.ExternalName = serviceClass.Spec.ExternalName
 	toUpdate.Spec.ExternalMetadata = serviceClass.Spec.ExternalMetadata
 
-	updatedServiceClass, err := c.serviceCatalogClient.ServiceClasses(broker.Namespace).Update(toUpdate)
-	if err != nil {
-		klog.Error(pcb.Messagef("Error updating %s: %v", pretty.ServiceClassName(serviceClass), err))
-		return err
+	change := catalogEntryUnchanged
+	updatedServiceClass := toUpdate
+	if !apiequality.Semantic.DeepEqual(existingServiceClass.Spec, toUpdate.Spec) {
+		klog.V(5).Info(pcb.Messagef("Found existing %s; updating", pretty.ServiceClassName(serviceClass)))
+		var err error
+		updatedServiceClass, err = c.serviceCatalogClient.ServiceClasses(broker.Namespace).Update(toUpdate)
+		if err != nil {
+			klog.Error(pcb.Messagef("Error updating %s: %v", pretty.ServiceClassName(serviceClass), err))
+			return catalogEntryUnchanged, err
+		}
+		change = catalogEntryUpdated
+	} else {
+		klog.V(5).Info(pcb.Messagef("Found existing %s; unchanged", pretty.ServiceClassName(serviceClass)))
 	}
 
 	if updatedServiceClass.Status.RemovedFromBrokerCatalog {
@@ -513,18 +618,19 @@ func (c *controller) reconcileServiceClassFromServiceBrokerCatalog(broker *v1bet
 			klog.Warning(pcb.Message(s))
 			c.recorder.Eventf(broker, corev1.EventTypeWarning, errorSyncingCatalogReason, s)
 			if err := c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorSyncingCatalogReason, errorSyncingCatalogMessage+s); err != nil {
-				return err
+				return catalogEntryUnchanged, err
 			}
-			return err
+			return catalogEntryUnchanged, err
 		}
+		change = catalogEntryAdded
 	}
 
-	return nil
+	return change, nil
 }
 
 // reconcileServicePlanFromServiceBrokerCatalog reconciles a
 // ServicePlan after the ServiceClass's catalog has been re-listed.
-func (c *controller) reconcileServicePlanFromServiceBrokerCatalog(broker *v1beta1.ServiceBroker, servicePlan, existingServicePlan *v1beta1.ServicePlan) error {
+func (c *controller) reconcileServicePlanFromServiceBrokerCatalog(broker *v1beta1.ServiceBroker, servicePlan, existingServicePlan *v1beta1.ServicePlan) (catalogEntryChange, error) {
 	pcb := pretty.NewServiceBrokerContextBuilder(broker)
 	servicePlan.Spec.ServiceBrokerName = broker.Name
 
@@ -534,7 +640,7 @@ func (c *controller) reconcileServicePlanFromServiceBrokerCatalog(broker *v1beta
 			// we expect _not_ to find a service class this way, so a not-
 			// found error is expected and legitimate.
 			if !errors.IsNotFound(err) {
-				return err
+				return catalogEntryUnchanged, err
 			}
 		} else {
 			// we do not expect to find an existing service class if we were
@@ -546,7 +652,7 @@ func (c *controller) reconcileServicePlanFromServiceBrokerCatalog(broker *v1beta
 					pretty.ServicePlanName(servicePlan), otherServicePlan.Spec.ServiceBrokerName,
 				)
 				klog.Error(pcb.Message(errMsg))
-				return fmt.Errorf(errMsg)
+				return catalogEntryUnchanged, fmt.Errorf(errMsg)
 			}
 		}
 
@@ -554,10 +660,10 @@ func (c *controller) reconcileServicePlanFromServiceBrokerCatalog(broker *v1beta
 		// not exist.  Create a new ServicePlan.
 		if _, err := c.serviceCatalogClient.ServicePlans(broker.Namespace).Create(servicePlan); err != nil {
 			klog.Error(pcb.Messagef("Error creating %s: %v", pretty.ServicePlanName(servicePlan), err))
-			return err
+			return catalogEntryUnchanged, err
 		}
 
-		return nil
+		return catalogEntryAdded, nil
 	}
 
 	if existingServicePlan.Spec.ExternalID != servicePlan.Spec.ExternalID {
@@ -566,13 +672,11 @@ func (c *controller) reconcileServicePlanFromServiceBrokerCatalog(broker *v1beta
 			pretty.ServicePlanName(servicePlan), existingServicePlan.Spec.ExternalID, servicePlan.Spec.ExternalID,
 		)
 		klog.Error(pcb.Message(errMsg))
-		return fmt.Errorf(errMsg)
+		return catalogEntryUnchanged, fmt.Errorf(errMsg)
 	}
 
-	klog.V(5).Info(pcb.Messagef("Found existing %s; updating", pretty.ServicePlanName(servicePlan)))
-
 	// There was an existing service plan -- project the update onto it and
-	// update it.
+	// update it if anything changed.
 	toUpdate := existingServicePlan.DeepCopy()
 	toUpdate.Spec.Description = servicePlan.Spec.Description
 	toUpdate.Spec.Bindable = servicePlan.Spec.Bindable
@@ -582,11 +686,21 @@ func (c *controller) reconcileServicePlanFromServiceBrokerCatalog(broker *v1beta
 	toUpdate.Spec.InstanceCreateParameterSchema = servicePlan.Spec.InstanceCreateParameterSchema
 	toUpdate.Spec.InstanceUpdateParameterSchema = servicePlan.Spec.InstanceUpdateParameterSchema
 	toUpdate.Spec.ServiceBindingCreateParameterSchema = servicePlan.Spec.ServiceBindingCreateParameterSchema
-
-	updatedPlan, err := c.serviceCatalogClient.ServicePlans(broker.Namespace).Update(toUpdate)
-	if err != nil {
-		klog.Error(pcb.Messagef("Error updating %s: %v", pretty.ServicePlanName(servicePlan), err))
-		return err
+	toUpdate.Spec.MaintenanceInfo = servicePlan.Spec.MaintenanceInfo
+
+	change := catalogEntryUnchanged
+	updatedPlan := toUpdate
+	if !apiequality.Semantic.DeepEqual(existingServicePlan.Spec, toUpdate.Spec) {
+		klog.V(5).Info(pcb.Messagef("Found existing %s; updating", pretty.ServicePlanName(servicePlan)))
+		var err error
+		updatedPlan, err = c.serviceCatalogClient.ServicePlans(broker.Namespace).Update(toUpdate)
+		if err != nil {
+			klog.Error(pcb.Messagef("Error updating %s: %v", pretty.ServicePlanName(servicePlan), err))
+			return catalogEntryUnchanged, err
+		}
+		change = catalogEntryUpdated
+	} else {
+		klog.V(5).Info(pcb.Messagef("Found existing %s; unchanged", pretty.ServicePlanName(servicePlan)))
 	}
 
 	if updatedPlan.Status.RemovedFromBrokerCatalog {
@@ -599,13 +713,14 @@ func (c *controller) reconcileServicePlanFromServiceBrokerCatalog(broker *v1beta
 			klog.Error(pcb.Message(s))
 			c.recorder.Eventf(broker, corev1.EventTypeWarning, errorSyncingCatalogReason, s)
 			if err := c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorSyncingCatalogReason, errorSyncingCatalogMessage+s); err != nil {
-				return err
+				return catalogEntryUnchanged, err
 			}
-			return err
+			return catalogEntryUnchanged, err
 		}
+		change = catalogEntryAdded
 	}
 
-	return nil
+	return change, nil
 }
 
 // updateCommonStatusCondition updates the common ready condition for the given CommonServiceBrokerStatus
@@ -699,6 +814,38 @@ func (c *controller) updateServiceBrokerFinalizers(
 	return err
 }
 
+// countServiceBrokerCatalogEntries returns the number of ServiceClasses and
+// ServicePlans of the broker that are still in its catalog, as seen by the
+// informers.
+func (c *controller) countServiceBrokerCatalogEntries(broker *v1beta1.ServiceBroker) (int, int, error) {
+	selector := labels.SelectorFromSet(labels.Set{
+		v1beta1.GroupName + "/" + v1beta1.FilterSpecServiceBrokerName: broker.Name,
+	})
+	serviceClasses, err := c.serviceClassLister.ServiceClasses(broker.Namespace).List(selector)
+	if err != nil {
+		return 0, 0, err
+	}
+	servicePlans, err := c.servicePlanLister.ServicePlans(broker.Namespace).List(selector)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	serviceClassCount := 0
+	for _, serviceClass := range serviceClasses {
+		if !serviceClass.Status.RemovedFromBrokerCatalog && isServiceCatalogManagedResource(serviceClass) {
+			serviceClassCount++
+		}
+	}
+	servicePlanCount := 0
+	for _, servicePlan := range servicePlans {
+		if !servicePlan.Status.RemovedFromBrokerCatalog && isServiceCatalogManagedResource(servicePlan) {
+			servicePlanCount++
+		}
+	}
+
+	return serviceClassCount, servicePlanCount, nil
+}
+
 func (c *controller) getCurrentServiceClassesAndPlansForNamespacedBroker(broker *v1beta1.ServiceBroker) ([]v1beta1.ServiceClass, []v1beta1.ServicePlan, error) {
 	pcb := pretty.NewServiceBrokerContextBuilder(broker)
 	labelSelector := labels.SelectorFromSet(labels.Set{
@@ -766,8 +913,13 @@ func convertServicePlanListToMap(list []v1beta1.ServicePlan) map[string]*v1beta1
 }
 
 func getServiceBrokerLastConditionState(status v1beta1.CommonServiceBrokerStatus) string {
-	if len(status.Conditions) > 0 {
-		condition := status.Conditions[len(status.Conditions)-1]
+	for i := len(status.Conditions) - 1; i >= 0; i-- {
+		condition := status.Conditions[i]
+		// Healthy is reported independently of the reconciliation of the
+		// broker, so it is left out of the aggregated state.
+		if condition.Type == v1beta1.ServiceBrokerConditionHealthy {
+			continue
+		}
 		if condition.Status == v1beta1.ConditionTrue {
 			return string(condition.Type)
 		}
@@ -26,8 +26,8 @@ import (
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
 	"github.com/kubernetes-sigs/service-catalog/test/fake"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
 
-	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -282,7 +282,7 @@ func TestReconcileServiceClassFromServiceBrokerCatalog(t *testing.T) {
 				sharedInformers.ServiceClasses().Informer().GetStore().Add(tc.listerServiceClass)
 			}
 
-			err = testController.reconcileServiceClassFromServiceBrokerCatalog(broker, tc.newServiceClass, tc.existingServiceClass)
+			_, err = testController.reconcileServiceClassFromServiceBrokerCatalog(broker, tc.newServiceClass, tc.existingServiceClass)
 			if err != nil {
 				if !tc.shouldError {
 					t.Fatalf("unexpected error from method under test: %v", err)
@@ -387,7 +387,7 @@ func TestReconcileServicePlanFromServiceBrokerCatalog(t *testing.T) {
 				sharedInformers.ServicePlans().Informer().GetStore().Add(tc.listerServicePlan)
 			}
 
-			err = testController.reconcileServicePlanFromServiceBrokerCatalog(broker, tc.newServicePlan, tc.existingServicePlan)
+			_, err = testController.reconcileServicePlanFromServiceBrokerCatalog(broker, tc.newServicePlan, tc.existingServicePlan)
 			if err != nil {
 				if !tc.shouldError {
 					t.Fatalf("unexpected error from method under test: %v", err)
@@ -441,13 +441,14 @@ func TestReconcileServiceBrokerExistingServiceClassAndServicePlan(t *testing.T)
 	}
 
 	actions := fakeCatalogClient.Actions()
-	assertNumberOfActions(t, actions, 6)
+	assertNumberOfActions(t, actions, 5)
 	assertList(t, actions[0], &v1beta1.ServiceClass{}, listRestrictions)
 	assertList(t, actions[1], &v1beta1.ServicePlan{}, listRestrictions)
-	assertUpdate(t, actions[2], testServiceClass)
-	assertCreate(t, actions[3], testServicePlan)
+	// the existing class is identical to the one in the catalog, so it is
+	// not updated
+	assertCreate(t, actions[2], testServicePlan)
 
-	updatedServiceBroker := assertUpdateStatus(t, actions[5], getTestServiceBroker())
+	updatedServiceBroker := assertUpdateStatus(t, actions[4], getTestServiceBroker())
 	assertServiceBrokerReadyTrue(t, updatedServiceBroker)
 
 	// verify no kube resources created
@@ -462,4 +463,9 @@ func TestReconcileServiceBrokerExistingServiceClassAndServicePlan(t *testing.T)
 	if updateObject.Status.LastConditionState != "Ready" {
 		t.Fatalf("LastConditionState has unexpected value. Expected: %v, got: %v", "Ready", updateObject.Status.LastConditionState)
 	}
+
+	expectedDiff := v1beta1.CatalogDiff{AddedServicePlans: 2}
+	if updateObject.Status.LastCatalogDiff == nil || *updateObject.Status.LastCatalogDiff != expectedDiff {
+		t.Fatalf("LastCatalogDiff has unexpected value. Expected: %+v, got: %+v", expectedDiff, updateObject.Status.LastCatalogDiff)
+	}
 }
@@ -17,15 +17,21 @@ limitations under the License.
 package controller
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"reflect"
 	"runtime/debug"
+	"strings"
 	"testing"
 	"time"
 
-	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
-	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
 	"sigs.k8s.io/yaml"
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
@@ -42,12 +48,16 @@ import (
 
 	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
 	"github.com/kubernetes-sigs/service-catalog/test/fake"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/fake"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/informers"
 	clientgofake "k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 )
 
@@ -552,6 +562,64 @@ func getTestBearerAuthSecret() *corev1.Secret {
 	}
 }
 
+func getTestClusterBrokerTLSAuthInfo() *v1beta1.ClusterServiceBrokerAuthInfo {
+	return &v1beta1.ClusterServiceBrokerAuthInfo{
+		TLS: &v1beta1.ClusterTLSAuthConfig{
+			SecretRef: &v1beta1.ObjectReference{Namespace: "test-ns", Name: "auth-secret"},
+		},
+	}
+}
+
+// getTestTLSAuthSecret returns a kubernetes.io/tls secret holding a freshly
+// generated self-signed client certificate and its key
+func getTestTLSAuthSecret(t *testing.T) *corev1.Secret {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "service-catalog"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	return &corev1.Secret{
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+			corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		},
+	}
+}
+
+// newTestSecretLister returns a Secret lister holding the given secrets
+func newTestSecretLister(secrets ...*corev1.Secret) corev1listers.SecretLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, secret := range secrets {
+		indexer.Add(secret)
+	}
+	return corev1listers.NewSecretLister(indexer)
+}
+
+// newTestCatalogHistoryLister returns a ConfigMap lister holding the given
+// catalog snapshots
+func newTestCatalogHistoryLister(snapshots ...*corev1.ConfigMap) corev1listers.ConfigMapLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, snapshot := range snapshots {
+		indexer.Add(snapshot)
+	}
+	return corev1listers.NewConfigMapLister(indexer)
+}
+
 // a bindable service class wired to the result of getTestClusterServiceBroker()
 func getTestClusterServiceClass() *v1beta1.ClusterServiceClass {
 	broker := getTestClusterServiceBroker()
@@ -1778,6 +1846,60 @@ func TestConvertAndFilterCatalog(t *testing.T) {
 			plans:   []string{"Eastwatch-by-the-Sea", "OldOak", "Queensgate"},
 			catalog: largeTestCatalog,
 		},
+		{
+			name: "filter bindable classes",
+			restrictions: &v1beta1.CatalogRestrictions{
+				ServiceClass: []string{"spec.bindable=true", "spec.planUpdatable!=true"},
+			},
+			classes: []string{"Archonei", "Arrax", "Balerion"},
+			plans:   []string{"Goldengrove", "Eastwatch-by-the-Sea", "OldOak", "Ironrath", "Queensgate"},
+			catalog: largeTestCatalog,
+		},
+		{
+			name: "filter classes by externalMetadata",
+			restrictions: &v1beta1.CatalogRestrictions{
+				ServiceClass: []string{"externalMetadata.Pyke=ThreeTowers"},
+			},
+			classes: []string{"Archonei"},
+			plans:   []string{"Goldengrove"},
+			catalog: largeTestCatalog,
+		},
+		{
+			name: "filter classes by regular expression",
+			restrictions: &v1beta1.CatalogRestrictions{
+				ServiceClass: []string{"spec.externalName matches ^Ar(chonei|rax)$"},
+				ServicePlan:  []string{"spec.externalName notmatches Oak$"},
+			},
+			classes: []string{"Archonei", "Arrax"},
+			plans:   []string{"Goldengrove", "Eastwatch-by-the-Sea"},
+			catalog: largeTestCatalog,
+		},
+		{
+			name: "filter plans by prefix",
+			restrictions: &v1beta1.CatalogRestrictions{
+				ServicePlan: []string{"spec.externalName startswith Queen"},
+			},
+			classes: []string{"Balerion"},
+			plans:   []string{"Queensgate"},
+			catalog: largeTestCatalog,
+		},
+		{
+			name: "bad regular expression",
+			restrictions: &v1beta1.CatalogRestrictions{
+				ServiceClass: []string{"spec.externalName matches (Archonei"},
+			},
+			catalog: largeTestCatalog,
+			error:   true,
+		},
+		{
+			name: "filter plans by externalMetadata",
+			restrictions: &v1beta1.CatalogRestrictions{
+				ServicePlan: []string{"externalMetadata.Nightsong in (CrowsNest, Feastfires)"},
+			},
+			classes: []string{"Archonei", "Arrax"},
+			plans:   []string{"Goldengrove", "Eastwatch-by-the-Sea"},
+			catalog: largeTestCatalog,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1933,6 +2055,56 @@ func strPtr(s string) *string {
 	return &s
 }
 
+func TestCatalogConversionMaintenanceInfo(t *testing.T) {
+	description := "security fixes"
+	catalog := &osb.CatalogResponse{
+		Services: []osb.Service{
+			{
+				ID:   "service-id",
+				Name: "service",
+				Plans: []osb.Plan{
+					{
+						ID:   "plan-with-maintenance-info-id",
+						Name: "with-maintenance-info",
+						MaintenanceInfo: &osb.MaintenanceInfo{
+							Version:     "1.2.0",
+							Description: &description,
+						},
+					},
+					{
+						ID:   "plan-without-maintenance-info-id",
+						Name: "without-maintenance-info",
+					},
+				},
+			},
+		},
+	}
+
+	_, plans, err := convertAndFilterCatalog(catalog, nil, emptyServiceClasses, emptyServicePlans)
+	if err != nil {
+		t.Fatalf("Failed to convertAndFilterCatalog: %v", err)
+	}
+	if len(plans) != 2 {
+		t.Fatalf("Expected 2 plans, got %d", len(plans))
+	}
+
+	expected := &v1beta1.MaintenanceInfo{Version: "1.2.0", Description: description}
+	if !reflect.DeepEqual(plans[0].Spec.MaintenanceInfo, expected) {
+		t.Errorf("Unexpected maintenance info: %v", diff.ObjectReflectDiff(expected, plans[0].Spec.MaintenanceInfo))
+	}
+	if plans[1].Spec.MaintenanceInfo != nil {
+		t.Errorf("Expected no maintenance info, got %+v", plans[1].Spec.MaintenanceInfo)
+	}
+
+	servicePlans, err := convertServicePlans("test-ns", catalog.Services[0].Plans, "service-id", nil)
+	if err != nil {
+		t.Fatalf("Failed to convertServicePlans: %v", err)
+	}
+	if !reflect.DeepEqual(servicePlans[0].Spec.MaintenanceInfo, expected) {
+		t.Errorf("Unexpected maintenance info: %v", diff.ObjectReflectDiff(expected, servicePlans[0].Spec.MaintenanceInfo))
+	}
+}
+
 func TestCatalogConversionClusterServicePlanBindable(t *testing.T) {
 	catalog := &osb.CatalogResponse{}
 	err := json.Unmarshal([]byte(testCatalogForClusterServicePlanBindableOverride), &catalog)
@@ -2092,6 +2264,44 @@ const testCatalogForClusterServiceClassAndPlanWithInvalidExternalIDCharacters =
     }
 ]}`
 
+func TestCatalogDiffNames(t *testing.T) {
+	var manyNames []string
+	for i := 0; i < 20; i++ {
+		manyNames = append(manyNames, fmt.Sprintf("plan-%02d", i))
+	}
+
+	cases := []struct {
+		name     string
+		names    []string
+		expected string
+	}{
+		{
+			name:     "no names",
+			expected: "0",
+		},
+		{
+			name:     "sorted names",
+			names:    []string{"small", "large", "medium"},
+			expected: "3 (large, medium, small)",
+		},
+		{
+			name:     "truncated names",
+			names:    manyNames,
+			expected: "20 (plan-00, plan-01, plan-02, plan-03, plan-04, plan-05, plan-06, plan-07, plan-08, plan-09, plan-10 and 9 more)",
+		},
+		{
+			name:     "name longer than the limit",
+			names:    []string{"a", strings.Repeat("b", maxCatalogDiffNamesLength)},
+			expected: "2 (a and 1 more)",
+		},
+	}
+	for _, tc := range cases {
+		if e, a := tc.expected, catalogDiffNames(tc.names); e != a {
+			t.Errorf("%s: unexpected names; %s", tc.name, expectedGot(e, a))
+		}
+	}
+}
+
 func TestCatalogConversionInvalidCharactersInExternalID(t *testing.T) {
 	catalog := &osb.CatalogResponse{}
 	err := json.Unmarshal([]byte(testCatalogForClusterServiceClassAndPlanWithInvalidExternalIDCharacters), &catalog)
@@ -2303,6 +2513,56 @@ func TestGenerateEscapedName(t *testing.T) {
 	}
 }
 
+func TestGetClientCertificate(t *testing.T) {
+	validSecret := getTestTLSAuthSecret(t)
+	otherSecret := getTestTLSAuthSecret(t)
+	cases := []struct {
+		name   string
+		data   map[string][]byte
+		errMsg string
+	}{
+		{
+			name: "valid certificate and key",
+			data: validSecret.Data,
+		},
+		{
+			name:   "missing certificate",
+			data:   map[string][]byte{corev1.TLSPrivateKeyKey: validSecret.Data[corev1.TLSPrivateKeyKey]},
+			errMsg: "auth secret didn't contain tls.crt",
+		},
+		{
+			name:   "missing key",
+			data:   map[string][]byte{corev1.TLSCertKey: validSecret.Data[corev1.TLSCertKey]},
+			errMsg: "auth secret didn't contain tls.key",
+		},
+		{
+			name: "key not matching the certificate",
+			data: map[string][]byte{
+				corev1.TLSCertKey:       validSecret.Data[corev1.TLSCertKey],
+				corev1.TLSPrivateKeyKey: otherSecret.Data[corev1.TLSPrivateKeyKey],
+			},
+			errMsg: "auth secret contains an invalid client certificate",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cert, err := getClientCertificate(&corev1.Secret{Data: tc.data})
+			if tc.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+					t.Fatalf("expected error containing %q, got %v", tc.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cert == nil || len(cert.Certificate) != 1 {
+				t.Fatalf("expected a certificate chain of one certificate, got %v", cert)
+			}
+		})
+	}
+}
+
 func TestIsClusterServiceBrokerReady(t *testing.T) {
 	cases := []struct {
 		name  string
@@ -2440,6 +2700,7 @@ func newTestController(t *testing.T, config fakeosb.FakeClientConfiguration) (
 	testController, err := NewController(
 		fakeKubeClient,
 		k8sInformers.Secrets(),
+		k8sInformers.ConfigMaps(),
 		fakeCatalogClient.ServicecatalogV1beta1(),
 		serviceCatalogSharedInformers.ClusterServiceBrokers(),
 		serviceCatalogSharedInformers.ServiceBrokers(),
@@ -2458,6 +2719,14 @@ func newTestController(t *testing.T, config fakeosb.FakeClientConfiguration) (
 		DefaultClusterIDConfigMapName,
 		DefaultClusterIDConfigMapNamespace,
 		60*time.Second,
+		0,
+		0,
+		0,
+		0,
+		DefaultCatalogHistoryNamespace,
+		0,
+		0,
+		0,
 	)
 
 	if err != nil {
@@ -2736,6 +3005,20 @@ func assertClusterServiceBrokerCondition(t *testing.T, obj runtime.Object, condi
 	}
 }
 
+func assertClusterServiceBrokerLastCatalogDiff(t *testing.T, obj runtime.Object, diff v1beta1.CatalogDiff) {
+	broker, ok := obj.(*v1beta1.ClusterServiceBroker)
+	if !ok {
+		fatalf(t, "Couldn't convert object %+v into a *v1beta1.ClusterServiceBroker", obj)
+	}
+
+	if broker.Status.LastCatalogDiff == nil {
+		fatalf(t, "expected LastCatalogDiff to be set, but was not")
+	}
+	if e, a := diff, *broker.Status.LastCatalogDiff; e != a {
+		fatalf(t, "unexpected LastCatalogDiff; expected %+v, got %+v", e, a)
+	}
+}
+
 func assertClusterServiceBrokerOperationStartTimeSet(t *testing.T, obj runtime.Object, isOperationStartTimeSet bool) {
 	broker, ok := obj.(*v1beta1.ClusterServiceBroker)
 	if !ok {
@@ -3967,6 +4250,16 @@ func assertUpdateInstance(t *testing.T, action fakeosb.Action, request *osb.Upda
 	}
 }
 
+func assertGetInstance(t *testing.T, action fakeosb.Action, request *osb.GetInstanceRequest) {
+	if e, a := fakeosb.GetInstance, action.Type; e != a {
+		fatalf(t, "unexpected action type; expected %v, got %v", e, a)
+	}
+
+	if e, a := request, action.Request; !reflect.DeepEqual(e, a) {
+		fatalf(t, "unexpected diff in get instance request: %v\nexpected %+v\ngot      %+v", diff.ObjectReflectDiff(e, a), e, a)
+	}
+}
+
 func assertDeprovision(t *testing.T, action fakeosb.Action, request *osb.DeprovisionRequest) {
 	if e, a := fakeosb.DeprovisionInstance, action.Type; e != a {
 		fatalf(t, "unexpected action type; expected %v, got %v", e, a)
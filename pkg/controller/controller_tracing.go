@@ -0,0 +1,46 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/tracing"
+)
+
+// serviceInstanceSpanAttributes returns the attributes identifying the given
+// instance in the spans of its reconciliation. The external ID is recorded
+// under the same key as in the spans of the requests to the broker.
+func serviceInstanceSpanAttributes(instance *v1beta1.ServiceInstance) []tracing.Attribute {
+	return []tracing.Attribute{
+		tracing.String("k8s.namespace.name", instance.Namespace),
+		tracing.String("servicecatalog.serviceinstance.name", instance.Name),
+		tracing.String("servicecatalog.serviceinstance.uid", string(instance.UID)),
+		tracing.String("osb.instance_id", instance.Spec.ExternalID),
+	}
+}
+
+// serviceBindingSpanAttributes returns the attributes identifying the given
+// binding in the spans of its reconciliation.
+func serviceBindingSpanAttributes(binding *v1beta1.ServiceBinding) []tracing.Attribute {
+	return []tracing.Attribute{
+		tracing.String("k8s.namespace.name", binding.Namespace),
+		tracing.String("servicecatalog.servicebinding.name", binding.Name),
+		tracing.String("servicecatalog.servicebinding.uid", string(binding.UID)),
+		tracing.String("servicecatalog.serviceinstance.name", binding.Spec.InstanceRef.Name),
+		tracing.String("osb.binding_id", binding.Spec.ExternalID),
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog"
+)
+
+// oauth2TokenExpiryDelta is how long before its expiry a cached access token
+// is refreshed, so that it does not expire while a request to the broker is
+// in flight.
+const oauth2TokenExpiryDelta = 30 * time.Second
+
+// oauth2Credentials identifies an OAuth2 client and the access tokens it
+// requests. A change of any of the fields, for example when the client secret
+// is rotated, results in a new access token being requested.
+type oauth2Credentials struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	// Scopes is the space separated list of scopes to request.
+	Scopes string
+}
+
+type oauth2Token struct {
+	accessToken string
+	// expiry is the time the token expires, or zero if the token endpoint
+	// did not report its lifetime, in which case the token is used until
+	// the credentials change.
+	expiry time.Time
+}
+
+// oauth2TokenResponse is the successful response of a token endpoint, as
+// defined in section 5.1 of RFC 6749.
+type oauth2TokenResponse struct {
+	AccessToken string      `json:"access_token"`
+	TokenType   string      `json:"token_type"`
+	ExpiresIn   json.Number `json:"expires_in"`
+}
+
+// oauth2ErrorResponse is the error response of a token endpoint, as defined
+// in section 5.2 of RFC 6749.
+type oauth2ErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// oauth2TokenCache obtains access tokens through the OAuth2 client
+// credentials grant and caches them until shortly before they expire.
+type oauth2TokenCache struct {
+	mu     sync.Mutex
+	tokens map[oauth2Credentials]oauth2Token
+
+	client *http.Client
+	now    func() time.Time
+}
+
+// newOAuth2TokenCache creates an oauth2TokenCache whose requests to token
+// endpoints time out after the given duration.
+func newOAuth2TokenCache(timeout time.Duration) *oauth2TokenCache {
+	return &oauth2TokenCache{
+		tokens: map[oauth2Credentials]oauth2Token{},
+		client: &http.Client{Timeout: timeout},
+		now:    time.Now,
+	}
+}
+
+// Token returns a valid access token for the given credentials, requesting a
+// new one from the token endpoint if there is no cached token or the cached
+// token is about to expire.
+func (c *oauth2TokenCache) Token(creds oauth2Credentials) (string, error) {
+	now := c.now()
+
+	c.mu.Lock()
+	token, found := c.tokens[creds]
+	c.mu.Unlock()
+	if found && (token.expiry.IsZero() || now.Add(oauth2TokenExpiryDelta).Before(token.expiry)) {
+		return token.accessToken, nil
+	}
+
+	klog.V(4).Infof("Requesting OAuth2 access token for client %q from %s", creds.ClientID, creds.TokenURL)
+	token, err := c.requestToken(creds, now)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Forget the expired tokens, including those of credentials that are no
+	// longer used by any broker.
+	for k, t := range c.tokens {
+		if !t.expiry.IsZero() && !now.Before(t.expiry) {
+			delete(c.tokens, k)
+		}
+	}
+	c.tokens[creds] = token
+	return token.accessToken, nil
+}
+
+func (c *oauth2TokenCache) requestToken(creds oauth2Credentials, now time.Time) (oauth2Token, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if creds.Scopes != "" {
+		form.Set("scope", creds.Scopes)
+	}
+	req, err := http.NewRequest(http.MethodPost, creds.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return oauth2Token{}, fmt.Errorf("invalid OAuth2 token URL: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	// Section 2.3.1 of RFC 6749 requires the client credentials to be form
+	// encoded before they are used as basic auth credentials.
+	req.SetBasicAuth(url.QueryEscape(creds.ClientID), url.QueryEscape(creds.ClientSecret))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return oauth2Token{}, fmt.Errorf("error requesting OAuth2 access token: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return oauth2Token{}, fmt.Errorf("error reading OAuth2 token response: %v", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		errResp := oauth2ErrorResponse{}
+		if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error != "" {
+			if errResp.ErrorDescription != "" {
+				return oauth2Token{}, fmt.Errorf("OAuth2 token request failed with status %d: %s: %s", resp.StatusCode, errResp.Error, errResp.ErrorDescription)
+			}
+			return oauth2Token{}, fmt.Errorf("OAuth2 token request failed with status %d: %s", resp.StatusCode, errResp.Error)
+		}
+		return oauth2Token{}, fmt.Errorf("OAuth2 token request failed with status %d", resp.StatusCode)
+	}
+
+	tokenResp := oauth2TokenResponse{}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return oauth2Token{}, fmt.Errorf("error parsing OAuth2 token response: %v", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return oauth2Token{}, fmt.Errorf("OAuth2 token response didn't contain an access token")
+	}
+	if tokenResp.TokenType != "" && !strings.EqualFold(tokenResp.TokenType, "bearer") {
+		return oauth2Token{}, fmt.Errorf("unsupported OAuth2 token type %q", tokenResp.TokenType)
+	}
+
+	token := oauth2Token{accessToken: tokenResp.AccessToken}
+	if tokenResp.ExpiresIn != "" {
+		expiresIn, err := tokenResp.ExpiresIn.Int64()
+		if err != nil {
+			return oauth2Token{}, fmt.Errorf("invalid expires_in in OAuth2 token response: %v", err)
+		}
+		if expiresIn > 0 {
+			token.expiry = now.Add(time.Duration(expiresIn) * time.Second)
+		}
+	}
+	return token, nil
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// newTestTokenEndpoint starts a token endpoint issuing numbered access tokens
+// to the client "client-id" with the secret "client-secret", and returns the
+// number of tokens issued so far along with the server.
+func newTestTokenEndpoint(t *testing.T, expiresIn string) (*int, *httptest.Server) {
+	issued := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unexpected error parsing token request: %v", err)
+		}
+		if e, a := "client_credentials", r.PostForm.Get("grant_type"); e != a {
+			t.Errorf("unexpected grant type: expected %q, got %q", e, a)
+		}
+		if e, a := "catalog:read catalog:write", r.PostForm.Get("scope"); e != a {
+			t.Errorf("unexpected scope: expected %q, got %q", e, a)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if id, secret, ok := r.BasicAuth(); !ok || id != "client-id" || secret != "client-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"invalid_client","error_description":"unknown client"}`)
+			return
+		}
+		issued++
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer"%s}`, issued, expiresIn)
+	}))
+	return &issued, server
+}
+
+func testOAuth2Credentials(tokenURL string) oauth2Credentials {
+	return oauth2Credentials{
+		TokenURL:     tokenURL,
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		Scopes:       "catalog:read catalog:write",
+	}
+}
+
+func TestOAuth2TokenCacheCachesTokens(t *testing.T) {
+	issued, server := newTestTokenEndpoint(t, `,"expires_in":3600`)
+	defer server.Close()
+
+	now := time.Now()
+	cache := newOAuth2TokenCache(time.Minute)
+	cache.now = func() time.Time { return now }
+	creds := testOAuth2Credentials(server.URL)
+
+	for i := 0; i < 2; i++ {
+		token, err := cache.Token(creds)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if e, a := "token-1", token; e != a {
+			t.Fatalf("unexpected token: expected %q, got %q", e, a)
+		}
+	}
+	if *issued != 1 {
+		t.Fatalf("expected the token to be requested once, got %d requests", *issued)
+	}
+
+	// The token is refreshed shortly before it expires
+	now = now.Add(time.Hour - oauth2TokenExpiryDelta)
+	token, err := cache.Token(creds)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := "token-2", token; e != a {
+		t.Fatalf("unexpected token: expected %q, got %q", e, a)
+	}
+}
+
+func TestOAuth2TokenCacheWithoutExpiry(t *testing.T) {
+	issued, server := newTestTokenEndpoint(t, "")
+	defer server.Close()
+
+	now := time.Now()
+	cache := newOAuth2TokenCache(time.Minute)
+	cache.now = func() time.Time { return now }
+	creds := testOAuth2Credentials(server.URL)
+
+	if _, err := cache.Token(creds); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	now = now.Add(24 * time.Hour)
+	if _, err := cache.Token(creds); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *issued != 1 {
+		t.Fatalf("expected a token without expiry to be requested once, got %d requests", *issued)
+	}
+
+	// A rotated client secret results in a new token request
+	creds.ClientSecret = "rotated-secret"
+	_, err := cache.Token(creds)
+	if err == nil || !strings.Contains(err.Error(), "OAuth2 token request failed with status 401: invalid_client: unknown client") {
+		t.Fatalf("expected an invalid_client error, got %v", err)
+	}
+}
+
+func TestOAuth2TokenCacheInvalidResponses(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		body   string
+		errMsg string
+	}{
+		{
+			name:   "server error",
+			status: http.StatusInternalServerError,
+			body:   "oops",
+			errMsg: "OAuth2 token request failed with status 500",
+		},
+		{
+			name:   "missing access token",
+			status: http.StatusOK,
+			body:   `{"token_type":"Bearer"}`,
+			errMsg: "OAuth2 token response didn't contain an access token",
+		},
+		{
+			name:   "unsupported token type",
+			status: http.StatusOK,
+			body:   `{"access_token":"token","token_type":"mac"}`,
+			errMsg: `unsupported OAuth2 token type "mac"`,
+		},
+		{
+			name:   "invalid expiry",
+			status: http.StatusOK,
+			body:   `{"access_token":"token","expires_in":"soon"}`,
+			errMsg: "error parsing OAuth2 token response",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.body)
+			}))
+			defer server.Close()
+
+			_, err := newOAuth2TokenCache(time.Minute).Token(testOAuth2Credentials(server.URL))
+			if err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Fatalf("expected error containing %q, got %v", tc.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestGetOAuth2Credentials(t *testing.T) {
+	secret := &corev1.Secret{
+		Data: map[string][]byte{
+			v1beta1.OAuth2ClientIDKey:     []byte("client-id"),
+			v1beta1.OAuth2ClientSecretKey: []byte("client-secret"),
+			v1beta1.OAuth2TokenURLKey:     []byte("https://auth.example.com/token\n"),
+			v1beta1.OAuth2ScopesKey:       []byte(" catalog:read\ncatalog:write "),
+		},
+	}
+	creds, err := getOAuth2Credentials(secret)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := testOAuth2Credentials("https://auth.example.com/token"), creds; e != a {
+		t.Fatalf("unexpected credentials: expected %+v, got %+v", e, a)
+	}
+
+	for _, key := range []string{v1beta1.OAuth2ClientIDKey, v1beta1.OAuth2ClientSecretKey, v1beta1.OAuth2TokenURLKey} {
+		incomplete := secret.DeepCopy()
+		delete(incomplete.Data, key)
+		if _, err := getOAuth2Credentials(incomplete); err == nil || !strings.Contains(err.Error(), "auth secret didn't contain "+key) {
+			t.Errorf("expected missing %s error, got %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+)
+
+// defaultServiceAccountTokenExpirationSeconds is the lifetime of the service
+// account tokens requested for brokers that don't specify one.
+const defaultServiceAccountTokenExpirationSeconds = int64(60 * 60)
+
+// serviceAccountTokenRefreshRatio is the fraction of the lifetime of a service
+// account token after which a new token is requested, the same as the kubelet
+// uses for projected service account token volumes.
+const serviceAccountTokenRefreshRatio = 0.8
+
+// serviceAccountTokenKey identifies the tokens requested for a broker.
+type serviceAccountTokenKey struct {
+	Namespace         string
+	Name              string
+	Audience          string
+	ExpirationSeconds int64
+}
+
+// newServiceAccountTokenKey returns the key of the tokens of the given service
+// account, applying the default token lifetime if none is given.
+func newServiceAccountTokenKey(namespace, name, audience string, expirationSeconds *int64) serviceAccountTokenKey {
+	key := serviceAccountTokenKey{
+		Namespace:         namespace,
+		Name:              name,
+		Audience:          audience,
+		ExpirationSeconds: defaultServiceAccountTokenExpirationSeconds,
+	}
+	if expirationSeconds != nil {
+		key.ExpirationSeconds = *expirationSeconds
+	}
+	return key
+}
+
+type serviceAccountToken struct {
+	token     string
+	refreshAt time.Time
+	expiry    time.Time
+}
+
+// serviceAccountTokenCache requests bound service account tokens through the
+// TokenRequest API and caches them until most of their lifetime has elapsed.
+type serviceAccountTokenCache struct {
+	mu     sync.Mutex
+	tokens map[serviceAccountTokenKey]serviceAccountToken
+
+	kubeClient kubernetes.Interface
+	now        func() time.Time
+}
+
+// newServiceAccountTokenCache creates a serviceAccountTokenCache requesting
+// tokens with the given client.
+func newServiceAccountTokenCache(kubeClient kubernetes.Interface) *serviceAccountTokenCache {
+	return &serviceAccountTokenCache{
+		tokens:     map[serviceAccountTokenKey]serviceAccountToken{},
+		kubeClient: kubeClient,
+		now:        time.Now,
+	}
+}
+
+// Token returns a valid token of the service account identified by the given
+// key, requesting a new one if there is no cached token or the cached token
+// is due to be refreshed.
+func (c *serviceAccountTokenCache) Token(key serviceAccountTokenKey) (string, error) {
+	now := c.now()
+
+	c.mu.Lock()
+	token, found := c.tokens[key]
+	c.mu.Unlock()
+	if found && now.Before(token.refreshAt) {
+		return token.token, nil
+	}
+
+	// A token without an audience is accepted by the API server, and must
+	// never be sent to a broker.
+	if key.Audience == "" {
+		return "", fmt.Errorf("no audience set for the tokens of service account %s/%s", key.Namespace, key.Name)
+	}
+
+	klog.V(4).Infof("Requesting token for service account %s/%s", key.Namespace, key.Name)
+	request := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         []string{key.Audience},
+			ExpirationSeconds: &key.ExpirationSeconds,
+		},
+	}
+	response, err := c.kubeClient.CoreV1().ServiceAccounts(key.Namespace).CreateToken(key.Name, request)
+	if err != nil {
+		return "", fmt.Errorf("error requesting token for service account %s/%s: %v", key.Namespace, key.Name, err)
+	}
+	if response.Status.Token == "" {
+		return "", fmt.Errorf("token request for service account %s/%s returned an empty token", key.Namespace, key.Name)
+	}
+
+	// The API server may shorten the requested lifetime, so the refresh is
+	// based on the expiration it reports.
+	expiry := response.Status.ExpirationTimestamp.Time
+	if !expiry.After(now) {
+		expiry = now.Add(time.Duration(key.ExpirationSeconds) * time.Second)
+	}
+	token = serviceAccountToken{
+		token:     response.Status.Token,
+		refreshAt: now.Add(time.Duration(float64(expiry.Sub(now)) * serviceAccountTokenRefreshRatio)),
+		expiry:    expiry,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Forget the expired tokens, including those of brokers that no longer
+	// use service account token auth.
+	for k, t := range c.tokens {
+		if !now.Before(t.expiry) {
+			delete(c.tokens, k)
+		}
+	}
+	c.tokens[key] = token
+	return token.token, nil
+}
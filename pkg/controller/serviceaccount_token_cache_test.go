@@ -0,0 +1,174 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	fakeosb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/fake"
+)
+
+// addTokenRequestReactor makes the given client issue numbered tokens for
+// token requests, expiring after the requested lifetime, and returns the
+// token requests received so far.
+func addTokenRequestReactor(client *clientgofake.Clientset, now func() time.Time) *[]clientgotesting.CreateAction {
+	requests := []clientgotesting.CreateAction{}
+	client.PrependReactor("create", "serviceaccounts", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		createAction := action.(clientgotesting.CreateAction)
+		requests = append(requests, createAction)
+		request := createAction.GetObject().(*authenticationv1.TokenRequest)
+		return true, &authenticationv1.TokenRequest{
+			Status: authenticationv1.TokenRequestStatus{
+				Token:               fmt.Sprintf("token-%d", len(requests)),
+				ExpirationTimestamp: metav1.NewTime(now().Add(time.Duration(*request.Spec.ExpirationSeconds) * time.Second)),
+			},
+		}, nil
+	})
+	return &requests
+}
+
+func TestServiceAccountTokenCacheRefreshesTokens(t *testing.T) {
+	now := time.Now()
+	clock := func() time.Time { return now }
+	client := &clientgofake.Clientset{}
+	requests := addTokenRequestReactor(client, clock)
+	cache := newServiceAccountTokenCache(client)
+	cache.now = clock
+	key := newServiceAccountTokenKey("test-ns", "broker-client", "https://broker.example.com", nil)
+
+	for i := 0; i < 2; i++ {
+		token, err := cache.Token(key)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if e, a := "token-1", token; e != a {
+			t.Fatalf("unexpected token: expected %q, got %q", e, a)
+		}
+	}
+	if len(*requests) != 1 {
+		t.Fatalf("expected the token to be requested once, got %d requests", len(*requests))
+	}
+	request := (*requests)[0]
+	if e, a := "test-ns", request.GetNamespace(); e != a {
+		t.Fatalf("unexpected namespace: expected %q, got %q", e, a)
+	}
+	spec := request.GetObject().(*authenticationv1.TokenRequest).Spec
+	if len(spec.Audiences) != 1 || spec.Audiences[0] != "https://broker.example.com" {
+		t.Fatalf("unexpected audiences: %v", spec.Audiences)
+	}
+	if e, a := defaultServiceAccountTokenExpirationSeconds, *spec.ExpirationSeconds; e != a {
+		t.Fatalf("unexpected expiration: expected %d, got %d", e, a)
+	}
+
+	// The token is refreshed once 80% of its lifetime has elapsed
+	now = now.Add(47 * time.Minute)
+	if token, _ := cache.Token(key); token != "token-1" {
+		t.Fatalf("expected the cached token before the refresh, got %q", token)
+	}
+	now = now.Add(time.Minute)
+	if token, _ := cache.Token(key); token != "token-2" {
+		t.Fatalf("expected a refreshed token, got %q", token)
+	}
+}
+
+func TestServiceAccountTokenCacheErrors(t *testing.T) {
+	client := &clientgofake.Clientset{}
+	client.PrependReactor("create", "serviceaccounts", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, nil, fmt.Errorf("serviceaccounts \"broker-client\" is forbidden")
+	})
+	cache := newServiceAccountTokenCache(client)
+
+	_, err := cache.Token(newServiceAccountTokenKey("test-ns", "broker-client", "https://broker.example.com", nil))
+	if err == nil || !strings.Contains(err.Error(), "error requesting token for service account test-ns/broker-client") {
+		t.Fatalf("expected a token request error, got %v", err)
+	}
+}
+
+func TestServiceAccountTokenCacheRequiresAudience(t *testing.T) {
+	client := &clientgofake.Clientset{}
+	requests := addTokenRequestReactor(client, time.Now)
+	cache := newServiceAccountTokenCache(client)
+
+	_, err := cache.Token(newServiceAccountTokenKey("test-ns", "broker-client", "", nil))
+	if err == nil || !strings.Contains(err.Error(), "no audience set for the tokens of service account test-ns/broker-client") {
+		t.Fatalf("expected a missing audience error, got %v", err)
+	}
+	if len(*requests) != 0 {
+		t.Fatalf("expected no token request, got %d", len(*requests))
+	}
+}
+
+func TestGetAuthCredentialsWithServiceAccountToken(t *testing.T) {
+	fakeKubeClient, _, _, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{})
+	requests := addTokenRequestReactor(fakeKubeClient, time.Now)
+	expirationSeconds := int64(600)
+
+	clusterBroker := getTestClusterServiceBrokerWithAuth(&v1beta1.ClusterServiceBrokerAuthInfo{
+		ServiceAccountToken: &v1beta1.ClusterServiceAccountTokenAuthConfig{
+			ServiceAccountRef: &v1beta1.ObjectReference{Namespace: "broker-ns", Name: "broker-client"},
+			Audience:          "https://broker.example.com",
+			ExpirationSeconds: &expirationSeconds,
+		},
+	})
+	authConfig, err := testController.getAuthCredentialsFromClusterServiceBroker(clusterBroker)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if authConfig.BearerConfig == nil || authConfig.BearerConfig.Token != "token-1" {
+		t.Fatalf("expected the service account token as bearer token, got %+v", authConfig)
+	}
+
+	broker := getTestServiceBrokerWithAuth(&v1beta1.ServiceBrokerAuthInfo{
+		ServiceAccountToken: &v1beta1.ServiceAccountTokenAuthConfig{
+			ServiceAccountRef: &v1beta1.LocalObjectReference{Name: "broker-client"},
+			Audience:          "https://broker.example.com",
+		},
+	})
+	authConfig, err = testController.getAuthCredentialsFromServiceBroker(broker)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if authConfig.BearerConfig == nil || authConfig.BearerConfig.Token != "token-2" {
+		t.Fatalf("expected the service account token as bearer token, got %+v", authConfig)
+	}
+
+	if len(*requests) != 2 {
+		t.Fatalf("expected 2 token requests, got %d", len(*requests))
+	}
+	if e, a := "broker-ns", (*requests)[0].GetNamespace(); e != a {
+		t.Fatalf("unexpected namespace of the cluster broker token request: expected %q, got %q", e, a)
+	}
+	if e, a := expirationSeconds, *(*requests)[0].GetObject().(*authenticationv1.TokenRequest).Spec.ExpirationSeconds; e != a {
+		t.Fatalf("unexpected expiration: expected %d, got %d", e, a)
+	}
+	if e, a := broker.Namespace, (*requests)[1].GetNamespace(); e != a {
+		t.Fatalf("unexpected namespace of the broker token request: expected %q, got %q", e, a)
+	}
+}
@@ -17,36 +17,45 @@ limitations under the License.
 package filter
 
 import (
-	"fmt"
 	"regexp"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/labels"
 )
 
-var conditionalsRegex = regexp.MustCompile("=|==|!=| in | notin ")
+var conditionalsRegex = regexp.MustCompile("=|==|!=| in | notin |\\s+(" +
+	MatchesOperator + "|" + NotMatchesOperator + "|" + StartsWithOperator + "|" + EndsWithOperator + ")\\s")
 
 // CreatePredicate creates the Predicate that will be used to
-// test if acceptance is allowed for service classes.
+// test if acceptance is allowed for service classes. Restrictions using one
+// of the match operators (matches, notmatches, startswith, endswith) hold a
+// single requirement; all other restrictions are label selector requirements.
 func CreatePredicate(restrictions []string) (Predicate, error) {
 	// default is no requirements
-	requirements := ""
-	if len(restrictions) > 0 {
-		requirements = string(restrictions[0])
-
-		for i := 1; i < len(restrictions); i++ {
-			requirements = fmt.Sprintf("%s, %s", requirements, string(restrictions[i]))
+	requirements := []string{}
+	matches := []*matchRequirement{}
+	for _, restriction := range restrictions {
+		match, ok, err := parseMatchRequirement(restriction)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matches = append(matches, match)
+		} else {
+			requirements = append(requirements, restriction)
 		}
 	}
 
-	selector, err := labels.Parse(requirements)
+	selector, err := labels.Parse(strings.Join(requirements, ", "))
 	if err != nil {
 		return nil, err
 	}
-	predicate := internalPredicate{selector: selector}
+	predicate := internalPredicate{selector: selector, matches: matches}
 	return predicate, nil
 }
 
-// ConvertToSelector converts Predicate to a labels.Selector
+// ConvertToSelector converts Predicate to a labels.Selector. It fails if the
+// predicate has requirements using one of the match operators.
 func ConvertToSelector(p Predicate) (labels.Selector, error) {
 	return labels.Parse(p.String())
 }
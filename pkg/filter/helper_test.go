@@ -52,6 +52,31 @@ func TestCreatePredicate(t *testing.T) {
 			},
 			predicate: "name in (Bar,Foo),name notin (Barf,Baz)",
 		},
+		{
+			name: "valid match restrictions",
+			restrictions: []string{
+				"spec.externalName matches ^azure-sql-(basic|premium), v2$",
+				"name in (Foo)",
+				"  spec.externalName   startswith   azure- ",
+				"spec.externalName endswith -db",
+				"spec.externalName notmatches preview",
+			},
+			predicate: "name in (Foo),spec.externalName matches ^azure-sql-(basic|premium), v2$,spec.externalName startswith azure-,spec.externalName endswith -db,spec.externalName notmatches preview",
+		},
+		{
+			name: "invalid regular expression",
+			restrictions: []string{
+				"spec.externalName matches ^azure-sql-(",
+			},
+			error: true,
+		},
+		{
+			name: "invalid property in match restriction",
+			restrictions: []string{
+				"spec.external/Name/ matches ^azure",
+			},
+			error: true,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -63,6 +88,9 @@ func TestCreatePredicate(t *testing.T) {
 				}
 				t.Fatalf("Unexpected error from CreatePredicateForServiceClassesFromRestrictions: %v", err)
 			}
+			if tc.error {
+				t.Fatalf("Expected an error from restrictions: %+v", tc.restrictions)
+			}
 
 			if predicate == nil {
 				t.Fatalf("Failed to create predicate from restrictions: %+v", tc.restrictions)
@@ -80,3 +108,20 @@ func TestCreatePredicate(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractProperty(t *testing.T) {
+	cases := map[string]string{
+		"spec.externalName=foo":               "spec.externalName",
+		"spec.externalName!=foo":              "spec.externalName",
+		"spec.externalName notin (foo, bar)":  "spec.externalName",
+		"spec.externalName matches ^foo=bar$": "spec.externalName",
+		"spec.externalName startswith foo":    "spec.externalName",
+		"spec.tags endswith -preview":         "spec.tags",
+		"spec.tags notmatches in":             "spec.tags",
+	}
+	for restriction, expected := range cases {
+		if e, a := expected, ExtractProperty(restriction); e != a {
+			t.Errorf("Unexpected property extracted from %q; expected %q, got %q", restriction, e, a)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Operators of the requirements that match the values of a property against
+// a pattern, which label selectors do not support.
+const (
+	// MatchesOperator requires a value to match a regular expression.
+	MatchesOperator = "matches"
+	// NotMatchesOperator requires no value to match a regular expression.
+	NotMatchesOperator = "notmatches"
+	// StartsWithOperator requires a value to start with a prefix.
+	StartsWithOperator = "startswith"
+	// EndsWithOperator requires a value to end with a suffix.
+	EndsWithOperator = "endswith"
+)
+
+// matchRequirementRegex matches restrictions of the form
+// "<property> <operator> <pattern>". The pattern is the rest of the
+// restriction, so a regular expression may contain spaces and commas.
+var matchRequirementRegex = regexp.MustCompile(`^\s*(\S+)\s+(` +
+	MatchesOperator + `|` + NotMatchesOperator + `|` + StartsWithOperator + `|` + EndsWithOperator +
+	`)\s+(.*?)\s*$`)
+
+// matchRequirement is a requirement on the values of a property using one of
+// the match operators.
+type matchRequirement struct {
+	property string
+	operator string
+	pattern  string
+	regexp   *regexp.Regexp
+}
+
+// parseMatchRequirement parses a restriction using one of the match
+// operators. It returns false if the restriction does not use a match
+// operator.
+func parseMatchRequirement(restriction string) (*matchRequirement, bool, error) {
+	parts := matchRequirementRegex.FindStringSubmatch(restriction)
+	if parts == nil {
+		return nil, false, nil
+	}
+	r := &matchRequirement{property: parts[1], operator: parts[2], pattern: parts[3]}
+
+	if errs := validation.IsQualifiedName(r.property); len(errs) > 0 {
+		return nil, true, fmt.Errorf("invalid property %q in %q: %s", r.property, restriction, strings.Join(errs, "; "))
+	}
+	if r.pattern == "" {
+		return nil, true, fmt.Errorf("missing value for operator %q in %q", r.operator, restriction)
+	}
+	if r.operator == MatchesOperator || r.operator == NotMatchesOperator {
+		re, err := regexp.Compile(r.pattern)
+		if err != nil {
+			return nil, true, fmt.Errorf("invalid regular expression in %q: %v", restriction, err)
+		}
+		r.regexp = re
+	}
+	return r, true, nil
+}
+
+// matches returns true if the value meets the requirement, ignoring whether
+// the requirement is negative.
+func (r *matchRequirement) matches(value string) bool {
+	switch r.operator {
+	case StartsWithOperator:
+		return strings.HasPrefix(value, r.pattern)
+	case EndsWithOperator:
+		return strings.HasSuffix(value, r.pattern)
+	default:
+		return r.regexp.MatchString(value)
+	}
+}
+
+// matchesValues returns true if the requirement is met by a property with the
+// given values. A positive requirement is met if any of the values matches,
+// and notmatches if none of the values matches.
+func (r *matchRequirement) matchesValues(values []string) bool {
+	for _, value := range values {
+		if r.matches(value) {
+			return r.operator != NotMatchesOperator
+		}
+	}
+	return r.operator == NotMatchesOperator
+}
+
+// String returns the requirement in the format it is parsed from.
+func (r *matchRequirement) String() string {
+	return fmt.Sprintf("%s %s %s", r.property, r.operator, r.pattern)
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package filter
 
 import (
+	"strings"
+
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 )
 
 // Predicate is used to test if the set of rules accepts the properties given.
@@ -40,9 +43,10 @@ func NewPredicate() Predicate {
 
 // internalPredicate is our internal representation of Predicate. It will be
 // implemented as a wrapper around labels.Selector to leverage the label
-// selector work.
+// selector work, plus the match requirements label selectors do not support.
 type internalPredicate struct {
 	selector labels.Selector
+	matches  []*matchRequirement
 }
 
 // Accepts tests to see if the given properties are allowed for this
@@ -51,11 +55,56 @@ func (ip internalPredicate) Accepts(p Properties) bool {
 	if ip.Empty() {
 		return true
 	}
-	return ip.selector.Matches(p)
+	if ip.selector != nil {
+		requirements, _ := ip.selector.Requirements()
+		for _, r := range requirements {
+			if !matchesValues(r, propertyValues(p, r.Key())) {
+				return false
+			}
+		}
+	}
+	for _, r := range ip.matches {
+		if !r.matchesValues(propertyValues(p, r.property)) {
+			return false
+		}
+	}
+	return true
+}
+
+// propertyValues returns all the values of the property.
+func propertyValues(p Properties, property string) []string {
+	if mp, ok := p.(MultiValueProperties); ok {
+		return mp.Values(property)
+	}
+	if p.Has(property) {
+		return []string{p.Get(property)}
+	}
+	return nil
+}
+
+// matchesValues returns true if the requirement is met by a property with the
+// given values. A positive requirement is met if any of the values meets it,
+// a negative requirement (!=, notin, !property) if all of the values meet it.
+func matchesValues(r labels.Requirement, values []string) bool {
+	if len(values) == 0 {
+		return r.Matches(labels.Set{})
+	}
+	negative := r.Operator() == selection.NotEquals ||
+		r.Operator() == selection.NotIn ||
+		r.Operator() == selection.DoesNotExist
+	for _, value := range values {
+		if r.Matches(labels.Set{r.Key(): value}) != negative {
+			return !negative
+		}
+	}
+	return negative
 }
 
 // Empty returns true if this predicate does not restrict the acceptance space.
 func (ip internalPredicate) Empty() bool {
+	if len(ip.matches) > 0 {
+		return false
+	}
 	if ip.selector == nil {
 		return true
 	}
@@ -64,5 +113,12 @@ func (ip internalPredicate) Empty() bool {
 
 // String returns a human-readable version of the selector.
 func (ip internalPredicate) String() string {
-	return ip.selector.String()
+	requirements := []string{}
+	if ip.selector != nil && !ip.selector.Empty() {
+		requirements = append(requirements, ip.selector.String())
+	}
+	for _, r := range ip.matches {
+		requirements = append(requirements, r.String())
+	}
+	return strings.Join(requirements, ",")
 }
@@ -0,0 +1,167 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestPredicateAccepts(t *testing.T) {
+	properties := Set{
+		"name":      {"foo"},
+		"spec.tags": {"mysql", "production"},
+	}
+	cases := []struct {
+		name         string
+		restrictions []string
+		properties   Properties
+		accepted     bool
+	}{
+		{
+			name:       "no restrictions",
+			properties: properties,
+			accepted:   true,
+		},
+		{
+			name:         "single value property",
+			restrictions: []string{"name=foo"},
+			properties:   properties,
+			accepted:     true,
+		},
+		{
+			name:         "labels set",
+			restrictions: []string{"name in (foo, bar)"},
+			properties:   labels.Set{"name": "bar"},
+			accepted:     true,
+		},
+		{
+			name:         "equality met by any value",
+			restrictions: []string{"spec.tags=production"},
+			properties:   properties,
+			accepted:     true,
+		},
+		{
+			name:         "in met by any value",
+			restrictions: []string{"spec.tags in (beta, mysql)"},
+			properties:   properties,
+			accepted:     true,
+		},
+		{
+			name:         "in not met by any value",
+			restrictions: []string{"spec.tags in (beta, postgresql)"},
+			properties:   properties,
+			accepted:     false,
+		},
+		{
+			name:         "inequality not met by one value",
+			restrictions: []string{"spec.tags!=mysql"},
+			properties:   properties,
+			accepted:     false,
+		},
+		{
+			name:         "notin met by all values",
+			restrictions: []string{"spec.tags notin (beta, deprecated)"},
+			properties:   properties,
+			accepted:     true,
+		},
+		{
+			name:         "exists",
+			restrictions: []string{"spec.tags"},
+			properties:   properties,
+			accepted:     true,
+		},
+		{
+			name:         "does not exist",
+			restrictions: []string{"!spec.tags"},
+			properties:   Set{"name": {"foo"}},
+			accepted:     true,
+		},
+		{
+			name:         "notin met by property without values",
+			restrictions: []string{"spec.tags notin (beta)"},
+			properties:   Set{"name": {"foo"}},
+			accepted:     true,
+		},
+		{
+			name:         "matches",
+			restrictions: []string{"name matches ^f.o$"},
+			properties:   labels.Set{"name": "foo"},
+			accepted:     true,
+		},
+		{
+			name:         "matches not met by missing property",
+			restrictions: []string{"spec.externalName matches .*"},
+			properties:   properties,
+			accepted:     false,
+		},
+		{
+			name:         "matches met by any value",
+			restrictions: []string{"spec.tags matches ^prod"},
+			properties:   properties,
+			accepted:     true,
+		},
+		{
+			name:         "notmatches not met by one value",
+			restrictions: []string{"spec.tags notmatches sql$"},
+			properties:   properties,
+			accepted:     false,
+		},
+		{
+			name:         "notmatches met by missing property",
+			restrictions: []string{"spec.externalName notmatches ^foo"},
+			properties:   properties,
+			accepted:     true,
+		},
+		{
+			name:         "startswith",
+			restrictions: []string{"spec.tags startswith my"},
+			properties:   properties,
+			accepted:     true,
+		},
+		{
+			name:         "endswith",
+			restrictions: []string{"spec.tags endswith -preview"},
+			properties:   properties,
+			accepted:     false,
+		},
+		{
+			name:         "match and label selector requirements",
+			restrictions: []string{"spec.tags startswith prod", "name=foo"},
+			properties:   properties,
+			accepted:     true,
+		},
+		{
+			name:         "all requirements must be met",
+			restrictions: []string{"spec.tags=mysql", "name=bar"},
+			properties:   properties,
+			accepted:     false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			predicate, err := CreatePredicate(tc.restrictions)
+			if err != nil {
+				t.Fatalf("Unexpected error from CreatePredicate: %v", err)
+			}
+			if e, a := tc.accepted, predicate.Accepts(tc.properties); e != a {
+				t.Fatalf("Unexpected acceptance of %v by %q; expected %v, got %v", tc.properties, predicate, e, a)
+			}
+		})
+	}
+}
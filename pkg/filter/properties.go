@@ -22,3 +22,42 @@ import (
 
 // Properties allows you to present properties independently from their storage.
 type Properties labels.Labels
+
+// MultiValueProperties are Properties in which a property can have several
+// values, such as the tags of a service class.
+type MultiValueProperties interface {
+	Properties
+
+	// Values returns all the values of the given property.
+	Values(property string) []string
+}
+
+// Set is a set of properties, each of which can have several values. It
+// implements MultiValueProperties.
+type Set map[string][]string
+
+// Has returns true if the property has at least one value.
+func (s Set) Has(property string) bool {
+	return len(s[property]) > 0
+}
+
+// Get returns the first value of the property, or "" if it has no value.
+func (s Set) Get(property string) string {
+	if values := s[property]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Values returns all the values of the property.
+func (s Set) Values(property string) []string {
+	return s[property]
+}
+
+// Add appends values to the property. The property is left unset if there
+// are no values.
+func (s Set) Add(property string, values ...string) {
+	if len(values) > 0 {
+		s[property] = append(s[property], values...)
+	}
+}
@@ -72,13 +72,145 @@ var (
 		},
 		[]string{"broker", "method", "status"},
 	)
+
+	// OSBRequestDurationSeconds exposes how long the HTTP requests made to
+	// Open Service Brokers took, by broker name and broker method.
+	OSBRequestDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: catalogNamespace,
+			Name:      "osb_request_duration_seconds",
+			Help:      "Duration in seconds of the HTTP requests from the OSB Client to the specified Service Broker grouped by broker name and broker method.",
+			Buckets:   []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		},
+		[]string{"broker", "method"},
+	)
+
+	// OSBRequestErrorCount exposes the number of HTTP requests made to Open
+	// Service Brokers that failed.  The metric is broken out by broker name,
+	// broker method and error class (timeout, 4xx, 5xx, async-required,
+	// conflict or client-error)
+	OSBRequestErrorCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: catalogNamespace,
+			Name:      "osb_request_error_count",
+			Help:      "Cumulative number of failed HTTP requests from the OSB Client to the specified Service Broker grouped by broker name, broker method, and error class.",
+		},
+		[]string{"broker", "method", "class"},
+	)
+
+	// OSBAsyncPollCount exposes the number of times the last operation of an
+	// instance or binding was polled.  The metric is broken out by broker
+	// name, broker method and the state of the operation reported by the
+	// broker ('error' if the request failed)
+	OSBAsyncPollCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: catalogNamespace,
+			Name:      "osb_async_poll_count",
+			Help:      "Cumulative number of polls of the last operation of asynchronous operations of the specified Service Broker grouped by broker name, broker method, and operation state.",
+		},
+		[]string{"broker", "method", "state"},
+	)
+
+	// OSBThrottleWaitSeconds exposes how long requests to Open Service Brokers
+	// waited for the rate limit and concurrency cap of the broker before they
+	// were sent or given up on.
+	OSBThrottleWaitSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: catalogNamespace,
+			Name:      "osb_throttle_wait_seconds",
+			Help:      "Time in seconds requests from the OSB Client to the specified Service Broker waited for the rate limit of the broker.",
+			Buckets:   prometheus.ExponentialBuckets(0.005, 2, 10),
+		},
+		[]string{"broker"},
+	)
+
+	// OSBThrottledRequestCount exposes the number of requests to Open Service
+	// Brokers that were not sent because they exceeded the rate limit of the
+	// broker, and were requeued instead.
+	OSBThrottledRequestCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: catalogNamespace,
+			Name:      "osb_throttled_request_count",
+			Help:      "Cumulative number of requests from the OSB Client to the specified Service Broker that were requeued because they exceeded the rate limit of the broker.",
+		},
+		[]string{"broker"},
+	)
+
+	// BrokerCircuitBreakerState exposes the state of the circuit breaker of
+	// each broker: 0 when requests are sent to the broker, 1 while a request
+	// probes whether the broker has recovered, and 2 when requests to the
+	// broker are suspended.
+	BrokerCircuitBreakerState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: catalogNamespace,
+			Name:      "broker_circuit_breaker_state",
+			Help:      "State of the circuit breaker of the specified Service Broker; 0 is closed, 1 is half-open and 2 is open.",
+		},
+		[]string{"broker"},
+	)
+
+	// TimeToReadySeconds exposes how long instances took to become ready
+	// after the start of their provision, and bindings after the start of
+	// their bind, by operation and broker name.
+	TimeToReadySeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: catalogNamespace,
+			Name:      "time_to_ready_seconds",
+			Help:      "Time in seconds from the start of the provision of a ServiceInstance or the bind of a ServiceBinding until it became ready, grouped by operation and broker name.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 14),
+		},
+		[]string{"operation", "broker"},
+	)
+
+	// BrokerUp exposes whether each broker was up the last time its health
+	// was checked.
+	BrokerUp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: catalogNamespace,
+			Name:      "broker_up",
+			Help:      "Whether the specified Service Broker was up the last time its health was checked; 1 is up and 0 is down.",
+		},
+		[]string{"broker"},
+	)
+)
+
+// ServiceInstanceCountDesc describes the number of ServiceInstances, broken
+// out by namespace, class, plan, broker name, and the status of each of the
+// Ready, Failed, OrphanMitigation and AsyncInProgress conditions. The metric
+// is collected from the informer caches of the controller on each scrape.
+var ServiceInstanceCountDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(catalogNamespace, "", "service_instance_count"),
+	"Number of ServiceInstances grouped by namespace, class, plan, broker name, and the status of each condition.",
+	[]string{"namespace", "class", "plan", "broker", "condition", "status"},
+	nil,
+)
+
+// ServiceBindingCountDesc describes the number of ServiceBindings, broken out
+// like ServiceInstanceCountDesc by the class, plan and broker of their
+// instance.
+var ServiceBindingCountDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(catalogNamespace, "", "service_binding_count"),
+	"Number of ServiceBindings grouped by namespace, class, plan, broker name, and the status of each condition.",
+	[]string{"namespace", "class", "plan", "broker", "condition", "status"},
+	nil,
 )
 
+// registry is the registry of the Service Catalog metrics.
+var registry = prometheus.NewRegistry()
+
 func register(registry *prometheus.Registry) {
 	registerMetrics.Do(func() {
 		registry.MustRegister(BrokerServiceClassCount)
 		registry.MustRegister(BrokerServicePlanCount)
 		registry.MustRegister(OSBRequestCount)
+		registry.MustRegister(OSBRequestDurationSeconds)
+		registry.MustRegister(OSBRequestErrorCount)
+		registry.MustRegister(OSBAsyncPollCount)
+		registry.MustRegister(OSBThrottleWaitSeconds)
+		registry.MustRegister(OSBThrottledRequestCount)
+		registry.MustRegister(BrokerCircuitBreakerState)
+		registry.MustRegister(BrokerUp)
+		registry.MustRegister(TimeToReadySeconds)
 	})
 }
 
@@ -86,8 +218,13 @@ func register(registry *prometheus.Registry) {
 // objects with Prometheus and installs the Prometheus http handler at the
 // default context.
 func RegisterMetricsAndInstallHandler(m *http.ServeMux) {
-	registry := prometheus.NewRegistry()
 	register(registry)
 	m.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError}))
 	klog.V(4).Info("Registered /metrics with prometheus")
 }
+
+// RegisterCollector registers a collector of metrics that are computed on
+// each scrape with the registry of the Service Catalog metrics.
+func RegisterCollector(collector prometheus.Collector) error {
+	return registry.Register(collector)
+}
@@ -19,10 +19,13 @@ limitations under the License.
 package osbclientproxy
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"time"
 
-	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
 	"github.com/kubernetes-sigs/service-catalog/pkg/metrics"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
 	"k8s.io/klog"
 )
 
@@ -47,6 +50,16 @@ func NewClient(config *osb.ClientConfiguration) (osb.Client, error) {
 
 var _ osb.CreateFunc = NewClient
 
+var _ osb.ContextClient = proxyclient{}
+
+// WithContext implements
+// go-open-service-broker-client/v2/ContextClient.WithContext by returning a
+// proxy of the underlying implementation sending its requests with the given
+// context.
+func (pc proxyclient) WithContext(ctx context.Context) osb.Client {
+	return proxyclient{brokerName: pc.brokerName, realOSBClient: osb.WithContext(ctx, pc.realOSBClient)}
+}
+
 const (
 	getCatalog               = "GetCatalog"
 	provisionInstance        = "ProvisionInstance"
@@ -57,6 +70,7 @@ const (
 	bind                     = "Bind"
 	unbind                   = "Unbind"
 	getBinding               = "GetBinding"
+	getInstance              = "GetInstance"
 )
 
 // GetCatalog implements go-open-service-broker-client/v2/Client.GetCatalog by
@@ -64,8 +78,9 @@ const (
 // metrics.
 func (pc proxyclient) GetCatalog() (*osb.CatalogResponse, error) {
 	klog.V(9).Info("OSBClientProxy getCatalog()")
+	start := time.Now()
 	response, err := pc.realOSBClient.GetCatalog()
-	pc.updateMetrics(getCatalog, err)
+	pc.updateMetrics(getCatalog, start, err)
 	return response, err
 }
 
@@ -74,8 +89,9 @@ func (pc proxyclient) GetCatalog() (*osb.CatalogResponse, error) {
 // method to the underlying implementation and capturing request metrics.
 func (pc proxyclient) ProvisionInstance(r *osb.ProvisionRequest) (*osb.ProvisionResponse, error) {
 	klog.V(9).Info("OSBClientProxy ProvisionInstance()")
+	start := time.Now()
 	response, err := pc.realOSBClient.ProvisionInstance(r)
-	pc.updateMetrics(provisionInstance, err)
+	pc.updateMetrics(provisionInstance, start, err)
 	return response, err
 
 }
@@ -85,8 +101,9 @@ func (pc proxyclient) ProvisionInstance(r *osb.ProvisionRequest) (*osb.Provision
 // to the underlying implementation and capturing request metrics.
 func (pc proxyclient) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
 	klog.V(9).Info("OSBClientProxy UpdateInstance()")
+	start := time.Now()
 	response, err := pc.realOSBClient.UpdateInstance(r)
-	pc.updateMetrics(updateInstance, err)
+	pc.updateMetrics(updateInstance, start, err)
 	return response, err
 }
 
@@ -95,8 +112,9 @@ func (pc proxyclient) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateI
 // method to the underlying implementation and capturing request metrics.
 func (pc proxyclient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
 	klog.V(9).Info("OSBClientProxy DeprovisionInstance()")
+	start := time.Now()
 	response, err := pc.realOSBClient.DeprovisionInstance(r)
-	pc.updateMetrics(deprovisionInstance, err)
+	pc.updateMetrics(deprovisionInstance, start, err)
 	return response, err
 }
 
@@ -105,8 +123,10 @@ func (pc proxyclient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.Depro
 // method to the underlying implementation and capturing request metrics.
 func (pc proxyclient) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
 	klog.V(9).Info("OSBClientProxy PollLastOperation()")
+	start := time.Now()
 	response, err := pc.realOSBClient.PollLastOperation(r)
-	pc.updateMetrics(pollLastOperation, err)
+	pc.updateMetrics(pollLastOperation, start, err)
+	pc.updatePollMetrics(pollLastOperation, response, err)
 	return response, err
 }
 
@@ -115,8 +135,10 @@ func (pc proxyclient) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastO
 // the method to the underlying implementation and capturing request metrics.
 func (pc proxyclient) PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error) {
 	klog.V(9).Info("OSBClientProxy PollBindingLastOperation()")
+	start := time.Now()
 	response, err := pc.realOSBClient.PollBindingLastOperation(r)
-	pc.updateMetrics(pollBindingLastOperation, err)
+	pc.updateMetrics(pollBindingLastOperation, start, err)
+	pc.updatePollMetrics(pollBindingLastOperation, response, err)
 	return response, err
 }
 
@@ -124,8 +146,9 @@ func (pc proxyclient) PollBindingLastOperation(r *osb.BindingLastOperationReques
 // method to the underlying implementation and capturing request metrics.
 func (pc proxyclient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
 	klog.V(9).Info("OSBClientProxy Bind().")
+	start := time.Now()
 	response, err := pc.realOSBClient.Bind(r)
-	pc.updateMetrics(bind, err)
+	pc.updateMetrics(bind, start, err)
 	return response, err
 }
 
@@ -133,8 +156,9 @@ func (pc proxyclient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
 // the method to the underlying implementation and capturing request metrics.
 func (pc proxyclient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
 	klog.V(9).Info("OSBClientProxy Unbind()")
+	start := time.Now()
 	response, err := pc.realOSBClient.Unbind(r)
-	pc.updateMetrics(unbind, err)
+	pc.updateMetrics(unbind, start, err)
 	return response, err
 }
 
@@ -143,18 +167,40 @@ func (pc proxyclient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error)
 // metrics.
 func (pc proxyclient) GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error) {
 	klog.V(9).Info("OSBClientProxy GetBinding()")
+	start := time.Now()
 	response, err := pc.realOSBClient.GetBinding(r)
-	pc.updateMetrics(getBinding, err)
+	pc.updateMetrics(getBinding, start, err)
 	return response, err
 }
 
-const clientErr = "client-error"
+// GetInstance implements go-open-service-broker-client/v2/Client.GetInstance
+// by proxying the method to the underlying implementation and capturing
+// request metrics.
+func (pc proxyclient) GetInstance(r *osb.GetInstanceRequest) (*osb.GetInstanceResponse, error) {
+	klog.V(9).Info("OSBClientProxy GetInstance()")
+	start := time.Now()
+	response, err := pc.realOSBClient.GetInstance(r)
+	pc.updateMetrics(getInstance, start, err)
+	return response, err
+}
+
+// The classes of the errors returned by brokers, in the order they are
+// checked in.
+const (
+	asyncRequiredErr = "async-required"
+	conflictErr      = "conflict"
+	timeoutErr       = "timeout"
+	clientErr        = "client-error"
+)
 
 // updateMetrics bumps the request count metric for the specific broker, method
-// and status
-func (pc proxyclient) updateMetrics(method string, err error) {
+// and status, records the duration of the request since the given start time,
+// and counts the error, if any, by its class.
+func (pc proxyclient) updateMetrics(method string, start time.Time, err error) {
 	var statusGroup string
 
+	metrics.OSBRequestDurationSeconds.WithLabelValues(pc.brokerName, method).Observe(time.Since(start).Seconds())
+
 	// for this metric, lack of an error translates into a 2xx status
 	if err == nil {
 		metrics.OSBRequestCount.WithLabelValues(pc.brokerName, method, "2xx").Inc()
@@ -168,4 +214,39 @@ func (pc proxyclient) updateMetrics(method string, err error) {
 		statusGroup = clientErr
 	}
 	metrics.OSBRequestCount.WithLabelValues(pc.brokerName, method, statusGroup).Inc()
+	metrics.OSBRequestErrorCount.WithLabelValues(pc.brokerName, method, classifyError(err)).Inc()
+}
+
+// classifyError returns the class of an error returned by the OSB client:
+// async-required or conflict for the responses of brokers asking for an
+// asynchronous operation or reporting a conflict, the status group of other
+// error responses, timeout for requests that timed out, and client-error for
+// any other error.
+func classifyError(err error) string {
+	switch {
+	case osb.IsAsyncRequiredError(err):
+		return asyncRequiredErr
+	case osb.IsConflictError(err):
+		return conflictErr
+	}
+	if status, ok := osb.IsHTTPError(err); ok {
+		return fmt.Sprintf("%dxx", status.StatusCode/100)
+	}
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return timeoutErr
+	}
+	return clientErr
+}
+
+// updatePollMetrics counts an iteration of polling the last operation of an
+// instance or binding by the state the broker reported.
+func (pc proxyclient) updatePollMetrics(method string, response *osb.LastOperationResponse, err error) {
+	state := "error"
+	switch {
+	case err == nil:
+		state = string(response.State)
+	case osb.IsGoneError(err):
+		state = "gone"
+	}
+	metrics.OSBAsyncPollCount.WithLabelValues(pc.brokerName, method, state).Inc()
 }
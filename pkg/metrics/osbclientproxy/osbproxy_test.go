@@ -0,0 +1,129 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osbclientproxy
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/metrics"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/fake"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestClassifyError(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name: "async required",
+			err: osb.HTTPStatusCodeError{
+				StatusCode:   http.StatusUnprocessableEntity,
+				ErrorMessage: strPtr(osb.AsyncErrorMessage),
+				Description:  strPtr(osb.AsyncErrorDescription),
+			},
+			expected: asyncRequiredErr,
+		},
+		{
+			name:     "conflict",
+			err:      osb.HTTPStatusCodeError{StatusCode: http.StatusConflict},
+			expected: conflictErr,
+		},
+		{
+			name:     "bad request",
+			err:      osb.HTTPStatusCodeError{StatusCode: http.StatusBadRequest},
+			expected: "4xx",
+		},
+		{
+			name:     "service unavailable",
+			err:      osb.HTTPStatusCodeError{StatusCode: http.StatusServiceUnavailable},
+			expected: "5xx",
+		},
+		{
+			name:     "timeout",
+			err:      &url.Error{Op: "Get", URL: "http://broker", Err: timeoutError{}},
+			expected: timeoutErr,
+		},
+		{
+			name:     "connection refused",
+			err:      &url.Error{Op: "Get", URL: "http://broker", Err: errors.New("connection refused")},
+			expected: clientErr,
+		},
+	}
+	for _, tc := range cases {
+		if e, a := tc.expected, classifyError(tc.err); e != a {
+			t.Errorf("%s: expected %q, got %q", tc.name, e, a)
+		}
+	}
+}
+
+func counterValue(t *testing.T, counter *prometheus.CounterVec, labels ...string) float64 {
+	var m dto.Metric
+	if err := counter.WithLabelValues(labels...).Write(&m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetCounter().GetValue()
+}
+
+func TestProxyClientMetrics(t *testing.T) {
+	fakeClient := fakeosb.NewFakeClient(fakeosb.FakeClientConfiguration{
+		CatalogReaction: &fakeosb.CatalogReaction{
+			Error: osb.HTTPStatusCodeError{StatusCode: http.StatusConflict},
+		},
+		PollLastOperationReaction: &fakeosb.PollLastOperationReaction{
+			Response: &osb.LastOperationResponse{State: osb.StateInProgress},
+		},
+	})
+	proxy := proxyclient{brokerName: "test-metrics-broker", realOSBClient: fakeClient}
+
+	proxy.GetCatalog()
+	if e, a := 1.0, counterValue(t, metrics.OSBRequestErrorCount, "test-metrics-broker", getCatalog, conflictErr); e != a {
+		t.Fatalf("unexpected error count: expected %v, got %v", e, a)
+	}
+
+	proxy.PollLastOperation(&osb.LastOperationRequest{})
+	proxy.PollLastOperation(&osb.LastOperationRequest{})
+	if e, a := 2.0, counterValue(t, metrics.OSBAsyncPollCount, "test-metrics-broker", pollLastOperation, string(osb.StateInProgress)); e != a {
+		t.Fatalf("unexpected poll count: expected %v, got %v", e, a)
+	}
+
+	var m dto.Metric
+	observer := metrics.OSBRequestDurationSeconds.WithLabelValues("test-metrics-broker", pollLastOperation)
+	if err := observer.(prometheus.Metric).Write(&m); err != nil {
+		t.Fatal(err)
+	}
+	if e, a := uint64(2), m.GetHistogram().GetSampleCount(); e != a {
+		t.Fatalf("unexpected number of observed durations: expected %v, got %v", e, a)
+	}
+}
@@ -31,72 +31,82 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeyTransform":                schema_pkg_apis_servicecatalog_v1beta1_AddKeyTransform(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeysFromTransform":           schema_pkg_apis_servicecatalog_v1beta1_AddKeysFromTransform(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BasicAuthConfig":                schema_pkg_apis_servicecatalog_v1beta1_BasicAuthConfig(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BearerTokenAuthConfig":          schema_pkg_apis_servicecatalog_v1beta1_BearerTokenAuthConfig(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions":            schema_pkg_apis_servicecatalog_v1beta1_CatalogRestrictions(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBasicAuthConfig":         schema_pkg_apis_servicecatalog_v1beta1_ClusterBasicAuthConfig(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBearerTokenAuthConfig":   schema_pkg_apis_servicecatalog_v1beta1_ClusterBearerTokenAuthConfig(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference":         schema_pkg_apis_servicecatalog_v1beta1_ClusterObjectReference(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBroker":           schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBroker(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerAuthInfo":   schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerAuthInfo(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerList":       schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerList(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerSpec":       schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerStatus":     schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceClass":            schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceClass(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceClassList":        schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceClassList(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceClassSpec":        schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceClassSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceClassStatus":      schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceClassStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServicePlan":             schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlan(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServicePlanList":         schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlanList(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServicePlanSpec":         schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlanSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServicePlanStatus":       schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlanStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceBrokerSpec":        schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceBrokerStatus":      schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceClassSpec":         schema_pkg_apis_servicecatalog_v1beta1_CommonServiceClassSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceClassStatus":       schema_pkg_apis_servicecatalog_v1beta1_CommonServiceClassStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServicePlanSpec":          schema_pkg_apis_servicecatalog_v1beta1_CommonServicePlanSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServicePlanStatus":        schema_pkg_apis_servicecatalog_v1beta1_CommonServicePlanStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference":           schema_pkg_apis_servicecatalog_v1beta1_LocalObjectReference(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference":                schema_pkg_apis_servicecatalog_v1beta1_ObjectReference(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource":           schema_pkg_apis_servicecatalog_v1beta1_ParametersFromSource(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.PlanReference":                  schema_pkg_apis_servicecatalog_v1beta1_PlanReference(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.RemoveKeyTransform":             schema_pkg_apis_servicecatalog_v1beta1_RemoveKeyTransform(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.RenameKeyTransform":             schema_pkg_apis_servicecatalog_v1beta1_RenameKeyTransform(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretKeyReference":             schema_pkg_apis_servicecatalog_v1beta1_SecretKeyReference(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretTransform":                schema_pkg_apis_servicecatalog_v1beta1_SecretTransform(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBinding":                 schema_pkg_apis_servicecatalog_v1beta1_ServiceBinding(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingCondition":        schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingCondition(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingList":             schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingList(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingPropertiesState":  schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingPropertiesState(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingSpec":             schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingStatus":           schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBroker":                  schema_pkg_apis_servicecatalog_v1beta1_ServiceBroker(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerAuthInfo":          schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerAuthInfo(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerCondition":         schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerCondition(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerList":              schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerList(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerSpec":              schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerStatus":            schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceClass":                   schema_pkg_apis_servicecatalog_v1beta1_ServiceClass(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceClassList":               schema_pkg_apis_servicecatalog_v1beta1_ServiceClassList(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceClassSpec":               schema_pkg_apis_servicecatalog_v1beta1_ServiceClassSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceClassStatus":             schema_pkg_apis_servicecatalog_v1beta1_ServiceClassStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstance":                schema_pkg_apis_servicecatalog_v1beta1_ServiceInstance(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceCondition":       schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceCondition(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceList":            schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceList(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstancePropertiesState": schema_pkg_apis_servicecatalog_v1beta1_ServiceInstancePropertiesState(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceSpec":            schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceStatus":          schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlan":                    schema_pkg_apis_servicecatalog_v1beta1_ServicePlan(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanList":                schema_pkg_apis_servicecatalog_v1beta1_ServicePlanList(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanSpec":                schema_pkg_apis_servicecatalog_v1beta1_ServicePlanSpec(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanStatus":              schema_pkg_apis_servicecatalog_v1beta1_ServicePlanStatus(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo":                       schema_pkg_apis_servicecatalog_v1beta1_UserInfo(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/settings/v1alpha1.PodPreset":                           schema_pkg_apis_settings_v1alpha1_PodPreset(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/settings/v1alpha1.PodPresetList":                       schema_pkg_apis_settings_v1alpha1_PodPresetList(ref),
-		"github.com/kubernetes-sigs/service-catalog/pkg/apis/settings/v1alpha1.PodPresetSpec":                       schema_pkg_apis_settings_v1alpha1_PodPresetSpec(ref),
-		"k8s.io/api/core/v1.AWSElasticBlockStoreVolumeSource":                                                       schema_k8sio_api_core_v1_AWSElasticBlockStoreVolumeSource(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeyTransform":                      schema_pkg_apis_servicecatalog_v1beta1_AddKeyTransform(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeysFromTransform":                 schema_pkg_apis_servicecatalog_v1beta1_AddKeysFromTransform(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BasicAuthConfig":                      schema_pkg_apis_servicecatalog_v1beta1_BasicAuthConfig(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BearerTokenAuthConfig":                schema_pkg_apis_servicecatalog_v1beta1_BearerTokenAuthConfig(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogDiff":                          schema_pkg_apis_servicecatalog_v1beta1_CatalogDiff(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions":                  schema_pkg_apis_servicecatalog_v1beta1_CatalogRestrictions(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBasicAuthConfig":               schema_pkg_apis_servicecatalog_v1beta1_ClusterBasicAuthConfig(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBearerTokenAuthConfig":         schema_pkg_apis_servicecatalog_v1beta1_ClusterBearerTokenAuthConfig(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterOAuth2AuthConfig":              schema_pkg_apis_servicecatalog_v1beta1_ClusterOAuth2AuthConfig(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference":               schema_pkg_apis_servicecatalog_v1beta1_ClusterObjectReference(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceAccountTokenAuthConfig": schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceAccountTokenAuthConfig(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBroker":                 schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBroker(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerAuthInfo":         schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerAuthInfo(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerList":             schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerList(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerSpec":             schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerStatus":           schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceClass":                  schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceClass(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceClassList":              schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceClassList(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceClassSpec":              schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceClassSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceClassStatus":            schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceClassStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServicePlan":                   schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlan(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServicePlanList":               schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlanList(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServicePlanSpec":               schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlanSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServicePlanStatus":             schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlanStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterTLSAuthConfig":                 schema_pkg_apis_servicecatalog_v1beta1_ClusterTLSAuthConfig(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceBrokerSpec":              schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceBrokerStatus":            schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceClassSpec":               schema_pkg_apis_servicecatalog_v1beta1_CommonServiceClassSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceClassStatus":             schema_pkg_apis_servicecatalog_v1beta1_CommonServiceClassStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServicePlanSpec":                schema_pkg_apis_servicecatalog_v1beta1_CommonServicePlanSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServicePlanStatus":              schema_pkg_apis_servicecatalog_v1beta1_CommonServicePlanStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference":                 schema_pkg_apis_servicecatalog_v1beta1_LocalObjectReference(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo":                      schema_pkg_apis_servicecatalog_v1beta1_MaintenanceInfo(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.OAuth2AuthConfig":                     schema_pkg_apis_servicecatalog_v1beta1_OAuth2AuthConfig(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference":                      schema_pkg_apis_servicecatalog_v1beta1_ObjectReference(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource":                 schema_pkg_apis_servicecatalog_v1beta1_ParametersFromSource(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.PlanReference":                        schema_pkg_apis_servicecatalog_v1beta1_PlanReference(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.RemoveKeyTransform":                   schema_pkg_apis_servicecatalog_v1beta1_RemoveKeyTransform(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.RenameKeyTransform":                   schema_pkg_apis_servicecatalog_v1beta1_RenameKeyTransform(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretKeyReference":                   schema_pkg_apis_servicecatalog_v1beta1_SecretKeyReference(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretTransform":                      schema_pkg_apis_servicecatalog_v1beta1_SecretTransform(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceAccountTokenAuthConfig":        schema_pkg_apis_servicecatalog_v1beta1_ServiceAccountTokenAuthConfig(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBinding":                       schema_pkg_apis_servicecatalog_v1beta1_ServiceBinding(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingCondition":              schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingCondition(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingList":                   schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingList(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingPropertiesState":        schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingPropertiesState(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingRotationStatus":         schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingRotationStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingSpec":                   schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingStatus":                 schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBroker":                        schema_pkg_apis_servicecatalog_v1beta1_ServiceBroker(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerAuthInfo":                schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerAuthInfo(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerCondition":               schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerCondition(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerList":                    schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerList(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerRateLimit":               schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerRateLimit(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerSpec":                    schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerStatus":                  schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceClass":                         schema_pkg_apis_servicecatalog_v1beta1_ServiceClass(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceClassList":                     schema_pkg_apis_servicecatalog_v1beta1_ServiceClassList(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceClassSpec":                     schema_pkg_apis_servicecatalog_v1beta1_ServiceClassSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceClassStatus":                   schema_pkg_apis_servicecatalog_v1beta1_ServiceClassStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstance":                      schema_pkg_apis_servicecatalog_v1beta1_ServiceInstance(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceCondition":             schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceCondition(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceList":                  schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceList(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstancePropertiesState":       schema_pkg_apis_servicecatalog_v1beta1_ServiceInstancePropertiesState(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceSpec":                  schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceStatus":                schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlan":                          schema_pkg_apis_servicecatalog_v1beta1_ServicePlan(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanList":                      schema_pkg_apis_servicecatalog_v1beta1_ServicePlanList(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanSpec":                      schema_pkg_apis_servicecatalog_v1beta1_ServicePlanSpec(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanStatus":                    schema_pkg_apis_servicecatalog_v1beta1_ServicePlanStatus(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.TLSAuthConfig":                        schema_pkg_apis_servicecatalog_v1beta1_TLSAuthConfig(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo":                             schema_pkg_apis_servicecatalog_v1beta1_UserInfo(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/settings/v1alpha1.PodPreset":                                 schema_pkg_apis_settings_v1alpha1_PodPreset(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/settings/v1alpha1.PodPresetList":                             schema_pkg_apis_settings_v1alpha1_PodPresetList(ref),
+		"github.com/kubernetes-sigs/service-catalog/pkg/apis/settings/v1alpha1.PodPresetSpec":                             schema_pkg_apis_settings_v1alpha1_PodPresetSpec(ref),
+		"k8s.io/api/core/v1.AWSElasticBlockStoreVolumeSource":                                                             schema_k8sio_api_core_v1_AWSElasticBlockStoreVolumeSource(ref),
 		"k8s.io/api/core/v1.Affinity":                                    schema_k8sio_api_core_v1_Affinity(ref),
 		"k8s.io/api/core/v1.AttachedVolume":                              schema_k8sio_api_core_v1_AttachedVolume(ref),
 		"k8s.io/api/core/v1.AvoidPods":                                   schema_k8sio_api_core_v1_AvoidPods(ref),
@@ -456,6 +466,62 @@ func schema_pkg_apis_servicecatalog_v1beta1_BearerTokenAuthConfig(ref common.Ref
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_CatalogDiff(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CatalogDiff counts the service classes and plans that were added, removed or updated while reconciling a broker's catalog.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"addedServiceClasses": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AddedServiceClasses is the number of service classes that were created or that reappeared in the broker's catalog.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"removedServiceClasses": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemovedServiceClasses is the number of service classes that were marked as removed from the broker's catalog.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"updatedServiceClasses": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UpdatedServiceClasses is the number of existing service classes whose spec changed.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"addedServicePlans": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AddedServicePlans is the number of service plans that were created or that reappeared in the broker's catalog.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"removedServicePlans": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemovedServicePlans is the number of service plans that were marked as removed from the broker's catalog.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"updatedServicePlans": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UpdatedServicePlans is the number of existing service plans whose spec changed.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"addedServiceClasses", "removedServiceClasses", "updatedServiceClasses", "addedServicePlans", "removedServicePlans", "updatedServicePlans"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_CatalogRestrictions(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -539,6 +605,27 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterBearerTokenAuthConfig(ref com
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_ClusterOAuth2AuthConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterOAuth2AuthConfig provides config for the OAuth2 client credentials authentication of cluster scoped brokers.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"secretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretRef is a reference to a Secret containing the OAuth2 client credentials the catalog should use to obtain access tokens for this ServiceBroker.\n\nRequired fields: - Secret.Data[\"clientID\"] - the client identifier - Secret.Data[\"clientSecret\"] - the client secret - Secret.Data[\"tokenURL\"] - the URL of the token endpoint\n\nOptional fields: - Secret.Data[\"scopes\"] - space separated scopes to request",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference"},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_ClusterObjectReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -559,6 +646,41 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterObjectReference(ref common.Re
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceAccountTokenAuthConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterServiceAccountTokenAuthConfig provides config for the service account token authentication of cluster scoped brokers.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"serviceAccountRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceAccountRef is a reference to the ServiceAccount whose tokens are presented to this ServiceBroker.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference"),
+						},
+					},
+					"audience": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Audience is the intended audience of the tokens, which the broker must verify. It is required, so that the tokens are not accepted by the API server.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"expirationSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExpirationSeconds is the requested lifetime of the tokens. The controller requests a new token once 80% of its lifetime has elapsed. Defaults to one hour, and must be at least 10 minutes.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference"},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBroker(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -630,11 +752,29 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerAuthInfo(ref com
 							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBearerTokenAuthConfig"),
 						},
 					},
+					"tls": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TLS provides configuration to authenticate with a client certificate and key, referenced from the given kubernetes.io/tls secret. Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterTLSAuthConfig"),
+						},
+					},
+					"oauth2": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OAuth2 provides configuration to authenticate with an access token obtained through the OAuth2 client credentials grant, using the client credentials and token endpoint referenced from the given secret. Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterOAuth2AuthConfig"),
+						},
+					},
+					"serviceAccountToken": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceAccountToken provides configuration to authenticate with a bound token of the given ServiceAccount, requested by the controller through the TokenRequest API and presented as a bearer token. Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceAccountTokenAuthConfig"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBasicAuthConfig", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBearerTokenAuthConfig"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBasicAuthConfig", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBearerTokenAuthConfig", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterOAuth2AuthConfig", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceAccountTokenAuthConfig", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterTLSAuthConfig"},
 	}
 }
 
@@ -722,7 +862,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerSpec(ref common.
 					},
 					"relistDuration": {
 						SchemaProps: spec.SchemaProps{
-							Description: "RelistDuration is the frequency by which a controller will relist the broker when the RelistBehavior is set to ServiceBrokerRelistBehaviorDuration. Users are cautioned against configuring low values for the RelistDuration, as this can easily overload the controller manager in an environment with many brokers. The actual interval is intrinsically governed by the configured resync interval of the controller, which acts as a minimum bound. For example, with a resync interval of 5m and a RelistDuration of 2m, relists will occur at the resync interval of 5m.",
+							Description: "RelistDuration is the frequency by which a controller will relist the broker when the RelistBehavior is set to ServiceBrokerRelistBehaviorDuration or ServiceBrokerRelistBehaviorProxy. Users are cautioned against configuring low values for the RelistDuration, as this can easily overload the controller manager in an environment with many brokers. The actual interval is intrinsically governed by the configured resync interval of the controller, which acts as a minimum bound. For example, with a resync interval of 5m and a RelistDuration of 2m, relists will occur at the resync interval of 5m.",
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
@@ -739,6 +879,19 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerSpec(ref common.
 							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions"),
 						},
 					},
+					"rateLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RateLimit limits the rate and concurrency of the requests sent to the broker by the controller. Requests are not limited when it is unset. Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerRateLimit"),
+						},
+					},
+					"healthCheckPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HealthCheckPath is the path of the endpoint of the broker, relative to its URL, that the controller sends GET requests to in order to check whether the broker is up. If unset, HEAD requests are sent to the catalog endpoint of the broker instead. Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"authInfo": {
 						SchemaProps: spec.SchemaProps{
 							Description: "AuthInfo contains the data that the service catalog should use to authenticate with the ClusterServiceBroker.",
@@ -750,7 +903,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerSpec(ref common.
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerAuthInfo", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerAuthInfo", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerRateLimit", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -799,12 +952,31 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerStatus(ref commo
 							Format:      "",
 						},
 					},
+					"lastCatalogDiff": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nLastCatalogDiff summarizes the changes made to the broker's service classes and plans the last time its catalog was reconciled.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogDiff"),
+						},
+					},
+					"lastHealthCheckTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nLastHealthCheckTime is the time the health of the broker was last checked.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"catalogHistoryHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nCatalogHistoryHash is the hash of the catalog recorded as the latest revision of the catalog history of the broker.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"conditions", "reconciledGeneration", "lastConditionState"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerCondition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogDiff", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerCondition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -949,6 +1121,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceClassSpec(ref common.R
 							Format:      "",
 						},
 					},
+					"instanceRetrievable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nInstanceRetrievable indicates whether fetching a service instance via a GET on its endpoint is supported for all plans.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"planUpdatable": {
 						SchemaProps: spec.SchemaProps{
 							Description: "PlanUpdatable indicates whether instances provisioned from this ServiceClass may change ServicePlans after being provisioned.",
@@ -1210,6 +1389,12 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlanSpec(ref common.Re
 							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
 						},
 					},
+					"maintenanceInfo": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nMaintenanceInfo is the maintenance information of this plan, as reported by the broker. A change in its version indicates that an upgrade is available for ServiceInstances on this plan.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo"),
+						},
+					},
 					"clusterServiceBrokerName": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ClusterServiceBrokerName is the name of the ClusterServiceBroker that offers this ClusterServicePlan.",
@@ -1228,7 +1413,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlanSpec(ref common.Re
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -1253,6 +1438,27 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServicePlanStatus(ref common.
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_ClusterTLSAuthConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterTLSAuthConfig provides config for the mutual TLS authentication of cluster scoped brokers.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"secretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretRef is a reference to a kubernetes.io/tls Secret containing the client certificate the catalog should present to this ServiceBroker.\n\nRequired fields: - Secret.Data[\"tls.crt\"] - PEM encoded client certificate - Secret.Data[\"tls.key\"] - PEM encoded private key of the certificate",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference"},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1290,7 +1496,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerSpec(ref common.R
 					},
 					"relistDuration": {
 						SchemaProps: spec.SchemaProps{
-							Description: "RelistDuration is the frequency by which a controller will relist the broker when the RelistBehavior is set to ServiceBrokerRelistBehaviorDuration. Users are cautioned against configuring low values for the RelistDuration, as this can easily overload the controller manager in an environment with many brokers. The actual interval is intrinsically governed by the configured resync interval of the controller, which acts as a minimum bound. For example, with a resync interval of 5m and a RelistDuration of 2m, relists will occur at the resync interval of 5m.",
+							Description: "RelistDuration is the frequency by which a controller will relist the broker when the RelistBehavior is set to ServiceBrokerRelistBehaviorDuration or ServiceBrokerRelistBehaviorProxy. Users are cautioned against configuring low values for the RelistDuration, as this can easily overload the controller manager in an environment with many brokers. The actual interval is intrinsically governed by the configured resync interval of the controller, which acts as a minimum bound. For example, with a resync interval of 5m and a RelistDuration of 2m, relists will occur at the resync interval of 5m.",
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
@@ -1307,12 +1513,25 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerSpec(ref common.R
 							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions"),
 						},
 					},
+					"rateLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RateLimit limits the rate and concurrency of the requests sent to the broker by the controller. Requests are not limited when it is unset. Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerRateLimit"),
+						},
+					},
+					"healthCheckPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HealthCheckPath is the path of the endpoint of the broker, relative to its URL, that the controller sends GET requests to in order to check whether the broker is up. If unset, HEAD requests are sent to the catalog endpoint of the broker instead. Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"url"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerRateLimit", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -1361,12 +1580,31 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerStatus(ref common
 							Format:      "",
 						},
 					},
+					"lastCatalogDiff": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nLastCatalogDiff summarizes the changes made to the broker's service classes and plans the last time its catalog was reconciled.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogDiff"),
+						},
+					},
+					"lastHealthCheckTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nLastHealthCheckTime is the time the health of the broker was last checked.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"catalogHistoryHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nCatalogHistoryHash is the hash of the catalog recorded as the latest revision of the catalog history of the broker.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"conditions", "reconciledGeneration", "lastConditionState"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerCondition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogDiff", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerCondition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -1412,6 +1650,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServiceClassSpec(ref common.Re
 							Format:      "",
 						},
 					},
+					"instanceRetrievable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nInstanceRetrievable indicates whether fetching a service instance via a GET on its endpoint is supported for all plans.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"planUpdatable": {
 						SchemaProps: spec.SchemaProps{
 							Description: "PlanUpdatable indicates whether instances provisioned from this ServiceClass may change ServicePlans after being provisioned.",
@@ -1567,12 +1812,18 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServicePlanSpec(ref common.Ref
 							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
 						},
 					},
+					"maintenanceInfo": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nMaintenanceInfo is the maintenance information of this plan, as reported by the broker. A change in its version indicates that an upgrade is available for ServiceInstances on this plan.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo"),
+						},
+					},
 				},
 				Required: []string{"externalName", "externalID", "description", "free"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -1617,6 +1868,55 @@ func schema_pkg_apis_servicecatalog_v1beta1_LocalObjectReference(ref common.Refe
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_MaintenanceInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MaintenanceInfo describes the version of the software deployed for ServiceInstances of a plan.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version is the semantic version of the maintenance information.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"description": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Description is a human-readable description of the changes in this version.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"version"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_servicecatalog_v1beta1_OAuth2AuthConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OAuth2AuthConfig provides config for the OAuth2 client credentials authentication of namespaced brokers.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"secretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretRef is a reference to a Secret containing the OAuth2 client credentials the catalog should use to obtain access tokens for this ServiceBroker.\n\nRequired fields: - Secret.Data[\"clientID\"] - the client identifier - Secret.Data[\"clientSecret\"] - the client secret - Secret.Data[\"tokenURL\"] - the URL of the token endpoint\n\nOptional fields: - Secret.Data[\"scopes\"] - space separated scopes to request",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference"},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_ObjectReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1878,6 +2178,41 @@ func schema_pkg_apis_servicecatalog_v1beta1_SecretTransform(ref common.Reference
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_ServiceAccountTokenAuthConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceAccountTokenAuthConfig provides config for the service account token authentication of namespaced brokers.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"serviceAccountRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceAccountRef is a reference to the ServiceAccount, in the namespace of the ServiceBroker, whose tokens are presented to this ServiceBroker.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference"),
+						},
+					},
+					"audience": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Audience is the intended audience of the tokens, which the broker must verify. It is required, so that the tokens are not accepted by the API server.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"expirationSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExpirationSeconds is the requested lifetime of the tokens. The controller requests a new token once 80% of its lifetime has elapsed. Defaults to one hour, and must be at least 10 minutes.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference"},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_ServiceBinding(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -2061,6 +2396,75 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingPropertiesState(ref co
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingRotationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceBindingRotationStatus describes the progress of the rotation of the credentials of a ServiceBinding.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"rotationRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RotationRequests is the value of spec.rotationRequests the rotation was started for.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the phase the rotation is in.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"previousExternalID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PreviousExternalID is the external ID of the binding whose credentials are being replaced. It is cleared once that binding has been unbound.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"externalID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExternalID is the external ID chosen by the controller for the new binding. Only this value may replace spec.externalID while the new binding is created.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartTime is the time at which the rotation started.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"previousUnbindTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PreviousUnbindTime is the time after which the binding whose credentials were replaced is unbound.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"completionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletionTime is the time at which the rotation completed.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is a human readable description of why the rotation failed.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"rotationRequests", "phase"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -2115,7 +2519,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingSpec(ref common.Refere
 					},
 					"externalID": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ExternalID is the identity of this object for use with the OSB API.\n\nImmutable.",
+							Description: "ExternalID is the identity of this object for use with the OSB API.\n\nImmutable, except when it is replaced by the controller while rotating the credentials of the ServiceBinding.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -2126,6 +2530,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingSpec(ref common.Refere
 							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo"),
 						},
 					},
+					"rotationRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nRotationRequests is a strictly increasing, non-negative integer counter that can be manually incremented by a user to request the credentials of the ServiceBinding to be rotated. A new binding is created at the broker, its credentials replace those in the Secret, and the previous binding is unbound once the rotation grace period has elapsed.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 				},
 				Required: []string{"instanceRef"},
 			},
@@ -2214,6 +2625,30 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingStatus(ref common.Refe
 							Format:      "",
 						},
 					},
+					"lastRetrievalTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nLastRetrievalTime is the time the credentials of the ServiceBinding were last fetched from the broker to be compared against its Secret.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"rotationStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nRotationStatus describes the progress of the most recent rotation of the credentials of the ServiceBinding.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingRotationStatus"),
+						},
+					},
+					"expiresAt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nExpiresAt is the time at which the credentials of the ServiceBinding expire, as reported by the broker.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"renewBefore": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nRenewBefore is the time, as reported by the broker, before which the credentials of the ServiceBinding should be renewed. The controller rotates the credentials of the ServiceBinding once it is reached.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 					"lastConditionState": {
 						SchemaProps: spec.SchemaProps{
 							Description: "LastConditionState aggregates state from the Conditions array It is used for printing in a kubectl output via additionalPrinterColumns",
@@ -2226,7 +2661,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingStatus(ref common.Refe
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingCondition", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingPropertiesState", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingCondition", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingPropertiesState", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingRotationStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -2301,11 +2736,29 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerAuthInfo(ref common.Ref
 							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BearerTokenAuthConfig"),
 						},
 					},
+					"tls": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TLS provides configuration to authenticate with a client certificate and key, referenced from the given kubernetes.io/tls secret. Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.TLSAuthConfig"),
+						},
+					},
+					"oauth2": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OAuth2 provides configuration to authenticate with an access token obtained through the OAuth2 client credentials grant, using the client credentials and token endpoint referenced from the given secret. Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.OAuth2AuthConfig"),
+						},
+					},
+					"serviceAccountToken": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceAccountToken provides configuration to authenticate with a bound token of the given ServiceAccount, requested by the controller through the TokenRequest API and presented as a bearer token. Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceAccountTokenAuthConfig"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BasicAuthConfig", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BearerTokenAuthConfig"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BasicAuthConfig", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.BearerTokenAuthConfig", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.OAuth2AuthConfig", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceAccountTokenAuthConfig", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.TLSAuthConfig"},
 	}
 }
 
@@ -2406,6 +2859,40 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerList(ref common.Referen
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerRateLimit(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceBrokerRateLimit limits the requests sent to a broker. Requests that exceed the limits are not sent, and the resources they were sent for are reconciled again later.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"qps": {
+						SchemaProps: spec.SchemaProps{
+							Description: "QPS is the maximum average number of requests per second sent to the broker. Zero means the rate of requests is not limited.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"burst": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Burst is the maximum number of requests sent to the broker at once when it has not received requests for a while. Defaults to QPS.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxInFlight": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxInFlight is the maximum number of requests sent to the broker that are awaiting a response. Zero means the number of concurrent requests is not limited.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -2443,7 +2930,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerSpec(ref common.Referen
 					},
 					"relistDuration": {
 						SchemaProps: spec.SchemaProps{
-							Description: "RelistDuration is the frequency by which a controller will relist the broker when the RelistBehavior is set to ServiceBrokerRelistBehaviorDuration. Users are cautioned against configuring low values for the RelistDuration, as this can easily overload the controller manager in an environment with many brokers. The actual interval is intrinsically governed by the configured resync interval of the controller, which acts as a minimum bound. For example, with a resync interval of 5m and a RelistDuration of 2m, relists will occur at the resync interval of 5m.",
+							Description: "RelistDuration is the frequency by which a controller will relist the broker when the RelistBehavior is set to ServiceBrokerRelistBehaviorDuration or ServiceBrokerRelistBehaviorProxy. Users are cautioned against configuring low values for the RelistDuration, as this can easily overload the controller manager in an environment with many brokers. The actual interval is intrinsically governed by the configured resync interval of the controller, which acts as a minimum bound. For example, with a resync interval of 5m and a RelistDuration of 2m, relists will occur at the resync interval of 5m.",
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
@@ -2460,6 +2947,19 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerSpec(ref common.Referen
 							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions"),
 						},
 					},
+					"rateLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RateLimit limits the rate and concurrency of the requests sent to the broker by the controller. Requests are not limited when it is unset. Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerRateLimit"),
+						},
+					},
+					"healthCheckPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HealthCheckPath is the path of the endpoint of the broker, relative to its URL, that the controller sends GET requests to in order to check whether the broker is up. If unset, HEAD requests are sent to the catalog endpoint of the broker instead. Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"authInfo": {
 						SchemaProps: spec.SchemaProps{
 							Description: "AuthInfo contains the data that the service catalog should use to authenticate with the ServiceBroker.",
@@ -2471,7 +2971,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerSpec(ref common.Referen
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerAuthInfo", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerAuthInfo", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerRateLimit", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -2520,12 +3020,31 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerStatus(ref common.Refer
 							Format:      "",
 						},
 					},
+					"lastCatalogDiff": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nLastCatalogDiff summarizes the changes made to the broker's service classes and plans the last time its catalog was reconciled.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogDiff"),
+						},
+					},
+					"lastHealthCheckTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nLastHealthCheckTime is the time the health of the broker was last checked.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"catalogHistoryHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nCatalogHistoryHash is the hash of the catalog recorded as the latest revision of the catalog history of the broker.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"conditions", "reconciledGeneration", "lastConditionState"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerCondition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogDiff", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerCondition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -2665,6 +3184,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceClassSpec(ref common.Referenc
 							Format:      "",
 						},
 					},
+					"instanceRetrievable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nInstanceRetrievable indicates whether fetching a service instance via a GET on its endpoint is supported for all plans.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"planUpdatable": {
 						SchemaProps: spec.SchemaProps{
 							Description: "PlanUpdatable indicates whether instances provisioned from this ServiceClass may change ServicePlans after being provisioned.",
@@ -2952,12 +3478,18 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstancePropertiesState(ref c
 							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo"),
 						},
 					},
+					"maintenanceInfo": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaintenanceInfo is the maintenance information that the broker knows this ServiceInstance to be at.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo"),
+						},
+					},
 				},
 				Required: []string{"clusterServicePlanExternalName", "clusterServicePlanExternalID"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -3115,11 +3647,17 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceSpec(ref common.Refer
 							Format:      "int64",
 						},
 					},
+					"maintenanceInfo": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nMaintenanceInfo is the maintenance information this instance should be at. Setting it to the MaintenanceInfo of the referenced plan requests an upgrade of the instance when the UpgradeAvailable condition is true.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -3171,6 +3709,19 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceStatus(ref common.Ref
 							Format:      "",
 						},
 					},
+					"lastRetrievalTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nLastRetrievalTime is the time the state of the ServiceInstance was last fetched from the broker.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"brokerReportedParametersChecksum": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nBrokerReportedParametersChecksum is the checksum of the parameters of the ServiceInstance reported by the broker when its state was last fetched. The parameters themselves are not stored, since they may hold values the ServiceInstance gets from secrets.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"currentOperation": {
 						SchemaProps: spec.SchemaProps{
 							Description: "CurrentOperation is the operation the Controller is currently performing on the ServiceInstance.",
@@ -3437,6 +3988,12 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServicePlanSpec(ref common.Reference
 							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
 						},
 					},
+					"maintenanceInfo": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nMaintenanceInfo is the maintenance information of this plan, as reported by the broker. A change in its version indicates that an upgrade is available for ServiceInstances on this plan.",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo"),
+						},
+					},
 					"serviceBrokerName": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ServiceBrokerName is the name of the ServiceBroker that offers this ServicePlan.",
@@ -3455,7 +4012,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServicePlanSpec(ref common.Reference
 			},
 		},
 		Dependencies: []string{
-			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference", "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
@@ -3480,6 +4037,27 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServicePlanStatus(ref common.Referen
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_TLSAuthConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TLSAuthConfig provides config for the mutual TLS authentication of namespaced brokers.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"secretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretRef is a reference to a kubernetes.io/tls Secret containing the client certificate the catalog should present to this ServiceBroker.\n\nRequired fields: - Secret.Data[\"tls.crt\"] - PEM encoded client certificate - Secret.Data[\"tls.key\"] - PEM encoded private key of the certificate",
+							Ref:         ref("github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference"},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_UserInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
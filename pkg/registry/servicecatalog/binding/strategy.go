@@ -141,17 +141,22 @@ func (bindingRESTStrategy) PrepareForUpdate(ctx context.Context, new, old runtim
 	newServiceBinding.Status = oldServiceBinding.Status
 
 	// TODO: We currently don't handle any changes to the spec in the
-	// reconciler. Once we do that, this check needs to be removed and
-	// proper validation of allowed changes needs to be implemented in
-	// ValidateUpdate. Also, the check for whether the generation needs
-	// to be updated needs to be un-commented.
+	// reconciler except for credential rotation. Once we do that, this check
+	// needs to be removed and proper validation of allowed changes needs to
+	// be implemented in ValidateUpdate.
+	rotationRequests := newServiceBinding.Spec.RotationRequests
+	externalID := newServiceBinding.Spec.ExternalID
 	newServiceBinding.Spec = oldServiceBinding.Spec
 
+	newServiceBinding.Spec.RotationRequests = rotationRequests
+	// The external ID may only be replaced by the one the controller chose
+	// for the rotation of the credentials of the binding.
+	if rotationID := oldServiceBinding.GetRotationExternalID(); rotationID != "" && externalID == rotationID {
+		newServiceBinding.Spec.ExternalID = externalID
+	}
+
 	// Spec updates bump the generation so that we can distinguish between
 	// spec changes and other changes to the object.
-	//
-	// Note that since we do not currently handle any changes to the spec,
-	// the generation will never be incremented
 	if !apiequality.Semantic.DeepEqual(oldServiceBinding.Spec, newServiceBinding.Spec) {
 		if utilfeature.DefaultFeatureGate.Enabled(scfeatures.OriginatingIdentity) {
 			setServiceBindingUserInfo(ctx, newServiceBinding)
@@ -157,3 +157,85 @@ func TestExternalIDUserProvided(t *testing.T) {
 		t.Errorf("Modified user provided ExternalID to %q", createdInstanceCredential.Spec.ExternalID)
 	}
 }
+
+// TestExternalIDUpdate checks that the ExternalID of a binding can only be
+// changed to the one chosen by the controller when rotating its credentials.
+func TestExternalIDUpdate(t *testing.T) {
+	cases := []struct {
+		name       string
+		rotation   *servicecatalog.ServiceBindingRotationStatus
+		externalID string
+		expected   string
+	}{
+		{
+			name:       "no rotation",
+			externalID: "other-id",
+			expected:   "old-id",
+		},
+		{
+			name: "rotation starting with the chosen ID",
+			rotation: &servicecatalog.ServiceBindingRotationStatus{
+				Phase:              servicecatalog.ServiceBindingRotationPhaseBinding,
+				PreviousExternalID: "old-id",
+				ExternalID:         "new-id",
+			},
+			externalID: "new-id",
+			expected:   "new-id",
+		},
+		{
+			name: "rotation starting with another ID",
+			rotation: &servicecatalog.ServiceBindingRotationStatus{
+				Phase:              servicecatalog.ServiceBindingRotationPhaseBinding,
+				PreviousExternalID: "old-id",
+				ExternalID:         "new-id",
+			},
+			externalID: "other-id",
+			expected:   "old-id",
+		},
+		{
+			name: "rotation starting without a chosen ID",
+			rotation: &servicecatalog.ServiceBindingRotationStatus{
+				Phase:              servicecatalog.ServiceBindingRotationPhaseBinding,
+				PreviousExternalID: "old-id",
+			},
+			externalID: "other-id",
+			expected:   "old-id",
+		},
+	}
+	for _, tc := range cases {
+		older := getTestInstanceCredential()
+		older.Spec.ExternalID = "old-id"
+		older.Status.RotationStatus = tc.rotation
+		newer := getTestInstanceCredential()
+		newer.Spec.ExternalID = tc.externalID
+		bindingRESTStrategies.PrepareForUpdate(sctestutil.ContextWithUserName("updater"), newer, older)
+
+		if e, a := tc.expected, newer.Spec.ExternalID; e != a {
+			t.Errorf("%v: expected %q, got %q for external ID", tc.name, e, a)
+		}
+	}
+
+	// A failed rotation may only be rolled back to the previous ID.
+	for _, tc := range []struct {
+		externalID string
+		expected   string
+	}{
+		{externalID: "old-id", expected: "old-id"},
+		{externalID: "other-id", expected: "new-id"},
+	} {
+		older := getTestInstanceCredential()
+		older.Spec.ExternalID = "new-id"
+		older.Status.RotationStatus = &servicecatalog.ServiceBindingRotationStatus{
+			Phase:              servicecatalog.ServiceBindingRotationPhaseFailed,
+			PreviousExternalID: "old-id",
+			ExternalID:         "new-id",
+		}
+		newer := getTestInstanceCredential()
+		newer.Spec.ExternalID = tc.externalID
+		bindingRESTStrategies.PrepareForUpdate(sctestutil.ContextWithUserName("updater"), newer, older)
+
+		if e, a := tc.expected, newer.Spec.ExternalID; e != a {
+			t.Errorf("failed rotation: expected %q, got %q for external ID", e, a)
+		}
+	}
+}
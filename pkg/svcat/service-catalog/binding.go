@@ -26,6 +26,7 @@ import (
 	"github.com/hashicorp/go-multierror"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -70,9 +71,10 @@ func (sdk *SDK) RetrieveBindingsByInstance(instance *v1beta1.ServiceInstance,
 	return bindings, nil
 }
 
-// Bind an instance to a secret.
-func (sdk *SDK) Bind(namespace, bindingName, externalID, instanceName, secretName string,
-	params interface{}, secrets map[string]string) (*v1beta1.ServiceBinding, error) {
+// BuildBinding returns the binding of an instance to a secret that Bind
+// creates.
+func BuildBinding(namespace, bindingName, externalID, instanceName, secretName string,
+	params interface{}, secrets map[string]string) *v1beta1.ServiceBinding {
 
 	// Manually defaulting the name of the binding
 	// I'm not doing the same for the secret since the API handles defaulting that value.
@@ -80,7 +82,7 @@ func (sdk *SDK) Bind(namespace, bindingName, externalID, instanceName, secretNam
 		bindingName = instanceName
 	}
 
-	request := &v1beta1.ServiceBinding{
+	return &v1beta1.ServiceBinding{
 		ObjectMeta: v1.ObjectMeta{
 			Name:      bindingName,
 			Namespace: namespace,
@@ -95,7 +97,13 @@ func (sdk *SDK) Bind(namespace, bindingName, externalID, instanceName, secretNam
 			ParametersFrom: BuildParametersFrom(secrets),
 		},
 	}
+}
+
+// Bind an instance to a secret.
+func (sdk *SDK) Bind(namespace, bindingName, externalID, instanceName, secretName string,
+	params interface{}, secrets map[string]string) (*v1beta1.ServiceBinding, error) {
 
+	request := BuildBinding(namespace, bindingName, externalID, instanceName, secretName, params, secrets)
 	result, err := sdk.ServiceCatalog().ServiceBindings(namespace).Create(request)
 	if err != nil {
 		return nil, errors.Wrap(err, "bind request failed")
@@ -208,8 +216,13 @@ func (sdk *SDK) BindingParentHierarchy(binding *v1beta1.ServiceBinding,
 // GetBindingStatusCondition returns the last condition on a binding status.
 // When no conditions exist, an empty condition is returned.
 func GetBindingStatusCondition(status v1beta1.ServiceBindingStatus) v1beta1.ServiceBindingCondition {
-	if len(status.Conditions) > 0 {
-		return status.Conditions[len(status.Conditions)-1]
+	for i := len(status.Conditions) - 1; i >= 0; i-- {
+		// The Drifted condition describes the last resync of the credentials
+		// and not the state of the current operation.
+		if status.Conditions[i].Type == v1beta1.ServiceBindingConditionDrifted {
+			continue
+		}
+		return status.Conditions[i]
 	}
 	return v1beta1.ServiceBindingCondition{}
 }
@@ -240,6 +253,61 @@ func (sdk *SDK) WaitForBinding(ns, name string, interval time.Duration, timeout
 	return binding, err
 }
 
+// RotateBinding requests that the controller rotate the credentials of a
+// binding by incrementing its spec.rotationRequests.
+func (sdk *SDK) RotateBinding(ns, name string, retries int) (*v1beta1.ServiceBinding, error) {
+	for j := 0; j < retries; j++ {
+		binding, err := sdk.RetrieveBinding(ns, name)
+		if err != nil {
+			return nil, err
+		}
+
+		binding.Spec.RotationRequests = binding.Spec.RotationRequests + 1
+
+		binding, err = sdk.ServiceCatalog().ServiceBindings(ns).Update(binding)
+		if err == nil {
+			return binding, nil
+		}
+		// if we didn't get a conflict, no idea what happened
+		if !apierrors.IsConflict(err) {
+			return nil, fmt.Errorf("could not rotate binding (%s)", err)
+		}
+	}
+
+	// conflict after `retries` tries
+	return nil, fmt.Errorf("could not rotate binding after %d tries", retries)
+}
+
+// WaitForBindingRotation waits for the controller to swap the credentials of
+// the binding for the specified rotation request (or for the binding to fail).
+func (sdk *SDK) WaitForBindingRotation(ns, name string, rotationRequests int64, interval time.Duration, timeout *time.Duration) (binding *v1beta1.ServiceBinding, err error) {
+	if timeout == nil {
+		notimeout := time.Duration(math.MaxInt64)
+		timeout = &notimeout
+	}
+
+	err = wait.PollImmediate(interval, *timeout,
+		func() (bool, error) {
+			binding, err = sdk.RetrieveBinding(ns, name)
+			if err != nil {
+				return true, err
+			}
+
+			if sdk.IsBindingFailed(binding) {
+				return true, nil
+			}
+
+			rotation := binding.Status.RotationStatus
+			if rotation == nil || rotation.RotationRequests < rotationRequests {
+				return false, nil
+			}
+			return rotation.Phase != v1beta1.ServiceBindingRotationPhaseBinding, nil
+		},
+	)
+
+	return binding, err
+}
+
 // IsBindingReady returns true if the instance is in the Ready status.
 func (sdk *SDK) IsBindingReady(binding *v1beta1.ServiceBinding) bool {
 	return sdk.bindingHasStatus(binding, v1beta1.ServiceBindingConditionReady)
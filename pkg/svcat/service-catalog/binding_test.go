@@ -280,6 +280,38 @@ var _ = Describe("Binding", func() {
 		})
 	})
 
+	Describe("RotateBinding", func() {
+		It("Increments the rotation requests of the binding", func() {
+			binding, err := sdk.RotateBinding(sb.Namespace, sb.Name, 3)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(binding.Spec.RotationRequests).To(Equal(int64(1)))
+			actions := svcCatClient.Actions()
+			Expect(len(actions)).To(Equal(2))
+			Expect(actions[0].Matches("get", "servicebindings")).To(BeTrue())
+			Expect(actions[1].Matches("update", "servicebindings")).To(BeTrue())
+			obj := actions[1].(testing.UpdateActionImpl).Object.(*v1beta1.ServiceBinding)
+			Expect(obj.Name).To(Equal(sb.Name))
+			Expect(obj.Spec.RotationRequests).To(Equal(int64(1)))
+		})
+		It("Bubbles up errors", func() {
+			badClient := &fake.Clientset{}
+			errorMessage := "error updating binding"
+			badClient.AddReactor("get", "servicebindings", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, sb, nil
+			})
+			badClient.AddReactor("update", "servicebindings", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, fmt.Errorf(errorMessage)
+			})
+			sdk.ServiceCatalogClient = badClient
+
+			_, err := sdk.RotateBinding(sb.Namespace, sb.Name, 3)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(errorMessage))
+		})
+	})
+
 	Describe("DeleteBindings", func() {
 		It("Calls the generated v1beta1 delete method for every binding", func() {
 			si := &v1beta1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: "myinstance", Namespace: sb.Namespace}}
@@ -173,8 +173,9 @@ func (sdk *SDK) RetrieveBrokerByClass(class *v1beta1.ClusterServiceClass,
 	return broker, nil
 }
 
-//Register creates a broker
-func (sdk *SDK) Register(brokerName string, url string, opts *RegisterOptions, scopeOpts *ScopeOptions) (Broker, error) {
+// BuildBroker returns the broker that Register creates, either a
+// ClusterServiceBroker or a ServiceBroker depending on the scope.
+func BuildBroker(brokerName string, url string, opts *RegisterOptions, scopeOpts *ScopeOptions) (Broker, error) {
 	var err error
 	var caBytes []byte
 	if opts.CAFile != "" {
@@ -219,15 +220,35 @@ func (sdk *SDK) Register(brokerName string, url string, opts *RegisterOptions, s
 					Namespace: opts.Namespace,
 				},
 			}
+		} else if opts.TLSSecret != "" {
+			request.Spec.AuthInfo = &v1beta1.ClusterServiceBrokerAuthInfo{}
+			request.Spec.AuthInfo.TLS = &v1beta1.ClusterTLSAuthConfig{
+				SecretRef: &v1beta1.ObjectReference{
+					Name:      opts.TLSSecret,
+					Namespace: opts.Namespace,
+				},
+			}
+		} else if opts.OAuth2Secret != "" {
+			request.Spec.AuthInfo = &v1beta1.ClusterServiceBrokerAuthInfo{}
+			request.Spec.AuthInfo.OAuth2 = &v1beta1.ClusterOAuth2AuthConfig{
+				SecretRef: &v1beta1.ObjectReference{
+					Name:      opts.OAuth2Secret,
+					Namespace: opts.Namespace,
+				},
+			}
+		} else if opts.ServiceAccount != "" {
+			request.Spec.AuthInfo = &v1beta1.ClusterServiceBrokerAuthInfo{}
+			request.Spec.AuthInfo.ServiceAccountToken = &v1beta1.ClusterServiceAccountTokenAuthConfig{
+				ServiceAccountRef: &v1beta1.ObjectReference{
+					Name:      opts.ServiceAccount,
+					Namespace: opts.Namespace,
+				},
+				Audience: opts.TokenAudience,
+			}
 		}
-
-		result, err := sdk.ServiceCatalog().ClusterServiceBrokers().Create(request)
-		if err != nil {
-			return nil, fmt.Errorf("register request failed (%s)", err)
-		}
-
-		return result, nil
+		return request, nil
 	} //else matches NamespaceScope
+	objectMeta.Namespace = scopeOpts.Namespace
 	request := &v1beta1.ServiceBroker{
 		ObjectMeta: objectMeta,
 		Spec: v1beta1.ServiceBrokerSpec{
@@ -250,9 +271,49 @@ func (sdk *SDK) Register(brokerName string, url string, opts *RegisterOptions, s
 				},
 			},
 		}
+	} else if opts.TLSSecret != "" {
+		request.Spec.AuthInfo = &v1beta1.ServiceBrokerAuthInfo{
+			TLS: &v1beta1.TLSAuthConfig{
+				SecretRef: &v1beta1.LocalObjectReference{
+					Name: opts.TLSSecret,
+				},
+			},
+		}
+	} else if opts.OAuth2Secret != "" {
+		request.Spec.AuthInfo = &v1beta1.ServiceBrokerAuthInfo{
+			OAuth2: &v1beta1.OAuth2AuthConfig{
+				SecretRef: &v1beta1.LocalObjectReference{
+					Name: opts.OAuth2Secret,
+				},
+			},
+		}
+	} else if opts.ServiceAccount != "" {
+		request.Spec.AuthInfo = &v1beta1.ServiceBrokerAuthInfo{
+			ServiceAccountToken: &v1beta1.ServiceAccountTokenAuthConfig{
+				ServiceAccountRef: &v1beta1.LocalObjectReference{
+					Name: opts.ServiceAccount,
+				},
+				Audience: opts.TokenAudience,
+			},
+		}
+	}
+	return request, nil
+}
+
+//Register creates a broker
+func (sdk *SDK) Register(brokerName string, url string, opts *RegisterOptions, scopeOpts *ScopeOptions) (Broker, error) {
+	request, err := BuildBroker(brokerName, url, opts, scopeOpts)
+	if err != nil {
+		return nil, err
 	}
 
-	result, err := sdk.ServiceCatalog().ServiceBrokers(scopeOpts.Namespace).Create(request)
+	var result Broker
+	switch broker := request.(type) {
+	case *v1beta1.ClusterServiceBroker:
+		result, err = sdk.ServiceCatalog().ClusterServiceBrokers().Create(broker)
+	case *v1beta1.ServiceBroker:
+		result, err = sdk.ServiceCatalog().ServiceBrokers(scopeOpts.Namespace).Create(broker)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("register request failed (%s)", err)
 	}
@@ -494,6 +494,92 @@ var _ = Describe("Broker", func() {
 			Expect(objectFromRequest.Spec.URL).To(Equal(url))
 			Expect(objectFromRequest.Spec.AuthInfo.Bearer.SecretRef.Name).To(Equal(bearerSecret))
 		})
+		It("creates a namespace service broker with a tls secret", func() {
+			brokerName := "potato_broker"
+			url := "http://potato.com"
+			namespace := "potatonamespace"
+			tlsSecret := "potatotlssecret"
+			opts := &RegisterOptions{
+				Namespace: namespace,
+				TLSSecret: tlsSecret,
+			}
+			scopeOpts := &ScopeOptions{
+				Namespace: namespace,
+				Scope:     NamespaceScope,
+			}
+
+			broker, err := sdk.Register(brokerName, url, opts, scopeOpts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker).NotTo(BeNil())
+			Expect(broker.GetName()).To(Equal(brokerName))
+			Expect(broker.GetURL()).To(Equal(url))
+
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("create", "servicebrokers")).To(BeTrue())
+			objectFromRequest := actions[0].(testing.CreateActionImpl).Object.(*v1beta1.ServiceBroker)
+			Expect(objectFromRequest.ObjectMeta.Name).To(Equal(brokerName))
+			Expect(objectFromRequest.Spec.URL).To(Equal(url))
+			Expect(objectFromRequest.Spec.AuthInfo.TLS.SecretRef.Name).To(Equal(tlsSecret))
+		})
+		It("creates a namespace service broker with a oauth2 secret", func() {
+			brokerName := "potato_broker"
+			url := "http://potato.com"
+			namespace := "potatonamespace"
+			oauth2Secret := "potatooauth2secret"
+			opts := &RegisterOptions{
+				Namespace:    namespace,
+				OAuth2Secret: oauth2Secret,
+			}
+			scopeOpts := &ScopeOptions{
+				Namespace: namespace,
+				Scope:     NamespaceScope,
+			}
+
+			broker, err := sdk.Register(brokerName, url, opts, scopeOpts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker).NotTo(BeNil())
+			Expect(broker.GetName()).To(Equal(brokerName))
+			Expect(broker.GetURL()).To(Equal(url))
+
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("create", "servicebrokers")).To(BeTrue())
+			objectFromRequest := actions[0].(testing.CreateActionImpl).Object.(*v1beta1.ServiceBroker)
+			Expect(objectFromRequest.ObjectMeta.Name).To(Equal(brokerName))
+			Expect(objectFromRequest.Spec.URL).To(Equal(url))
+			Expect(objectFromRequest.Spec.AuthInfo.OAuth2.SecretRef.Name).To(Equal(oauth2Secret))
+		})
+		It("creates a namespace service broker with a service account", func() {
+			brokerName := "potato_broker"
+			url := "http://potato.com"
+			namespace := "potatonamespace"
+			serviceAccount := "potatoserviceaccount"
+			opts := &RegisterOptions{
+				Namespace:      namespace,
+				ServiceAccount: serviceAccount,
+				TokenAudience:  url,
+			}
+			scopeOpts := &ScopeOptions{
+				Namespace: namespace,
+				Scope:     NamespaceScope,
+			}
+
+			broker, err := sdk.Register(brokerName, url, opts, scopeOpts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker).NotTo(BeNil())
+			Expect(broker.GetName()).To(Equal(brokerName))
+			Expect(broker.GetURL()).To(Equal(url))
+
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("create", "servicebrokers")).To(BeTrue())
+			objectFromRequest := actions[0].(testing.CreateActionImpl).Object.(*v1beta1.ServiceBroker)
+			Expect(objectFromRequest.ObjectMeta.Name).To(Equal(brokerName))
+			Expect(objectFromRequest.Spec.URL).To(Equal(url))
+			Expect(objectFromRequest.Spec.AuthInfo.ServiceAccountToken.ServiceAccountRef.Name).To(Equal(serviceAccount))
+			Expect(objectFromRequest.Spec.AuthInfo.ServiceAccountToken.Audience).To(Equal(url))
+		})
 		It("Bubbles up namespace service broker errors", func() {
 			errorMessage := "error provisioning broker"
 			brokerName := "potato_broker"
@@ -590,6 +676,92 @@ var _ = Describe("Broker", func() {
 			Expect(objectFromRequest.Spec.URL).To(Equal(url))
 			Expect(objectFromRequest.Spec.AuthInfo.Bearer.SecretRef.Name).To(Equal(bearerSecret))
 		})
+		It("creates a cluster service broker with a tls secret", func() {
+			brokerName := "potato_broker"
+			url := "http://potato.com"
+			namespace := "potatonamespace"
+			tlsSecret := "potatotlssecret"
+			opts := &RegisterOptions{
+				Namespace: namespace,
+				TLSSecret: tlsSecret,
+			}
+			scopeOpts := &ScopeOptions{
+				Namespace: namespace,
+				Scope:     ClusterScope,
+			}
+
+			broker, err := sdk.Register(brokerName, url, opts, scopeOpts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker).NotTo(BeNil())
+			Expect(broker.GetName()).To(Equal(brokerName))
+			Expect(broker.GetURL()).To(Equal(url))
+
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("create", "clusterservicebrokers")).To(BeTrue())
+			objectFromRequest := actions[0].(testing.CreateActionImpl).Object.(*v1beta1.ClusterServiceBroker)
+			Expect(objectFromRequest.ObjectMeta.Name).To(Equal(brokerName))
+			Expect(objectFromRequest.Spec.URL).To(Equal(url))
+			Expect(objectFromRequest.Spec.AuthInfo.TLS.SecretRef.Name).To(Equal(tlsSecret))
+		})
+		It("creates a cluster service broker with a oauth2 secret", func() {
+			brokerName := "potato_broker"
+			url := "http://potato.com"
+			namespace := "potatonamespace"
+			oauth2Secret := "potatooauth2secret"
+			opts := &RegisterOptions{
+				Namespace:    namespace,
+				OAuth2Secret: oauth2Secret,
+			}
+			scopeOpts := &ScopeOptions{
+				Namespace: namespace,
+				Scope:     ClusterScope,
+			}
+
+			broker, err := sdk.Register(brokerName, url, opts, scopeOpts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker).NotTo(BeNil())
+			Expect(broker.GetName()).To(Equal(brokerName))
+			Expect(broker.GetURL()).To(Equal(url))
+
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("create", "clusterservicebrokers")).To(BeTrue())
+			objectFromRequest := actions[0].(testing.CreateActionImpl).Object.(*v1beta1.ClusterServiceBroker)
+			Expect(objectFromRequest.ObjectMeta.Name).To(Equal(brokerName))
+			Expect(objectFromRequest.Spec.URL).To(Equal(url))
+			Expect(objectFromRequest.Spec.AuthInfo.OAuth2.SecretRef.Name).To(Equal(oauth2Secret))
+		})
+		It("creates a cluster service broker with a service account", func() {
+			brokerName := "potato_broker"
+			url := "http://potato.com"
+			namespace := "potatonamespace"
+			serviceAccount := "potatoserviceaccount"
+			opts := &RegisterOptions{
+				Namespace:      namespace,
+				ServiceAccount: serviceAccount,
+				TokenAudience:  url,
+			}
+			scopeOpts := &ScopeOptions{
+				Namespace: namespace,
+				Scope:     ClusterScope,
+			}
+
+			broker, err := sdk.Register(brokerName, url, opts, scopeOpts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker).NotTo(BeNil())
+			Expect(broker.GetName()).To(Equal(brokerName))
+			Expect(broker.GetURL()).To(Equal(url))
+
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("create", "clusterservicebrokers")).To(BeTrue())
+			objectFromRequest := actions[0].(testing.CreateActionImpl).Object.(*v1beta1.ClusterServiceBroker)
+			Expect(objectFromRequest.ObjectMeta.Name).To(Equal(brokerName))
+			Expect(objectFromRequest.Spec.URL).To(Equal(url))
+			Expect(objectFromRequest.Spec.AuthInfo.ServiceAccountToken.ServiceAccountRef.Name).To(Equal(serviceAccount))
+			Expect(objectFromRequest.Spec.AuthInfo.ServiceAccountToken.Audience).To(Equal(url))
+		})
 		It("creates a cluster service broker without auth info", func() {
 			brokerName := "potato_broker"
 			url := "http://potato.com"
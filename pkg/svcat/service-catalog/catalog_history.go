@@ -0,0 +1,73 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/cataloghistory"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CatalogRevision is a snapshot of the catalog of a broker, as recorded by
+// the controller each time the catalog changed.
+type CatalogRevision struct {
+	Revision int                  `json:"revision"`
+	Recorded metav1.Time          `json:"recorded"`
+	Catalog  *osb.CatalogResponse `json:"catalog"`
+}
+
+// RetrieveCatalogHistory gets the recorded revisions of the catalog of a
+// broker, oldest first. The history of a ClusterServiceBroker is read from
+// clusterHistoryNamespace, the namespace passed to the controller with
+// --catalog-history-namespace. The history is empty when the controller does
+// not record catalog snapshots.
+func (sdk *SDK) RetrieveCatalogHistory(broker Broker, clusterHistoryNamespace string) ([]CatalogRevision, error) {
+	namespace := broker.GetNamespace()
+	brokerLabels := cataloghistory.ServiceBrokerLabels(broker.GetName())
+	if namespace == "" {
+		namespace = clusterHistoryNamespace
+		brokerLabels = cataloghistory.ClusterServiceBrokerLabels(broker.GetName())
+	}
+
+	list, err := sdk.Core().ConfigMaps(namespace).List(metav1.ListOptions{
+		LabelSelector: cataloghistory.Selector(brokerLabels),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the catalog history of broker %s (%s)", broker.GetName(), err)
+	}
+
+	history := []CatalogRevision{}
+	for _, snapshot := range cataloghistory.SortByRevision(list.Items) {
+		revision, err := cataloghistory.Revision(&snapshot)
+		if err != nil {
+			return nil, err
+		}
+		catalog, err := cataloghistory.Catalog(&snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read revision %d of the catalog history of broker %s (%s)", revision, broker.GetName(), err)
+		}
+		history = append(history, CatalogRevision{
+			Revision: revision,
+			Recorded: snapshot.CreationTimestamp,
+			Catalog:  catalog,
+		})
+	}
+
+	return history, nil
+}
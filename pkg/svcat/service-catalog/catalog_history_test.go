@@ -0,0 +1,104 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-sigs/service-catalog/pkg/cataloghistory"
+	"github.com/kubernetes-sigs/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+
+	. "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Catalog History", func() {
+	var (
+		sdk              *SDK
+		k8sClient        *k8sfake.Clientset
+		clusterBroker    *v1beta1.ClusterServiceBroker
+		namespacedBroker *v1beta1.ServiceBroker
+		catalogV1        *osb.CatalogResponse
+		catalogV2        *osb.CatalogResponse
+	)
+
+	newSnapshot := func(namespace string, brokerLabels map[string]string, revision int, catalog *osb.CatalogResponse) *corev1.ConfigMap {
+		snapshot, err := cataloghistory.NewSnapshot(namespace, "foobar", brokerLabels, revision, catalog)
+		Expect(err).NotTo(HaveOccurred())
+		return snapshot
+	}
+
+	BeforeEach(func() {
+		clusterBroker = &v1beta1.ClusterServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar"}}
+		namespacedBroker = &v1beta1.ServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "foobar-namespace"}}
+		catalogV1 = &osb.CatalogResponse{Services: []osb.Service{{ID: "a", Name: "service-a"}}}
+		catalogV2 = &osb.CatalogResponse{Services: []osb.Service{{ID: "a", Name: "service-a"}, {ID: "b", Name: "service-b"}}}
+
+		k8sClient = k8sfake.NewSimpleClientset(
+			newSnapshot("catalog", cataloghistory.ClusterServiceBrokerLabels("foobar"), 2, catalogV2),
+			newSnapshot("catalog", cataloghistory.ClusterServiceBrokerLabels("foobar"), 1, catalogV1),
+			newSnapshot("foobar-namespace", cataloghistory.ServiceBrokerLabels("foobar"), 1, catalogV2),
+			newSnapshot("other-namespace", cataloghistory.ClusterServiceBrokerLabels("foobar"), 3, catalogV1),
+		)
+		sdk = &SDK{
+			K8sClient:            k8sClient,
+			ServiceCatalogClient: fake.NewSimpleClientset(),
+		}
+	})
+
+	Describe("RetrieveCatalogHistory", func() {
+		It("Gets the catalog history of a cluster-scoped broker, oldest first", func() {
+			history, err := sdk.RetrieveCatalogHistory(clusterBroker, "catalog")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history).To(HaveLen(2))
+			Expect(history[0].Revision).To(Equal(1))
+			Expect(history[0].Catalog).To(Equal(catalogV1))
+			Expect(history[1].Revision).To(Equal(2))
+			Expect(history[1].Catalog).To(Equal(catalogV2))
+
+			actions := k8sClient.Actions()
+			Expect(actions[0].Matches("list", "configmaps")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal("catalog"))
+		})
+		It("Gets the catalog history of a namespaced broker", func() {
+			history, err := sdk.RetrieveCatalogHistory(namespacedBroker, "catalog")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history).To(HaveLen(1))
+			Expect(history[0].Revision).To(Equal(1))
+			Expect(history[0].Catalog).To(Equal(catalogV2))
+
+			actions := k8sClient.Actions()
+			Expect(actions[0].Matches("list", "configmaps")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal(namespacedBroker.Namespace))
+			Expect(actions[0].(testing.ListActionImpl).GetListRestrictions().Labels.String()).To(Equal(cataloghistory.Selector(cataloghistory.ServiceBrokerLabels("foobar"))))
+		})
+		It("Returns an empty history when no catalog snapshots were recorded", func() {
+			history, err := sdk.RetrieveCatalogHistory(&v1beta1.ClusterServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "other"}}, "catalog")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history).To(BeEmpty())
+		})
+	})
+})
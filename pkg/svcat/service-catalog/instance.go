@@ -162,44 +162,44 @@ func (sdk *SDK) InstanceToServiceClassAndPlan(instance *v1beta1.ServiceInstance,
 	}
 }
 
-// Provision creates an instance of a specific service class and plan specified
-// by their k8s names. Depending on provisionClusterInstance, it will create either
-// an instance of a cluster class/plan or a namespaced class/plan
-func (sdk *SDK) Provision(instanceName, classKubeName, planKubeName string, provisionClusterInstance bool, opts *ProvisionOptions) (*v1beta1.ServiceInstance, error) {
-	var request *v1beta1.ServiceInstance
+// BuildInstance returns the instance of a specific service class and plan
+// specified by their k8s names that Provision creates. Depending on
+// provisionClusterInstance, it is either an instance of a cluster class/plan
+// or a namespaced class/plan
+func BuildInstance(instanceName, classKubeName, planKubeName string, provisionClusterInstance bool, opts *ProvisionOptions) *v1beta1.ServiceInstance {
+	request := &v1beta1.ServiceInstance{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      instanceName,
+			Namespace: opts.Namespace,
+		},
+		Spec: v1beta1.ServiceInstanceSpec{
+			ExternalID:     opts.ExternalID,
+			Parameters:     BuildParameters(opts.Params),
+			ParametersFrom: BuildParametersFrom(opts.Secrets),
+		},
+	}
 	if provisionClusterInstance {
-		request = &v1beta1.ServiceInstance{
-			ObjectMeta: v1.ObjectMeta{
-				Name:      instanceName,
-				Namespace: opts.Namespace,
-			},
-			Spec: v1beta1.ServiceInstanceSpec{
-				ExternalID: opts.ExternalID,
-				PlanReference: v1beta1.PlanReference{
-					ClusterServiceClassName: classKubeName,
-					ClusterServicePlanName:  planKubeName,
-				},
-				Parameters:     BuildParameters(opts.Params),
-				ParametersFrom: BuildParametersFrom(opts.Secrets),
-			},
+		request.Spec.PlanReference = v1beta1.PlanReference{
+			ClusterServiceClassName: classKubeName,
+			ClusterServicePlanName:  planKubeName,
 		}
 	} else {
-		request = &v1beta1.ServiceInstance{
-			ObjectMeta: v1.ObjectMeta{
-				Name:      instanceName,
-				Namespace: opts.Namespace,
-			},
-			Spec: v1beta1.ServiceInstanceSpec{
-				ExternalID: opts.ExternalID,
-				PlanReference: v1beta1.PlanReference{
-					ServiceClassName: classKubeName,
-					ServicePlanName:  planKubeName,
-				},
-				Parameters:     BuildParameters(opts.Params),
-				ParametersFrom: BuildParametersFrom(opts.Secrets),
-			},
+		request.Spec.PlanReference = v1beta1.PlanReference{
+			ServiceClassName: classKubeName,
+			ServicePlanName:  planKubeName,
 		}
 	}
+	return request
+}
+
+// Provision creates an instance of a specific service class and plan specified
+// by their k8s names. Depending on provisionClusterInstance, it will create either
+// an instance of a cluster class/plan or a namespaced class/plan
+func (sdk *SDK) Provision(instanceName, classKubeName, planKubeName string, provisionClusterInstance bool, opts *ProvisionOptions) (*v1beta1.ServiceInstance, error) {
+	request := BuildInstance(instanceName, classKubeName, planKubeName, provisionClusterInstance, opts)
+	if opts.DryRun {
+		return sdk.provisionDryRun(request)
+	}
 	result, err := sdk.ServiceCatalog().ServiceInstances(opts.Namespace).Create(request)
 	if err != nil {
 		return nil, fmt.Errorf("provision request failed (%s)", err)
@@ -207,6 +207,25 @@ func (sdk *SDK) Provision(instanceName, classKubeName, planKubeName string, prov
 	return result, nil
 }
 
+// provisionDryRun submits the instance to the server in dry run mode, and
+// returns the instance as it would have been persisted, after the defaulting
+// and validation of the admission webhooks. The generated client does not
+// support create options, so the request is built with the REST client.
+func (sdk *SDK) provisionDryRun(request *v1beta1.ServiceInstance) (*v1beta1.ServiceInstance, error) {
+	result := &v1beta1.ServiceInstance{}
+	err := sdk.ServiceCatalog().RESTClient().Post().
+		Namespace(request.Namespace).
+		Resource("serviceinstances").
+		Param("dryRun", "All").
+		Body(request).
+		Do().
+		Into(result)
+	if err != nil {
+		return nil, fmt.Errorf("provision dry run request failed (%s)", err)
+	}
+	return result, nil
+}
+
 // Deprovision deletes an instance.
 func (sdk *SDK) Deprovision(namespace, instanceName string) error {
 	err := sdk.ServiceCatalog().ServiceInstances(namespace).Delete(instanceName, &v1.DeleteOptions{})
@@ -241,6 +260,129 @@ func (sdk *SDK) TouchInstance(ns, name string, retries int) error {
 	return fmt.Errorf("could not sync service broker after %d tries", retries)
 }
 
+// UpdateInstance changes the plan of an instance to the plan with the given
+// external name, and replaces its parameters and secrets. The plan change is
+// only allowed if the class of the instance is PlanUpdatable, and the
+// parameters must match the update schema of the plan of the instance. The
+// parameters of an instance with secrets are not required to include the
+// required parameters, which the secrets may provide.
+func (sdk *SDK) UpdateInstance(ns, name string, opts *UpdateInstanceOptions) (*v1beta1.ServiceInstance, error) {
+	instance, err := sdk.RetrieveInstance(ns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan Plan
+	if opts.PlanName != "" {
+		plan, err = sdk.RetrieveInstanceUpdatePlan(instance, opts.PlanName)
+		if err != nil {
+			return nil, err
+		}
+		setInstancePlan(instance, plan)
+	} else if opts.Params != nil {
+		plan, err = sdk.RetrieveInstancePlan(instance)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Secrets != nil {
+		instance.Spec.ParametersFrom = BuildParametersFrom(opts.Secrets)
+	}
+	if opts.Params != nil {
+		schema, err := ParseSchema(plan.GetInstanceUpdateSchema())
+		if err != nil {
+			return nil, err
+		}
+		if schema != nil && len(instance.Spec.ParametersFrom) > 0 {
+			partialSchema := *schema
+			partialSchema.Required = nil
+			schema = &partialSchema
+		}
+		if err := schema.Validate(opts.Params); err != nil {
+			return nil, err
+		}
+		instance.Spec.Parameters = BuildParameters(opts.Params)
+	}
+
+	result, err := sdk.ServiceCatalog().ServiceInstances(ns).Update(instance)
+	if err != nil {
+		return nil, fmt.Errorf("update request failed (%s)", err)
+	}
+	return result, nil
+}
+
+// RetrieveInstancePlan gets the plan an instance was resolved to.
+func (sdk *SDK) RetrieveInstancePlan(instance *v1beta1.ServiceInstance) (Plan, error) {
+	if ref := instance.Spec.ClusterServicePlanRef; ref != nil {
+		return sdk.RetrievePlanByID(ref.Name, ScopeOptions{Scope: ClusterScope})
+	}
+	if ref := instance.Spec.ServicePlanRef; ref != nil {
+		return sdk.RetrievePlanByID(ref.Name, ScopeOptions{Scope: NamespaceScope, Namespace: instance.Namespace})
+	}
+	return nil, fmt.Errorf("the plan of instance '%s.%s' is not resolved yet", instance.Namespace, instance.Name)
+}
+
+// RetrieveInstanceUpdatePlan gets the plan with the given external name of the
+// class of an instance, and checks that the class allows the plan change.
+func (sdk *SDK) RetrieveInstanceUpdatePlan(instance *v1beta1.ServiceInstance, planName string) (Plan, error) {
+	var class Class
+	var scopeOpts ScopeOptions
+	switch {
+	case instance.Spec.ClusterServiceClassRef != nil:
+		scopeOpts = ScopeOptions{Scope: ClusterScope}
+		csc, err := sdk.ServiceCatalog().ClusterServiceClasses().Get(instance.Spec.ClusterServiceClassRef.Name, v1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get the class of instance '%s.%s' (%s)", instance.Namespace, instance.Name, err)
+		}
+		class = csc
+	case instance.Spec.ServiceClassRef != nil:
+		scopeOpts = ScopeOptions{Scope: NamespaceScope, Namespace: instance.Namespace}
+		sc, err := sdk.ServiceCatalog().ServiceClasses(instance.Namespace).Get(instance.Spec.ServiceClassRef.Name, v1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get the class of instance '%s.%s' (%s)", instance.Namespace, instance.Name, err)
+		}
+		class = sc
+	default:
+		return nil, fmt.Errorf("the class of instance '%s.%s' is not resolved yet", instance.Namespace, instance.Name)
+	}
+
+	if !class.GetSpec().PlanUpdatable {
+		return nil, fmt.Errorf("the plan of instance '%s.%s' cannot be changed: class '%s' is not plan updatable", instance.Namespace, instance.Name, class.GetExternalName())
+	}
+	plan, err := sdk.RetrievePlanByClassIDAndName(class.GetName(), planName, scopeOpts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find plan '%s': %s", planName, err)
+	}
+	return plan, nil
+}
+
+// setInstancePlan sets the plan of an instance to the given plan, referring to
+// it in the same way as the instance referred to its previous plan.
+func setInstancePlan(instance *v1beta1.ServiceInstance, plan Plan) {
+	pr := &instance.Spec.PlanReference
+	switch p := plan.(type) {
+	case *v1beta1.ClusterServicePlan:
+		switch {
+		case pr.ClusterServicePlanName != "":
+			pr.ClusterServicePlanName = p.Name
+		case pr.ClusterServicePlanExternalID != "":
+			pr.ClusterServicePlanExternalID = p.Spec.ExternalID
+		default:
+			pr.ClusterServicePlanExternalName = p.Spec.ExternalName
+		}
+	case *v1beta1.ServicePlan:
+		switch {
+		case pr.ServicePlanName != "":
+			pr.ServicePlanName = p.Name
+		case pr.ServicePlanExternalID != "":
+			pr.ServicePlanExternalID = p.Spec.ExternalID
+		default:
+			pr.ServicePlanExternalName = p.Spec.ExternalName
+		}
+	}
+}
+
 // WaitForInstanceToNotExist waits for the specified instance to no longer exist.
 func (sdk *SDK) WaitForInstanceToNotExist(ns, name string, interval time.Duration, timeout *time.Duration) (instance *v1beta1.ServiceInstance, err error) {
 	if timeout == nil {
@@ -280,6 +422,13 @@ func (sdk *SDK) WaitForInstance(ns, name string, interval time.Duration, timeout
 				return false, nil
 			}
 
+			// The conditions are those of a previous operation until the
+			// controller observes the latest spec, e.g. after an update
+			if instance.Status.ObservedGeneration < instance.Generation &&
+				instance.Status.ReconciledGeneration < instance.Generation {
+				return false, nil
+			}
+
 			isDone := (sdk.IsInstanceReady(instance) || sdk.IsInstanceFailed(instance)) && !instance.Status.AsyncOpInProgress
 			return isDone, nil
 		},
@@ -595,6 +595,124 @@ var _ = Describe("Instances", func() {
 		Expect(actions[0].Matches("delete", "serviceinstances")).To(BeTrue())
 		Expect(actions[0].(testing.DeleteActionImpl).Name).To(Equal(si.Name))
 	})
+	Describe("UpdateInstance", func() {
+		var (
+			csc     *v1beta1.ClusterServiceClass
+			premium *v1beta1.ClusterServicePlan
+		)
+		BeforeEach(func() {
+			si.Spec.PlanReference = v1beta1.PlanReference{
+				ClusterServiceClassExternalName: "mysqldb",
+				ClusterServicePlanExternalName:  "free",
+			}
+			si.Spec.ClusterServiceClassRef = &v1beta1.ClusterObjectReference{Name: "mysqldb-id"}
+			si.Spec.ClusterServicePlanRef = &v1beta1.ClusterObjectReference{Name: "free-id"}
+			csc = &v1beta1.ClusterServiceClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "mysqldb-id"},
+				Spec: v1beta1.ClusterServiceClassSpec{
+					CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{ExternalName: "mysqldb", PlanUpdatable: true},
+				},
+			}
+			updateSchema := &runtime.RawExtension{Raw: []byte(`{"type": "object", "properties": {"size": {"type": "integer", "minimum": 1}}, "additionalProperties": false}`)}
+			free := &v1beta1.ClusterServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "free-id"},
+				Spec: v1beta1.ClusterServicePlanSpec{
+					CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{ExternalName: "free", InstanceUpdateParameterSchema: updateSchema},
+				},
+			}
+			premium = &v1beta1.ClusterServicePlan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "premium-id",
+					Labels: map[string]string{
+						v1beta1.GroupName + "/" + v1beta1.FilterSpecClusterServiceClassRefName: "mysqldb-id",
+						v1beta1.GroupName + "/" + v1beta1.FilterSpecExternalName:               "premium",
+					},
+				},
+				Spec: v1beta1.ClusterServicePlanSpec{
+					CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{ExternalName: "premium"},
+				},
+			}
+			svcCatClient = fake.NewSimpleClientset(si, csc, free, premium)
+			sdk.ServiceCatalogClient = svcCatClient
+		})
+		It("Changes the plan of the instance", func() {
+			instance, err := sdk.UpdateInstance(si.Namespace, si.Name, &UpdateInstanceOptions{PlanName: "premium"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Spec.ClusterServicePlanExternalName).To(Equal("premium"))
+			Expect(instance.Spec.Parameters).To(BeNil())
+			actions := svcCatClient.Actions()
+			Expect(actions[len(actions)-1].Matches("update", "serviceinstances")).To(BeTrue())
+		})
+		It("Replaces the parameters and secrets of the instance", func() {
+			opts := &UpdateInstanceOptions{
+				Params:  map[string]interface{}{"size": 2},
+				Secrets: map[string]string{"mysecret": "dbparams"},
+			}
+			instance, err := sdk.UpdateInstance(si.Namespace, si.Name, opts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Spec.ClusterServicePlanExternalName).To(Equal("free"))
+			Expect(string(instance.Spec.Parameters.Raw)).To(Equal(`{"size":2}`))
+			Expect(instance.Spec.ParametersFrom).To(ConsistOf(v1beta1.ParametersFromSource{
+				SecretKeyRef: &v1beta1.SecretKeyReference{Name: "mysecret", Key: "dbparams"},
+			}))
+		})
+		It("Validates the parameters against the update schema of the plan", func() {
+			opts := &UpdateInstanceOptions{Params: map[string]interface{}{"size": 0, "region": "eastus"}}
+			_, err := sdk.UpdateInstance(si.Namespace, si.Name, opts)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("region: is not a known parameter"))
+			Expect(err.Error()).To(ContainSubstring("size: must be greater than or equal to 1"))
+			for _, action := range svcCatClient.Actions() {
+				Expect(action.Matches("update", "serviceinstances")).To(BeFalse())
+			}
+		})
+		It("Does not require the parameters of an instance with secrets", func() {
+			free := &v1beta1.ClusterServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "free-id"},
+				Spec: v1beta1.ClusterServicePlanSpec{
+					CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
+						ExternalName:                  "free",
+						InstanceUpdateParameterSchema: &runtime.RawExtension{Raw: []byte(`{"type": "object", "required": ["password"], "properties": {"size": {"type": "integer"}}}`)},
+					},
+				},
+			}
+			si.Spec.ParametersFrom = []v1beta1.ParametersFromSource{
+				{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "mysecret", Key: "password"}},
+			}
+			svcCatClient = fake.NewSimpleClientset(si, csc, free)
+			sdk.ServiceCatalogClient = svcCatClient
+
+			_, err := sdk.UpdateInstance(si.Namespace, si.Name, &UpdateInstanceOptions{Params: map[string]interface{}{"size": 2}})
+			Expect(err).NotTo(HaveOccurred())
+
+			si.Spec.ParametersFrom = nil
+			svcCatClient = fake.NewSimpleClientset(si, csc, free)
+			sdk.ServiceCatalogClient = svcCatClient
+
+			_, err = sdk.UpdateInstance(si.Namespace, si.Name, &UpdateInstanceOptions{Params: map[string]interface{}{"size": 2}})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("password: is required"))
+		})
+		It("Refuses to change the plan of an instance of a class that is not plan updatable", func() {
+			csc.Spec.PlanUpdatable = false
+			svcCatClient = fake.NewSimpleClientset(si, csc, premium)
+			sdk.ServiceCatalogClient = svcCatClient
+
+			_, err := sdk.UpdateInstance(si.Namespace, si.Name, &UpdateInstanceOptions{PlanName: "premium"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("class 'mysqldb' is not plan updatable"))
+		})
+		It("Bubbles up errors", func() {
+			_, err := sdk.UpdateInstance(si.Namespace, si.Name, &UpdateInstanceOptions{PlanName: "gold"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to find plan 'gold'"))
+		})
+	})
 	Describe("WaitForInstance", func() {
 		var (
 			counter          int
@@ -659,6 +777,22 @@ var _ = Describe("Instances", func() {
 				Expect(v.(testing.GetActionImpl).Namespace).To(Equal(si.Namespace))
 			}
 		})
+		It("Waits until the controller observes the latest generation of the instance", func() {
+			updatedInstance := si.DeepCopy()
+			updatedInstance.Generation = 2
+			updatedInstance.Status.ObservedGeneration = 1
+			waitClient.PrependReactor("get", "serviceinstances", func(action testing.Action) (bool, runtime.Object, error) {
+				counter++
+				if counter > 5 {
+					return true, si, nil
+				}
+				return true, updatedInstance, nil
+			})
+			instance, err := sdk.WaitForInstance(si.Namespace, si.Name, interval, &timeout)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance).To(Equal(si))
+			Expect(counter).To(BeNumerically(">", 5))
+		})
 		It("Bubbles up errors", func() {
 			errorMessage := "backend exploded"
 			waitClient.PrependReactor("get", "serviceinstances", func(action testing.Action) (bool, runtime.Object, error) {
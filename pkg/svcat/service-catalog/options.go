@@ -33,16 +33,31 @@ type RegisterOptions struct {
 	CAFile            string
 	ClassRestrictions []string
 	Namespace         string
+	OAuth2Secret      string
 	PlanRestrictions  []string
 	RelistBehavior    v1beta1.ServiceBrokerRelistBehavior
 	RelistDuration    *metav1.Duration
+	ServiceAccount    string
 	SkipTLS           bool
+	TLSSecret         string
+	TokenAudience     string
 }
 
 // ProvisionOptions allows for the passing of optional fields to the instance Provision method.
+// With DryRun, the instance is defaulted and validated by the server but not persisted.
 type ProvisionOptions struct {
+	DryRun     bool
 	ExternalID string
 	Namespace  string
 	Params     interface{}
 	Secrets    map[string]string
 }
+
+// UpdateInstanceOptions allows for the passing of the changes to the instance
+// UpdateInstance method. Nil parameters or secrets leave those of the instance
+// unchanged.
+type UpdateInstanceOptions struct {
+	PlanName string
+	Params   interface{}
+	Secrets  map[string]string
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ParameterError describes a parameter that does not match the schema of a
+// plan.
+type ParameterError struct {
+	// Path is the dotted path of the parameter, e.g. "firewall.rules[0].name",
+	// or "" for the parameters themselves.
+	Path    string
+	Message string
+}
+
+func (e ParameterError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ParameterErrors is the list of the parameters that do not match the schema
+// of a plan.
+type ParameterErrors []ParameterError
+
+func (e ParameterErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("invalid parameters:\n  %s", strings.Join(messages, "\n  "))
+}
+
+// Schema is the subset of a JSON schema of the parameters of a plan that the
+// parameters are validated against. Keywords that are not supported are
+// ignored.
+type Schema struct {
+	Type                 schemaTypes        `json:"type,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *schemaOrBool      `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+}
+
+// schemaTypes is the type keyword of a schema, which is either a type or a
+// list of types.
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings")
+	}
+	*t = multiple
+	return nil
+}
+
+// schemaOrBool is the additionalProperties keyword of a schema, which is
+// either a schema or whether additional properties are allowed.
+type schemaOrBool struct {
+	Allowed bool
+	Schema  *Schema
+}
+
+func (s *schemaOrBool) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &s.Allowed); err == nil {
+		return nil
+	}
+	s.Allowed = true
+	return json.Unmarshal(data, &s.Schema)
+}
+
+// ParseSchema parses a schema of the parameters of a plan. It returns nil if
+// the plan has no schema.
+func ParseSchema(raw *runtime.RawExtension) (*Schema, error) {
+	if raw == nil || len(raw.Raw) == 0 {
+		return nil, nil
+	}
+	schema := &Schema{}
+	if err := json.Unmarshal(raw.Raw, schema); err != nil {
+		return nil, fmt.Errorf("unable to parse the parameters schema (%s)", err)
+	}
+	return schema, nil
+}
+
+// HasType returns whether the schema allows values of the given JSON type. A
+// schema without type allows any value.
+func (s *Schema) HasType(t string) bool {
+	if len(s.Type) == 0 {
+		return true
+	}
+	for _, schemaType := range s.Type {
+		if schemaType == t || (schemaType == "number" && t == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns the ParameterErrors of the parameters that do not match the
+// schema, or nil if they all do. The parameters may be any value that encodes
+// to JSON.
+func (s *Schema) Validate(params interface{}) error {
+	if s == nil {
+		return nil
+	}
+	// Round-trip the parameters through JSON so that they are made of the
+	// same types as the schema
+	data, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("unable to encode the parameters (%s)", err)
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("unable to decode the parameters (%s)", err)
+	}
+
+	var errs ParameterErrors
+	s.validate("", value, &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// ValidateParameters validates the parameters against a schema of the
+// parameters of a plan. Any parameter is valid for a plan without schema.
+func ValidateParameters(raw *runtime.RawExtension, params interface{}) error {
+	schema, err := ParseSchema(raw)
+	if err != nil {
+		return err
+	}
+	return schema.Validate(params)
+}
+
+func (s *Schema) validate(path string, value interface{}, errs *ParameterErrors) {
+	fail := func(format string, a ...interface{}) {
+		*errs = append(*errs, ParameterError{Path: path, Message: fmt.Sprintf(format, a...)})
+	}
+
+	t := jsonType(value)
+	if !s.HasType(t) {
+		fail("must be of type %s, got %s", strings.Join(s.Type, " or "), t)
+		return
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+		for _, allowed := range s.Enum {
+			if reflect.DeepEqual(allowed, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			allowed := make([]string, len(s.Enum))
+			for i, v := range s.Enum {
+				data, _ := json.Marshal(v)
+				allowed[i] = string(data)
+			}
+			fail("must be one of %s", strings.Join(allowed, ", "))
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				*errs = append(*errs, ParameterError{Path: joinParameterPath(path, name), Message: "is required"})
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, ok := s.Properties[name]; ok {
+				property.validate(joinParameterPath(path, name), v[name], errs)
+				continue
+			}
+			if s.AdditionalProperties == nil {
+				continue
+			}
+			if !s.AdditionalProperties.Allowed {
+				*errs = append(*errs, ParameterError{Path: joinParameterPath(path, name), Message: "is not a known parameter"})
+			} else if s.AdditionalProperties.Schema != nil {
+				s.AdditionalProperties.Schema.validate(joinParameterPath(path, name), v[name], errs)
+			}
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			fail("must have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			fail("must have at most %d items", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, errs)
+			}
+		}
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.MinLength != nil && length < *s.MinLength {
+			fail("must be at least %d characters long", *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			fail("must be at most %d characters long", *s.MaxLength)
+		}
+		if s.Pattern != "" {
+			if re, err := regexp.Compile(s.Pattern); err == nil && !re.MatchString(v) {
+				fail("must match the pattern %q", s.Pattern)
+			}
+		}
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			fail("must be greater than or equal to %v", *s.Minimum)
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			fail("must be less than or equal to %v", *s.Maximum)
+		}
+	}
+}
+
+// jsonType returns the JSON type of a decoded JSON value.
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func joinParameterPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
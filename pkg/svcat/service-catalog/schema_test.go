@@ -0,0 +1,97 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+
+	. "github.com/kubernetes-sigs/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Schema", func() {
+	schema := &runtime.RawExtension{Raw: []byte(`{
+		"type": "object",
+		"required": ["location"],
+		"properties": {
+			"location": {"type": "string", "enum": ["eastus", "westus"]},
+			"name": {"type": "string", "minLength": 3, "pattern": "^[a-z]+$"},
+			"encrypt": {"type": "boolean"},
+			"firewallRules": {
+				"type": "array",
+				"maxItems": 1,
+				"items": {
+					"type": "object",
+					"properties": {"port": {"type": "integer", "maximum": 65535}}
+				}
+			},
+			"ratio": {"type": ["number", "null"]}
+		},
+		"additionalProperties": false
+	}`)}
+
+	Describe("ValidateParameters", func() {
+		It("accepts any parameters without schema", func() {
+			Expect(ValidateParameters(nil, map[string]interface{}{"foo": "bar"})).To(Succeed())
+		})
+		It("accepts parameters matching the schema", func() {
+			params := map[string]interface{}{
+				"location":      "eastus",
+				"name":          "mydb",
+				"encrypt":       true,
+				"firewallRules": []interface{}{map[string]interface{}{"port": 3306}},
+				"ratio":         0.5,
+			}
+			Expect(ValidateParameters(schema, params)).To(Succeed())
+		})
+		It("reports each parameter not matching the schema with its path", func() {
+			params := map[string]interface{}{
+				"name":          "My-DB",
+				"encrypt":       "yes",
+				"firewallRules": []interface{}{map[string]interface{}{"port": 1.5}, map[string]interface{}{"port": 70000}},
+				"size":          10,
+			}
+			err := ValidateParameters(schema, params)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err).To(BeAssignableToTypeOf(ParameterErrors{}))
+			Expect(err.(ParameterErrors)).To(ConsistOf(
+				ParameterError{Path: "location", Message: "is required"},
+				ParameterError{Path: "encrypt", Message: "must be of type boolean, got string"},
+				ParameterError{Path: "firewallRules", Message: "must have at most 1 items"},
+				ParameterError{Path: "firewallRules[0].port", Message: "must be of type integer, got number"},
+				ParameterError{Path: "firewallRules[1].port", Message: "must be less than or equal to 65535"},
+				ParameterError{Path: "name", Message: `must match the pattern "^[a-z]+$"`},
+				ParameterError{Path: "size", Message: "is not a known parameter"},
+			))
+		})
+		It("reports values that are not allowed", func() {
+			err := ValidateParameters(schema, map[string]interface{}{"location": "northpole"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`location: must be one of "eastus", "westus"`))
+		})
+		It("errors if the schema cannot be parsed", func() {
+			err := ValidateParameters(&runtime.RawExtension{Raw: []byte(`{"type": 1}`)}, nil)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to parse the parameters schema"))
+		})
+	})
+})
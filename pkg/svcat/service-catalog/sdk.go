@@ -41,8 +41,10 @@ type SvcatClient interface {
 	RetrieveBinding(string, string) (*apiv1beta1.ServiceBinding, error)
 	RetrieveBindings(string) (*apiv1beta1.ServiceBindingList, error)
 	RetrieveBindingsByInstance(*apiv1beta1.ServiceInstance) ([]apiv1beta1.ServiceBinding, error)
+	RotateBinding(string, string, int) (*apiv1beta1.ServiceBinding, error)
 	Unbind(string, string) ([]types.NamespacedName, error)
 	WaitForBinding(string, string, time.Duration, *time.Duration) (*apiv1beta1.ServiceBinding, error)
+	WaitForBindingRotation(string, string, int64, time.Duration, *time.Duration) (*apiv1beta1.ServiceBinding, error)
 	RemoveBindingFinalizerByInstance(string, string) ([]types.NamespacedName, error)
 	RemoveFinalizerForBindings([]types.NamespacedName) ([]types.NamespacedName, error)
 	RemoveFinalizerForBinding(types.NamespacedName) error
@@ -52,6 +54,7 @@ type SvcatClient interface {
 	RetrieveBrokers(opts ScopeOptions) ([]Broker, error)
 	RetrieveBrokerByID(string, ScopeOptions) (Broker, error)
 	RetrieveBrokerByClass(*apiv1beta1.ClusterServiceClass) (*apiv1beta1.ClusterServiceBroker, error)
+	RetrieveCatalogHistory(Broker, string) ([]CatalogRevision, error)
 	Register(string, string, *RegisterOptions, *ScopeOptions) (Broker, error)
 	Sync(string, ScopeOptions, int) error
 	WaitForBroker(string, *ScopeOptions, time.Duration, *time.Duration) (Broker, error)
@@ -70,9 +73,12 @@ type SvcatClient interface {
 	Provision(string, string, string, bool, *ProvisionOptions) (*apiv1beta1.ServiceInstance, error)
 	RetrieveInstance(string, string) (*apiv1beta1.ServiceInstance, error)
 	RetrieveInstanceByBinding(*apiv1beta1.ServiceBinding) (*apiv1beta1.ServiceInstance, error)
+	RetrieveInstancePlan(*apiv1beta1.ServiceInstance) (Plan, error)
+	RetrieveInstanceUpdatePlan(*apiv1beta1.ServiceInstance, string) (Plan, error)
 	RetrieveInstances(string, string, string) (*apiv1beta1.ServiceInstanceList, error)
 	RetrieveInstancesByPlan(Plan) ([]apiv1beta1.ServiceInstance, error)
 	TouchInstance(string, string, int) error
+	UpdateInstance(string, string, *UpdateInstanceOptions) (*apiv1beta1.ServiceInstance, error)
 	WaitForInstance(string, string, time.Duration, *time.Duration) (*apiv1beta1.ServiceInstance, error)
 	WaitForInstanceToNotExist(string, string, time.Duration, *time.Duration) (*apiv1beta1.ServiceInstance, error)
 
@@ -154,6 +154,21 @@ type FakeSvcatClient struct {
 		result1 []apiv1beta1.ServiceBinding
 		result2 error
 	}
+	RotateBindingStub        func(string, string, int) (*apiv1beta1.ServiceBinding, error)
+	rotateBindingMutex       sync.RWMutex
+	rotateBindingArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}
+	rotateBindingReturns struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 error
+	}
+	rotateBindingReturnsOnCall map[int]struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 error
+	}
 	UnbindStub        func(string, string) ([]types.NamespacedName, error)
 	unbindMutex       sync.RWMutex
 	unbindArgsForCall []struct {
@@ -184,6 +199,23 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ServiceBinding
 		result2 error
 	}
+	WaitForBindingRotationStub        func(string, string, int64, time.Duration, *time.Duration) (*apiv1beta1.ServiceBinding, error)
+	waitForBindingRotationMutex       sync.RWMutex
+	waitForBindingRotationArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+		arg4 time.Duration
+		arg5 *time.Duration
+	}
+	waitForBindingRotationReturns struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 error
+	}
+	waitForBindingRotationReturnsOnCall map[int]struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 error
+	}
 	RemoveBindingFinalizerByInstanceStub        func(string, string) ([]types.NamespacedName, error)
 	removeBindingFinalizerByInstanceMutex       sync.RWMutex
 	removeBindingFinalizerByInstanceArgsForCall []struct {
@@ -286,6 +318,20 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ClusterServiceBroker
 		result2 error
 	}
+	RetrieveCatalogHistoryStub        func(servicecatalog.Broker, string) ([]servicecatalog.CatalogRevision, error)
+	retrieveCatalogHistoryMutex       sync.RWMutex
+	retrieveCatalogHistoryArgsForCall []struct {
+		arg1 servicecatalog.Broker
+		arg2 string
+	}
+	retrieveCatalogHistoryReturns struct {
+		result1 []servicecatalog.CatalogRevision
+		result2 error
+	}
+	retrieveCatalogHistoryReturnsOnCall map[int]struct {
+		result1 []servicecatalog.CatalogRevision
+		result2 error
+	}
 	RegisterStub        func(string, string, *servicecatalog.RegisterOptions, *servicecatalog.ScopeOptions) (servicecatalog.Broker, error)
 	registerMutex       sync.RWMutex
 	registerArgsForCall []struct {
@@ -508,6 +554,33 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ServiceInstance
 		result2 error
 	}
+	RetrieveInstancePlanStub        func(*apiv1beta1.ServiceInstance) (servicecatalog.Plan, error)
+	retrieveInstancePlanMutex       sync.RWMutex
+	retrieveInstancePlanArgsForCall []struct {
+		arg1 *apiv1beta1.ServiceInstance
+	}
+	retrieveInstancePlanReturns struct {
+		result1 servicecatalog.Plan
+		result2 error
+	}
+	retrieveInstancePlanReturnsOnCall map[int]struct {
+		result1 servicecatalog.Plan
+		result2 error
+	}
+	RetrieveInstanceUpdatePlanStub        func(*apiv1beta1.ServiceInstance, string) (servicecatalog.Plan, error)
+	retrieveInstanceUpdatePlanMutex       sync.RWMutex
+	retrieveInstanceUpdatePlanArgsForCall []struct {
+		arg1 *apiv1beta1.ServiceInstance
+		arg2 string
+	}
+	retrieveInstanceUpdatePlanReturns struct {
+		result1 servicecatalog.Plan
+		result2 error
+	}
+	retrieveInstanceUpdatePlanReturnsOnCall map[int]struct {
+		result1 servicecatalog.Plan
+		result2 error
+	}
 	RetrieveInstancesStub        func(string, string, string) (*apiv1beta1.ServiceInstanceList, error)
 	retrieveInstancesMutex       sync.RWMutex
 	retrieveInstancesArgsForCall []struct {
@@ -549,6 +622,21 @@ type FakeSvcatClient struct {
 	touchInstanceReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateInstanceStub        func(string, string, *servicecatalog.UpdateInstanceOptions) (*apiv1beta1.ServiceInstance, error)
+	updateInstanceMutex       sync.RWMutex
+	updateInstanceArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *servicecatalog.UpdateInstanceOptions
+	}
+	updateInstanceReturns struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}
+	updateInstanceReturnsOnCall map[int]struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}
 	WaitForInstanceStub        func(string, string, time.Duration, *time.Duration) (*apiv1beta1.ServiceInstance, error)
 	waitForInstanceMutex       sync.RWMutex
 	waitForInstanceArgsForCall []struct {
@@ -1153,6 +1241,59 @@ func (fake *FakeSvcatClient) RetrieveBindingsByInstanceReturnsOnCall(i int, resu
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RotateBinding(arg1 string, arg2 string, arg3 int) (*apiv1beta1.ServiceBinding, error) {
+	fake.rotateBindingMutex.Lock()
+	ret, specificReturn := fake.rotateBindingReturnsOnCall[len(fake.rotateBindingArgsForCall)]
+	fake.rotateBindingArgsForCall = append(fake.rotateBindingArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("RotateBinding", []interface{}{arg1, arg2, arg3})
+	fake.rotateBindingMutex.Unlock()
+	if fake.RotateBindingStub != nil {
+		return fake.RotateBindingStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.rotateBindingReturns.result1, fake.rotateBindingReturns.result2
+}
+
+func (fake *FakeSvcatClient) RotateBindingCallCount() int {
+	fake.rotateBindingMutex.RLock()
+	defer fake.rotateBindingMutex.RUnlock()
+	return len(fake.rotateBindingArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RotateBindingArgsForCall(i int) (string, string, int) {
+	fake.rotateBindingMutex.RLock()
+	defer fake.rotateBindingMutex.RUnlock()
+	return fake.rotateBindingArgsForCall[i].arg1, fake.rotateBindingArgsForCall[i].arg2, fake.rotateBindingArgsForCall[i].arg3
+}
+
+func (fake *FakeSvcatClient) RotateBindingReturns(result1 *apiv1beta1.ServiceBinding, result2 error) {
+	fake.RotateBindingStub = nil
+	fake.rotateBindingReturns = struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RotateBindingReturnsOnCall(i int, result1 *apiv1beta1.ServiceBinding, result2 error) {
+	fake.RotateBindingStub = nil
+	if fake.rotateBindingReturnsOnCall == nil {
+		fake.rotateBindingReturnsOnCall = make(map[int]struct {
+			result1 *apiv1beta1.ServiceBinding
+			result2 error
+		})
+	}
+	fake.rotateBindingReturnsOnCall[i] = struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) Unbind(arg1 string, arg2 string) ([]types.NamespacedName, error) {
 	fake.unbindMutex.Lock()
 	ret, specificReturn := fake.unbindReturnsOnCall[len(fake.unbindArgsForCall)]
@@ -1259,6 +1400,61 @@ func (fake *FakeSvcatClient) WaitForBindingReturnsOnCall(i int, result1 *apiv1be
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) WaitForBindingRotation(arg1 string, arg2 string, arg3 int64, arg4 time.Duration, arg5 *time.Duration) (*apiv1beta1.ServiceBinding, error) {
+	fake.waitForBindingRotationMutex.Lock()
+	ret, specificReturn := fake.waitForBindingRotationReturnsOnCall[len(fake.waitForBindingRotationArgsForCall)]
+	fake.waitForBindingRotationArgsForCall = append(fake.waitForBindingRotationArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+		arg4 time.Duration
+		arg5 *time.Duration
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("WaitForBindingRotation", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.waitForBindingRotationMutex.Unlock()
+	if fake.WaitForBindingRotationStub != nil {
+		return fake.WaitForBindingRotationStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.waitForBindingRotationReturns.result1, fake.waitForBindingRotationReturns.result2
+}
+
+func (fake *FakeSvcatClient) WaitForBindingRotationCallCount() int {
+	fake.waitForBindingRotationMutex.RLock()
+	defer fake.waitForBindingRotationMutex.RUnlock()
+	return len(fake.waitForBindingRotationArgsForCall)
+}
+
+func (fake *FakeSvcatClient) WaitForBindingRotationArgsForCall(i int) (string, string, int64, time.Duration, *time.Duration) {
+	fake.waitForBindingRotationMutex.RLock()
+	defer fake.waitForBindingRotationMutex.RUnlock()
+	return fake.waitForBindingRotationArgsForCall[i].arg1, fake.waitForBindingRotationArgsForCall[i].arg2, fake.waitForBindingRotationArgsForCall[i].arg3, fake.waitForBindingRotationArgsForCall[i].arg4, fake.waitForBindingRotationArgsForCall[i].arg5
+}
+
+func (fake *FakeSvcatClient) WaitForBindingRotationReturns(result1 *apiv1beta1.ServiceBinding, result2 error) {
+	fake.WaitForBindingRotationStub = nil
+	fake.waitForBindingRotationReturns = struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) WaitForBindingRotationReturnsOnCall(i int, result1 *apiv1beta1.ServiceBinding, result2 error) {
+	fake.WaitForBindingRotationStub = nil
+	if fake.waitForBindingRotationReturnsOnCall == nil {
+		fake.waitForBindingRotationReturnsOnCall = make(map[int]struct {
+			result1 *apiv1beta1.ServiceBinding
+			result2 error
+		})
+	}
+	fake.waitForBindingRotationReturnsOnCall[i] = struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) RemoveBindingFinalizerByInstance(arg1 string, arg2 string) ([]types.NamespacedName, error) {
 	fake.removeBindingFinalizerByInstanceMutex.Lock()
 	ret, specificReturn := fake.removeBindingFinalizerByInstanceReturnsOnCall[len(fake.removeBindingFinalizerByInstanceArgsForCall)]
@@ -1667,6 +1863,58 @@ func (fake *FakeSvcatClient) RetrieveBrokerByClassReturnsOnCall(i int, result1 *
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RetrieveCatalogHistory(arg1 servicecatalog.Broker, arg2 string) ([]servicecatalog.CatalogRevision, error) {
+	fake.retrieveCatalogHistoryMutex.Lock()
+	ret, specificReturn := fake.retrieveCatalogHistoryReturnsOnCall[len(fake.retrieveCatalogHistoryArgsForCall)]
+	fake.retrieveCatalogHistoryArgsForCall = append(fake.retrieveCatalogHistoryArgsForCall, struct {
+		arg1 servicecatalog.Broker
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("RetrieveCatalogHistory", []interface{}{arg1, arg2})
+	fake.retrieveCatalogHistoryMutex.Unlock()
+	if fake.RetrieveCatalogHistoryStub != nil {
+		return fake.RetrieveCatalogHistoryStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveCatalogHistoryReturns.result1, fake.retrieveCatalogHistoryReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveCatalogHistoryCallCount() int {
+	fake.retrieveCatalogHistoryMutex.RLock()
+	defer fake.retrieveCatalogHistoryMutex.RUnlock()
+	return len(fake.retrieveCatalogHistoryArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveCatalogHistoryArgsForCall(i int) (servicecatalog.Broker, string) {
+	fake.retrieveCatalogHistoryMutex.RLock()
+	defer fake.retrieveCatalogHistoryMutex.RUnlock()
+	return fake.retrieveCatalogHistoryArgsForCall[i].arg1, fake.retrieveCatalogHistoryArgsForCall[i].arg2
+}
+
+func (fake *FakeSvcatClient) RetrieveCatalogHistoryReturns(result1 []servicecatalog.CatalogRevision, result2 error) {
+	fake.RetrieveCatalogHistoryStub = nil
+	fake.retrieveCatalogHistoryReturns = struct {
+		result1 []servicecatalog.CatalogRevision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveCatalogHistoryReturnsOnCall(i int, result1 []servicecatalog.CatalogRevision, result2 error) {
+	fake.RetrieveCatalogHistoryStub = nil
+	if fake.retrieveCatalogHistoryReturnsOnCall == nil {
+		fake.retrieveCatalogHistoryReturnsOnCall = make(map[int]struct {
+			result1 []servicecatalog.CatalogRevision
+			result2 error
+		})
+	}
+	fake.retrieveCatalogHistoryReturnsOnCall[i] = struct {
+		result1 []servicecatalog.CatalogRevision
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) Register(arg1 string, arg2 string, arg3 *servicecatalog.RegisterOptions, arg4 *servicecatalog.ScopeOptions) (servicecatalog.Broker, error) {
 	fake.registerMutex.Lock()
 	ret, specificReturn := fake.registerReturnsOnCall[len(fake.registerArgsForCall)]
@@ -2496,6 +2744,109 @@ func (fake *FakeSvcatClient) RetrieveInstanceByBindingReturnsOnCall(i int, resul
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RetrieveInstancePlan(arg1 *apiv1beta1.ServiceInstance) (servicecatalog.Plan, error) {
+	fake.retrieveInstancePlanMutex.Lock()
+	ret, specificReturn := fake.retrieveInstancePlanReturnsOnCall[len(fake.retrieveInstancePlanArgsForCall)]
+	fake.retrieveInstancePlanArgsForCall = append(fake.retrieveInstancePlanArgsForCall, struct {
+		arg1 *apiv1beta1.ServiceInstance
+	}{arg1})
+	fake.recordInvocation("RetrieveInstancePlan", []interface{}{arg1})
+	fake.retrieveInstancePlanMutex.Unlock()
+	if fake.RetrieveInstancePlanStub != nil {
+		return fake.RetrieveInstancePlanStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveInstancePlanReturns.result1, fake.retrieveInstancePlanReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveInstancePlanCallCount() int {
+	fake.retrieveInstancePlanMutex.RLock()
+	defer fake.retrieveInstancePlanMutex.RUnlock()
+	return len(fake.retrieveInstancePlanArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveInstancePlanArgsForCall(i int) *apiv1beta1.ServiceInstance {
+	fake.retrieveInstancePlanMutex.RLock()
+	defer fake.retrieveInstancePlanMutex.RUnlock()
+	return fake.retrieveInstancePlanArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) RetrieveInstancePlanReturns(result1 servicecatalog.Plan, result2 error) {
+	fake.RetrieveInstancePlanStub = nil
+	fake.retrieveInstancePlanReturns = struct {
+		result1 servicecatalog.Plan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveInstancePlanReturnsOnCall(i int, result1 servicecatalog.Plan, result2 error) {
+	fake.RetrieveInstancePlanStub = nil
+	if fake.retrieveInstancePlanReturnsOnCall == nil {
+		fake.retrieveInstancePlanReturnsOnCall = make(map[int]struct {
+			result1 servicecatalog.Plan
+			result2 error
+		})
+	}
+	fake.retrieveInstancePlanReturnsOnCall[i] = struct {
+		result1 servicecatalog.Plan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveInstanceUpdatePlan(arg1 *apiv1beta1.ServiceInstance, arg2 string) (servicecatalog.Plan, error) {
+	fake.retrieveInstanceUpdatePlanMutex.Lock()
+	ret, specificReturn := fake.retrieveInstanceUpdatePlanReturnsOnCall[len(fake.retrieveInstanceUpdatePlanArgsForCall)]
+	fake.retrieveInstanceUpdatePlanArgsForCall = append(fake.retrieveInstanceUpdatePlanArgsForCall, struct {
+		arg1 *apiv1beta1.ServiceInstance
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("RetrieveInstanceUpdatePlan", []interface{}{arg1, arg2})
+	fake.retrieveInstanceUpdatePlanMutex.Unlock()
+	if fake.RetrieveInstanceUpdatePlanStub != nil {
+		return fake.RetrieveInstanceUpdatePlanStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveInstanceUpdatePlanReturns.result1, fake.retrieveInstanceUpdatePlanReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveInstanceUpdatePlanCallCount() int {
+	fake.retrieveInstanceUpdatePlanMutex.RLock()
+	defer fake.retrieveInstanceUpdatePlanMutex.RUnlock()
+	return len(fake.retrieveInstanceUpdatePlanArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveInstanceUpdatePlanArgsForCall(i int) (*apiv1beta1.ServiceInstance, string) {
+	fake.retrieveInstanceUpdatePlanMutex.RLock()
+	defer fake.retrieveInstanceUpdatePlanMutex.RUnlock()
+	return fake.retrieveInstanceUpdatePlanArgsForCall[i].arg1, fake.retrieveInstanceUpdatePlanArgsForCall[i].arg2
+}
+
+func (fake *FakeSvcatClient) RetrieveInstanceUpdatePlanReturns(result1 servicecatalog.Plan, result2 error) {
+	fake.RetrieveInstanceUpdatePlanStub = nil
+	fake.retrieveInstanceUpdatePlanReturns = struct {
+		result1 servicecatalog.Plan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveInstanceUpdatePlanReturnsOnCall(i int, result1 servicecatalog.Plan, result2 error) {
+	fake.RetrieveInstanceUpdatePlanStub = nil
+	if fake.retrieveInstanceUpdatePlanReturnsOnCall == nil {
+		fake.retrieveInstanceUpdatePlanReturnsOnCall = make(map[int]struct {
+			result1 servicecatalog.Plan
+			result2 error
+		})
+	}
+	fake.retrieveInstanceUpdatePlanReturnsOnCall[i] = struct {
+		result1 servicecatalog.Plan
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) RetrieveInstances(arg1 string, arg2 string, arg3 string) (*apiv1beta1.ServiceInstanceList, error) {
 	fake.retrieveInstancesMutex.Lock()
 	ret, specificReturn := fake.retrieveInstancesReturnsOnCall[len(fake.retrieveInstancesArgsForCall)]
@@ -2650,6 +3001,59 @@ func (fake *FakeSvcatClient) TouchInstanceReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSvcatClient) UpdateInstance(arg1 string, arg2 string, arg3 *servicecatalog.UpdateInstanceOptions) (*apiv1beta1.ServiceInstance, error) {
+	fake.updateInstanceMutex.Lock()
+	ret, specificReturn := fake.updateInstanceReturnsOnCall[len(fake.updateInstanceArgsForCall)]
+	fake.updateInstanceArgsForCall = append(fake.updateInstanceArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *servicecatalog.UpdateInstanceOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("UpdateInstance", []interface{}{arg1, arg2, arg3})
+	fake.updateInstanceMutex.Unlock()
+	if fake.UpdateInstanceStub != nil {
+		return fake.UpdateInstanceStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.updateInstanceReturns.result1, fake.updateInstanceReturns.result2
+}
+
+func (fake *FakeSvcatClient) UpdateInstanceCallCount() int {
+	fake.updateInstanceMutex.RLock()
+	defer fake.updateInstanceMutex.RUnlock()
+	return len(fake.updateInstanceArgsForCall)
+}
+
+func (fake *FakeSvcatClient) UpdateInstanceArgsForCall(i int) (string, string, *servicecatalog.UpdateInstanceOptions) {
+	fake.updateInstanceMutex.RLock()
+	defer fake.updateInstanceMutex.RUnlock()
+	return fake.updateInstanceArgsForCall[i].arg1, fake.updateInstanceArgsForCall[i].arg2, fake.updateInstanceArgsForCall[i].arg3
+}
+
+func (fake *FakeSvcatClient) UpdateInstanceReturns(result1 *apiv1beta1.ServiceInstance, result2 error) {
+	fake.UpdateInstanceStub = nil
+	fake.updateInstanceReturns = struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) UpdateInstanceReturnsOnCall(i int, result1 *apiv1beta1.ServiceInstance, result2 error) {
+	fake.UpdateInstanceStub = nil
+	if fake.updateInstanceReturnsOnCall == nil {
+		fake.updateInstanceReturnsOnCall = make(map[int]struct {
+			result1 *apiv1beta1.ServiceInstance
+			result2 error
+		})
+	}
+	fake.updateInstanceReturnsOnCall[i] = struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) WaitForInstance(arg1 string, arg2 string, arg3 time.Duration, arg4 *time.Duration) (*apiv1beta1.ServiceInstance, error) {
 	fake.waitForInstanceMutex.Lock()
 	ret, specificReturn := fake.waitForInstanceReturnsOnCall[len(fake.waitForInstanceArgsForCall)]
@@ -3135,10 +3539,14 @@ func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	defer fake.retrieveBindingsMutex.RUnlock()
 	fake.retrieveBindingsByInstanceMutex.RLock()
 	defer fake.retrieveBindingsByInstanceMutex.RUnlock()
+	fake.rotateBindingMutex.RLock()
+	defer fake.rotateBindingMutex.RUnlock()
 	fake.unbindMutex.RLock()
 	defer fake.unbindMutex.RUnlock()
 	fake.waitForBindingMutex.RLock()
 	defer fake.waitForBindingMutex.RUnlock()
+	fake.waitForBindingRotationMutex.RLock()
+	defer fake.waitForBindingRotationMutex.RUnlock()
 	fake.removeBindingFinalizerByInstanceMutex.RLock()
 	defer fake.removeBindingFinalizerByInstanceMutex.RUnlock()
 	fake.removeFinalizerForBindingsMutex.RLock()
@@ -3155,6 +3563,8 @@ func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	defer fake.retrieveBrokerByIDMutex.RUnlock()
 	fake.retrieveBrokerByClassMutex.RLock()
 	defer fake.retrieveBrokerByClassMutex.RUnlock()
+	fake.retrieveCatalogHistoryMutex.RLock()
+	defer fake.retrieveCatalogHistoryMutex.RUnlock()
 	fake.registerMutex.RLock()
 	defer fake.registerMutex.RUnlock()
 	fake.syncMutex.RLock()
@@ -3187,12 +3597,18 @@ func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	defer fake.retrieveInstanceMutex.RUnlock()
 	fake.retrieveInstanceByBindingMutex.RLock()
 	defer fake.retrieveInstanceByBindingMutex.RUnlock()
+	fake.retrieveInstancePlanMutex.RLock()
+	defer fake.retrieveInstancePlanMutex.RUnlock()
+	fake.retrieveInstanceUpdatePlanMutex.RLock()
+	defer fake.retrieveInstanceUpdatePlanMutex.RUnlock()
 	fake.retrieveInstancesMutex.RLock()
 	defer fake.retrieveInstancesMutex.RUnlock()
 	fake.retrieveInstancesByPlanMutex.RLock()
 	defer fake.retrieveInstancesByPlanMutex.RUnlock()
 	fake.touchInstanceMutex.RLock()
 	defer fake.touchInstanceMutex.RUnlock()
+	fake.updateInstanceMutex.RLock()
+	defer fake.updateInstanceMutex.RUnlock()
 	fake.waitForInstanceMutex.RLock()
 	defer fake.waitForInstanceMutex.RUnlock()
 	fake.waitForInstanceToNotExistMutex.RLock()
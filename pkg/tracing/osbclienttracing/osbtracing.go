@@ -0,0 +1,136 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package osbclienttracing wraps the OSB Client Library to record a span for
+// each request sent to a broker.
+package osbclienttracing
+
+import (
+	"context"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/tracing"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+)
+
+const (
+	brokerAttribute     = "osb.broker"
+	instanceIDAttribute = "osb.instance_id"
+	bindingIDAttribute  = "osb.binding_id"
+)
+
+// NewCreateFunc returns a CreateFunc creating clients with the given
+// CreateFunc that record a span for each request.
+func NewCreateFunc(createFunc osb.CreateFunc) osb.CreateFunc {
+	return func(config *osb.ClientConfiguration) (osb.Client, error) {
+		client, err := createFunc(config)
+		if err != nil {
+			return nil, err
+		}
+		return tracingClient{brokerName: config.Name, client: client, ctx: context.Background()}, nil
+	}
+}
+
+// tracingClient is an osb.Client recording a span for each request of the
+// wrapped client, as a child of the span carried by its context.
+type tracingClient struct {
+	brokerName string
+	client     osb.Client
+	ctx        context.Context
+}
+
+var _ osb.ContextClient = tracingClient{}
+
+// WithContext implements
+// go-open-service-broker-client/v2/ContextClient.WithContext.
+func (tc tracingClient) WithContext(ctx context.Context) osb.Client {
+	return tracingClient{brokerName: tc.brokerName, client: tc.client, ctx: ctx}
+}
+
+// start starts the span of a request, and returns the wrapped client sending
+// the request with the context of the span.
+func (tc tracingClient) start(method string, attributes ...tracing.Attribute) (osb.Client, tracing.Span) {
+	attributes = append(attributes, tracing.String(brokerAttribute, tc.brokerName))
+	ctx, span := tracing.Start(tc.ctx, "osb."+method, attributes...)
+	return osb.WithContext(ctx, tc.client), span
+}
+
+func instanceAttributes(instanceID string) []tracing.Attribute {
+	return []tracing.Attribute{tracing.String(instanceIDAttribute, instanceID)}
+}
+
+func bindingAttributes(instanceID, bindingID string) []tracing.Attribute {
+	return []tracing.Attribute{tracing.String(instanceIDAttribute, instanceID), tracing.String(bindingIDAttribute, bindingID)}
+}
+
+func (tc tracingClient) GetCatalog() (*osb.CatalogResponse, error) {
+	client, span := tc.start("GetCatalog")
+	response, err := client.GetCatalog()
+	return response, tracing.EndWithError(span, err)
+}
+
+func (tc tracingClient) ProvisionInstance(r *osb.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	client, span := tc.start("ProvisionInstance", instanceAttributes(r.InstanceID)...)
+	response, err := client.ProvisionInstance(r)
+	return response, tracing.EndWithError(span, err)
+}
+
+func (tc tracingClient) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	client, span := tc.start("UpdateInstance", instanceAttributes(r.InstanceID)...)
+	response, err := client.UpdateInstance(r)
+	return response, tracing.EndWithError(span, err)
+}
+
+func (tc tracingClient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	client, span := tc.start("DeprovisionInstance", instanceAttributes(r.InstanceID)...)
+	response, err := client.DeprovisionInstance(r)
+	return response, tracing.EndWithError(span, err)
+}
+
+func (tc tracingClient) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
+	client, span := tc.start("PollLastOperation", instanceAttributes(r.InstanceID)...)
+	response, err := client.PollLastOperation(r)
+	return response, tracing.EndWithError(span, err)
+}
+
+func (tc tracingClient) PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error) {
+	client, span := tc.start("PollBindingLastOperation", bindingAttributes(r.InstanceID, r.BindingID)...)
+	response, err := client.PollBindingLastOperation(r)
+	return response, tracing.EndWithError(span, err)
+}
+
+func (tc tracingClient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
+	client, span := tc.start("Bind", bindingAttributes(r.InstanceID, r.BindingID)...)
+	response, err := client.Bind(r)
+	return response, tracing.EndWithError(span, err)
+}
+
+func (tc tracingClient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
+	client, span := tc.start("Unbind", bindingAttributes(r.InstanceID, r.BindingID)...)
+	response, err := client.Unbind(r)
+	return response, tracing.EndWithError(span, err)
+}
+
+func (tc tracingClient) GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error) {
+	client, span := tc.start("GetBinding", bindingAttributes(r.InstanceID, r.BindingID)...)
+	response, err := client.GetBinding(r)
+	return response, tracing.EndWithError(span, err)
+}
+
+func (tc tracingClient) GetInstance(r *osb.GetInstanceRequest) (*osb.GetInstanceResponse, error) {
+	client, span := tc.start("GetInstance", instanceAttributes(r.InstanceID)...)
+	response, err := client.GetInstance(r)
+	return response, tracing.EndWithError(span, err)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osbclienttracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kubernetes-sigs/service-catalog/pkg/tracing"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/fake"
+)
+
+type parentKey struct{}
+
+type recordedSpan struct {
+	name       string
+	parent     string
+	attributes []tracing.Attribute
+	err        error
+	ended      bool
+}
+
+func (s *recordedSpan) SetAttributes(attributes ...tracing.Attribute) {
+	s.attributes = append(s.attributes, attributes...)
+}
+
+func (s *recordedSpan) RecordError(err error) {
+	s.err = err
+}
+
+func (s *recordedSpan) End() {
+	s.ended = true
+}
+
+type recordingTracer struct {
+	spans []*recordedSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, attributes ...tracing.Attribute) (context.Context, tracing.Span) {
+	parent, _ := ctx.Value(parentKey{}).(string)
+	span := &recordedSpan{name: name, parent: parent, attributes: attributes}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, parentKey{}, name), span
+}
+
+func TestRequestSpans(t *testing.T) {
+	tracer := &recordingTracer{}
+	tracing.SetTracer(tracer)
+	defer tracing.SetTracer(nil)
+
+	fakeClient := fakeosb.NewFakeClient(fakeosb.FakeClientConfiguration{
+		CatalogReaction: &fakeosb.CatalogReaction{Response: &osb.CatalogResponse{}},
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Error: errors.New("provision failed"),
+		},
+	})
+	config := osb.DefaultClientConfiguration()
+	config.Name = "test-broker"
+	client, err := NewCreateFunc(func(*osb.ClientConfiguration) (osb.Client, error) {
+		return fakeClient, nil
+	})(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Without a span in its context, the request still has its own span
+	if _, err := client.GetCatalog(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx, span := tracing.Start(context.Background(), "reconcile")
+	if _, err := osb.WithContext(ctx, client).ProvisionInstance(&osb.ProvisionRequest{InstanceID: "instance-id"}); err == nil {
+		t.Fatal("expected the provision to fail")
+	}
+	span.End()
+
+	if e, a := 3, len(tracer.spans); e != a {
+		t.Fatalf("expected %d spans, got %d", e, a)
+	}
+	catalogSpan, provisionSpan := tracer.spans[0], tracer.spans[2]
+	if e, a := "osb.GetCatalog", catalogSpan.name; e != a {
+		t.Fatalf("unexpected span name: expected %q, got %q", e, a)
+	}
+	if catalogSpan.parent != "" || catalogSpan.err != nil || !catalogSpan.ended {
+		t.Fatalf("expected an ended root span without error, got %+v", catalogSpan)
+	}
+	if e, a := "osb.ProvisionInstance", provisionSpan.name; e != a {
+		t.Fatalf("unexpected span name: expected %q, got %q", e, a)
+	}
+	if e, a := "reconcile", provisionSpan.parent; e != a {
+		t.Fatalf("expected the request to be a child of the reconciliation, got parent %q", a)
+	}
+	if provisionSpan.err == nil || !provisionSpan.ended {
+		t.Fatalf("expected an ended span with the error of the request, got %+v", provisionSpan)
+	}
+	expectedAttributes := []tracing.Attribute{
+		tracing.String(instanceIDAttribute, "instance-id"),
+		tracing.String(brokerAttribute, "test-broker"),
+	}
+	for i, e := range expectedAttributes {
+		if a := provisionSpan.attributes[i]; e != a {
+			t.Fatalf("unexpected attribute: expected %+v, got %+v", e, a)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing defines the spans the controller records for the
+// reconciliations of instances and bindings and for the requests it sends to
+// brokers. The spans are recorded by the Tracer set with SetTracer; the
+// default Tracer records nothing.
+//
+// No Tracer exporting the spans is built into the controller manager yet:
+// the OpenTelemetry SDK needs newer versions of grpc, golang/protobuf and
+// go-logr than the ones pinned in Gopkg.toml. Once they can be upgraded, a
+// Tracer backed by the SDK can be set without changing the callers.
+package tracing
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Attribute is a key-value pair describing a span.
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+// String returns an attribute with the given key and value.
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span records the work done by an operation.
+type Span interface {
+	// SetAttributes adds the given attributes to the span.
+	SetAttributes(attributes ...Attribute)
+	// RecordError records that the operation failed with the given error.
+	RecordError(err error)
+	// End ends the span.
+	End()
+}
+
+// Tracer starts spans.
+type Tracer interface {
+	// Start starts a span of the named operation as a child of the span
+	// carried by the given context, if any, and returns a copy of the
+	// context carrying the new span.
+	Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span)
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...Attribute) {}
+func (noopSpan) RecordError(error)          {}
+func (noopSpan) End()                       {}
+
+type noopTracer struct{}
+
+func (noopTracer) Start(ctx context.Context, _ string, _ ...Attribute) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+// tracerHolder wraps the global Tracer, as an atomic.Value must always hold
+// values of the same concrete type.
+type tracerHolder struct {
+	tracer Tracer
+}
+
+var globalTracer atomic.Value
+
+func init() {
+	globalTracer.Store(tracerHolder{tracer: noopTracer{}})
+}
+
+// SetTracer sets the Tracer recording the spans started from now on. A nil
+// Tracer restores the default one, which records nothing.
+func SetTracer(tracer Tracer) {
+	if tracer == nil {
+		tracer = noopTracer{}
+	}
+	globalTracer.Store(tracerHolder{tracer: tracer})
+}
+
+func currentTracer() Tracer {
+	return globalTracer.Load().(tracerHolder).tracer
+}
+
+// Enabled returns whether a Tracer recording spans has been set.
+func Enabled() bool {
+	_, noop := currentTracer().(noopTracer)
+	return !noop
+}
+
+// Start starts a span with the Tracer set with SetTracer.
+func Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span) {
+	return currentTracer().Start(ctx, name, attributes...)
+}
+
+// EndWithError records the given error, if any, and ends the given span,
+// returning the error so that a function can end its span with its own
+// return value.
+func EndWithError(span Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+	return err
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type parentKey struct{}
+
+// recordedSpan is a span recorded by a recordingTracer.
+type recordedSpan struct {
+	name       string
+	parent     string
+	attributes []Attribute
+	err        error
+	ended      bool
+}
+
+func (s *recordedSpan) SetAttributes(attributes ...Attribute) {
+	s.attributes = append(s.attributes, attributes...)
+}
+
+func (s *recordedSpan) RecordError(err error) {
+	s.err = err
+}
+
+func (s *recordedSpan) End() {
+	s.ended = true
+}
+
+// recordingTracer is a Tracer recording the spans it starts, with the name
+// of the span carried by the context they are started with as their parent.
+type recordingTracer struct {
+	spans []*recordedSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span) {
+	parent, _ := ctx.Value(parentKey{}).(string)
+	span := &recordedSpan{name: name, parent: parent, attributes: attributes}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, parentKey{}, name), span
+}
+
+func TestStartDefault(t *testing.T) {
+	if Enabled() {
+		t.Fatal("expected tracing to be disabled by default")
+	}
+	ctx := context.Background()
+	spanCtx, span := Start(ctx, "operation", String("key", "value"))
+	if spanCtx != ctx {
+		t.Fatal("expected the context to be returned unchanged")
+	}
+	// The default span accepts all calls
+	span.SetAttributes(String("other", "value"))
+	if e, a := errors.New("failed"), EndWithError(span, errors.New("failed")); e.Error() != a.Error() {
+		t.Fatalf("expected the error to be returned, got %v", a)
+	}
+}
+
+func TestSetTracer(t *testing.T) {
+	tracer := &recordingTracer{}
+	SetTracer(tracer)
+	defer SetTracer(nil)
+
+	if !Enabled() {
+		t.Fatal("expected tracing to be enabled")
+	}
+	ctx, parent := Start(context.Background(), "parent", String("key", "value"))
+	_, child := Start(ctx, "child")
+	EndWithError(child, errors.New("failed"))
+	EndWithError(parent, nil)
+
+	if e, a := 2, len(tracer.spans); e != a {
+		t.Fatalf("expected %d spans, got %d", e, a)
+	}
+	parentSpan, childSpan := tracer.spans[0], tracer.spans[1]
+	if e, a := "parent", childSpan.parent; e != a {
+		t.Fatalf("expected the child span to have parent %q, got %q", e, a)
+	}
+	if e, a := (Attribute{Key: "key", Value: "value"}), parentSpan.attributes[0]; e != a {
+		t.Fatalf("unexpected attribute: expected %+v, got %+v", e, a)
+	}
+	if childSpan.err == nil || parentSpan.err != nil {
+		t.Fatalf("expected only the child span to have an error, got %v and %v", childSpan.err, parentSpan.err)
+	}
+	if !parentSpan.ended || !childSpan.ended {
+		t.Fatal("expected the spans to be ended")
+	}
+
+	SetTracer(nil)
+	if Enabled() {
+		t.Fatal("expected tracing to be disabled again")
+	}
+}
@@ -60,6 +60,10 @@ func (h *AccessToBroker) Validate(ctx context.Context, req admission.Request, cs
 		return nil
 	}
 
+	if csb.Spec.AuthInfo.ServiceAccountToken != nil {
+		return h.validateServiceAccountToken(ctx, req, csb, traced)
+	}
+
 	var secretRef *sc.ObjectReference
 	if csb.Spec.AuthInfo.Basic != nil {
 		secretRef = csb.Spec.AuthInfo.Basic.SecretRef
@@ -109,6 +113,57 @@ func (h *AccessToBroker) Validate(ctx context.Context, req admission.Request, cs
 	return nil
 }
 
+// validateServiceAccountToken checks if client may request tokens for the
+// service account of the broker. The controller requests these tokens on
+// behalf of the broker, so a client that could not request them itself must
+// not be able to obtain them through a broker.
+func (h *AccessToBroker) validateServiceAccountToken(ctx context.Context, req admission.Request, csb *sc.ClusterServiceBroker, traced *webhookutil.TracedLogger) *webhookutil.WebhookError {
+	serviceAccountRef := csb.Spec.AuthInfo.ServiceAccountToken.ServiceAccountRef
+	if serviceAccountRef == nil {
+		traced.Infof("%s %q has no ServiceAccountRef in ServiceAccountToken auth. Operation completed", csb.Kind, csb.Name)
+		return nil
+	}
+	namespace := serviceAccountRef.Namespace
+
+	user := req.UserInfo
+	sar := &authorizationapi.SubjectAccessReview{
+		Spec: authorizationapi.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationapi.ResourceAttributes{
+				Namespace:   namespace,
+				Verb:        "create",
+				Group:       corev1.SchemeGroupVersion.Group,
+				Version:     corev1.SchemeGroupVersion.Version,
+				Resource:    "serviceaccounts",
+				Subresource: "token",
+				Name:        serviceAccountRef.Name,
+			},
+			User:   user.Username,
+			Groups: user.Groups,
+			Extra:  convertToSARExtra(user.Extra),
+			UID:    user.UID,
+		},
+	}
+
+	err := h.client.Create(ctx, sar)
+	if err != nil {
+		traced.Errorf("Could not create SubjectAccessReview for %s %q: %v", csb.Kind, csb.Name, err)
+		return webhookutil.NewWebhookError(err.Error(), http.StatusForbidden)
+	}
+
+	if !sar.Status.Allowed {
+		msg := fmt.Sprintf(
+			"broker forbidden to request tokens for service account (%s/%s): Reason: %s, EvaluationError: %s",
+			namespace,
+			serviceAccountRef.Name,
+			sar.Status.Reason,
+			sar.Status.EvaluationError)
+		traced.Info(msg)
+		return webhookutil.NewWebhookError(msg, http.StatusForbidden)
+	}
+
+	return nil
+}
+
 func convertToSARExtra(extra map[string]authenticationapi.ExtraValue) map[string]authorizationapi.ExtraValue {
 	if extra == nil {
 		return nil
@@ -109,12 +109,24 @@ func (h *CreateUpdateHandler) mutateOnCreate(ctx context.Context, req admission.
 
 func (h *CreateUpdateHandler) mutateOnUpdate(ctx context.Context, req admission.Request, oldServiceBinding, newServiceBinding *sc.ServiceBinding) {
 	// TODO: We currently don't handle any changes to the spec in the
-	// reconciler. Once we do that, this check needs to be removed and
-	// proper validation of allowed changes needs to be implemented in
-	// ValidateUpdate. Also, the check for whether the generation needs
-	// to be updated needs to be un-commented.
-	// If the Spec change is allowed do not forget to update UserInfo (setServiceBindingUserInfo function)
+	// reconciler except for credential rotation. Once we do that, this check
+	// needs to be removed and proper validation of allowed changes needs to
+	// be implemented in ValidateUpdate.
+	rotationRequests := newServiceBinding.Spec.RotationRequests
+	externalID := newServiceBinding.Spec.ExternalID
 	newServiceBinding.Spec = oldServiceBinding.Spec
+
+	newServiceBinding.Spec.RotationRequests = rotationRequests
+	// The external ID may only be replaced by the one the controller chose
+	// for the rotation of the credentials of the binding.
+	if rotationID := oldServiceBinding.GetRotationExternalID(); rotationID != "" && externalID == rotationID {
+		newServiceBinding.Spec.ExternalID = externalID
+	}
+
+	if newServiceBinding.Spec.RotationRequests != oldServiceBinding.Spec.RotationRequests &&
+		utilfeature.DefaultFeatureGate.Enabled(scfeatures.OriginatingIdentity) {
+		setServiceBindingUserInfo(req, newServiceBinding)
+	}
 }
 
 // setServiceBindingUserInfo injects user.Info from the request context
@@ -200,6 +200,173 @@ func TestCreateUpdateHandlerHandleUpdateSuccess(t *testing.T) {
 				},
 			},
 		},
+		"Should keep rotation requests and replace external ID changes": {
+			givenOldRawObj: []byte(`{
+  				"apiVersion": "servicecatalog.k8s.io/v1beta1",
+  				"kind": "ServiceBinding",
+  				"metadata": {
+  				  "creationTimestamp": null,
+  				  "name": "test-binding"
+  				},
+  				"spec": {
+                  "externalID": "id-0123",
+				  "instanceRef": {
+					"name": "some-instance"
+				  }
+  				}
+			}`),
+			givenNewRawObj: []byte(`{
+  				"apiVersion": "servicecatalog.k8s.io/v1beta1",
+  				"kind": "ServiceBinding",
+  				"metadata": {
+  				  "creationTimestamp": null,
+  				  "name": "test-binding"
+  				},
+  				"spec": {
+				  "externalID": "id-4567",
+				  "instanceRef": {
+					"name": "some-instance"
+				  },
+				  "rotationRequests": 1
+  				}
+			}`),
+			expPatches: []jsonpatch.Operation{
+				{
+					Operation: "replace",
+					Path:      "/spec/externalID",
+					Value:     "id-0123",
+				},
+			},
+		},
+		"Should allow external ID change when rotation is starting": {
+			givenOldRawObj: []byte(`{
+  				"apiVersion": "servicecatalog.k8s.io/v1beta1",
+  				"kind": "ServiceBinding",
+  				"metadata": {
+  				  "creationTimestamp": null,
+  				  "name": "test-binding"
+  				},
+  				"spec": {
+                  "externalID": "id-0123",
+				  "instanceRef": {
+					"name": "some-instance"
+				  },
+				  "rotationRequests": 1
+  				},
+  				"status": {
+				  "rotationStatus": {
+					"rotationRequests": 1,
+					"phase": "Binding",
+					"previousExternalID": "id-0123",
+					"externalID": "id-4567"
+				  }
+  				}
+			}`),
+			givenNewRawObj: []byte(`{
+  				"apiVersion": "servicecatalog.k8s.io/v1beta1",
+  				"kind": "ServiceBinding",
+  				"metadata": {
+  				  "creationTimestamp": null,
+  				  "name": "test-binding"
+  				},
+  				"spec": {
+				  "externalID": "id-4567",
+				  "instanceRef": {
+					"name": "some-instance"
+				  },
+				  "rotationRequests": 1
+  				}
+			}`),
+			expPatches: []jsonpatch.Operation{},
+		},
+		"Should replace external ID change not chosen by the controller when rotation is starting": {
+			givenOldRawObj: []byte(`{
+  				"apiVersion": "servicecatalog.k8s.io/v1beta1",
+  				"kind": "ServiceBinding",
+  				"metadata": {
+  				  "creationTimestamp": null,
+  				  "name": "test-binding"
+  				},
+  				"spec": {
+                  "externalID": "id-0123",
+				  "instanceRef": {
+					"name": "some-instance"
+				  },
+				  "rotationRequests": 1
+  				},
+  				"status": {
+				  "rotationStatus": {
+					"rotationRequests": 1,
+					"phase": "Binding",
+					"previousExternalID": "id-0123",
+					"externalID": "id-4567"
+				  }
+  				}
+			}`),
+			givenNewRawObj: []byte(`{
+  				"apiVersion": "servicecatalog.k8s.io/v1beta1",
+  				"kind": "ServiceBinding",
+  				"metadata": {
+  				  "creationTimestamp": null,
+  				  "name": "test-binding"
+  				},
+  				"spec": {
+				  "externalID": "id-8901",
+				  "instanceRef": {
+					"name": "some-instance"
+				  },
+				  "rotationRequests": 1
+  				}
+			}`),
+			expPatches: []jsonpatch.Operation{
+				{
+					Operation: "replace",
+					Path:      "/spec/externalID",
+					Value:     "id-0123",
+				},
+			},
+		},
+		"Should allow external ID change back to the previous one when rotation failed": {
+			givenOldRawObj: []byte(`{
+  				"apiVersion": "servicecatalog.k8s.io/v1beta1",
+  				"kind": "ServiceBinding",
+  				"metadata": {
+  				  "creationTimestamp": null,
+  				  "name": "test-binding"
+  				},
+  				"spec": {
+                  "externalID": "id-4567",
+				  "instanceRef": {
+					"name": "some-instance"
+				  },
+				  "rotationRequests": 1
+  				},
+  				"status": {
+				  "rotationStatus": {
+					"rotationRequests": 1,
+					"phase": "Failed",
+					"previousExternalID": "id-0123",
+					"externalID": "id-4567"
+				  }
+  				}
+			}`),
+			givenNewRawObj: []byte(`{
+  				"apiVersion": "servicecatalog.k8s.io/v1beta1",
+  				"kind": "ServiceBinding",
+  				"metadata": {
+  				  "creationTimestamp": null,
+  				  "name": "test-binding"
+  				},
+  				"spec": {
+				  "externalID": "id-0123",
+				  "instanceRef": {
+					"name": "some-instance"
+				  },
+				  "rotationRequests": 1
+  				}
+			}`),
+			expPatches: []jsonpatch.Operation{},
+		},
 	}
 
 	for tn, tc := range tests {
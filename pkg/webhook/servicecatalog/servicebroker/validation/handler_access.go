@@ -48,6 +48,10 @@ func (h *AccessToBroker) Validate(ctx context.Context, req admission.Request, sb
 		return nil
 	}
 
+	if sb.Spec.AuthInfo.ServiceAccountToken != nil {
+		return h.validateServiceAccountToken(ctx, req, sb, traced)
+	}
+
 	var secretRef *sc.LocalObjectReference
 	if sb.Spec.AuthInfo.Basic != nil {
 		secretRef = sb.Spec.AuthInfo.Basic.SecretRef
@@ -97,6 +101,57 @@ func (h *AccessToBroker) Validate(ctx context.Context, req admission.Request, sb
 	return nil
 }
 
+// validateServiceAccountToken checks if client may request tokens for the
+// service account of the broker. The controller requests these tokens on
+// behalf of the broker, so a client that could not request them itself must
+// not be able to obtain them through a broker.
+func (h *AccessToBroker) validateServiceAccountToken(ctx context.Context, req admission.Request, sb *sc.ServiceBroker, traced *webhookutil.TracedLogger) *webhookutil.WebhookError {
+	serviceAccountRef := sb.Spec.AuthInfo.ServiceAccountToken.ServiceAccountRef
+	if serviceAccountRef == nil {
+		traced.Infof("%s %q has no ServiceAccountRef in ServiceAccountToken auth. Operation completed", sb.Kind, sb.Name)
+		return nil
+	}
+	namespace := sb.Namespace
+
+	user := req.UserInfo
+	sar := &authorizationapi.SubjectAccessReview{
+		Spec: authorizationapi.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationapi.ResourceAttributes{
+				Namespace:   namespace,
+				Verb:        "create",
+				Group:       corev1.SchemeGroupVersion.Group,
+				Version:     corev1.SchemeGroupVersion.Version,
+				Resource:    "serviceaccounts",
+				Subresource: "token",
+				Name:        serviceAccountRef.Name,
+			},
+			User:   user.Username,
+			Groups: user.Groups,
+			Extra:  convertToSARExtra(user.Extra),
+			UID:    user.UID,
+		},
+	}
+
+	err := h.client.Create(ctx, sar)
+	if err != nil {
+		traced.Errorf("Could not create SubjectAccessReview for %s %q: %v", sb.Kind, sb.Name, err)
+		return webhookutil.NewWebhookError(err.Error(), http.StatusForbidden)
+	}
+
+	if !sar.Status.Allowed {
+		msg := fmt.Sprintf(
+			"broker forbidden to request tokens for service account (%s/%s): Reason: %s, EvaluationError: %s",
+			namespace,
+			serviceAccountRef.Name,
+			sar.Status.Reason,
+			sar.Status.EvaluationError)
+		traced.Info(msg)
+		return webhookutil.NewWebhookError(msg, http.StatusForbidden)
+	}
+
+	return nil
+}
+
 func convertToSARExtra(extra map[string]authenticationapi.ExtraValue) map[string]authorizationapi.ExtraValue {
 	if extra == nil {
 		return nil
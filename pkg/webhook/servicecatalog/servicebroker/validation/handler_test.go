@@ -17,10 +17,19 @@ limitations under the License.
 package validation_test
 
 import (
+	"context"
 	"testing"
 
+	sc "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/pkg/webhook/servicecatalog/servicebroker/validation"
 	"github.com/kubernetes-sigs/service-catalog/pkg/webhookutil/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 func TestSpecValidationHandlerDecoderErrors(t *testing.T) {
@@ -34,3 +43,73 @@ func TestSpecValidationHandlerDecoderErrors(t *testing.T) {
 		fn(t, &handler, "ServiceBroker")
 	}
 }
+
+// TestSpecValidationHandlerCatalogRestrictions tests that malformed catalog
+// restrictions are rejected. All restriction validations are covered by the
+// pkg/apis/servicecatalog/validation package
+func TestSpecValidationHandlerCatalogRestrictions(t *testing.T) {
+	tests := map[string]struct {
+		givenRestriction string
+		expectedAllowed  bool
+	}{
+		"Should allow a valid regular expression": {
+			givenRestriction: "spec.externalName matches ^azure-sql-.*",
+			expectedAllowed:  true,
+		},
+		"Should not allow a malformed regular expression": {
+			givenRestriction: "spec.externalName matches ^azure-sql-(",
+			expectedAllowed:  false,
+		},
+		"Should not allow a malformed label selector": {
+			givenRestriction: "spec.externalName in (azure-sql",
+			expectedAllowed:  false,
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			// given
+			sc.AddToScheme(scheme.Scheme)
+			decoder, err := admission.NewDecoder(scheme.Scheme)
+			require.NoError(t, err)
+			handler := &validation.SpecValidationHandler{
+				CreateValidators: []validation.Validator{&validation.StaticCreate{}},
+			}
+			handler.InjectDecoder(decoder)
+
+			req := admission.Request{
+				AdmissionRequest: admissionv1beta1.AdmissionRequest{
+					Operation: admissionv1beta1.Create,
+					Name:      "test-broker",
+					Namespace: "test-ns",
+					Kind: metav1.GroupVersionKind{
+						Kind:    "ServiceBroker",
+						Version: "v1beta1",
+						Group:   "servicecatalog.k8s.io",
+					},
+					Object: runtime.RawExtension{Raw: []byte(`{
+  				"apiVersion": "servicecatalog.k8s.io/v1beta1",
+  				"kind": "ServiceBroker",
+  				"metadata": {
+  				  "creationTimestamp": null,
+  				  "namespace": "test-ns",
+  				  "name": "test-broker"
+  				},
+  				"spec": {
+  				  "relistBehavior": "Manual",
+  				  "url": "http://localhost:8081/",
+  				  "catalogRestrictions": {
+  				    "serviceClass": ["` + tc.givenRestriction + `"]
+  				  }
+  				}
+			}`)},
+				},
+			}
+
+			// when
+			resp := handler.Handle(context.Background(), req)
+
+			// then
+			assert.Equal(t, tc.expectedAllowed, resp.Allowed)
+		})
+	}
+}
@@ -90,6 +90,10 @@ func (s *sarcheck) Admit(a admission.Attributes, o admission.ObjectInterfaces) e
 			return nil
 		}
 
+		if tokenAuth := clusterServiceBroker.Spec.AuthInfo.ServiceAccountToken; tokenAuth != nil && tokenAuth.ServiceAccountRef != nil {
+			return s.checkServiceAccountTokenAccess(a, tokenAuth.ServiceAccountRef.Namespace, tokenAuth.ServiceAccountRef.Name)
+		}
+
 		var secretRef *servicecatalog.ObjectReference
 		if clusterServiceBroker.Spec.AuthInfo.Basic != nil {
 			secretRef = clusterServiceBroker.Spec.AuthInfo.Basic.SecretRef
@@ -114,6 +118,10 @@ func (s *sarcheck) Admit(a admission.Attributes, o admission.ObjectInterfaces) e
 			return nil
 		}
 
+		if tokenAuth := serviceBroker.Spec.AuthInfo.ServiceAccountToken; tokenAuth != nil && tokenAuth.ServiceAccountRef != nil {
+			return s.checkServiceAccountTokenAccess(a, serviceBroker.Namespace, tokenAuth.ServiceAccountRef.Name)
+		}
+
 		var secretRef *servicecatalog.LocalObjectReference
 		if serviceBroker.Spec.AuthInfo.Basic != nil {
 			secretRef = serviceBroker.Spec.AuthInfo.Basic.SecretRef
@@ -161,6 +169,40 @@ func (s *sarcheck) Admit(a admission.Attributes, o admission.ObjectInterfaces) e
 	return nil
 }
 
+// checkServiceAccountTokenAccess checks that the user may request tokens for
+// the service account of a broker, since the controller requests them on
+// behalf of the broker.
+func (s *sarcheck) checkServiceAccountTokenAccess(a admission.Attributes, namespace, name string) error {
+	userInfo := a.GetUserInfo()
+
+	sar := &authorizationapi.SubjectAccessReview{
+		Spec: authorizationapi.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationapi.ResourceAttributes{
+				Namespace:   namespace,
+				Verb:        "create",
+				Group:       corev1.SchemeGroupVersion.Group,
+				Version:     corev1.SchemeGroupVersion.Version,
+				Resource:    "serviceaccounts",
+				Subresource: "token",
+				Name:        name,
+			},
+			User:   userInfo.GetName(),
+			Groups: userInfo.GetGroups(),
+			Extra:  convertToSARExtra(userInfo.GetExtra()),
+			UID:    userInfo.GetUID(),
+		},
+	}
+	sar, err := s.client.AuthorizationV1().SubjectAccessReviews().Create(sar)
+	if err != nil {
+		return err
+	}
+
+	if !sar.Status.Allowed {
+		return admission.NewForbidden(a, fmt.Errorf("broker forbidden to request tokens for service account (%s/%s): Reason: %s, EvaluationError: %s", namespace, name, sar.Status.Reason, sar.Status.EvaluationError))
+	}
+	return nil
+}
+
 // NewSARCheck creates a new subject access review check admission control handler
 func NewSARCheck() (admission.Interface, error) {
 	return &sarcheck{
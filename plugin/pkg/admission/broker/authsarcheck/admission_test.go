@@ -183,6 +183,62 @@ func TestAdmissionBroker(t *testing.T) {
 			},
 			allowed: false,
 		},
+		{
+			name: "broker with service account token, user may request tokens",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						ServiceAccountToken: &servicecatalog.ClusterServiceAccountTokenAuthConfig{
+							ServiceAccountRef: &servicecatalog.ObjectReference{
+								Namespace: "test-ns",
+								Name:      "test-broker-client",
+							},
+							Audience: "test-broker",
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: "Manual",
+					},
+				},
+			},
+			userInfo: &user.DefaultInfo{
+				Name:   "system:serviceaccount:test-ns:catalog",
+				Groups: []string{"system:serviceaccount", "system:serviceaccounts:test-ns"},
+			},
+			allowed: true,
+		},
+		{
+			name: "broker with service account token, user may not request tokens",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						ServiceAccountToken: &servicecatalog.ClusterServiceAccountTokenAuthConfig{
+							ServiceAccountRef: &servicecatalog.ObjectReference{
+								Namespace: "test-ns",
+								Name:      "test-broker-client",
+							},
+							Audience: "test-broker",
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: "Manual",
+					},
+				},
+			},
+			userInfo: &user.DefaultInfo{
+				Name:   "system:serviceaccount:test-ns:forbidden",
+				Groups: []string{"system:serviceaccount", "system:serviceaccounts:test-ns"},
+			},
+			allowed: false,
+		},
 		{
 			name: "broker with empty authInfo",
 			broker: &servicecatalog.ClusterServiceBroker{
@@ -277,6 +333,34 @@ func TestAdmissionBroker(t *testing.T) {
 			},
 			allowed: true,
 		},
+		{
+			name: "namespace broker with service account token, user may not request tokens",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-ns",
+					Name:      "test-broker",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
+						ServiceAccountToken: &servicecatalog.ServiceAccountTokenAuthConfig{
+							ServiceAccountRef: &servicecatalog.LocalObjectReference{
+								Name: "test-broker-client",
+							},
+							Audience: "test-broker",
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: "Manual",
+					},
+				},
+			},
+			userInfo: &user.DefaultInfo{
+				Name:   "system:serviceaccount:test-ns:forbidden",
+				Groups: []string{"system:serviceaccount", "system:serviceaccounts:test-ns"},
+			},
+			allowed: false,
+		},
 		{
 			name: "namespace broker with basic auth, user authenticated",
 			broker: &servicecatalog.ServiceBroker{
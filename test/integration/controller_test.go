@@ -39,10 +39,6 @@ import (
 	// avoid error `servicecatalog/v1beta1 is not enabled`
 	_ "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/install"
 
-	osb "github.com/kubernetes-sigs/go-open-service-broker-client/v2"
-	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
-	generator "github.com/kubernetes-sigs/go-open-service-broker-client/v2/generator"
-
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog"
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/pkg/client/clientset_generated/clientset"
@@ -52,6 +48,9 @@ import (
 	"github.com/kubernetes-sigs/service-catalog/pkg/controller"
 	scfeatures "github.com/kubernetes-sigs/service-catalog/pkg/features"
 	"github.com/kubernetes-sigs/service-catalog/test/util"
+	osb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
+	fakeosb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/fake"
+	generator "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/generator"
 	k8sinformers "k8s.io/client-go/informers"
 )
 
@@ -775,6 +774,7 @@ func newControllerTestTestController(ct *controllerTest) (
 	testController, err := controller.NewController(
 		fakeKubeClient,
 		coreInformers.V1().Secrets(),
+		coreInformers.V1().ConfigMaps(),
 		catalogClient.ServicecatalogV1beta1(),
 		serviceCatalogSharedInformers.ClusterServiceBrokers(),
 		serviceCatalogSharedInformers.ServiceBrokers(),
@@ -793,6 +793,14 @@ func newControllerTestTestController(ct *controllerTest) (
 		controller.DefaultClusterIDConfigMapName,
 		controller.DefaultClusterIDConfigMapNamespace,
 		60*time.Second,
+		0,
+		0,
+		0,
+		0,
+		controller.DefaultCatalogHistoryNamespace,
+		0,
+		0,
+		0,
 	)
 	t.Log("controller start")
 	if err != nil {
@@ -942,6 +950,7 @@ func newTestController(t *testing.T) (
 	testController, err := controller.NewController(
 		fakeKubeClient,
 		coreInformers.V1().Secrets(),
+		coreInformers.V1().ConfigMaps(),
 		catalogClient.ServicecatalogV1beta1(),
 		serviceCatalogSharedInformers.ClusterServiceBrokers(),
 		serviceCatalogSharedInformers.ServiceBrokers(),
@@ -960,6 +969,14 @@ func newTestController(t *testing.T) (
 		controller.DefaultClusterIDConfigMapName,
 		controller.DefaultClusterIDConfigMapNamespace,
 		60*time.Second,
+		0,
+		0,
+		0,
+		0,
+		controller.DefaultCatalogHistoryNamespace,
+		0,
+		0,
+		0,
 	)
 	t.Log("controller start")
 	if err != nil {
@@ -23,13 +23,13 @@ import (
 
 	// avoid error `servicecatalog/v1beta1 is not enabled`
 	_ "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/install"
-	fakeosb "github.com/kubernetes-sigs/go-open-service-broker-client/v2/fake"
 
 	"time"
 
 	"github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-sigs/service-catalog/test/util"
-	"github.com/kubernetes-sigs/go-open-service-broker-client/v2/generator"
+	fakeosb "github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/fake"
+	"github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2/generator"
 )
 
 func TestClusterServiceClassRemovedFromCatalogAfterFiltering(t *testing.T) {
@@ -26,11 +26,12 @@ import (
 // internal message body types
 
 type bindRequestBody struct {
-	ServiceID    string                 `json:"service_id"`
-	PlanID       string                 `json:"plan_id"`
-	Parameters   map[string]interface{} `json:"parameters,omitempty"`
-	BindResource map[string]interface{} `json:"bind_resource,omitempty"`
-	Context      map[string]interface{} `json:"context,omitempty"`
+	ServiceID            string                 `json:"service_id"`
+	PlanID               string                 `json:"plan_id"`
+	Parameters           map[string]interface{} `json:"parameters,omitempty"`
+	BindResource         map[string]interface{} `json:"bind_resource,omitempty"`
+	Context              map[string]interface{} `json:"context,omitempty"`
+	PredecessorBindingID *string                `json:"predecessor_binding_id,omitempty"`
 }
 
 type bindSuccessResponseBody struct {
@@ -39,6 +40,7 @@ type bindSuccessResponseBody struct {
 	RouteServiceURL *string                `json:"route_service_url"`
 	VolumeMounts    []interface{}          `json:"volume_mounts"`
 	Operation       *string                `json:"operation"`
+	Metadata        *BindingMetadata       `json:"metadata"`
 }
 
 const (
@@ -76,6 +78,10 @@ func (c *client) Bind(r *BindRequest) (*BindResponse, error) {
 		requestBody.Context = r.Context
 	}
 
+	if c.validateAlphaAPIMethodsAllowed() == nil {
+		requestBody.PredecessorBindingID = r.PredecessorBindingID
+	}
+
 	if r.BindResource != nil {
 		requestBody.BindResource = map[string]interface{}{}
 		if r.BindResource.AppGUID != nil {
@@ -127,6 +133,7 @@ func (c *client) Bind(r *BindRequest) (*BindResponse, error) {
 			RouteServiceURL: responseBodyObj.RouteServiceURL,
 			VolumeMounts:    responseBodyObj.VolumeMounts,
 			OperationKey:    opPtr,
+			Metadata:        responseBodyObj.Metadata,
 		}
 		if response.StatusCode == http.StatusAccepted {
 			if c.Verbose {
@@ -18,6 +18,7 @@ package v2
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
@@ -128,9 +129,17 @@ type client struct {
 
 	httpClient    *http.Client
 	doRequestFunc doRequestFunc
+	ctx           context.Context
 }
 
-var _ Client = &client{}
+var _ ContextClient = &client{}
+
+// WithContext implements ContextClient.WithContext.
+func (c *client) WithContext(ctx context.Context) Client {
+	withContext := *c
+	withContext.ctx = ctx
+	return &withContext
+}
 
 // This file contains shared methods used by each interface method of the
 // Client interface.  Individual interface methods are in the following files:
@@ -168,6 +177,9 @@ func (c *client) prepareAndDo(method, URL string, params map[string]string, body
 	if err != nil {
 		return nil, err
 	}
+	if c.ctx != nil {
+		request = request.WithContext(c.ctx)
+	}
 
 	request.Header.Set(APIVersionHeader, c.APIVersion.HeaderValue())
 	if bodyReader != nil {
@@ -211,6 +211,19 @@ func (e GetBindingNotAllowedError) Error() string {
 	)
 }
 
+// GetInstanceNotAllowedError is an error type signifying that doing a GET to
+// fetch an instance is not allowed for this client.
+type GetInstanceNotAllowedError struct {
+	reason string
+}
+
+func (e GetInstanceNotAllowedError) Error() string {
+	return fmt.Sprintf(
+		"GetInstance not allowed: %s",
+		e.reason,
+	)
+}
+
 // AsyncBindingOperationsNotAllowedError is an error type signifying that asynchronous
 // binding operations (bind/unbind/poll) are not allowed for this client.
 type AsyncBindingOperationsNotAllowedError struct {
@@ -21,7 +21,7 @@ import (
 	"net/http"
 	"sync"
 
-	"github.com/kubernetes-sigs/go-open-service-broker-client/v2"
+	"github.com/kubernetes-sigs/service-catalog/third_party/forked/go-open-service-broker-client/v2"
 )
 
 // NewFakeClientFunc returns a v2.CreateFunc that returns a FakeClient with
@@ -55,6 +55,7 @@ func NewFakeClient(config FakeClientConfiguration) *FakeClient {
 		BindReaction:                     config.BindReaction,
 		UnbindReaction:                   config.UnbindReaction,
 		GetBindingReaction:               config.GetBindingReaction,
+		GetInstanceReaction:              config.GetInstanceReaction,
 	}
 }
 
@@ -70,6 +71,7 @@ type FakeClientConfiguration struct {
 	BindReaction                     BindReactionInterface
 	UnbindReaction                   UnbindReactionInterface
 	GetBindingReaction               GetBindingReactionInterface
+	GetInstanceReaction              GetInstanceReactionInterface
 }
 
 // Action is a record of a method call on the FakeClient.
@@ -93,6 +95,7 @@ const (
 	Bind                     ActionType = "Bind"
 	Unbind                   ActionType = "Unbind"
 	GetBinding               ActionType = "GetBinding"
+	GetInstance              ActionType = "GetInstance"
 )
 
 // FakeClient is a fake implementation of the v2.Client interface. It records
@@ -110,6 +113,7 @@ type FakeClient struct {
 	BindReaction                     BindReactionInterface
 	UnbindReaction                   UnbindReactionInterface
 	GetBindingReaction               GetBindingReactionInterface
+	GetInstanceReaction              GetInstanceReactionInterface
 
 	sync.Mutex
 	actions []Action
@@ -259,6 +263,20 @@ func (c *FakeClient) GetBinding(*v2.GetBindingRequest) (*v2.GetBindingResponse,
 	return nil, UnexpectedActionError()
 }
 
+// GetInstance implements the Client.GetInstance method for the FakeClient.
+func (c *FakeClient) GetInstance(r *v2.GetInstanceRequest) (*v2.GetInstanceResponse, error) {
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	c.actions = append(c.actions, Action{GetInstance, r})
+
+	if c.GetInstanceReaction != nil {
+		return c.GetInstanceReaction.react(r)
+	}
+
+	return nil, UnexpectedActionError()
+}
+
 // UnexpectedActionError returns an error message when an action is not found
 // in the FakeClient's action array.
 func UnexpectedActionError() error {
@@ -483,6 +501,29 @@ func (r DynamicGetBindingReaction) react() (*v2.GetBindingResponse, error) {
 	return r()
 }
 
+// GetInstanceReactionInterface defines the reaction to GetInstance requests.
+type GetInstanceReactionInterface interface {
+	react(*v2.GetInstanceRequest) (*v2.GetInstanceResponse, error)
+}
+
+type GetInstanceReaction struct {
+	Response *v2.GetInstanceResponse
+	Error    error
+}
+
+func (r *GetInstanceReaction) react(_ *v2.GetInstanceRequest) (*v2.GetInstanceResponse, error) {
+	if r == nil {
+		return nil, UnexpectedActionError()
+	}
+	return r.Response, r.Error
+}
+
+type DynamicGetInstanceReaction func(*v2.GetInstanceRequest) (*v2.GetInstanceResponse, error)
+
+func (r DynamicGetInstanceReaction) react(req *v2.GetInstanceRequest) (*v2.GetInstanceResponse, error) {
+	return r(req)
+}
+
 func strPtr(s string) *string {
 	return &s
 }
@@ -41,6 +41,14 @@ func (c *client) GetCatalog() (*CatalogResponse, error) {
 			return nil, HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
 		}
 
+		if c.validateAlphaAPIMethodsAllowed() != nil {
+			for ii := range catalogResponse.Services {
+				for jj := range catalogResponse.Services[ii].Plans {
+					catalogResponse.Services[ii].Plans[jj].MaintenanceInfo = nil
+				}
+			}
+		}
+
 		if !c.APIVersion.AtLeast(Version2_13()) {
 			for ii := range catalogResponse.Services {
 				for jj := range catalogResponse.Services[ii].Plans {
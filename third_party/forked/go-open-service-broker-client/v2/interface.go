@@ -17,6 +17,7 @@ limitations under the License.
 package v2
 
 import (
+	"context"
 	"crypto/tls"
 )
 
@@ -200,6 +201,14 @@ type Client interface {
 	// binding endpoint
 	// (/v2/service_instances/instance-id/service_bindings/binding-id)
 	GetBinding(r *GetBindingRequest) (*GetBindingResponse, error)
+	// GetInstance is an ALPHA API method and may change. Alpha features must
+	// be enabled and the client must be using the latest API Version in
+	// order to use this method.
+	//
+	// GetInstance returns information about an existing instance.
+	// GetInstance calls GET on the Broker's instance endpoint
+	// (/v2/service_instances/instance-id)
+	GetInstance(r *GetInstanceRequest) (*GetInstanceResponse, error)
 }
 
 // CreateFunc allows control over which implementation of a Client is
@@ -208,3 +217,22 @@ type Client interface {
 // prohibitive.  In order to make such code testable, users of the API can
 // inject a CreateFunc, and use the CreateFunc from the fake package in tests.
 type CreateFunc func(*ClientConfiguration) (Client, error)
+
+// ContextClient is implemented by Clients that can send their requests with
+// a context, e.g. to carry a deadline or the trace of the caller.
+type ContextClient interface {
+	Client
+	// WithContext returns a copy of the client that sends its requests with
+	// the given context.
+	WithContext(ctx context.Context) Client
+}
+
+// WithContext returns a copy of the given client that sends its requests
+// with the given context, or the client itself if it does not implement
+// ContextClient.
+func WithContext(ctx context.Context, client Client) Client {
+	if contextClient, ok := client.(ContextClient); ok {
+		return contextClient.WithContext(ctx)
+	}
+	return client
+}
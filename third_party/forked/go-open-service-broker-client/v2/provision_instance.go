@@ -32,6 +32,7 @@ type provisionRequestBody struct {
 	SpaceGUID        string                 `json:"space_guid"`
 	Parameters       map[string]interface{} `json:"parameters,omitempty"`
 	Context          map[string]interface{} `json:"context,omitempty"`
+	MaintenanceInfo  *MaintenanceInfo       `json:"maintenance_info,omitempty"`
 }
 
 type provisionSuccessResponseBody struct {
@@ -63,6 +64,10 @@ func (c *client) ProvisionInstance(r *ProvisionRequest) (*ProvisionResponse, err
 		requestBody.Context = r.Context
 	}
 
+	if c.validateAlphaAPIMethodsAllowed() == nil {
+		requestBody.MaintenanceInfo = r.MaintenanceInfo
+	}
+
 	response, err := c.prepareAndDo(http.MethodPut, fullURL, params, requestBody, r.OriginatingIdentity)
 	if err != nil {
 		return nil, err
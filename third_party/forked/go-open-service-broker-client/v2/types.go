@@ -51,6 +51,13 @@ type Service struct {
 	// (/v2/service_instances/instance-id/service_bindings/binding-id) is
 	// supported for all plans.
 	BindingsRetrievable bool `json:"bindings_retrievable,omitempty"`
+	// InstancesRetrievable is ALPHA and may change or disappear at any time.
+	// InstancesRetrievable will only be provided if alpha features are enabled.
+	//
+	// InstancesRetrievable represents whether fetching a service instance via
+	// a GET on the instance resource's endpoint
+	// (/v2/service_instances/instance-id) is supported for all plans.
+	InstancesRetrievable bool `json:"instances_retrievable,omitempty"`
 	// PlanUpdatable represents whether instances of this service may be
 	// updated to a different plan. The serialized form 'plan_updateable' is
 	// a mistake that has become written into the API for backward
@@ -105,6 +112,28 @@ type Plan struct {
 	// the expected parameters for creation and update of instances and
 	// creation of bindings.
 	Schemas *Schemas `json:"schemas,omitempty"`
+	// MaintenanceInfo is information used to determine whether an instance
+	// of this plan is up to date. Optional.
+	//
+	// MaintenanceInfo is an ALPHA API attribute and may change. Alpha
+	// features must be enabled and the client must be using the
+	// latest API Version in order to use this.
+	MaintenanceInfo *MaintenanceInfo `json:"maintenance_info,omitempty"`
+}
+
+// MaintenanceInfo describes the version of the software deployed for
+// instances of a plan. A change in the version of a plan's MaintenanceInfo
+// indicates that an upgrade is available for existing instances.
+//
+// MaintenanceInfo is an ALPHA API attribute and may change. Alpha
+// features must be enabled and the client must be using the
+// latest API Version in order to use this.
+type MaintenanceInfo struct {
+	// Version is the semantic version of the plan's maintenance info.
+	Version string `json:"version"`
+	// Description is a human-readable description of the changes in this
+	// version. Optional.
+	Description *string `json:"description,omitempty"`
 }
 
 // Schemas requires a client API version >=2.13.
@@ -219,6 +248,13 @@ type ProvisionRequest struct {
 	// OriginatingIdentity is the identity on the platform of the user making
 	// this request.
 	OriginatingIdentity *OriginatingIdentity `json:"originatingIdentity,omitempty"`
+	// MaintenanceInfo is the maintenance information of the plan the instance
+	// is provisioned from. Optional.
+	//
+	// MaintenanceInfo is an ALPHA API attribute and may change. Alpha
+	// features must be enabled and the client must be using the
+	// latest API Version in order to use this.
+	MaintenanceInfo *MaintenanceInfo `json:"maintenance_info,omitempty"`
 }
 
 // ProvisionResponse is sent in response to a provision call.
@@ -309,6 +345,14 @@ type UpdateInstanceRequest struct {
 	// OriginatingIdentity is the identity on the platform of the user making
 	// this request.
 	OriginatingIdentity *OriginatingIdentity `json:"originatingIdentity,omitempty"`
+	// MaintenanceInfo is the maintenance information the instance should be
+	// upgraded to. If unset, indicates that the client does not wish to
+	// upgrade the instance.
+	//
+	// MaintenanceInfo is an ALPHA API attribute and may change. Alpha
+	// features must be enabled and the client must be using the
+	// latest API Version in order to use this.
+	MaintenanceInfo *MaintenanceInfo `json:"maintenance_info,omitempty"`
 }
 
 // PreviousValues represents information about the service instance prior to the update.
@@ -329,6 +373,12 @@ type PreviousValues struct {
 	// in the top-level field context. ID of the space specified for the service
 	// instance. If present, MUST be a non-empty string.
 	SpaceID string `json:"space_id,omitempty"`
+	// MaintenanceInfo of the service instance prior to the update. Optional.
+	//
+	// MaintenanceInfo is an ALPHA API attribute and may change. Alpha
+	// features must be enabled and the client must be using the
+	// latest API Version in order to use this.
+	MaintenanceInfo *MaintenanceInfo `json:"maintenance_info,omitempty"`
 }
 
 // UpdateInstanceResponse represents a broker's response to an update instance
@@ -489,6 +539,13 @@ type BindRequest struct {
 	// OriginatingIdentity is the identity on the platform of the user making
 	// this request.
 	OriginatingIdentity *OriginatingIdentity `json:"originatingIdentity,omitempty"`
+	// PredecessorBindingID is an ALPHA API attribute and may change. Alpha
+	// features must be enabled and the client must be using the latest API
+	// Version in order to use this.
+	//
+	// PredecessorBindingID is the ID of the binding whose credentials are
+	// being rotated by the new binding. Optional.
+	PredecessorBindingID *string `json:"predecessor_binding_id,omitempty"`
 }
 
 // BindResource contains data for platform resources associated with a
@@ -530,6 +587,23 @@ type BindResponse struct {
 	// OperationKey is an extra identifier supplied by the broker to identify
 	// asynchronous operations.
 	OperationKey *OperationKey `json:"operation,omitempty"`
+	// Metadata is an ALPHA API attribute and may change.
+	//
+	// Metadata holds the lifetime of the binding, for brokers that issue
+	// credentials that expire.
+	Metadata *BindingMetadata `json:"metadata,omitempty"`
+}
+
+// BindingMetadata describes the lifetime of a binding.
+//
+// BindingMetadata is an ALPHA API attribute and may change.
+type BindingMetadata struct {
+	// ExpiresAt is the time, in ISO 8601 format, at which the credentials of
+	// the binding expire. Optional.
+	ExpiresAt string `json:"expires_at,omitempty"`
+	// RenewBefore is the time, in ISO 8601 format, before which the binding
+	// should be rotated by the platform. Optional.
+	RenewBefore string `json:"renew_before,omitempty"`
 }
 
 // UnbindRequest represents a request to unbind a particular binding.
@@ -577,6 +651,35 @@ type UnbindResponse struct {
 	OperationKey *OperationKey `json:"operation,omitempty"`
 }
 
+// GetInstanceRequest represents a request to do a GET on a particular
+// instance.
+type GetInstanceRequest struct {
+	// InstanceID is the ID of the instance to fetch.
+	InstanceID string `json:"instance_id"`
+	// ServiceID is the ID of the service the instance is provisioned from.
+	// Optional.
+	ServiceID *string `json:"service_id,omitempty"`
+	// PlanID is the ID of the plan the instance is provisioned from.
+	// Optional.
+	PlanID *string `json:"plan_id,omitempty"`
+}
+
+// GetInstanceResponse is sent as the response to doing a GET on a particular
+// instance.
+type GetInstanceResponse struct {
+	// ServiceID is the ID of the service the instance is provisioned from.
+	ServiceID string `json:"service_id,omitempty"`
+	// PlanID is the ID of the plan the instance is provisioned from.
+	PlanID string `json:"plan_id,omitempty"`
+	// DashboardURL is the URL of a web-based management user interface for
+	// the service instance.
+	DashboardURL *string `json:"dashboard_url,omitempty"`
+	// Parameters is the configuration parameters of the instance.
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	// MaintenanceInfo is the maintenance information the instance is at.
+	MaintenanceInfo *MaintenanceInfo `json:"maintenance_info,omitempty"`
+}
+
 // GetBindingRequest represents a request to do a GET on a particular binding.
 type GetBindingRequest struct {
 	// InstanceID is the ID of the instance the binding is for.
@@ -605,4 +708,9 @@ type GetBindingResponse struct {
 	VolumeMounts []interface{} `json:"volume_mounts,omitempty"`
 	// Parameters is configuration parameters for the binding.
 	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	// Metadata is an ALPHA API attribute and may change.
+	//
+	// Metadata holds the lifetime of the binding, for brokers that issue
+	// credentials that expire.
+	Metadata *BindingMetadata `json:"metadata,omitempty"`
 }
@@ -24,11 +24,12 @@ import (
 // internal message body types
 
 type updateInstanceRequestBody struct {
-	ServiceID      string                 `json:"service_id"`
-	PlanID         *string                `json:"plan_id,omitempty"`
-	Parameters     map[string]interface{} `json:"parameters,omitempty"`
-	Context        map[string]interface{} `json:"context,omitempty"`
-	PreviousValues *PreviousValues        `json:"previous_values,omitempty"`
+	ServiceID       string                 `json:"service_id"`
+	PlanID          *string                `json:"plan_id,omitempty"`
+	Parameters      map[string]interface{} `json:"parameters,omitempty"`
+	Context         map[string]interface{} `json:"context,omitempty"`
+	PreviousValues  *PreviousValues        `json:"previous_values,omitempty"`
+	MaintenanceInfo *MaintenanceInfo       `json:"maintenance_info,omitempty"`
 }
 
 type updateInstanceResponseBody struct {
@@ -58,6 +59,14 @@ func (c *client) UpdateInstance(r *UpdateInstanceRequest) (*UpdateInstanceRespon
 		requestBody.Context = r.Context
 	}
 
+	if c.validateAlphaAPIMethodsAllowed() == nil {
+		requestBody.MaintenanceInfo = r.MaintenanceInfo
+	} else if requestBody.PreviousValues != nil && requestBody.PreviousValues.MaintenanceInfo != nil {
+		previousValues := *requestBody.PreviousValues
+		previousValues.MaintenanceInfo = nil
+		requestBody.PreviousValues = &previousValues
+	}
+
 	response, err := c.prepareAndDo(http.MethodPatch, fullURL, params, requestBody, r.OriginatingIdentity)
 	if err != nil {
 		return nil, err